@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/Hirogava/Go-NN-Learn/pkg/autograd"
 	"github.com/Hirogava/Go-NN-Learn/pkg/dataloader"
@@ -14,7 +13,8 @@ import (
 )
 
 func main() {
-	rand.Seed(42)
+	tensor.ManualSeed(42)
+	rng := tensor.DefaultGenerator()
 
 	const (
 		inputDim   = 28 * 28
@@ -29,7 +29,7 @@ func main() {
 	for c := 0; c < numClasses; c++ {
 		center := make([]float64, inputDim)
 		for i := range center {
-			center[i] = rand.NormFloat64() * 3
+			center[i] = rng.NormFloat64() * 3
 		}
 		centers[c] = center
 	}
@@ -38,9 +38,9 @@ func main() {
 	y := tensor.Zeros(samples, numClasses)
 
 	for i := 0; i < samples; i++ {
-		label := rand.Intn(numClasses)
+		label := rng.Intn(numClasses)
 		for j := 0; j < inputDim; j++ {
-			x.Data[i*inputDim+j] = centers[label][j] + rand.NormFloat64()*0.8
+			x.Data[i*inputDim+j] = centers[label][j] + rng.NormFloat64()*0.8
 		}
 		y.Data[i*y.Strides[0]+label] = 1.0
 	}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"math/rand"
 	"testing"
 
 	"github.com/Hirogava/Go-NN-Learn/pkg/autograd"
@@ -14,7 +13,8 @@ import (
 )
 
 func TestMNISTLikeMinimal(t *testing.T) {
-	rand.Seed(123)
+	tensor.ManualSeed(123)
+	rng := tensor.DefaultGenerator()
 
 	const (
 		inputDim   = 28 * 28
@@ -28,9 +28,9 @@ func TestMNISTLikeMinimal(t *testing.T) {
 	y := tensor.Zeros(samples, numClasses)
 
 	for i := 0; i < samples; i++ {
-		label := rand.Intn(numClasses)
+		label := rng.Intn(numClasses)
 		for j := 0; j < inputDim; j++ {
-			x.Data[i*inputDim+j] = rand.NormFloat64()
+			x.Data[i*inputDim+j] = rng.NormFloat64()
 		}
 		y.Data[i*y.Strides[0]+label] = 1.0
 	}
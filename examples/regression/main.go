@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/Hirogava/Go-NN-Learn/pkg/autograd"
 	"github.com/Hirogava/Go-NN-Learn/pkg/dataloader"
@@ -14,7 +13,8 @@ import (
 // Минимальный end-to-end пример обучения
 // y = x * W
 func main() {
-	rand.Seed(42)
+	tensor.ManualSeed(42)
+	rng := tensor.DefaultGenerator()
 
 	// Dataset
 	numSamples := 1000
@@ -23,7 +23,7 @@ func main() {
 	yData := tensor.Zeros(numSamples, 1)
 
 	for i := 0; i < numSamples; i++ {
-		x := rand.Float64()*2 - 1
+		x := rng.Float64()*2 - 1
 		y := 2 * x // БЕЗ bias
 
 		xData.Data[i] = x
@@ -2,7 +2,6 @@ package main
 
 import (
 	"math"
-	"math/rand"
 	"testing"
 
 	"github.com/Hirogava/Go-NN-Learn/pkg/autograd"
@@ -14,7 +13,8 @@ import (
 
 // Проверяем, что модель действительно обучается
 func TestMinimalTraining(t *testing.T) {
-	rand.Seed(42)
+	tensor.ManualSeed(42)
+	rng := tensor.DefaultGenerator()
 
 	// Dataset
 	numSamples := 500
@@ -23,7 +23,7 @@ func TestMinimalTraining(t *testing.T) {
 	yData := tensor.Zeros(numSamples, 1)
 
 	for i := 0; i < numSamples; i++ {
-		x := rand.Float64()*2 - 1
+		x := rng.Float64()*2 - 1
 		y := 2 * x
 
 		xData.Data[i] = x
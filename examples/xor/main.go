@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/Hirogava/Go-NN-Learn/pkg/autograd"
 	"github.com/Hirogava/Go-NN-Learn/pkg/dataloader"
@@ -14,7 +13,7 @@ import (
 )
 
 func main() {
-	rand.Seed(42)
+	tensor.ManualSeed(42)
 
 	// XOR датасет: 4 точки
 	// (0,0)->0, (1,1)->0, (0,1)->1, (1,0)->1
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"math/rand"
 	"testing"
 
 	"github.com/Hirogava/Go-NN-Learn/pkg/autograd"
@@ -15,7 +14,7 @@ import (
 
 // runXORTraining выполняет один запуск обучения и возвращает (accuracy, steps, ok).
 func runXORTraining(seed int64) (float64, int, bool) {
-	rand.Seed(seed)
+	tensor.ManualSeed(seed)
 
 	x := tensor.Zeros(4, 2)
 	y := tensor.Zeros(4, 1)
@@ -3,15 +3,14 @@ package text
 import (
 	"errors"
 	"strings"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
 )
 
 var ErrEmptyDataset = errors.New("empty dataset")
 
-func RunPipeline(texts []string, cfg PreprocessConfig) (*Output, error) {
-	if len(texts) == 0 {
-		return nil, ErrEmptyDataset
-	}
-
+// tokenizeAll нормализует и токенизирует тексты; пустые тексты дают пустой список.
+func tokenizeAll(texts []string, cfg PreprocessConfig) [][]string {
 	allTokens := make([][]string, 0, len(texts))
 
 	for _, t := range texts {
@@ -26,6 +25,15 @@ func RunPipeline(texts []string, cfg PreprocessConfig) (*Output, error) {
 		allTokens = append(allTokens, tokens)
 	}
 
+	return allTokens
+}
+
+func RunPipeline(texts []string, cfg PreprocessConfig) (*Output, error) {
+	if len(texts) == 0 {
+		return nil, ErrEmptyDataset
+	}
+
+	allTokens := tokenizeAll(texts, cfg)
 	vocab := BuildVocab(allTokens, cfg)
 
 	features := make([][]float32, 0, len(allTokens))
@@ -41,22 +49,36 @@ func RunPipeline(texts []string, cfg PreprocessConfig) (*Output, error) {
 	}, nil
 }
 
+// RunPipelineSparse — то же, что RunPipeline, но признаки возвращаются
+// разреженной CSR-матрицей [len(texts), len(vocab)]: память и время умножения
+// пропорциональны числу токенов, а не размеру словаря.
+func RunPipelineSparse(texts []string, cfg PreprocessConfig) (*SparseOutput, error) {
+	if len(texts) == 0 {
+		return nil, ErrEmptyDataset
+	}
+
+	allTokens := tokenizeAll(texts, cfg)
+	vocab := BuildVocab(allTokens, cfg)
+
+	return &SparseOutput{
+		Vocab:    vocab,
+		Features: bowCSR(allTokens, vocab),
+	}, nil
+}
+
 // Inference pipeline
 func Transform(texts []string, vocab *Vocab, cfg PreprocessConfig) [][]float32 {
 	features := make([][]float32, 0, len(texts))
 
-	for _, t := range texts {
-		if strings.TrimSpace(t) == "" {
-			features = append(features, make([]float32, len(vocab.IdxToToken)))
-			continue
-		}
-
-		norm := Normalize(t, cfg)
-		tokens := Tokenize(norm, cfg)
-
+	for _, tokens := range tokenizeAll(texts, cfg) {
 		vec := VectorizeBoW(tokens, vocab)
 		features = append(features, vec)
 	}
 
 	return features
 }
+
+// TransformSparse — разреженный вариант Transform.
+func TransformSparse(texts []string, vocab *Vocab, cfg PreprocessConfig) *tensor.CSR {
+	return bowCSR(tokenizeAll(texts, cfg), vocab)
+}
@@ -235,3 +235,35 @@ func TestRunPipeline_Basic(t *testing.T) {
 		t.Fatal("vocab should not be empty")
 	}
 }
+
+func TestRunPipelineSparse_MatchesDense(t *testing.T) {
+	texts := []string{"the cat sat on the mat", "", "dog and cat"}
+	cfg := DefaultConfig()
+
+	dense, err := RunPipeline(texts, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sparse, err := RunPipelineSparse(texts, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dense.Vocab, sparse.Vocab) {
+		t.Fatal("sparse pipeline built a different vocab")
+	}
+
+	got := sparse.Features.ToDense()
+	cols := len(dense.Vocab.IdxToToken)
+	for i, row := range dense.Features {
+		for j, v := range row {
+			if float64(v) != got.Data[i*cols+j] {
+				t.Fatalf("feature[%d][%d]: dense %v, sparse %v", i, j, v, got.Data[i*cols+j])
+			}
+		}
+	}
+
+	transformed := TransformSparse([]string{"cat cat unknown"}, sparse.Vocab, cfg)
+	if transformed.NNZ() != 1 || transformed.Values[0] != 2 {
+		t.Fatalf("TransformSparse = %+v, want a single count of 2", transformed)
+	}
+}
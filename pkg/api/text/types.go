@@ -1,8 +1,16 @@
 package text
 
+import "github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+
 type InputTexts []string
 
 type Output struct {
 	Vocab    *Vocab
 	Features [][]float32
 }
+
+// SparseOutput — результат RunPipelineSparse: BoW-признаки в формате CSR.
+type SparseOutput struct {
+	Vocab    *Vocab
+	Features *tensor.CSR
+}
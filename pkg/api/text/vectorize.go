@@ -1,5 +1,11 @@
 package text
 
+import (
+	"sort"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+)
+
 func VectorizeBoW(tokens []string, vocab *Vocab) []float32 {
 	vec := make([]float32, len(vocab.IdxToToken))
 
@@ -12,3 +18,42 @@ func VectorizeBoW(tokens []string, vocab *Vocab) []float32 {
 
 	return vec
 }
+
+// VectorizeBoWSparse возвращает только ненулевые элементы BoW-вектора:
+// индексы токенов словаря по возрастанию и их количества.
+func VectorizeBoWSparse(tokens []string, vocab *Vocab) ([]int, []float64) {
+	counts := make(map[int]float64)
+	for _, t := range tokens {
+		if idx, ok := vocab.TokenToIdx[t]; ok {
+			counts[idx]++
+		}
+	}
+
+	indices := make([]int, 0, len(counts))
+	for idx := range counts {
+		indices = append(indices, idx)
+	}
+	sort.Ints(indices)
+
+	values := make([]float64, len(indices))
+	for i, idx := range indices {
+		values[i] = counts[idx]
+	}
+	return indices, values
+}
+
+// bowCSR собирает BoW-матрицу [len(allTokens), len(vocab)] в формате CSR.
+func bowCSR(allTokens [][]string, vocab *Vocab) *tensor.CSR {
+	m := &tensor.CSR{
+		Rows:   len(allTokens),
+		Cols:   len(vocab.IdxToToken),
+		RowPtr: make([]int, len(allTokens)+1),
+	}
+	for i, tokens := range allTokens {
+		indices, values := VectorizeBoWSparse(tokens, vocab)
+		m.ColIdx = append(m.ColIdx, indices...)
+		m.Values = append(m.Values, values...)
+		m.RowPtr[i+1] = len(m.Values)
+	}
+	return m
+}
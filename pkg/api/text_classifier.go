@@ -11,7 +11,6 @@ import (
 
 	"github.com/Hirogava/Go-NN-Learn/pkg/api/text"
 	"github.com/Hirogava/Go-NN-Learn/pkg/autograd"
-	"github.com/Hirogava/Go-NN-Learn/pkg/dataloader"
 	"github.com/Hirogava/Go-NN-Learn/pkg/gnn"
 	"github.com/Hirogava/Go-NN-Learn/pkg/layers"
 	"github.com/Hirogava/Go-NN-Learn/pkg/optimizers"
@@ -105,7 +104,7 @@ func (c *TextClassifier) Fit(texts []string, labels []string) error {
 		}
 	}
 
-	featuresOut, err := text.RunPipeline(texts, c.cfg.TextConfig)
+	featuresOut, err := text.RunPipelineSparse(texts, c.cfg.TextConfig)
 	if err != nil {
 		return err
 	}
@@ -115,11 +114,6 @@ func (c *TextClassifier) Fit(texts []string, labels []string) error {
 		return err
 	}
 
-	featuresTensor, err := featuresToTensor(featuresOut.Features)
-	if err != nil {
-		return err
-	}
-
 	targetsTensor, err := oneHotTargets(labels, classToIdx)
 	if err != nil {
 		return err
@@ -138,38 +132,44 @@ func (c *TextClassifier) Fit(texts []string, labels []string) error {
 		batchSize = len(texts)
 	}
 
-	ds := dataloader.NewSimpleDataset(featuresTensor, targetsTensor)
-	loader := dataloader.NewDataLoader(ds, dataloader.DataLoaderConfig{
-		BatchSize: batchSize,
-		Shuffle:    true,
-		Seed:       c.cfg.Seed,
-	})
+	// Признаки остаются разреженными: батчи собираются выбором строк CSR,
+	// так что стоимость не зависит от размера словаря.
+	features := featuresOut.Features
+	rng := tensor.NewGenerator(c.cfg.Seed)
 
 	opt := optimizers.NewAdam(c.cfg.LearningRate, 0.9, 0.999, 1e-8)
 
 	for epoch := 0; epoch < c.cfg.Epochs; epoch++ {
-		loader.Reset()
+		order := rng.Perm(features.Rows)
 
 		var sumLoss float64
 		var sumCorrect float64
 		var seen int
 		var batches int
 
-		for loader.HasNext() {
-			batch := loader.Next()
+		for start := 0; start < len(order); start += batchSize {
+			rows := order[start:min(start+batchSize, len(order))]
+			batchFeatures, err := features.SelectRows(rows)
+			if err != nil {
+				return err
+			}
+			batchTargets, err := selectRows(targetsTensor, rows)
+			if err != nil {
+				return err
+			}
 			batches++
 
 			ctx := autograd.NewGraph()
 			ctx.WithGrad()
 			autograd.SetGraph(ctx)
 
-			input := graph.NewNode(batch.Features, nil, nil)
+			input := graph.NewSparseNode(batchFeatures)
 			logits := c.model.Forward(input)
 			if logits == nil {
 				return fmt.Errorf("fit: model returned nil logits")
 			}
 
-			lossNode := ctx.Engine().SoftmaxCrossEntropy(logits, batch.Targets)
+			lossNode := ctx.Engine().SoftmaxCrossEntropy(logits, batchTargets)
 			if lossNode == nil || lossNode.Value == nil {
 				return fmt.Errorf("fit: loss is nil")
 			}
@@ -179,9 +179,9 @@ func (c *TextClassifier) Fit(texts []string, labels []string) error {
 			opt.ZeroGrad(c.model.Params())
 
 			batchLoss := meanTensor(lossNode.Value)
-			batchCorrect := batchAccuracy(logits.Value, batch.Targets)
+			batchCorrect := batchAccuracy(logits.Value, batchTargets)
 
-			batchSizeNow := batch.Features.Shape[0]
+			batchSizeNow := len(rows)
 			sumLoss += batchLoss * float64(batchSizeNow)
 			sumCorrect += batchCorrect * float64(batchSizeNow)
 			seen += batchSizeNow
@@ -226,15 +226,11 @@ func (c *TextClassifier) PredictProba(texts []string) ([][]float64, error) {
 		return nil, err
 	}
 
-	features := text.Transform(texts, c.vocab, c.cfg.TextConfig)
-	featuresTensor, err := featuresToTensor(features)
-	if err != nil {
-		return nil, err
-	}
+	features := text.TransformSparse(texts, c.vocab, c.cfg.TextConfig)
 
 	var logits *graph.Node
-	gnn.NoGrad(func() {
-		input := graph.NewNode(featuresTensor, nil, nil)
+	gnn.NoGradScope(func(s *graph.Scope) {
+		input := s.Input(graph.NewSparseNode(features))
 		logits = c.model.Forward(input)
 	})
 	if logits == nil || logits.Value == nil {
@@ -375,26 +371,13 @@ func oneHotTargets(labels []string, mapping map[string]int) (*tensor.Tensor, err
 	return targets, nil
 }
 
-func featuresToTensor(features [][]float32) (*tensor.Tensor, error) {
-	if len(features) == 0 {
-		return nil, ErrEmptyDataset
-	}
-
-	cols := len(features[0])
-	for i := range features {
-		if len(features[i]) != cols {
-			return nil, fmt.Errorf("feature shape mismatch at row %d", i)
-		}
+// selectRows собирает строки rows двумерного тензора в новый тензор.
+func selectRows(t *tensor.Tensor, rows []int) (*tensor.Tensor, error) {
+	index := tensor.Zeros(len(rows))
+	for i, r := range rows {
+		index.Data[i] = float64(r)
 	}
-
-	out := tensor.Zeros(len(features), cols)
-	for i, row := range features {
-		for j, v := range row {
-			out.Data[i*out.Strides[0]+j] = float64(v)
-		}
-	}
-
-	return out, nil
+	return tensor.IndexSelect(t, 0, index)
 }
 
 func meanTensor(t *tensor.Tensor) float64 {
@@ -419,28 +402,18 @@ func batchAccuracy(logits *tensor.Tensor, targets *tensor.Tensor) float64 {
 		return 0
 	}
 
-	var correct int
-	for i := 0; i < rows; i++ {
-		logitBase := i * logits.Strides[0]
-		targetBase := i * targets.Strides[0]
-
-		predIdx := 0
-		predMax := logits.Data[logitBase]
-		for j := 1; j < cols; j++ {
-			if logits.Data[logitBase+j] > predMax {
-				predMax = logits.Data[logitBase+j]
-				predIdx = j
-			}
-		}
-
-		targetIdx := 0
-		for j := 1; j < cols; j++ {
-			if targets.Data[targetBase+j] > targets.Data[targetBase+targetIdx] {
-				targetIdx = j
-			}
-		}
+	pred, err := tensor.ArgMax(logits, 1, false)
+	if err != nil {
+		return 0
+	}
+	target, err := tensor.ArgMax(targets, 1, false)
+	if err != nil {
+		return 0
+	}
 
-		if predIdx == targetIdx {
+	var correct int
+	for i := range pred.Data {
+		if pred.Data[i] == target.Data[i] {
 			correct++
 		}
 	}
@@ -510,9 +483,10 @@ type textClassifierModel struct {
 }
 
 func newTextClassifierModel(inputDim, hiddenDim, numClasses int, seed int64) *textClassifierModel {
+	gen := tensor.NewGenerator(seed)
 	return &textClassifierModel{
-		hidden: layers.NewDense(inputDim, hiddenDim, layers.HeInit(inputDim), layers.ZeroInit()),
-		output: layers.NewDense(hiddenDim, numClasses, layers.XavierInit(hiddenDim, numClasses), layers.ZeroInit()),
+		hidden: layers.NewDense(inputDim, hiddenDim, layers.HeInit(inputDim, gen), layers.ZeroInit()),
+		output: layers.NewDense(hiddenDim, numClasses, layers.XavierInit(hiddenDim, numClasses, gen), layers.ZeroInit()),
 	}
 }
 
@@ -10,6 +10,10 @@ import (
 
 type Engine struct {
 	Nodes []*graph.Node
+
+	// scope — область узлов операций, у родителей которых её нет
+	// (см. graph.NewNodeIn); nil — обычный режим.
+	scope *graph.Scope
 }
 
 func NewEngine() *Engine {
@@ -18,17 +22,51 @@ func NewEngine() *Engine {
 	}
 }
 
-// Backward выполняет обратное распространение по всему графу
-func (e *Engine) Backward(finalNode *graph.Node) {
+// NewEngineIn создаёт Engine, операции которого строят узлы в области s,
+// даже если их входы — только параметры без области.
+func NewEngineIn(s *graph.Scope) *Engine {
+	e := NewEngine()
+	e.scope = s
+	return e
+}
+
+// newNode создаёт узел операции в области e (см. graph.NewNodeIn).
+func (e *Engine) newNode(value *tensor.Tensor, parents []*graph.Node, op graph.Operation) *graph.Node {
+	return graph.NewNodeIn(e.scope, value, parents, op)
+}
+
+// Backward выполняет обратное распространение по всему графу.
+// Перед передачей градиента узла родителям вызываются его хуки (Node.RegisterHook).
+// С опцией CreateGraph(true) обратный проход сам записывается в граф
+// (см. backwardCreateGraph), и градиенты можно дифференцировать повторно;
+// ошибка возвращается, только если операция графа не поддерживает этот режим.
+func (e *Engine) Backward(finalNode *graph.Node, opts ...BackwardOption) error {
+	var cfg backwardConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.createGraph {
+		return e.backwardCreateGraph(finalNode)
+	}
+
 	// Инициализировать градиент конечного узла единицами той же формы
-	finalNode.Grad = tensor.Ones(finalNode.Value.Shape...)
+	e.backwardFrom(finalNode, tensor.Ones(finalNode.Value.Shape...))
+	return nil
+}
+
+// backwardFrom распространяет градиент grad узла root на его предков.
+func (e *Engine) backwardFrom(root *graph.Node, grad *tensor.Tensor) {
+	root.Grad = grad
 
 	// Выполнить топологическую сортировку узлов
-	sortedNodes := e.topologicalSort(finalNode)
+	sortedNodes := e.topologicalSort(root)
 
 	// Выполнить обратное распространение в обратном порядке
 	for i := len(sortedNodes) - 1; i >= 0; i-- {
 		node := sortedNodes[i]
+		if node.HasHooks() && node.Grad != nil {
+			node.Grad = node.ApplyHooks(node.Grad)
+		}
 		if node.Operation != nil {
 			node.Operation.Backward(node.Grad)
 		}
@@ -95,7 +133,7 @@ func (op *ReLUOp) Forward() *tensor.Tensor {
 func (e *Engine) ReLU(input *graph.Node) *graph.Node {
 	op := NewReLUOp(input)
 	result := op.Forward()
-	node := graph.NewNode(result, []*graph.Node{input}, op)
+	node := e.newNode(result, []*graph.Node{input}, op)
 	e.Nodes = append(e.Nodes, node)
 	return node
 }
@@ -132,7 +170,7 @@ func (op *SigmoidOp) Forward() *tensor.Tensor {
 func (e *Engine) Sigmoid(input *graph.Node) *graph.Node {
 	op := NewSigmoidOp(input)
 	result := op.Forward()
-	node := graph.NewNode(result, []*graph.Node{input}, op)
+	node := e.newNode(result, []*graph.Node{input}, op)
 	e.Nodes = append(e.Nodes, node)
 	return node
 }
@@ -171,7 +209,7 @@ func (op *TanhOp) Forward() *tensor.Tensor {
 func (e *Engine) Tanh(input *graph.Node) *graph.Node {
 	op := NewTanhOp(input)
 	result := op.Forward()
-	node := graph.NewNode(result, []*graph.Node{input}, op)
+	node := e.newNode(result, []*graph.Node{input}, op)
 	e.Nodes = append(e.Nodes, node)
 	return node
 }
@@ -231,7 +269,7 @@ func (op *SoftPlusOp) Forward() *tensor.Tensor {
 func (e *Engine) SoftPlus(input *graph.Node) *graph.Node {
 	op := NewSoftPlusOp(input)
 	result := op.Forward()
-	node := graph.NewNode(result, []*graph.Node{input}, op)
+	node := e.newNode(result, []*graph.Node{input}, op)
 	e.Nodes = append(e.Nodes, node)
 	return node
 }
@@ -280,7 +318,7 @@ func (op *GELUOp) Forward() *tensor.Tensor {
 func (e *Engine) GELU(input *graph.Node) *graph.Node {
 	op := NewGELUOp(input)
 	result := op.Forward()
-	node := graph.NewNode(result, []*graph.Node{input}, op)
+	node := e.newNode(result, []*graph.Node{input}, op)
 	e.Nodes = append(e.Nodes, node)
 	return node
 }
@@ -327,7 +365,7 @@ func (op *LeakyReLUOp) Forward() *tensor.Tensor {
 func (e *Engine) LeakyReLU(input *graph.Node, slope float64) *graph.Node {
 	op := NewLeakyReLUOp(input, slope)
 	result := op.Forward()
-	node := graph.NewNode(result, []*graph.Node{input}, op)
+	node := e.newNode(result, []*graph.Node{input}, op)
 	e.Nodes = append(e.Nodes, node)
 	return node
 }
@@ -375,7 +413,7 @@ func (op *ELUOp) Forward() *tensor.Tensor {
 func (e *Engine) ELU(input *graph.Node, alpha float64) *graph.Node {
 	op := NewELUOp(input, alpha)
 	result := op.Forward()
-	node := graph.NewNode(result, []*graph.Node{input}, op)
+	node := e.newNode(result, []*graph.Node{input}, op)
 	e.Nodes = append(e.Nodes, node)
 	return node
 }
@@ -459,7 +497,7 @@ func (op *SoftmaxOp) Forward() *tensor.Tensor {
 func (e *Engine) Softmax(input *graph.Node) *graph.Node {
 	op := NewSoftmaxOp(input)
 	result := op.Forward()
-	node := graph.NewNode(result, []*graph.Node{input}, op)
+	node := e.newNode(result, []*graph.Node{input}, op)
 	e.Nodes = append(e.Nodes, node)
 	return node
 }
@@ -551,7 +589,7 @@ func (op *SoftmaxCrossEntropyOp) Forward() *tensor.Tensor {
 func (e *Engine) SoftmaxCrossEntropy(input *graph.Node, target *tensor.Tensor) *graph.Node {
 	op := NewSoftmaxCrossEntropyOp(input, target)
 	result := op.Forward()
-	node := graph.NewNode(result, []*graph.Node{input}, op)
+	node := e.newNode(result, []*graph.Node{input}, op)
 	e.Nodes = append(e.Nodes, node)
 	return node
 }
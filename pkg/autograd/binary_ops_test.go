@@ -0,0 +1,55 @@
+package autograd
+
+import (
+	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+)
+
+func TestBinaryOpsBroadcastGradientCheck(t *testing.T) {
+	x := tensor.Randn([]int{4, 3}, 404)
+	bias := tensor.Randn([]int{3}, 405)
+	col := tensor.Apply(tensor.Randn([]int{4, 1}, 406), func(v float64) float64 { return 1.5 + v*v })
+
+	checkGradCases(t, []gradCase{
+		binaryCase("Add[N,F]+[F]", x, bias, (*Engine).Add),
+		binaryCase("Mul[N,F]*[F]", x, bias, (*Engine).Mul),
+		binaryCase("Sub[F]-[N,F]", bias, x, (*Engine).Sub),
+		binaryCase("Div[N,F]/[N,1]", x, col, (*Engine).Div),
+		binaryCase("Pow[N,1]^[F]", col, bias, (*Engine).Pow),
+		binaryCase("Maximum[N,F],[F]", x, bias, (*Engine).Maximum),
+		binaryCase("Minimum[N,1],[N,F]", col, x, (*Engine).Minimum),
+	}, nil)
+}
+
+func TestAddBiasGradShape(t *testing.T) {
+	e := NewEngine()
+	x := e.RequireGrad(tensor.Ones(5, 3))
+	b := e.RequireGrad(tensor.Zeros(3))
+	y := e.Add(x, b)
+	e.Backward(e.Sum(y))
+
+	if len(b.Grad.Shape) != 1 || b.Grad.Shape[0] != 3 {
+		t.Fatalf("bias grad shape = %v, want [3]", b.Grad.Shape)
+	}
+	for i, v := range b.Grad.Data {
+		if v != 5 {
+			t.Fatalf("bias grad[%d] = %v, want 5", i, v)
+		}
+	}
+}
+
+func TestMaximumGradSplitsTies(t *testing.T) {
+	e := NewEngine()
+	a := e.RequireGrad(newTensor([]float64{1, 2, 3}, 3))
+	b := e.RequireGrad(newTensor([]float64{2, 2, 1}, 3))
+	e.Backward(e.Maximum(a, b))
+
+	wantA := []float64{0, 0.5, 1}
+	wantB := []float64{1, 0.5, 0}
+	for i := range wantA {
+		if a.Grad.Data[i] != wantA[i] || b.Grad.Data[i] != wantB[i] {
+			t.Fatalf("grad[%d] = (%v, %v), want (%v, %v)", i, a.Grad.Data[i], b.Grad.Data[i], wantA[i], wantB[i])
+		}
+	}
+}
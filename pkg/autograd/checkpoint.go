@@ -0,0 +1,136 @@
+package autograd
+
+import (
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+// Чекпоинтинг активаций: Checkpoint выполняет f без графа и хранит только вход
+// и выход, а промежуточные активации f пересчитываются во время Backward.
+// Память под активации обменивается на повторный прямой проход f.
+
+// CheckpointOption настраивает Checkpoint.
+type CheckpointOption func(*checkpointConfig)
+
+type checkpointConfig struct {
+	snapshots []func() (restore func())
+}
+
+// PreserveRNG добавляет генераторы, позиция которых воспроизводится при
+// пересчёте, например генератор Dropout.
+func PreserveRNG(gens ...*tensor.Generator) CheckpointOption {
+	return func(c *checkpointConfig) {
+		for _, g := range gens {
+			if g != nil {
+				c.snapshots = append(c.snapshots, generatorSnapshot(g))
+			}
+		}
+	}
+}
+
+// PreserveState добавляет состояние, которое f меняет между вызовами (скрытое
+// состояние RNN, running-статистики): snapshot сохраняет его и возвращает
+// функцию восстановления. На время пересчёта состояние возвращается к снимку,
+// сделанному перед прямым проходом, а после пересчёта — к текущему.
+func PreserveState(snapshot func() (restore func())) CheckpointOption {
+	return func(c *checkpointConfig) {
+		c.snapshots = append(c.snapshots, snapshot)
+	}
+}
+
+func generatorSnapshot(g *tensor.Generator) func() func() {
+	return func() func() {
+		pos := g.Position()
+		return func() { g.Seek(pos) }
+	}
+}
+
+func takeSnapshots(snapshots []func() func()) []func() {
+	restore := make([]func(), len(snapshots))
+	for i, s := range snapshots {
+		restore[i] = s()
+	}
+	return restore
+}
+
+func restoreAll(restore []func()) {
+	for _, r := range restore {
+		r()
+	}
+}
+
+// CheckpointOp — операция Checkpoint: в Backward пересчитывает f и проводит
+// градиент через построенный заново граф.
+type CheckpointOp struct {
+	Parents   []*graph.Node
+	f         func(*graph.Node) *graph.Node
+	snapshots []func() func()
+	restore   []func() // состояние перед прямым проходом
+}
+
+// Checkpoint возвращает f(x), не сохраняя промежуточные активации f: прямой
+// проход выполняется в области NoGrad, вложенной в область x, а в Backward f вызывается повторно с
+// построением графа, и градиент проходит к x и к параметрам, которые
+// использует f. Позиция генераторов из PreserveRNG при пересчёте
+// воспроизводится, так что Dropout со своим генератором даёт те же маски.
+// tensor.DefaultGenerator не сохраняется: он общий для всего процесса, и
+// его позицию могут сдвинуть другие горутины.
+//
+// Узлы внутри f следует создавать через EngineOf, как это делают слои:
+// Engine, захваченный замыканием, сохранил бы их в Nodes. Backward с
+// CreateGraph(true) и Engine.Grad через Checkpoint не поддерживаются.
+func Checkpoint(f func(x *graph.Node) *graph.Node, x *graph.Node, opts ...CheckpointOption) *graph.Node {
+	if !x.Scope.GradEnabled() {
+		return f(x)
+	}
+	var cfg checkpointConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	op := &CheckpointOp{
+		Parents:   []*graph.Node{x},
+		f:         f,
+		snapshots: cfg.snapshots,
+	}
+	op.restore = takeSnapshots(op.snapshots)
+
+	scope := x.Scope.With(graph.ModeNoGrad)
+	out := f(scope.Input(x))
+	scope.Close()
+	if out == nil {
+		return nil
+	}
+	return graph.NewNode(out.Value, op.Parents, op)
+}
+
+func (op *CheckpointOp) Backward(grad *tensor.Tensor) {
+	x := op.Parents[0]
+	current := takeSnapshots(op.snapshots)
+	restoreAll(op.restore)
+	defer restoreAll(current)
+
+	leaf := graph.NewNode(x.Value, nil, nil)
+	out := op.f(leaf)
+	if out == nil {
+		panic("autograd: Checkpoint: f вернула nil при пересчёте")
+	}
+	e := NewEngine()
+	e.backwardFrom(out, grad.Clone())
+	if leaf.Grad != nil {
+		accumulateGrad(x, leaf.Grad)
+	}
+
+	// Градиенты параметров f не входят во внешний граф, поэтому
+	// GraphContext.Backward не вынесет их из арены сам.
+	a := tensor.ArenaOf(grad)
+	if a == nil {
+		a = tensor.ArenaOf(x.Value)
+	}
+	if a != nil {
+		for _, n := range e.topologicalSort(out) {
+			if n.IsLeaf() && n != leaf && n.Grad != nil {
+				n.Grad = a.Detach(n.Grad)
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package autograd
+
+import (
+	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+// checkpointRun строит loss = Σ block(x)² на текущем графе (с чекпоинтом или без),
+// выполняет Backward и возвращает loss, градиенты x, w1, w2 и число узлов Engine.
+func checkpointRun(t *testing.T, checkpoint bool, arena *tensor.Arena) (float64, [3][]float64, int) {
+	t.Helper()
+	x := graph.NewNode(tensor.Randn([]int{3, 4}, 2501), nil, nil)
+	w1 := graph.NewNode(tensor.Randn([]int{4, 5}, 2502), nil, nil)
+	w2 := graph.NewNode(tensor.Randn([]int{5, 2}, 2503), nil, nil)
+	block := func(in *graph.Node) *graph.Node {
+		e := EngineOf(in)
+		h := e.Sigmoid(e.MatMul(in, w1))
+		return e.Tanh(e.MatMul(e.Mul(h, h), w2))
+	}
+
+	ctx := NewGraph()
+	if arena != nil {
+		ctx.UseArena(arena)
+	}
+	SetGraph(ctx)
+	defer ClearGraph()
+	e := ctx.Engine()
+	var out *graph.Node
+	if checkpoint {
+		out = Checkpoint(block, x)
+		if len(out.Parents) != 1 || out.Parents[0] != x {
+			t.Fatal("у узла Checkpoint единственный родитель — вход")
+		}
+	} else {
+		out = block(x)
+	}
+	loss := e.Sum(e.Mul(out, out))
+	nodes := len(e.topologicalSort(loss))
+	ctx.Backward(loss)
+	return loss.Value.Data[0], [3][]float64{x.Grad.Data, w1.Grad.Data, w2.Grad.Data}, nodes
+}
+
+func TestCheckpointMatchesPlainBackward(t *testing.T) {
+	for _, arena := range []*tensor.Arena{nil, tensor.NewArena()} {
+		// Обычный прогон идёт вторым: с ареной он переиспользует её буферы, и
+		// градиенты параметров чекпоинта должны быть вынесены из неё.
+		loss, grads, nodes := checkpointRun(t, true, arena)
+		plainLoss, plain, plainNodes := checkpointRun(t, false, arena)
+		if loss != plainLoss {
+			t.Fatalf("loss %v, без чекпоинта %v", loss, plainLoss)
+		}
+		for i, name := range []string{"dx", "dw1", "dw2"} {
+			assertClose(t, name, grads[i], plain[i], 1e-12)
+		}
+		// Активации блока не попадают в граф во время прямого прохода.
+		if nodes >= plainNodes {
+			t.Fatalf("узлов с чекпоинтом %d, без него %d", nodes, plainNodes)
+		}
+	}
+}
+
+func TestCheckpointReplaysRNG(t *testing.T) {
+	// f умножает вход на случайную маску из своего генератора, как Dropout.
+	gen := tensor.NewGenerator(7)
+	var masks []*tensor.Tensor
+	f := func(in *graph.Node) *graph.Node {
+		mask := gen.Uniform(in.Value.Shape, 0, 1)
+		masks = append(masks, mask)
+		return NewEngine().Mul(in, graph.NewNode(mask, nil, nil))
+	}
+
+	defaultPos := tensor.DefaultGenerator().Position()
+	e := NewEngine()
+	x := e.RequireGrad(tensor.Randn([]int{2, 3}, 2504))
+	e.Backward(e.Sum(Checkpoint(f, x, PreserveRNG(gen))))
+	next := gen.Float64()
+
+	if len(masks) != 2 {
+		t.Fatalf("f вызвана %d раз, ожидалось 2 (прямой проход и пересчёт)", len(masks))
+	}
+	assertClose(t, "маска пересчёта", masks[1].Data, masks[0].Data, 0)
+	assertClose(t, "dx", x.Grad.Data, masks[0].Data, 0)
+
+	// Пересчёт не сдвигает поток: следующее число то же, что без чекпоинта.
+	ref := tensor.NewGenerator(7)
+	ref.Uniform([]int{2, 3}, 0, 1)
+	if want := ref.Float64(); next != want {
+		t.Fatalf("после Backward генератор выдал %v, ожидалось %v", next, want)
+	}
+	if pos := tensor.DefaultGenerator().Position(); pos != defaultPos {
+		t.Fatalf("Checkpoint сдвинул генератор по умолчанию: %d -> %d", defaultPos, pos)
+	}
+}
@@ -0,0 +1,94 @@
+package autograd
+
+import (
+	"strings"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+// Einsum
+type EinsumOp struct {
+	Parents []*graph.Node
+	Inputs  []string // индексы операндов
+	Output  string   // индексы выхода
+}
+
+// Backward: градиент операнда k — einsum остальных операндов и grad с индексами
+// операнда k на выходе. Индексы, встречающиеся только в операнде k, в этой свёртке
+// отсутствуют: градиент по ним постоянен и восстанавливается broadcasting-ом.
+func (op *EinsumOp) Backward(grad *tensor.Tensor) {
+	for k, p := range op.Parents {
+		spec, keepShape, full := op.gradSpec(k)
+		others := make([]*tensor.Tensor, 0, len(op.Parents))
+		for j, q := range op.Parents {
+			if j != k {
+				others = append(others, q.Value)
+			}
+		}
+		gLocal, err := tensor.Einsum(spec, append(others, grad)...)
+		if err != nil {
+			panic(err)
+		}
+		if !full {
+			gLocal = expandGrad(gLocal, keepShape, p.Value.Shape)
+		}
+		accumulateGrad(p, gLocal)
+	}
+}
+
+// gradSpec возвращает спецификацию einsum для градиента операнда k (операнды —
+// остальные входы по порядку и grad), форму результата с единицами на месте
+// отсутствующих в свёртке осей и признак того, что таких осей нет.
+func (op *EinsumOp) gradSpec(k int) (spec string, keepShape []int, full bool) {
+	var specs []string
+	for j, in := range op.Inputs {
+		if j != k {
+			specs = append(specs, in)
+		}
+	}
+	specs = append(specs, op.Output)
+	used := strings.Join(specs, "")
+
+	var kept strings.Builder
+	shape := op.Parents[k].Value.Shape
+	keepShape = make([]int, len(shape))
+	for d, r := range op.Inputs[k] {
+		keepShape[d] = 1
+		if strings.ContainsRune(used, r) {
+			kept.WriteRune(r)
+			keepShape[d] = shape[d]
+		}
+	}
+	full = kept.Len() == len(op.Inputs[k])
+	return strings.Join(specs, ",") + "->" + kept.String(), keepShape, full
+}
+
+// Einsum вычисляет свёртку узлов по нотации Эйнштейна (см. tensor.Einsum),
+// например e.Einsum("bqd,bkd->bqk", q, k) для scores внимания.
+// Выражения с повторяющимися индексами внутри операнда (диагонали) не поддерживаются.
+func (e *Engine) Einsum(spec string, operands ...*graph.Node) *graph.Node {
+	inputs, output, err := tensor.EinsumLabels(spec, len(operands))
+	if err != nil {
+		return nil
+	}
+	for _, in := range inputs {
+		for i, r := range in {
+			if strings.ContainsRune(in[i+1:], r) {
+				return nil
+			}
+		}
+	}
+	values := make([]*tensor.Tensor, len(operands))
+	for i, o := range operands {
+		values[i] = o.Value
+	}
+	val, err := tensor.Einsum(spec, values...)
+	if err != nil {
+		return nil
+	}
+	op := &EinsumOp{Parents: operands, Inputs: inputs, Output: output}
+	n := e.newNode(val, operands, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
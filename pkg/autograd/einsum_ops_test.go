@@ -0,0 +1,46 @@
+package autograd
+
+import (
+	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+func TestEinsumGradientCheck(t *testing.T) {
+	cases := []struct {
+		spec   string
+		shapes [][]int
+	}{
+		{"ij,jk->ik", [][]int{{2, 3}, {3, 4}}},
+		{"bqd,bkd->bqk", [][]int{{2, 3, 4}, {2, 2, 4}}},
+		{"bi,ij,bj->b", [][]int{{3, 4}, {4, 2}, {3, 2}}},
+		{"i,j->ij", [][]int{{3}, {2}}},
+		{"ij,k->i", [][]int{{2, 3}, {4}}},
+		{"ijk->ki", [][]int{{2, 3, 4}}},
+		{"ij,ij->", [][]int{{2, 3}, {2, 3}}},
+	}
+	var gradCases []gradCase
+	for n, tc := range cases {
+		inputs := make([]*tensor.Tensor, len(tc.shapes))
+		for i, s := range tc.shapes {
+			inputs[i] = tensor.Randn(s, int64(600+10*n+i))
+		}
+		gradCases = append(gradCases, gradCase{tc.spec, inputs, func(e *Engine, in []*graph.Node) *graph.Node {
+			return e.Einsum(tc.spec, in...)
+		}})
+	}
+	// weightedOutput делает градиент выхода неединичным
+	checkGradCases(t, gradCases, weightedOutput)
+}
+
+func TestEinsumRejectsDiagonal(t *testing.T) {
+	e := NewEngine()
+	a := graph.NewNode(tensor.Randn([]int{3, 3}, 1), nil, nil)
+	if e.Einsum("ii->i", a) != nil {
+		t.Fatal("expected nil for repeated indices")
+	}
+	if e.Einsum("ij,jk->ik", a) != nil {
+		t.Fatal("expected nil for operand count mismatch")
+	}
+}
@@ -0,0 +1,125 @@
+package autograd
+
+import (
+	"math"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+// Поэлементные функции одного аргумента (Clamp, Abs, Sqrt, PowScalar).
+// Локальная производная считается по входу (или выходу) и умножается на grad.
+
+// unaryBackward добавляет к градиенту входа grad·local(x) для каждого элемента x.
+func unaryBackward(p *graph.Node, grad *tensor.Tensor, local func(x float64) float64) {
+	gLocal, err := tensor.Mul(tensor.Apply(p.Value, local), grad)
+	if err != nil {
+		panic(err)
+	}
+	accumulateGrad(p, gLocal)
+}
+
+// Clamp
+type ClampOp struct {
+	Parents []*graph.Node
+	Min     float64
+	Max     float64
+}
+
+// Backward: градиент проходит только там, где вход лежит внутри [Min, Max] (включая границы).
+func (op *ClampOp) Backward(grad *tensor.Tensor) {
+	unaryBackward(op.Parents[0], grad, func(x float64) float64 {
+		if x >= op.Min && x <= op.Max {
+			return 1
+		}
+		return 0
+	})
+}
+
+// Clamp ограничивает элементы a отрезком [lo, hi].
+func (e *Engine) Clamp(a *graph.Node, lo, hi float64) *graph.Node {
+	val := tensor.Clamp(a.Value, lo, hi)
+	op := &ClampOp{Parents: []*graph.Node{a}, Min: lo, Max: hi}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// Abs
+type AbsOp struct {
+	Parents []*graph.Node
+}
+
+// d|x|/dx = sign(x); в нуле принимается 0.
+func (op *AbsOp) Backward(grad *tensor.Tensor) {
+	unaryBackward(op.Parents[0], grad, func(x float64) float64 {
+		switch {
+		case x > 0:
+			return 1
+		case x < 0:
+			return -1
+		}
+		return 0
+	})
+}
+
+// Abs возвращает модуль каждого элемента a.
+func (e *Engine) Abs(a *graph.Node) *graph.Node {
+	val := tensor.Abs(a.Value)
+	op := &AbsOp{Parents: []*graph.Node{a}}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// Sqrt
+type SqrtOp struct {
+	Parents []*graph.Node
+	Out     *tensor.Tensor
+}
+
+// d√x/dx = 1/(2√x); в нуле градиент равен +Inf, как в PyTorch.
+func (op *SqrtOp) Backward(grad *tensor.Tensor) {
+	p := op.Parents[0]
+	local := tensor.Apply(op.Out, func(y float64) float64 { return 0.5 / y })
+	gLocal, err := tensor.Mul(local, grad)
+	if err != nil {
+		panic(err)
+	}
+	accumulateGrad(p, gLocal)
+}
+
+// Sqrt возвращает квадратный корень каждого элемента a.
+func (e *Engine) Sqrt(a *graph.Node) *graph.Node {
+	val := tensor.Sqrt(a.Value)
+	op := &SqrtOp{Parents: []*graph.Node{a}, Out: val}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// PowScalar
+type PowScalarOp struct {
+	Parents []*graph.Node
+	P       float64
+}
+
+// d/dx x^p = p·x^(p-1).
+func (op *PowScalarOp) Backward(grad *tensor.Tensor) {
+	unaryBackward(op.Parents[0], grad, func(x float64) float64 {
+		if op.P == 0 {
+			return 0
+		}
+		return op.P * math.Pow(x, op.P-1)
+	})
+}
+
+// PowScalar возводит каждый элемент a в постоянную степень p.
+// Для степени-узла используйте Pow.
+func (e *Engine) PowScalar(a *graph.Node, p float64) *graph.Node {
+	val := tensor.PowScalar(a.Value, p)
+	op := &PowScalarOp{Parents: []*graph.Node{a}, P: p}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
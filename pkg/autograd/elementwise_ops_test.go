@@ -0,0 +1,47 @@
+package autograd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+func TestElementwiseAndScanGradientCheck(t *testing.T) {
+	x := tensor.Randn([]int{3, 4}, 404)
+	positive := tensor.Apply(x, func(v float64) float64 { return math.Abs(v) + 0.5 })
+
+	checkGradCases(t, []gradCase{
+		unaryCase("Clamp", x, func(e *Engine, in *graph.Node) *graph.Node { return e.Clamp(in, -0.5, 0.5) }),
+		unaryCase("Abs", x, func(e *Engine, in *graph.Node) *graph.Node { return e.Abs(in) }),
+		unaryCase("Sqrt", positive, func(e *Engine, in *graph.Node) *graph.Node { return e.Sqrt(in) }),
+		unaryCase("PowScalar", positive, func(e *Engine, in *graph.Node) *graph.Node { return e.PowScalar(in, 1.5) }),
+		unaryCase("CumSumRows", x, func(e *Engine, in *graph.Node) *graph.Node { return e.CumSum(in, 1) }),
+		unaryCase("CumSumCols", x, func(e *Engine, in *graph.Node) *graph.Node { return e.CumSum(in, 0) }),
+		unaryCase("TopK", x, func(e *Engine, in *graph.Node) *graph.Node {
+			values, _ := e.TopK(in, 2, -1, true)
+			return values
+		}),
+	}, nil)
+}
+
+func TestTopKGradOnlyForSelected(t *testing.T) {
+	e := NewEngine()
+	x := e.RequireGrad(newTensor([]float64{1, 5, 3, 4, 2, 0}, 2, 3))
+	values, indices := e.TopK(x, 1, 1, true)
+	if values == nil {
+		t.Fatal("TopK вернул nil")
+	}
+	if indices.Data[0] != 1 || indices.Data[1] != 0 {
+		t.Fatalf("indices = %v", indices.Data)
+	}
+	e.Backward(e.Sum(values))
+
+	want := []float64{0, 1, 0, 1, 0, 0}
+	for i, w := range want {
+		if x.Grad.Data[i] != w {
+			t.Fatalf("grad[%d] = %v, want %v", i, x.Grad.Data[i], w)
+		}
+	}
+}
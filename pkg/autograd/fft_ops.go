@@ -0,0 +1,326 @@
+package autograd
+
+import (
+	"math"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/fft"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+// Дифференцируемые спектральные преобразования (см. пакет fft).
+// Комплексные тензоры хранятся парами [..., 2]; градиент по ним — пара
+// (dL/dRe, dL/dIm). Для линейного оператора A в таком представлении
+// градиент входа равен A^H·g, где g = dL/dRe + i·dL/dIm.
+
+// complexTransform выбирает преобразование fft по числу осей и направлению.
+func complexTransform(axes int, inverse bool) func(*tensor.Tensor) (*tensor.Tensor, error) {
+	switch {
+	case axes == 2 && inverse:
+		return fft.IFFT2
+	case axes == 2:
+		return fft.FFT2
+	case inverse:
+		return fft.IFFT
+	}
+	return fft.FFT
+}
+
+// FFT / IFFT / FFT2 / IFFT2
+type FFTOp struct {
+	Parents []*graph.Node
+	Axes    int // число преобразуемых комплексных осей: 1 или 2
+	Inverse bool
+}
+
+// Backward: сопряжённый к FFT оператор — n·IFFT, к IFFT — FFT/n.
+func (op *FFTOp) Backward(grad *tensor.Tensor) {
+	g, err := complexTransform(op.Axes, !op.Inverse)(grad)
+	if err != nil {
+		panic(err)
+	}
+	n := 1
+	for _, d := range grad.Shape[len(grad.Shape)-1-op.Axes : len(grad.Shape)-1] {
+		n *= d
+	}
+	scale := float64(n)
+	if op.Inverse {
+		scale = 1 / scale
+	}
+	tensor.ScaleInPlace(scale, g)
+	accumulateGrad(op.Parents[0], g)
+}
+
+func (e *Engine) complexTransform(a *graph.Node, axes int, inverse bool) *graph.Node {
+	val, err := complexTransform(axes, inverse)(a.Value)
+	if err != nil {
+		return nil
+	}
+	op := &FFTOp{Parents: []*graph.Node{a}, Axes: axes, Inverse: inverse}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// FFT вычисляет преобразование Фурье комплексного сигнала a [..., n, 2] (см. fft.FFT).
+func (e *Engine) FFT(a *graph.Node) *graph.Node {
+	return e.complexTransform(a, 1, false)
+}
+
+// IFFT вычисляет обратное преобразование Фурье (см. fft.IFFT).
+func (e *Engine) IFFT(a *graph.Node) *graph.Node {
+	return e.complexTransform(a, 1, true)
+}
+
+// FFT2 вычисляет двумерное преобразование Фурье a [..., h, w, 2] (см. fft.FFT2).
+func (e *Engine) FFT2(a *graph.Node) *graph.Node {
+	return e.complexTransform(a, 2, false)
+}
+
+// IFFT2 вычисляет обратное двумерное преобразование Фурье (см. fft.IFFT2).
+func (e *Engine) IFFT2(a *graph.Node) *graph.Node {
+	return e.complexTransform(a, 2, true)
+}
+
+// rfftAdjoint — сопряжённый к RFFT оператор: спектр g [..., bins, 2] дополняется
+// нулями до n частот, и берётся действительная часть n·IFFT.
+func rfftAdjoint(g *tensor.Tensor, n int) *tensor.Tensor {
+	pads := make([][2]int, len(g.Shape))
+	pads[len(pads)-2] = [2]int{0, n - g.Shape[len(g.Shape)-2]}
+	full, err := tensor.Pad(g, pads, tensor.PadConstant, 0)
+	if err != nil {
+		panic(err)
+	}
+	x, err := fft.IFFT(full)
+	if err != nil {
+		panic(err)
+	}
+	re, _ := tensor.Narrow(x, -1, 0, 1)
+	re, _ = tensor.Squeeze(re, -1)
+	out := re.Contiguous()
+	tensor.ScaleInPlace(float64(n), out)
+	return out
+}
+
+// irfftAdjoint — сопряжённый к IRFFT(·, n) оператор для спектра из bins частот:
+// частота k получает c_k/n·RFFT(g)_k, где c_k = 2 для частот, входящих в сигнал
+// дважды (k и n-k), и 1 для нулевой и найквистовой.
+func irfftAdjoint(g *tensor.Tensor, n, bins int) *tensor.Tensor {
+	spec, err := fft.RFFT(g)
+	if err != nil {
+		panic(err)
+	}
+	m := n/2 + 1
+	for i := 0; i < len(spec.Data); i += 2 {
+		k := i / 2 % m
+		c := 2.0
+		if k == 0 || (n%2 == 0 && k == n/2) {
+			c = 1
+		}
+		spec.Data[i] *= c / float64(n)
+		spec.Data[i+1] *= c / float64(n)
+	}
+	pads := make([][2]int, len(spec.Shape))
+	if bins > m {
+		pads[len(pads)-2] = [2]int{0, bins - m}
+		spec, _ = tensor.Pad(spec, pads, tensor.PadConstant, 0)
+	} else if bins < m {
+		spec, _ = tensor.Narrow(spec, -2, 0, bins)
+		spec = spec.Contiguous()
+	}
+	return spec
+}
+
+// RFFT
+type RFFTOp struct {
+	Parents []*graph.Node
+}
+
+func (op *RFFTOp) Backward(grad *tensor.Tensor) {
+	p := op.Parents[0]
+	accumulateGrad(p, rfftAdjoint(grad, p.Value.Shape[len(p.Value.Shape)-1]))
+}
+
+// RFFT вычисляет преобразование Фурье действительного сигнала a [..., n] (см. fft.RFFT).
+func (e *Engine) RFFT(a *graph.Node) *graph.Node {
+	val, err := fft.RFFT(a.Value)
+	if err != nil {
+		return nil
+	}
+	op := &RFFTOp{Parents: []*graph.Node{a}}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// IRFFT
+type IRFFTOp struct {
+	Parents []*graph.Node
+}
+
+func (op *IRFFTOp) Backward(grad *tensor.Tensor) {
+	p := op.Parents[0]
+	n := grad.Shape[len(grad.Shape)-1]
+	accumulateGrad(p, irfftAdjoint(grad, n, p.Value.Shape[len(p.Value.Shape)-2]))
+}
+
+// IRFFT восстанавливает действительный сигнал длины n по спектру a (см. fft.IRFFT).
+func (e *Engine) IRFFT(a *graph.Node, n int) *graph.Node {
+	val, err := fft.IRFFT(a.Value, n)
+	if err != nil {
+		return nil
+	}
+	op := &IRFFTOp{Parents: []*graph.Node{a}}
+	node := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, node)
+	return node
+}
+
+// STFT
+type STFTOp struct {
+	Parents []*graph.Node
+	Config  fft.STFTConfig
+}
+
+// Backward: сопряжённые операции в обратном порядке — RFFT кадров, умножение
+// на окно, overlap-add (сопряжённый к нарезке на кадры) и Unpad полей Center.
+func (op *STFTOp) Backward(grad *tensor.Tensor) {
+	p, cfg := op.Parents[0], op.Config
+	frames := rfftAdjoint(grad, cfg.NFFT)
+	frames, err := tensor.Mul(frames, cfg.Window)
+	if err != nil {
+		panic(err)
+	}
+	pads := cfg.CenterPads(p.Value.Shape)
+	length := p.Value.Shape[len(p.Value.Shape)-1] + pads[len(pads)-1][0] + pads[len(pads)-1][1]
+	g, err := fft.OverlapAdd(frames, cfg.Hop, length)
+	if err != nil {
+		panic(err)
+	}
+	if g, err = tensor.Unpad(g, pads, tensor.PadReflect); err != nil {
+		panic(err)
+	}
+	accumulateGrad(p, g)
+}
+
+// STFT вычисляет оконное преобразование Фурье сигнала a [..., T] (см. fft.STFT).
+func (e *Engine) STFT(a *graph.Node, cfg fft.STFTConfig) *graph.Node {
+	cfg, err := cfg.Resolve()
+	if err != nil {
+		return nil
+	}
+	val, err := fft.STFT(a.Value, cfg)
+	if err != nil {
+		return nil
+	}
+	op := &STFTOp{Parents: []*graph.Node{a}, Config: cfg}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// ISTFT
+type ISTFTOp struct {
+	Parents []*graph.Node
+	Config  fft.STFTConfig
+}
+
+// Backward: градиент делится на огибающую окна, нарезается на кадры,
+// умножается на окно и проходит через сопряжённый к IRFFT оператор.
+func (op *ISTFTOp) Backward(grad *tensor.Tensor) {
+	p, cfg := op.Parents[0], op.Config
+	shape := p.Value.Shape
+	nf, bins := shape[len(shape)-3], shape[len(shape)-2]
+	env := cfg.WindowEnvelope(nf)
+	total := len(env.Data)
+	start := 0
+	if cfg.Center {
+		start = cfg.NFFT / 2
+	}
+	g := grad.Contiguous()
+	length := g.Shape[len(g.Shape)-1]
+	full := tensor.Zeros(append(append([]int{}, g.Shape[:len(g.Shape)-1]...), total)...)
+	for b := 0; b*total < len(full.Data); b++ {
+		for j := 0; j < length && start+j < total; j++ {
+			if v := env.Data[start+j]; v > fft.EnvelopeFloor {
+				full.Data[b*total+start+j] = g.Data[b*length+j] / v
+			}
+		}
+	}
+	frames, err := fft.Frame(full, cfg.NFFT, cfg.Hop)
+	if err != nil {
+		panic(err)
+	}
+	if frames, err = tensor.Mul(frames, cfg.Window); err != nil {
+		panic(err)
+	}
+	accumulateGrad(p, irfftAdjoint(frames, cfg.NFFT, bins))
+}
+
+// ISTFT восстанавливает сигнал длины length по спектру a [..., F, bins, 2] (см. fft.ISTFT).
+func (e *Engine) ISTFT(a *graph.Node, cfg fft.STFTConfig, length int) *graph.Node {
+	cfg, err := cfg.Resolve()
+	if err != nil {
+		return nil
+	}
+	val, err := fft.ISTFT(a.Value, cfg, length)
+	if err != nil {
+		return nil
+	}
+	op := &ISTFTOp{Parents: []*graph.Node{a}, Config: cfg}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// Magnitude / Power
+type ComplexNormOp struct {
+	Parents []*graph.Node
+	Squared bool
+}
+
+// Backward: d|z|/dz = z/|z| (0 при z = 0), d|z|²/dz = 2z.
+func (op *ComplexNormOp) Backward(grad *tensor.Tensor) {
+	p := op.Parents[0]
+	z := p.Value.Contiguous()
+	g := grad.Contiguous()
+	gLocal := tensor.Zeros(append([]int{}, p.Value.Shape...)...)
+	for i, gi := range g.Data {
+		re, im := z.Data[2*i], z.Data[2*i+1]
+		scale := 2 * gi
+		if !op.Squared {
+			r := math.Hypot(re, im)
+			if r == 0 {
+				continue
+			}
+			scale = gi / r
+		}
+		gLocal.Data[2*i], gLocal.Data[2*i+1] = scale*re, scale*im
+	}
+	accumulateGrad(p, gLocal)
+}
+
+func (e *Engine) complexNorm(a *graph.Node, squared bool) *graph.Node {
+	f := fft.Magnitude
+	if squared {
+		f = fft.Power
+	}
+	val, err := f(a.Value)
+	if err != nil {
+		return nil
+	}
+	op := &ComplexNormOp{Parents: []*graph.Node{a}, Squared: squared}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// Magnitude возвращает модуль комплексного тензора a [..., 2] (см. fft.Magnitude).
+func (e *Engine) Magnitude(a *graph.Node) *graph.Node {
+	return e.complexNorm(a, false)
+}
+
+// Power возвращает квадрат модуля комплексного тензора a [..., 2] (см. fft.Power).
+func (e *Engine) Power(a *graph.Node) *graph.Node {
+	return e.complexNorm(a, true)
+}
@@ -0,0 +1,61 @@
+package autograd
+
+import (
+	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/fft"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+func TestFFTOpsGradientCheck(t *testing.T) {
+	stft := fft.STFTConfig{NFFT: 8, Hop: 3, Center: true}
+	checkGradCases(t, []gradCase{
+		unaryCase("FFT", tensor.Randn([]int{2, 6, 2}, 1), func(e *Engine, in *graph.Node) *graph.Node { return e.FFT(in) }),
+		unaryCase("IFFT", tensor.Randn([]int{5, 2}, 2), func(e *Engine, in *graph.Node) *graph.Node { return e.IFFT(in) }),
+		unaryCase("FFT2", tensor.Randn([]int{3, 4, 2}, 3), func(e *Engine, in *graph.Node) *graph.Node { return e.FFT2(in) }),
+		unaryCase("IFFT2", tensor.Randn([]int{2, 3, 2}, 4), func(e *Engine, in *graph.Node) *graph.Node { return e.IFFT2(in) }),
+		unaryCase("RFFTEven", tensor.Randn([]int{2, 8}, 5), func(e *Engine, in *graph.Node) *graph.Node { return e.RFFT(in) }),
+		unaryCase("RFFTOdd", tensor.Randn([]int{7}, 6), func(e *Engine, in *graph.Node) *graph.Node { return e.RFFT(in) }),
+		unaryCase("IRFFTEven", tensor.Randn([]int{2, 5, 2}, 7), func(e *Engine, in *graph.Node) *graph.Node { return e.IRFFT(in, 8) }),
+		unaryCase("IRFFTOdd", tensor.Randn([]int{4, 2}, 8), func(e *Engine, in *graph.Node) *graph.Node { return e.IRFFT(in, 7) }),
+		unaryCase("IRFFTTruncated", tensor.Randn([]int{6, 2}, 9), func(e *Engine, in *graph.Node) *graph.Node { return e.IRFFT(in, 6) }),
+		unaryCase("STFTCenter", tensor.Randn([]int{2, 20}, 10), func(e *Engine, in *graph.Node) *graph.Node { return e.STFT(in, stft) }),
+		unaryCase("STFT", tensor.Randn([]int{19}, 11), func(e *Engine, in *graph.Node) *graph.Node {
+			return e.STFT(in, fft.STFTConfig{NFFT: 6, Hop: 4, Window: fft.HammingWindow(6)})
+		}),
+		unaryCase("ISTFT", tensor.Randn([]int{7, 5, 2}, 12), func(e *Engine, in *graph.Node) *graph.Node { return e.ISTFT(in, stft, 20) }),
+		unaryCase("Magnitude", tensor.Randn([]int{3, 2}, 13), func(e *Engine, in *graph.Node) *graph.Node { return e.Magnitude(in) }),
+		unaryCase("Power", tensor.Randn([]int{3, 2}, 14), func(e *Engine, in *graph.Node) *graph.Node { return e.Power(in) }),
+	}, weightedOutput)
+}
+
+func TestSpectralLossTrainsSignal(t *testing.T) {
+	// Минимизация расстояния между спектрами мощности приближает спектр сигнала к целевому.
+	cfg := fft.STFTConfig{NFFT: 8, Hop: 4, Center: true}
+	target, err := fft.STFT(tensor.Randn([]int{32}, 21), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetPower, _ := fft.Power(target)
+	x := tensor.Randn([]int{32}, 22)
+
+	loss := func() (*Engine, *graph.Node, *graph.Node) {
+		e := NewEngine()
+		in := e.RequireGrad(x)
+		diff := e.Sub(e.Power(e.STFT(in, cfg)), graph.NewNode(targetPower, nil, nil))
+		return e, in, e.Sum(e.Mul(diff, diff))
+	}
+	_, _, first := loss()
+	for step := 0; step < 50; step++ {
+		e, in, l := loss()
+		e.Backward(l)
+		for i, g := range in.Grad.Data {
+			x.Data[i] -= 1e-3 * g
+		}
+	}
+	_, _, last := loss()
+	if last.Value.Data[0] >= first.Value.Data[0]/2 {
+		t.Fatalf("спектральная функция потерь не уменьшилась: %v -> %v", first.Value.Data[0], last.Value.Data[0])
+	}
+}
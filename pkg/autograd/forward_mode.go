@@ -0,0 +1,286 @@
+package autograd
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+// Прямой режим дифференцирования на дуальных числах: каждому узлу сопоставляется
+// пара (значение, касательная) x + ẋ·ε с ε² = 0, и касательные распространяются
+// от входов к выходу в топологическом порядке. Операции, поддерживающие этот
+// режим, реализуют ForwardModeOp: арифметика (Add, Sub, Mul, Div, Pow, Scale),
+// MatMul, Transpose, Reshape, broadcasting и редукции (Sum, SumAxes,
+// MeanAxes), Exp, Log, Sqrt, Abs, Clamp, активации (ReLU, LeakyReLU, Sigmoid,
+// Tanh, SoftPlus, Softmax), функции потерь (MSE, CrossEntropy,
+// SoftmaxCrossEntropy, Hinge, BinaryCrossEntropy) и layers.Dense.
+// Для остальных операций JVP возвращает ошибку.
+
+// ForwardModeOp — операция с правилом прямого режима.
+type ForwardModeOp interface {
+	graph.Operation
+	// JVP возвращает касательную выхода out по касательным родителей
+	// (в порядке Parents; отсутствующие касательные передаются нулями).
+	JVP(out *tensor.Tensor, tangents []*tensor.Tensor) *tensor.Tensor
+}
+
+// tangents распространяет касательные seed (узел → касательная) до root.
+// Возвращает касательную root (nil, если root не зависит от seed).
+func (e *Engine) tangents(root *graph.Node, seed map[*graph.Node]*tensor.Tensor) (*tensor.Tensor, error) {
+	dual := make(map[*graph.Node]*tensor.Tensor, len(seed))
+	for n, t := range seed {
+		dual[n] = t
+	}
+	for _, n := range e.topologicalSort(root) {
+		if _, ok := dual[n]; ok || n.Operation == nil {
+			continue
+		}
+		ts := make([]*tensor.Tensor, len(n.Parents))
+		active := false
+		for i, p := range n.Parents {
+			ts[i] = dual[p]
+			active = active || ts[i] != nil
+		}
+		if !active {
+			continue
+		}
+		op, ok := n.Operation.(ForwardModeOp)
+		if !ok {
+			return nil, fmt.Errorf("autograd: операция %T не поддерживает прямой режим", n.Operation)
+		}
+		for i, p := range n.Parents {
+			if ts[i] == nil {
+				ts[i] = tensor.Zeros(p.Value.Shape...)
+			}
+		}
+		dual[n] = op.JVP(n.Value, ts)
+	}
+	return dual[root], nil
+}
+
+func must(t *tensor.Tensor, err error) *tensor.Tensor {
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// scaleLocal возвращает t·local(x) поэлементно.
+func scaleLocal(t, x *tensor.Tensor, local func(float64) float64) *tensor.Tensor {
+	return must(tensor.Mul(t, tensor.Apply(x, local)))
+}
+
+func (op *Add) JVP(_ *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	return must(tensor.Add(t[0], t[1]))
+}
+
+func (op *SubOp) JVP(_ *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	return must(tensor.Sub(t[0], t[1]))
+}
+
+func (op *MulOperation) JVP(_ *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	return must(tensor.Add(must(tensor.Mul(t[0], op.B)), must(tensor.Mul(op.A, t[1]))))
+}
+
+// (a/b)' = ȧ/b - a·ḃ/b²
+func (op *DivOp) JVP(out *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	b := op.Parents[1].Value
+	return must(tensor.Div(must(tensor.Sub(t[0], must(tensor.Mul(out, t[1])))), b))
+}
+
+func (op *MatMul) JVP(_ *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	return must(tensor.Add(must(tensor.MatMul(t[0], op.B)), must(tensor.MatMul(op.A, t[1]))))
+}
+
+func (op *TransposeOp) JVP(_ *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	return must(tensor.Transpose(t[0])).Contiguous()
+}
+
+func (op *ReshapeOp) JVP(out *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	return must(tensor.Reshape(t[0].Contiguous(), out.Shape))
+}
+
+func (op *Sum) JVP(_ *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	return tensor.Sum(t[0])
+}
+
+func (op *SumAxesOp) JVP(out *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	return must(tensor.Reshape(must(tensor.SumToShape(t[0], op.KeepShape)), out.Shape))
+}
+
+func (op *MeanAxesOp) JVP(out *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	m := must(tensor.Reshape(must(tensor.SumToShape(t[0], op.KeepShape)), out.Shape)).Clone()
+	tensor.ScaleInPlace(1/float64(op.Count), m)
+	return m
+}
+
+func (op *ScaleOp) JVP(_ *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	s := t[0].Clone()
+	tensor.ScaleInPlace(op.S, s)
+	return s
+}
+
+func (op *BroadcastToOp) JVP(out *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	return must(tensor.Expand(t[0], out.Shape...)).Contiguous()
+}
+
+func (op *SumToShapeOp) JVP(out *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	return must(tensor.SumToShape(t[0], out.Shape))
+}
+
+func (op *Exp) JVP(out *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	return must(tensor.Mul(t[0], out))
+}
+
+func (op *Log) JVP(_ *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	return must(tensor.Div(t[0], op.In))
+}
+
+func (op *SigmoidOp) JVP(out *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	return scaleLocal(t[0], out, func(s float64) float64 { return s * (1 - s) })
+}
+
+func (op *TanhOp) JVP(out *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	return scaleLocal(t[0], out, func(y float64) float64 { return 1 - y*y })
+}
+
+func (op *SoftPlusOp) JVP(_ *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	return must(tensor.Mul(t[0], op.sigmoid))
+}
+
+func (op *ReLUOp) JVP(_ *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	return scaleLocal(t[0], op.input.Value, op.deriv)
+}
+
+func (op *LeakyReLUOp) JVP(_ *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	return scaleLocal(t[0], op.input.Value, op.deriv)
+}
+
+func (op *ClampOp) JVP(_ *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	return scaleLocal(t[0], op.Parents[0].Value, op.deriv)
+}
+
+func (op *AbsOp) JVP(_ *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	return scaleLocal(t[0], op.Parents[0].Value, op.deriv)
+}
+
+func (op *SqrtOp) JVP(out *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	return scaleLocal(t[0], out, func(y float64) float64 { return 0.5 / y })
+}
+
+func (op *PowScalarOp) JVP(_ *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	return scaleLocal(t[0], op.Parents[0].Value, func(x float64) float64 {
+		if op.P == 0 {
+			return 0
+		}
+		return op.P * math.Pow(x, op.P-1)
+	})
+}
+
+// MSE' = 2/n · Σ (pred - target)·ṗred
+func (op *MSELossOp) JVP(_ *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	dot := 0.0
+	tc := t[0].Contiguous()
+	for i, d := range op.diff.Data {
+		dot += d * tc.Data[i]
+	}
+	out := tensor.Zeros(1)
+	out.Data[0] = 2 / op.n * dot
+	return out
+}
+
+// (a^b)' = ȧ·b·a^(b-1) + ḃ·a^b·ln(a) (для a <= 0 второе слагаемое 0, как в Backward)
+func (op *PowOp) JVP(out *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	a, b := op.Parents[0].Value, op.Parents[1].Value
+	dA := binaryLocal(a, b, func(x, y float64) float64 {
+		if y == 0 {
+			return 0
+		}
+		return y * math.Pow(x, y-1)
+	})
+	logA := binaryLocal(a, b, func(x, _ float64) float64 {
+		if x <= 0 {
+			return 0
+		}
+		return math.Log(x)
+	})
+	dB := must(tensor.Mul(out, logA))
+	return must(tensor.Add(must(tensor.Mul(dA, t[0])), must(tensor.Mul(dB, t[1]))))
+}
+
+// ṡ = s ⊙ (ẋ - ⟨s, ẋ⟩) по последней оси
+func (op *SoftmaxOp) JVP(out *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	tc := t[0].Contiguous()
+	res := tensor.Zeros(out.Shape...)
+	cols := out.Shape[len(out.Shape)-1]
+	for base := 0; base < len(res.Data); base += cols {
+		dot := 0.0
+		for c := 0; c < cols; c++ {
+			dot += out.Data[base+c] * tc.Data[base+c]
+		}
+		for c := 0; c < cols; c++ {
+			res.Data[base+c] = out.Data[base+c] * (tc.Data[base+c] - dot)
+		}
+	}
+	return res
+}
+
+// L' = Σ (softmax - target)·l̇ / batch
+func (op *CrossEntropyLogitsOp) JVP(_ *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	batchSize, numClasses := op.logits.Value.Shape[0], op.logits.Value.Shape[1]
+	dot := 0.0
+	for i := range batchSize {
+		for j := range numClasses {
+			idx := i*op.logits.Value.Strides[0] + j*op.logits.Value.Strides[1]
+			targetIdx := i*op.target.Strides[0] + j*op.target.Strides[1]
+			dot += (op.softmax.Data[idx] - op.target.Data[targetIdx]) * t[0].At(i, j)
+		}
+	}
+	out := tensor.Zeros(1)
+	out.Data[0] = dot / float64(batchSize)
+	return out
+}
+
+// L'ᵢ = Σⱼ (softmax - target)ᵢⱼ·ẋᵢⱼ для каждой строки
+func (op *SoftmaxCrossEntropyOp) JVP(out *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	rows, cols := op.input.Value.Shape[0], op.input.Value.Shape[1]
+	res := tensor.Zeros(out.Shape...)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			idx := i*op.input.Value.Strides[0] + j*op.input.Value.Strides[1]
+			targetIdx := i*op.target.Strides[0] + j*op.target.Strides[1]
+			res.Data[i] += (op.softmax.Data[idx] - op.target.Data[targetIdx]) * t[0].At(i, j)
+		}
+	}
+	return res
+}
+
+// L' = -Σ target·ṗred / n по элементам с положительным margin
+func (op *HingeLossOp) JVP(_ *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	tc := t[0].Contiguous()
+	dot := 0.0
+	for i, m := range op.margin.Data {
+		if m > 0 {
+			dot -= op.target.Data[i] * tc.Data[i]
+		}
+	}
+	out := tensor.Zeros(1)
+	out.Data[0] = dot / op.n
+	return out
+}
+
+// L' = Σ (p - y)/(p(1-p))·ṗ / n
+func (op *BinaryCrossEntropyOp) JVP(_ *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	const eps = 1e-15
+	tc := t[0].Contiguous()
+	dot := 0.0
+	for i, p := range op.pred.Value.Data {
+		p = min(max(p, eps), 1-eps)
+		dot += (p - op.target.Data[i]) / (p * (1 - p)) * tc.Data[i]
+	}
+	out := tensor.Zeros(1)
+	out.Data[0] = dot / op.n
+	return out
+}
@@ -0,0 +1,205 @@
+package autograd
+
+import (
+	"fmt"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+// Функциональный API поверх Engine: функция f строит граф на свежем Engine
+// из листьев xs (как build в CheckGradientEngine), а Grad, VJP, JVP, Jacobian,
+// Hessian и HessianVectorProduct возвращают производные как тензоры.
+// Для нескалярного выхода Grad и Hessian дифференцируют сумму его элементов.
+//
+// Производные первого порядка (Grad, VJP, Jacobian) считаются обычным Backward
+// и доступны для любых операций. Hessian и HessianVectorProduct строят
+// градиент как граф (Engine.Grad), поэтому операции f должны реализовывать
+// DifferentiableOp; JVP требует ForwardModeOp.
+
+// Func — дифференцируемая функция входов xs.
+type Func func(e *Engine, xs []*graph.Node) *graph.Node
+
+// trace строит граф f в точке inputs.
+func trace(f Func, inputs []*tensor.Tensor) (*Engine, []*graph.Node, *graph.Node, error) {
+	e := NewEngine()
+	xs := make([]*graph.Node, len(inputs))
+	for i, t := range inputs {
+		xs[i] = e.RequireGrad(t)
+	}
+	out := f(e, xs)
+	if out == nil || out.Value == nil {
+		return nil, nil, nil, fmt.Errorf("autograd: функция вернула nil")
+	}
+	return e, xs, out, nil
+}
+
+func values(nodes []*graph.Node) []*tensor.Tensor {
+	res := make([]*tensor.Tensor, len(nodes))
+	for i, n := range nodes {
+		res[i] = n.Value
+	}
+	return res
+}
+
+// checkShapes проверяет, что ts[i] имеют формы xs[i].
+func checkShapes(what string, ts []*tensor.Tensor, xs []*tensor.Tensor) error {
+	if len(ts) != len(xs) {
+		return fmt.Errorf("autograd: %d %s для %d входов", len(ts), what, len(xs))
+	}
+	for i := range ts {
+		if !shapesEqual(ts[i].Shape, xs[i].Shape) {
+			return fmt.Errorf("autograd: %s %d формы %v, ожидалась %v", what, i, ts[i].Shape, xs[i].Shape)
+		}
+	}
+	return nil
+}
+
+// vjp возвращает vᵀ·∂out/∂xs[i], вычисленные обычным Backward с градиентом
+// выхода v. Градиенты xs и узлов графа out предварительно обнуляются, поэтому
+// vjp можно вызывать повторно на том же графе.
+func (e *Engine) vjp(out *graph.Node, v *tensor.Tensor, xs []*graph.Node) ([]*tensor.Tensor, error) {
+	if !shapesEqual(v.Shape, out.Value.Shape) {
+		return nil, fmt.Errorf("autograd: градиент формы %v для выхода формы %v", v.Shape, out.Value.Shape)
+	}
+	for _, n := range append(e.topologicalSort(out), xs...) {
+		n.ZeroGrad()
+	}
+	e.backwardFrom(out, v.Clone())
+	res := make([]*tensor.Tensor, len(xs))
+	for i, x := range xs {
+		if res[i] = x.Grad; res[i] == nil {
+			res[i] = tensor.Zeros(x.Value.Shape...)
+		}
+	}
+	return res, nil
+}
+
+// Grad возвращает градиенты f по каждому из inputs.
+func Grad(f Func, inputs []*tensor.Tensor) ([]*tensor.Tensor, error) {
+	e, xs, out, err := trace(f, inputs)
+	if err != nil {
+		return nil, err
+	}
+	return e.vjp(out, tensor.Ones(out.Value.Shape...), xs)
+}
+
+// VJP возвращает значение f и произведение вектора v (формы выхода) на якобиан:
+// vᵀ·∂f/∂inputs[i] для каждого входа.
+func VJP(f Func, inputs []*tensor.Tensor, v *tensor.Tensor) (*tensor.Tensor, []*tensor.Tensor, error) {
+	e, xs, out, err := trace(f, inputs)
+	if err != nil {
+		return nil, nil, err
+	}
+	grads, err := e.vjp(out, v, xs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out.Value, grads, nil
+}
+
+// JVP возвращает значение f и производную по направлению tangents
+// (Σ ∂f/∂inputs[i]·tangents[i]), вычисленную в прямом режиме на дуальных числах.
+func JVP(f Func, inputs, tangents []*tensor.Tensor) (*tensor.Tensor, *tensor.Tensor, error) {
+	if err := checkShapes("касательных", tangents, inputs); err != nil {
+		return nil, nil, err
+	}
+	e, xs, out, err := trace(f, inputs)
+	if err != nil {
+		return nil, nil, err
+	}
+	seed := make(map[*graph.Node]*tensor.Tensor, len(xs))
+	for i, x := range xs {
+		seed[x] = tangents[i]
+	}
+	t, err := e.tangents(out, seed)
+	if err != nil {
+		return nil, nil, err
+	}
+	if t == nil {
+		t = tensor.Zeros(out.Value.Shape...)
+	}
+	return out.Value, t, nil
+}
+
+// Jacobian возвращает якобианы f по каждому входу: J[i] имеет форму
+// out.Shape ++ inputs[i].Shape. Строки считаются обратным режимом, по одной
+// на элемент выхода.
+func Jacobian(f Func, inputs []*tensor.Tensor) ([]*tensor.Tensor, error) {
+	e, xs, out, err := trace(f, inputs)
+	if err != nil {
+		return nil, err
+	}
+	return jacobianOf(e, out, xs)
+}
+
+// jacobianOf собирает якобиан узла out по xs построчно через vjp.
+func jacobianOf(e *Engine, out *graph.Node, xs []*graph.Node) ([]*tensor.Tensor, error) {
+	rows := int(out.Value.Size())
+	jac := make([]*tensor.Tensor, len(xs))
+	for i, x := range xs {
+		jac[i] = tensor.Zeros(append(append([]int{}, out.Value.Shape...), x.Value.Shape...)...)
+	}
+	for r := 0; r < rows; r++ {
+		seed := tensor.Zeros(out.Value.Shape...)
+		seed.Data[r] = 1
+		grads, err := e.vjp(out, seed, xs)
+		if err != nil {
+			return nil, err
+		}
+		for i, g := range grads {
+			cols := int(xs[i].Value.Size())
+			copy(jac[i].Data[r*cols:(r+1)*cols], g.Contiguous().Data)
+		}
+	}
+	return jac, nil
+}
+
+// Hessian возвращает блоки матрицы вторых производных f:
+// H[i][j] = ∂²f/∂inputs[i]∂inputs[j] формы inputs[i].Shape ++ inputs[j].Shape.
+func Hessian(f Func, inputs []*tensor.Tensor) ([][]*tensor.Tensor, error) {
+	e, xs, out, err := trace(f, inputs)
+	if err != nil {
+		return nil, err
+	}
+	grads, err := e.Grad([]*graph.Node{out}, xs)
+	if err != nil {
+		return nil, err
+	}
+	h := make([][]*tensor.Tensor, len(xs))
+	for i, g := range grads {
+		if h[i], err = jacobianOf(e, g, xs); err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// HessianVectorProduct возвращает H·v для f без построения всей матрицы H:
+// градиент скалярного произведения ⟨∇f, v⟩ (v[i] формы inputs[i]).
+func HessianVectorProduct(f Func, inputs, v []*tensor.Tensor) ([]*tensor.Tensor, error) {
+	if err := checkShapes("векторов", v, inputs); err != nil {
+		return nil, err
+	}
+	e, xs, out, err := trace(f, inputs)
+	if err != nil {
+		return nil, err
+	}
+	grads, err := e.Grad([]*graph.Node{out}, xs)
+	if err != nil {
+		return nil, err
+	}
+	var dot *graph.Node
+	for i, g := range grads {
+		term := e.Sum(e.Mul(g, e.constant(v[i])))
+		if dot == nil {
+			dot = term
+		} else {
+			dot = e.Add(dot, term)
+		}
+	}
+	if dot == nil {
+		return nil, nil
+	}
+	return e.vjp(dot, tensor.Ones(dot.Value.Shape...), xs)
+}
@@ -0,0 +1,307 @@
+package autograd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+func assertClose(t *testing.T, name string, got, want []float64, tol float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: длина %d, ожидалась %d", name, len(got), len(want))
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > tol*math.Max(1, math.Abs(want[i])) {
+			t.Fatalf("%s[%d] = %v, want %v", name, i, got[i], want[i])
+		}
+	}
+}
+
+// mlp — небольшая сеть с векторным выходом [3, 2] для проверок якобиана.
+func mlp(e *Engine, xs []*graph.Node) *graph.Node {
+	h := e.Tanh(e.MatMul(xs[0], xs[1]))
+	return e.Add(e.Mul(e.Sigmoid(h), h), e.Exp(e.Scale(h, -0.5)))
+}
+
+func TestFunctionalGrad(t *testing.T) {
+	x := newTensor([]float64{1, -2, 3}, 3)
+	y := newTensor([]float64{0.5, 2, -1}, 3)
+	grads, err := Grad(func(e *Engine, xs []*graph.Node) *graph.Node {
+		return e.Sum(e.Mul(e.Mul(xs[0], xs[0]), xs[1]))
+	}, []*tensor.Tensor{x, y})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertClose(t, "dx", grads[0].Data, []float64{1, -8, -6}, 1e-12)
+	assertClose(t, "dy", grads[1].Data, []float64{1, 4, 9}, 1e-12)
+
+	if _, err := Grad(func(e *Engine, xs []*graph.Node) *graph.Node { return nil }, []*tensor.Tensor{x}); err == nil {
+		t.Fatal("ожидалась ошибка для функции, вернувшей nil")
+	}
+}
+
+func TestFirstOrderWithoutBackwardGraph(t *testing.T) {
+	// Einsum, Concatenate и Pow не поддерживают CreateGraph, но Grad, VJP и
+	// Jacobian считаются обычным Backward.
+	a := newTensor([]float64{1, 2, 3, 4}, 2, 2)
+	b := newTensor([]float64{0.5, -1, 2, 1.5}, 2, 2)
+	two := tensor.Ones(4, 2)
+	tensor.FillInPlace(two, 2)
+	f := func(e *Engine, xs []*graph.Node) *graph.Node {
+		prod := e.Einsum("ij,jk->ik", xs[0], xs[1])
+		return e.Pow(e.Concatenate([]*graph.Node{prod, xs[0]}, 0), e.constant(two))
+	}
+	grads, err := Grad(func(e *Engine, xs []*graph.Node) *graph.Node { return e.Sum(f(e, xs)) }, []*tensor.Tensor{a, b})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Σ (A·B)² + Σ A²: dA = 2·(A·B)·Bᵀ + 2·A, dB = 2·Aᵀ·(A·B).
+	assertClose(t, "dA", grads[0].Data, []float64{2.5, 28, 9.5, 55}, 1e-12)
+	assertClose(t, "dB", grads[1].Data, []float64{66, 22, 94, 32}, 1e-12)
+
+	jac, err := Jacobian(f, []*tensor.Tensor{a, b})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !shapesEqual(jac[0].Shape, []int{4, 2, 2, 2}) {
+		t.Fatalf("форма якобиана %v", jac[0].Shape)
+	}
+	// Сумма строк якобиана — это градиент суммы выхода.
+	for i, j := range jac {
+		sum := make([]float64, 4)
+		for k, v := range j.Data {
+			sum[k%4] += v
+		}
+		assertClose(t, "Σ J", sum, grads[i].Data, 1e-12)
+	}
+}
+
+func TestJacobianVJPAndJVPAgree(t *testing.T) {
+	inputs := []*tensor.Tensor{tensor.Randn([]int{3, 4}, 2201), tensor.Randn([]int{4, 2}, 2202)}
+	tangents := []*tensor.Tensor{tensor.Randn([]int{3, 4}, 2203), tensor.Randn([]int{4, 2}, 2204)}
+	v := tensor.Randn([]int{3, 2}, 2205)
+
+	jac, err := Jacobian(mlp, inputs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertShape := func(got *tensor.Tensor, shape ...int) {
+		t.Helper()
+		if !shapesEqual(got.Shape, shape) {
+			t.Fatalf("форма якобиана %v, ожидалась %v", got.Shape, shape)
+		}
+	}
+	assertShape(jac[0], 3, 2, 3, 4)
+	assertShape(jac[1], 3, 2, 4, 2)
+
+	// J·t через якобиан и через прямой режим.
+	out, jvp, err := JVP(mlp, inputs, tangents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := make([]float64, 6)
+	for r := range want {
+		for i, j := range jac {
+			cols := len(tangents[i].Data)
+			for c := 0; c < cols; c++ {
+				want[r] += j.Data[r*cols+c] * tangents[i].Data[c]
+			}
+		}
+	}
+	assertClose(t, "JVP", jvp.Data, want, 1e-10)
+
+	// Конечные разности для проверки самого якобиана.
+	shifted := func(sign float64) []float64 {
+		const eps = 1e-6
+		xs := make([]*tensor.Tensor, len(inputs))
+		for i, in := range inputs {
+			xs[i] = in.Clone()
+			for k := range xs[i].Data {
+				xs[i].Data[k] += sign * eps * tangents[i].Data[k]
+			}
+		}
+		e := NewEngine()
+		return mlp(e, []*graph.Node{e.RequireGrad(xs[0]), e.RequireGrad(xs[1])}).Value.Data
+	}
+	plus, minus := shifted(1), shifted(-1)
+	for r := range want {
+		if fd := (plus[r] - minus[r]) / 2e-6; math.Abs(fd-jvp.Data[r]) > 1e-6 {
+			t.Fatalf("JVP[%d] = %v, конечные разности %v", r, jvp.Data[r], fd)
+		}
+	}
+	if len(out.Data) != 6 {
+		t.Fatalf("значение f формы %v", out.Shape)
+	}
+
+	// vᵀ·J через VJP и через якобиан.
+	_, vjp, err := VJP(mlp, inputs, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, j := range jac {
+		cols := len(inputs[i].Data)
+		want := make([]float64, cols)
+		for r, vr := range v.Data {
+			for c := range want {
+				want[c] += vr * j.Data[r*cols+c]
+			}
+		}
+		assertClose(t, "VJP", vjp[i].Data, want, 1e-10)
+	}
+}
+
+func TestJVPForwardRules(t *testing.T) {
+	x := tensor.Randn([]int{3, 4}, 2206)
+	positive := tensor.Apply(x, func(v float64) float64 { return math.Abs(v) + 0.5 })
+	row := tensor.Apply(tensor.Randn([]int{4}, 2207), func(v float64) float64 { return math.Abs(v) + 0.5 })
+	target := tensor.Randn([]int{3, 4}, 2208)
+	oneHot := newTensor([]float64{0, 1, 0, 0, 1, 0, 0, 0, 0, 0, 0, 1}, 3, 4)
+	signs := tensor.Apply(oneHot, func(v float64) float64 { return 2*v - 1 })
+
+	cases := []struct {
+		name   string
+		inputs []*tensor.Tensor
+		f      Func
+	}{
+		{"Div", []*tensor.Tensor{x, row}, func(e *Engine, xs []*graph.Node) *graph.Node { return e.Div(xs[0], xs[1]) }},
+		{"Sub", []*tensor.Tensor{x, row}, func(e *Engine, xs []*graph.Node) *graph.Node { return e.Sub(xs[1], xs[0]) }},
+		{"LogSqrt", []*tensor.Tensor{positive}, func(e *Engine, xs []*graph.Node) *graph.Node {
+			return e.Mul(e.Log(xs[0]), e.Sqrt(xs[0]))
+		}},
+		{"PowScalarSoftPlus", []*tensor.Tensor{positive}, func(e *Engine, xs []*graph.Node) *graph.Node {
+			return e.SoftPlus(e.PowScalar(xs[0], 1.5))
+		}},
+		{"Piecewise", []*tensor.Tensor{x}, func(e *Engine, xs []*graph.Node) *graph.Node {
+			a := e.Add(e.ReLU(xs[0]), e.LeakyReLU(xs[0], 0.1))
+			return e.Mul(e.Add(a, e.Abs(xs[0])), e.Clamp(xs[0], -0.5, 0.5))
+		}},
+		{"Reductions", []*tensor.Tensor{x}, func(e *Engine, xs []*graph.Node) *graph.Node {
+			s := e.SumAxes(e.Exp(xs[0]), []int{1}, true)
+			m := e.MeanAxes(e.Mul(xs[0], xs[0]), []int{0}, false)
+			return e.Add(e.Mul(s, e.BroadcastTo(m, []int{3, 4})), e.Sum(xs[0]))
+		}},
+		{"ReshapeTranspose", []*tensor.Tensor{x}, func(e *Engine, xs []*graph.Node) *graph.Node {
+			return e.SumToShape(e.Transpose(e.Reshape(e.Tanh(xs[0]), []int{4, 3})), []int{1, 4})
+		}},
+		{"MSELoss", []*tensor.Tensor{x}, func(e *Engine, xs []*graph.Node) *graph.Node {
+			return e.MSELoss(e.Sigmoid(xs[0]), target)
+		}},
+		{"Pow", []*tensor.Tensor{positive, x}, func(e *Engine, xs []*graph.Node) *graph.Node { return e.Pow(xs[0], xs[1]) }},
+		{"Softmax", []*tensor.Tensor{x}, func(e *Engine, xs []*graph.Node) *graph.Node { return e.Softmax(xs[0]) }},
+		{"CrossEntropyLoss", []*tensor.Tensor{x}, func(e *Engine, xs []*graph.Node) *graph.Node {
+			return e.CrossEntropyLoss(xs[0], oneHot)
+		}},
+		{"SoftmaxCrossEntropy", []*tensor.Tensor{x}, func(e *Engine, xs []*graph.Node) *graph.Node {
+			return e.SoftmaxCrossEntropy(xs[0], oneHot)
+		}},
+		{"HingeLoss", []*tensor.Tensor{x}, func(e *Engine, xs []*graph.Node) *graph.Node {
+			return e.HingeLoss(xs[0], signs)
+		}},
+		{"BinaryCrossEntropy", []*tensor.Tensor{x}, func(e *Engine, xs []*graph.Node) *graph.Node {
+			return e.BinaryCrossEntropy(e.Sigmoid(xs[0]), oneHot)
+		}},
+	}
+	for i, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tangents := make([]*tensor.Tensor, len(tc.inputs))
+			for j, in := range tc.inputs {
+				tangents[j] = tensor.Randn(in.Shape, int64(2300+10*i+j))
+			}
+			_, jvp, err := JVP(tc.f, tc.inputs, tangents)
+			if err != nil {
+				t.Fatal(err)
+			}
+			eval := func(sign float64) []float64 {
+				e := NewEngine()
+				xs := make([]*graph.Node, len(tc.inputs))
+				for j, in := range tc.inputs {
+					shifted := in.Clone()
+					for k := range shifted.Data {
+						shifted.Data[k] += sign * 1e-6 * tangents[j].Data[k]
+					}
+					xs[j] = e.RequireGrad(shifted)
+				}
+				return tc.f(e, xs).Value.Data
+			}
+			plus, minus := eval(1), eval(-1)
+			fd := make([]float64, len(plus))
+			for k := range fd {
+				fd[k] = (plus[k] - minus[k]) / 2e-6
+			}
+			assertClose(t, tc.name, jvp.Data, fd, 1e-5)
+		})
+	}
+}
+
+func TestHessianAndHVP(t *testing.T) {
+	// f(x, y) = xᵀ·A·x + Σ x²·y: H_xx = A + Aᵀ + 2·diag(y), H_xy = 2·diag(x), H_yy = 0.
+	a := newTensor([]float64{1, 2, 0, -1, 3, 1, 0.5, 0, 2}, 3, 3)
+	x := newTensor([]float64{1, -2, 0.5}, 3, 1)
+	y := newTensor([]float64{0.3, -1, 2}, 3, 1)
+	f := func(e *Engine, xs []*graph.Node) *graph.Node {
+		quad := e.Sum(e.Mul(xs[0], e.MatMul(graph.NewNode(a, nil, nil), xs[0])))
+		return e.Add(quad, e.Sum(e.Mul(e.Mul(xs[0], xs[0]), xs[1])))
+	}
+	inputs := []*tensor.Tensor{x, y}
+
+	h, err := Hessian(f, inputs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hxx := make([]float64, 9)
+	hxy := make([]float64, 9)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			hxx[i*3+j] = a.Data[i*3+j] + a.Data[j*3+i]
+		}
+		hxx[i*3+i] += 2 * y.Data[i]
+		hxy[i*3+i] = 2 * x.Data[i]
+	}
+	if !shapesEqual(h[0][1].Shape, []int{3, 1, 3, 1}) {
+		t.Fatalf("форма блока H_xy %v", h[0][1].Shape)
+	}
+	assertClose(t, "H_xx", h[0][0].Data, hxx, 1e-12)
+	assertClose(t, "H_xy", h[0][1].Data, hxy, 1e-12)
+	assertClose(t, "H_yx", h[1][0].Data, hxy, 1e-12)
+	assertClose(t, "H_yy", h[1][1].Data, make([]float64, 9), 1e-12)
+
+	v := []*tensor.Tensor{newTensor([]float64{0.2, 1, -1}, 3, 1), newTensor([]float64{-0.5, 0.1, 3}, 3, 1)}
+	hv, err := HessianVectorProduct(f, inputs, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range inputs {
+		want := make([]float64, 3)
+		for r := range want {
+			for j := range inputs {
+				for c := 0; c < 3; c++ {
+					want[r] += h[i][j].Data[r*3+c] * v[j].Data[c]
+				}
+			}
+		}
+		assertClose(t, "Hv", hv[i].Data, want, 1e-12)
+	}
+}
+
+func TestFunctionalErrors(t *testing.T) {
+	x := tensor.Randn([]int{2, 3}, 2209)
+	softmax := func(e *Engine, xs []*graph.Node) *graph.Node { return e.Softmax(xs[0]) }
+	cumsum := func(e *Engine, xs []*graph.Node) *graph.Node { return e.CumSum(xs[0], 1) }
+
+	if _, _, err := JVP(cumsum, []*tensor.Tensor{x}, []*tensor.Tensor{x}); err == nil {
+		t.Fatal("ожидалась ошибка прямого режима для CumSum")
+	}
+	gramDet := func(e *Engine, xs []*graph.Node) *graph.Node { return e.Det(e.MatMul(xs[0], e.Transpose(xs[0]))) }
+	if _, err := Hessian(gramDet, []*tensor.Tensor{x}); err == nil {
+		t.Fatal("ожидалась ошибка CreateGraph для Det в Hessian")
+	}
+	if _, _, err := JVP(mlp, []*tensor.Tensor{x, x}, []*tensor.Tensor{x}); err == nil {
+		t.Fatal("ожидалась ошибка для неверного числа касательных")
+	}
+	if _, _, err := VJP(softmax, []*tensor.Tensor{x}, tensor.Zeros(3)); err == nil {
+		t.Fatal("ожидалась ошибка для v неверной формы")
+	}
+}
@@ -0,0 +1,61 @@
+package autograd
+
+import (
+	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+// gradCase — случай табличной проверки градиента: build строит выход из узлов inputs.
+type gradCase struct {
+	name   string
+	inputs []*tensor.Tensor
+	build  func(e *Engine, in []*graph.Node) *graph.Node
+}
+
+// unaryCase описывает случай с одним входом x.
+func unaryCase(name string, x *tensor.Tensor, build func(e *Engine, in *graph.Node) *graph.Node) gradCase {
+	return gradCase{name, []*tensor.Tensor{x}, func(e *Engine, in []*graph.Node) *graph.Node {
+		return build(e, in[0])
+	}}
+}
+
+// binaryCase описывает случай с двумя входами a и b.
+func binaryCase(name string, a, b *tensor.Tensor, build func(e *Engine, a, b *graph.Node) *graph.Node) gradCase {
+	return gradCase{name, []*tensor.Tensor{a, b}, func(e *Engine, in []*graph.Node) *graph.Node {
+		return build(e, in[0], in[1])
+	}}
+}
+
+// gradWrap преобразует выход случая перед проверкой.
+type gradWrap func(e *Engine, out *graph.Node, inputs []*graph.Node) *graph.Node
+
+// checkGradCases проверяет каждый случай через CheckGradientEngine в отдельном
+// подтесте; wrap, если не nil, применяется к выходу build.
+func checkGradCases(t *testing.T, cases []gradCase, wrap gradWrap) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			build := func(e *Engine, inputs []*graph.Node) *graph.Node {
+				out := tc.build(e, inputs)
+				if wrap != nil {
+					out = wrap(e, out, inputs)
+				}
+				return out
+			}
+			inputs := make([]*graph.Node, len(tc.inputs))
+			for i, x := range tc.inputs {
+				inputs[i] = graph.NewNode(x, nil, nil)
+			}
+			if !CheckGradientEngine(build, inputs, 1e-6, 1e-4) {
+				t.Errorf("%s gradient check failed", tc.name)
+			}
+		})
+	}
+}
+
+// weightedOutput — gradWrap, взвешивающий выход через weighted.
+func weightedOutput(e *Engine, out *graph.Node, _ []*graph.Node) *graph.Node {
+	return weighted(e, out)
+}
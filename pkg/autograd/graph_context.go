@@ -3,41 +3,99 @@ package autograd
 import (
 	"sync"
 
+	"github.com/Hirogava/Go-NN-Learn/pkg/profiling"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
 )
 
+// GraphContext владеет графом одного шага: Engine, областью режима autograd
+// (graph.Scope) и, если подключена, ареной. Режим и арена действуют на узлы,
+// вычисленные из входов графа (Input, RequireGrad); режим задаётся WithGrad,
+// NoGrad и InferenceMode.
 type GraphContext struct {
-	engine      *Engine
-	gradEnabled bool
-	released    bool
-	mu          sync.RWMutex
+	engine   *Engine
+	scope    *graph.Scope
+	released bool
+	arena    *tensor.Arena
+	profiler *profiling.Profiler
+	mu       sync.RWMutex
 }
 
 func NewGraph() *GraphContext {
+	scope := graph.NewScope(graph.ModeGrad)
 	return &GraphContext{
-		engine:      NewEngine(),
-		gradEnabled: true,
-		released:    false,
+		engine:   NewEngineIn(scope),
+		scope:    scope,
+		released: false,
 	}
 }
 
 func (g *GraphContext) WithGrad() {
-	g.mu.Lock()
-	defer g.mu.Unlock()
-	g.gradEnabled = true
+	g.scope.SetMode(graph.ModeGrad)
 }
 
 func (g *GraphContext) NoGrad() {
+	g.scope.SetMode(graph.ModeNoGrad)
+}
+
+// InferenceMode переводит граф в режим инференса (см. graph.ModeInference).
+func (g *GraphContext) InferenceMode() {
+	g.scope.SetMode(graph.ModeInference)
+}
+
+func (g *GraphContext) GradEnabled() bool {
+	return g.scope.GradEnabled()
+}
+
+// Scope возвращает область режима графа.
+func (g *GraphContext) Scope() *graph.Scope {
+	return g.scope
+}
+
+// Input возвращает входной лист графа со значением t: узлы, вычисленные из
+// него, следуют режиму графа, а их значения и градиенты выделяются из арены
+// графа, если она подключена.
+func (g *GraphContext) Input(t *tensor.Tensor) *graph.Node {
+	return g.scope.NewNode(g.attach(t), nil, nil)
+}
+
+// attach привязывает t к арене графа (см. tensor.Arena.Attach).
+func (g *GraphContext) attach(t *tensor.Tensor) *tensor.Tensor {
+	if a := g.Arena(); a != nil {
+		return a.Attach(t)
+	}
+	return t
+}
+
+// UseArena подключает к графу арену шага: значения и градиенты узлов,
+// вычисленных из входов графа (Input, RequireGrad), выделяются из неё, а
+// Backward возвращает их в арену разом. Другие вычисления процесса, например
+// предсказатель в соседней горутине, арену не используют. Градиенты и
+// значения листьев (параметров, входов) и значение finalNode копируются из
+// арены до её сброса, остальные тензоры графа после Backward недействительны:
+// то, что хранится дольше шага, выносите из арены через tensor.DetachFromArena.
+//
+// Подключайте арену до создания входов. Одну арену можно передавать графам
+// последовательных шагов — тогда буферы переиспользуются между шагами.
+func (g *GraphContext) UseArena(a *tensor.Arena) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
-	g.gradEnabled = false
+	g.arena = a
 }
 
-func (g *GraphContext) GradEnabled() bool {
+// Arena возвращает арену графа или nil.
+func (g *GraphContext) Arena() *tensor.Arena {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
-	return g.gradEnabled
+	return g.arena
+}
+
+// SetProfiler задаёт профилировщик, в статистику которого (Stats.RecordMemory)
+// записываются пиковая память и число аллокаций арены за шаг.
+func (g *GraphContext) SetProfiler(p *profiling.Profiler) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.profiler = p
 }
 
 func (g *GraphContext) Backward(finalNode *graph.Node) {
@@ -49,23 +107,50 @@ func (g *GraphContext) Backward(finalNode *graph.Node) {
 	g.mu.Unlock()
 
 	g.engine.Backward(finalNode)
+	if a := g.Arena(); a != nil {
+		detachFromArena(a, g.engine.topologicalSort(finalNode), finalNode)
+	}
 	g.release()
 }
 
+// detachFromArena копирует из арены то, что переживает шаг: значения и
+// градиенты листьев (их читают оптимизаторы) и значение finalNode (loss).
+func detachFromArena(a *tensor.Arena, nodes []*graph.Node, finalNode *graph.Node) {
+	for _, n := range nodes {
+		if n.IsLeaf() {
+			n.Value = a.Detach(n.Value)
+			n.Grad = a.Detach(n.Grad)
+		}
+	}
+	finalNode.Value = a.Detach(finalNode.Value)
+}
+
 func (g *GraphContext) release() {
 	g.mu.Lock()
 	g.engine.Nodes = nil
 	g.released = true
+	a, p := g.arena, g.profiler
 	g.mu.Unlock()
 	currentGraph.Lock()
 	if currentGraph.ctx == g {
 		currentGraph.ctx = nil
 	}
 	currentGraph.Unlock()
+	if a != nil {
+		s := a.Reset()
+		if p != nil {
+			p.Stats.RecordMemory(profiling.MemoryStats{
+				Allocs:        s.Allocs,
+				Reused:        s.Reused,
+				PeakBytes:     s.PeakBytes,
+				ReservedBytes: s.ReservedBytes,
+			})
+		}
+	}
 }
 
 func (g *GraphContext) RequireGrad(t *tensor.Tensor) *graph.Node {
-	return g.engine.RequireGrad(t)
+	return g.engine.RequireGrad(g.attach(t))
 }
 
 func (g *GraphContext) ZeroGrad() {
@@ -98,6 +183,24 @@ func GradEnabled() bool {
 	return g != nil && g.GradEnabled()
 }
 
+// GradEnabledFor возвращает true, если операция над x должна строить граф:
+// область x в режиме ModeGrad (для x без области — вне gnn.NoGrad). Текущий
+// граф (SetGraph) на режим не влияет.
+func GradEnabledFor(x *graph.Node) bool {
+	if x.Scope == nil {
+		return !graph.IsNoGrad()
+	}
+	return x.Scope.GradEnabled()
+}
+
+// EngineOf возвращает Engine для операций слоёв над x: его узлы создаются в
+// области x, поэтому вне ModeGrad (NoGrad, InferenceMode) граф не строится
+// даже для узлов только из параметров. Предсказатель не трогает граф
+// обучения из другой горутины: Engine отдельный и от SetGraph не зависит.
+func EngineOf(x *graph.Node) *Engine {
+	return &Engine{scope: x.Scope}
+}
+
 func ClearGraph() {
 	currentGraph.Lock()
 	defer currentGraph.Unlock()
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/Hirogava/Go-NN-Learn/pkg/autograd"
+	"github.com/Hirogava/Go-NN-Learn/pkg/profiling"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
 )
@@ -30,6 +31,59 @@ func TestGraphContext_GradEnabledHelper(t *testing.T) {
 	}
 }
 
+func TestGraphContext_NoGradAppliesToInputs(t *testing.T) {
+	ctx := autograd.NewGraph()
+	e := ctx.Engine()
+	x := ctx.Input(&tensor.Tensor{Data: []float64{1, 2}, Shape: []int{2}, Strides: []int{1}})
+
+	ctx.NoGrad()
+	if y := e.Mul(x, x); y.Operation != nil || y.Grad != nil {
+		t.Fatalf("после ctx.NoGrad() узлы из входа графа не должны строить граф")
+	}
+	ctx.InferenceMode()
+	if y := e.Mul(x, x); y.Operation != nil || y.Grad != nil {
+		t.Fatalf("после ctx.InferenceMode() узлы из входа графа не должны строить граф")
+	}
+	ctx.WithGrad()
+	if y := e.Mul(x, x); y.Operation == nil || y.Grad == nil {
+		t.Fatalf("после ctx.WithGrad() граф должен строиться")
+	}
+
+	// Узлы только из параметров без области следуют режиму графа через Engine.
+	w := graph.NewNode(&tensor.Tensor{Data: []float64{3}, Shape: []int{1}, Strides: []int{1}}, nil, nil)
+	ctx.NoGrad()
+	if y := e.Mul(w, w); y.Operation != nil || y.Grad != nil {
+		t.Fatalf("после ctx.NoGrad() узлы из параметров не должны строить граф")
+	}
+	ctx.WithGrad()
+}
+
+func TestEngineOf_IndependentOfCurrentGraph(t *testing.T) {
+	// Текущий граф в режиме NoGrad не влияет на чужие узлы, и наоборот:
+	// режим EngineOf(x) задаёт только область x.
+	ctx := autograd.NewGraph()
+	ctx.NoGrad()
+	autograd.SetGraph(ctx)
+	defer autograd.ClearGraph()
+
+	val := &tensor.Tensor{Data: []float64{1, 2}, Shape: []int{2}, Strides: []int{1}}
+	x := graph.NewNode(val, nil, nil)
+	if y := autograd.EngineOf(x).Mul(x, x); y.Operation == nil || !autograd.GradEnabledFor(x) {
+		t.Fatal("узел без области должен строить граф независимо от SetGraph")
+	}
+
+	autograd.ClearGraph()
+	s := graph.NewScope(graph.ModeNoGrad)
+	in := s.Input(&graph.Node{Value: val})
+	if y := autograd.EngineOf(in).Mul(in, in); y.Operation != nil || autograd.GradEnabledFor(in) {
+		t.Fatal("узел области NoGrad не должен строить граф без текущего графа")
+	}
+	w := graph.NewNode(val, nil, nil)
+	if y := autograd.NewEngineIn(s).Mul(w, w); y.Operation != nil || y.Scope != s {
+		t.Fatal("узел из параметров в NewEngineIn(s) должен создаваться в области s")
+	}
+}
+
 func TestGraphContext_BackwardAndRelease(t *testing.T) {
 	ctx := autograd.NewGraph()
 	ctx.WithGrad()
@@ -85,3 +139,57 @@ func TestGraphContext_BackwardAndRelease(t *testing.T) {
 	ctx.Backward(out)
 }
 
+func TestGraphContext_ArenaReleasedAfterBackward(t *testing.T) {
+	arena := tensor.NewArena()
+	prof := profiling.NewProfiler(nil)
+	defer autograd.ClearGraph()
+
+	w := &graph.Node{Value: &tensor.Tensor{Data: []float64{1, 2, 3}, Shape: []int{3}, Strides: []int{1}}}
+	x := &tensor.Tensor{Data: []float64{4, 5, 6}, Shape: []int{3}, Strides: []int{1}}
+
+	var grads []*tensor.Tensor
+	for step := 0; step < 2; step++ {
+		ctx := autograd.NewGraph()
+		ctx.UseArena(arena)
+		ctx.SetProfiler(prof)
+		autograd.SetGraph(ctx)
+
+		e := ctx.Engine()
+		w.Grad = nil
+		hidden := e.Mul(w, ctx.RequireGrad(x))
+		out := e.Sum(e.Mul(hidden, hidden))
+		if !arena.Owns(hidden.Value) || !arena.Owns(out.Value) {
+			t.Fatalf("промежуточные значения выделены не из арены")
+		}
+
+		ctx.Backward(out)
+
+		if tensor.ArenaOf(hidden.Value) != nil {
+			t.Fatalf("шаг арены не завершён после Backward")
+		}
+		if arena.Owns(w.Grad) || arena.Owns(out.Value) {
+			t.Fatalf("градиент листа или loss не скопированы из арены")
+		}
+		// d/dw sum((w·x)²) = 2·w·x²
+		for i, want := range []float64{32, 100, 216} {
+			if w.Grad.Data[i] != want {
+				t.Fatalf("шаг %d: w.Grad = %v", step, w.Grad.Data)
+			}
+		}
+		if out.Value.Data[0] != 16+100+324 {
+			t.Fatalf("шаг %d: loss = %v", step, out.Value.Data[0])
+		}
+		grads = append(grads, w.Grad)
+	}
+
+	if grads[0].Data[2] != 216 {
+		t.Fatalf("градиент первого шага испорчен вторым шагом: %v", grads[0].Data)
+	}
+	mem := prof.Stats.Memory()
+	if mem.Steps != 2 || mem.Allocs == 0 || mem.PeakBytes == 0 {
+		t.Fatalf("статистика арены не записана в профилировщик: %+v", mem)
+	}
+	if mem.Reused != mem.Allocs/2 {
+		t.Fatalf("второй шаг должен полностью переиспользовать буферы первого: %+v", mem)
+	}
+}
@@ -0,0 +1,804 @@
+package autograd
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+// Градиенты высших порядков. В режиме CreateGraph обратный проход строится
+// из обычных операций Engine: градиент каждого узла — тоже узел графа, поэтому
+// его можно снова дифференцировать (gradient penalty, MAML, произведение
+// Гессиана на вектор). Операции, поддерживающие этот режим, реализуют
+// DifferentiableOp; остальные дают ошибку, если через них нужно пройти.
+
+// BackwardOption настраивает Engine.Backward.
+type BackwardOption func(*backwardConfig)
+
+type backwardConfig struct {
+	createGraph bool
+}
+
+// CreateGraph включает запись обратного прохода в граф (create_graph в PyTorch).
+// Кроме обычных Grad узлы получают GradNode — дифференцируемый градиент.
+func CreateGraph(enabled bool) BackwardOption {
+	return func(c *backwardConfig) { c.createGraph = enabled }
+}
+
+// DifferentiableOp — операция, обратный проход которой выражается операциями Engine.
+type DifferentiableOp interface {
+	graph.Operation
+	// BackwardGraph возвращает градиенты по родителям узла (в порядке Parents)
+	// для градиента выхода grad; nil означает нулевой градиент.
+	BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node
+}
+
+// constant добавляет в граф лист, по которому градиент не нужен.
+func (e *Engine) constant(t *tensor.Tensor) *graph.Node {
+	n := e.newNode(t, nil, nil)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// gradGraph строит градиенты outputs (с градиентами выходов seeds) по узлам их
+// подграфа. Если wrt не nil, градиенты распространяются только к узлам,
+// зависящим от wrt, — остальные ветви не обязаны поддерживать DifferentiableOp.
+// Хуки узлов вызываются на значениях градиентов; изменённый хуком градиент
+// становится константой и дальше по нему не дифференцируется.
+func (e *Engine) gradGraph(outputs, seeds []*graph.Node, wrt map[*graph.Node]bool) (map[*graph.Node]*graph.Node, []*graph.Node, error) {
+	visited := make(map[*graph.Node]bool)
+	var order []*graph.Node
+	for _, out := range outputs {
+		for _, n := range e.topologicalSort(out) {
+			if !visited[n] {
+				visited[n] = true
+				order = append(order, n)
+			}
+		}
+	}
+
+	needed := func(*graph.Node) bool { return true }
+	if wrt != nil {
+		depends := make(map[*graph.Node]bool)
+		for _, n := range order {
+			if wrt[n] {
+				depends[n] = true
+				continue
+			}
+			for _, p := range n.Parents {
+				if depends[p] {
+					depends[n] = true
+					break
+				}
+			}
+		}
+		needed = func(n *graph.Node) bool { return depends[n] }
+	}
+
+	grads := make(map[*graph.Node]*graph.Node)
+	for i, out := range outputs {
+		if g := grads[out]; g != nil {
+			grads[out] = e.Add(g, seeds[i])
+		} else {
+			grads[out] = seeds[i]
+		}
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		n := order[i]
+		g := grads[n]
+		if g == nil || !needed(n) {
+			continue
+		}
+		if n.HasHooks() {
+			if h := n.ApplyHooks(g.Value); h != g.Value {
+				g = e.constant(h)
+				grads[n] = g
+			}
+		}
+		if n.Operation == nil {
+			continue
+		}
+		op, ok := n.Operation.(DifferentiableOp)
+		if !ok {
+			return nil, nil, fmt.Errorf("autograd: операция %T не поддерживает CreateGraph", n.Operation)
+		}
+		for j, pg := range op.BackwardGraph(e, g) {
+			p := n.Parents[j]
+			if pg == nil || !needed(p) {
+				continue
+			}
+			if prev := grads[p]; prev != nil {
+				pg = e.Add(prev, pg)
+			}
+			grads[p] = pg
+		}
+	}
+	return grads, order, nil
+}
+
+// backwardCreateGraph — Backward с CreateGraph(true): Grad узлов накапливается
+// как обычно, а дифференцируемые градиенты сохраняются в GradNode.
+// Если в графе есть операция без BackwardGraph, возвращает ошибку, не изменяя
+// Grad и GradNode узлов.
+func (e *Engine) backwardCreateGraph(finalNode *graph.Node) error {
+	seed := e.constant(tensor.Ones(finalNode.Value.Shape...))
+	grads, order, err := e.gradGraph([]*graph.Node{finalNode}, []*graph.Node{seed}, nil)
+	if err != nil {
+		return err
+	}
+	for _, n := range order {
+		g := grads[n]
+		if g == nil {
+			continue
+		}
+		if n == finalNode {
+			n.Grad = g.Value.Clone()
+		} else {
+			accumulateGrad(n, g.Value)
+		}
+		if n.GradNode != nil {
+			g = e.Add(n.GradNode, g)
+		}
+		n.GradNode = g
+	}
+	return nil
+}
+
+// Grad возвращает градиенты суммы всех элементов outputs по inputs как
+// дифференцируемые узлы графа (аналог torch.autograd.grad с create_graph=True).
+// Grad и GradNode узлов не изменяются. Для входов, от которых outputs не
+// зависят, возвращаются нулевые узлы.
+func (e *Engine) Grad(outputs, inputs []*graph.Node) ([]*graph.Node, error) {
+	seeds := make([]*graph.Node, len(outputs))
+	for i, out := range outputs {
+		seeds[i] = e.constant(tensor.Ones(out.Value.Shape...))
+	}
+	return e.gradWithSeeds(outputs, seeds, inputs)
+}
+
+// gradWithSeeds — Grad с заданными градиентами выходов seeds (формы outputs).
+func (e *Engine) gradWithSeeds(outputs, seeds, inputs []*graph.Node) ([]*graph.Node, error) {
+	if len(seeds) != len(outputs) {
+		return nil, fmt.Errorf("autograd: %d градиентов для %d выходов", len(seeds), len(outputs))
+	}
+	for i, out := range outputs {
+		if !shapesEqual(seeds[i].Value.Shape, out.Value.Shape) {
+			return nil, fmt.Errorf("autograd: градиент формы %v для выхода формы %v", seeds[i].Value.Shape, out.Value.Shape)
+		}
+	}
+	wrt := make(map[*graph.Node]bool, len(inputs))
+	for _, in := range inputs {
+		wrt[in] = true
+	}
+	grads, _, err := e.gradGraph(outputs, seeds, wrt)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*graph.Node, len(inputs))
+	for i, in := range inputs {
+		if res[i] = grads[in]; res[i] == nil {
+			res[i] = e.constant(tensor.Zeros(in.Value.Shape...))
+		}
+	}
+	return res, nil
+}
+
+func shapesEqual(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Scale
+type ScaleOp struct {
+	Parents []*graph.Node
+	S       float64
+}
+
+func (op *ScaleOp) Backward(grad *tensor.Tensor) {
+	g := grad.Clone()
+	tensor.ScaleInPlace(op.S, g)
+	accumulateGrad(op.Parents[0], g)
+}
+
+func (op *ScaleOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	return []*graph.Node{e.Scale(grad, op.S)}
+}
+
+// Scale умножает a на постоянный скаляр s.
+func (e *Engine) Scale(a *graph.Node, s float64) *graph.Node {
+	val := a.Value.Clone()
+	tensor.ScaleInPlace(s, val)
+	op := &ScaleOp{Parents: []*graph.Node{a}, S: s}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// BroadcastTo
+type BroadcastToOp struct {
+	Parents []*graph.Node
+	InShape []int
+}
+
+func (op *BroadcastToOp) Backward(grad *tensor.Tensor) {
+	accumulateGrad(op.Parents[0], unbroadcast(grad, op.Parents[0]))
+}
+
+func (op *BroadcastToOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	return []*graph.Node{e.SumToShape(grad, op.InShape)}
+}
+
+// BroadcastTo расширяет a до формы shape по правилам broadcasting (результат плотный).
+func (e *Engine) BroadcastTo(a *graph.Node, shape []int) *graph.Node {
+	if shapesEqual(a.Value.Shape, shape) {
+		return a
+	}
+	val, err := tensor.Expand(a.Value, shape...)
+	if err != nil {
+		return nil
+	}
+	op := &BroadcastToOp{Parents: []*graph.Node{a}, InShape: append([]int{}, a.Value.Shape...)}
+	n := e.newNode(val.Contiguous(), []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// SumToShape
+type SumToShapeOp struct {
+	Parents []*graph.Node
+	InShape []int
+}
+
+func (op *SumToShapeOp) Backward(grad *tensor.Tensor) {
+	g, err := tensor.Expand(grad, op.InShape...)
+	if err != nil {
+		panic(err)
+	}
+	accumulateGrad(op.Parents[0], g.Contiguous())
+}
+
+func (op *SumToShapeOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	return []*graph.Node{e.BroadcastTo(grad, op.InShape)}
+}
+
+// SumToShape суммирует a до формы shape, из которой a получается broadcasting-ом
+// (обратная к BroadcastTo операция).
+func (e *Engine) SumToShape(a *graph.Node, shape []int) *graph.Node {
+	if shapesEqual(a.Value.Shape, shape) {
+		return a
+	}
+	val, err := tensor.SumToShape(a.Value, shape)
+	if err != nil {
+		return nil
+	}
+	op := &SumToShapeOp{Parents: []*graph.Node{a}, InShape: append([]int{}, a.Value.Shape...)}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// unbroadcastNode — версия unbroadcast для режима CreateGraph.
+func (e *Engine) unbroadcastNode(grad, p *graph.Node) *graph.Node {
+	return e.SumToShape(grad, p.Value.Shape)
+}
+
+// maskGrad умножает grad на постоянную локальную производную local(x):
+// для кусочно-линейных функций вторая производная равна нулю.
+func (e *Engine) maskGrad(grad *graph.Node, x *tensor.Tensor, local func(float64) float64) *graph.Node {
+	return e.Mul(grad, e.constant(tensor.Apply(x, local)))
+}
+
+func (op *Add) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	a, b := op.Parents[0], op.Parents[1]
+	return []*graph.Node{e.unbroadcastNode(grad, a), e.unbroadcastNode(grad, b)}
+}
+
+func (op *SubOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	a, b := op.Parents[0], op.Parents[1]
+	return []*graph.Node{e.unbroadcastNode(grad, a), e.unbroadcastNode(e.Scale(grad, -1), b)}
+}
+
+func (op *MulOperation) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	a, b := op.Parents[0], op.Parents[1]
+	return []*graph.Node{
+		e.unbroadcastNode(e.Mul(grad, b), a),
+		e.unbroadcastNode(e.Mul(grad, a), b),
+	}
+}
+
+// d/db (a / b) = -a / b²
+func (op *DivOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	a, b := op.Parents[0], op.Parents[1]
+	gb := e.Scale(e.Div(e.Mul(grad, a), e.Mul(b, b)), -1)
+	return []*graph.Node{e.unbroadcastNode(e.Div(grad, b), a), e.unbroadcastNode(gb, b)}
+}
+
+func (op *MatMul) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	a, b := op.Parents[0], op.Parents[1]
+	return []*graph.Node{e.MatMul(grad, e.Transpose(b)), e.MatMul(e.Transpose(a), grad)}
+}
+
+func (op *TransposeOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	return []*graph.Node{e.Transpose(grad)}
+}
+
+func (op *Sum) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	return []*graph.Node{e.BroadcastTo(grad, op.InputShape)}
+}
+
+func (op *Exp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	return []*graph.Node{e.Mul(grad, e.Exp(op.Parents[0]))}
+}
+
+func (op *Log) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	return []*graph.Node{e.Div(grad, op.Parents[0])}
+}
+
+func (op *ReshapeOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	return []*graph.Node{e.Reshape(grad, op.InShape)}
+}
+
+// expandNode — версия expandGrad для режима CreateGraph.
+func (e *Engine) expandNode(grad *graph.Node, keepShape, inShape []int) *graph.Node {
+	return e.BroadcastTo(e.Reshape(grad, keepShape), inShape)
+}
+
+// nonZeroMask возвращает постоянные маски t == 0 и t != 0.
+func nonZeroMask(t *tensor.Tensor) (zero, nonZero *tensor.Tensor) {
+	zero = tensor.Apply(t, func(v float64) float64 {
+		if v == 0 {
+			return 1
+		}
+		return 0
+	})
+	return zero, tensor.Apply(zero, func(v float64) float64 { return 1 - v })
+}
+
+// safeDenominator заменяет нули d единицами; результат деления на них
+// обнуляется маской nonZero, как в Backward для нулевой нормы или std.
+func (e *Engine) safeDenominator(d *graph.Node, zero *tensor.Tensor) *graph.Node {
+	return e.Add(d, e.constant(zero))
+}
+
+func (op *SumAxesOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	p := op.Parents[0]
+	return []*graph.Node{e.expandNode(grad, op.KeepShape, p.Value.Shape)}
+}
+
+func (op *MeanAxesOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	p := op.Parents[0]
+	g := e.expandNode(grad, op.KeepShape, p.Value.Shape)
+	return []*graph.Node{e.Scale(g, 1/float64(op.Count))}
+}
+
+// Π_{j≠i} x_j без деления на нули: nz — вход с нулями, заменёнными единицами,
+// P = Π(nz), S — сумма нулевых элементов группы (равна 0, но не её производная).
+// Без нулей производная равна P/x_i; с одним нулём — P у нулевого элемента и
+// S·P/x_i у остальных; с двумя — (S - x_i)·P у нулевых элементов (значение
+// второго нуля) и 0 у остальных; при большем числе нулей она равна 0 вместе
+// с первыми производными.
+func (op *ProdAxesOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	x := op.Parents[0]
+	shape := x.Value.Shape
+	zero, nonZero := nonZeroMask(x.Value)
+	counts, _ := tensor.SumAxes(zero, op.Axes, true)
+	counts = expandGrad(counts, op.KeepShape, shape)
+	noZeros := tensor.Zeros(shape...)  // группа без нулей
+	otherOne := tensor.Zeros(shape...) // ненулевой элемент группы с одним нулём
+	zeroOne := tensor.Zeros(shape...)  // нуль группы с одним нулём
+	zeroTwo := tensor.Zeros(shape...)  // нуль группы с двумя нулями
+	for i, c := range counts.Data {
+		isZero := zero.Data[i] == 1
+		switch {
+		case c == 0:
+			noZeros.Data[i] = 1
+		case c == 1 && !isZero:
+			otherOne.Data[i] = 1
+		case c == 1:
+			zeroOne.Data[i] = 1
+		case c == 2 && isZero:
+			zeroTwo.Data[i] = 1
+		}
+	}
+
+	nz := e.safeDenominator(e.Mul(x, e.constant(nonZero)), zero)
+	prod := e.BroadcastTo(e.ProdAxes(nz, op.Axes, true), shape)
+	s := e.BroadcastTo(e.SumAxes(e.Mul(x, e.constant(zero)), op.Axes, true), shape)
+	divided := e.Mul(e.Div(prod, nz), e.Add(e.constant(noZeros), e.Mul(s, e.constant(otherOne))))
+	atZeros := e.Mul(prod, e.Add(e.constant(zeroOne), e.Mul(e.Sub(s, x), e.constant(zeroTwo))))
+	local := e.Add(divided, atZeros)
+	return []*graph.Node{e.Mul(e.expandNode(grad, op.KeepShape, shape), local)}
+}
+
+// Max/Min кусочно-линейны: вторая производная равна нулю.
+func (op *ExtremumAxesOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	g := e.expandNode(grad, op.KeepShape, op.Parents[0].Value.Shape)
+	return []*graph.Node{e.Mul(g, e.constant(op.weights()))}
+}
+
+// dVar/dx_i = 2(x_i - mean)/(N - ddof), dStd/dx_i = dVar/dx_i / (2·std).
+func (op *VarAxesOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	x := op.Parents[0]
+	centered := e.Sub(x, e.MeanAxes(x, op.Axes, true))
+	d := e.Scale(centered, 2/float64(op.Count-op.DDof))
+	if op.Std != nil {
+		std, _ := tensor.Reshape(op.Std, op.KeepShape)
+		zero, nonZero := nonZeroMask(std)
+		denom := e.safeDenominator(e.StdAxes(x, op.Axes, true, op.DDof), zero)
+		d = e.Mul(e.Div(d, e.Scale(denom, 2)), e.constant(nonZero))
+	}
+	return []*graph.Node{e.Mul(e.expandNode(grad, op.KeepShape, x.Value.Shape), d)}
+}
+
+// d(lse)/dx_i = exp(x_i - lse).
+func (op *LogSumExpAxesOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	x := op.Parents[0]
+	soft := e.Exp(e.Sub(x, e.LogSumExpAxes(x, op.Axes, true)))
+	return []*graph.Node{e.Mul(e.expandNode(grad, op.KeepShape, x.Value.Shape), soft)}
+}
+
+// d‖x‖/dx_i = x_i/‖x‖, для нулевой нормы — 0.
+func (op *NormAxesOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	x := op.Parents[0]
+	norm, _ := tensor.Reshape(op.Out, op.KeepShape)
+	zero, nonZero := nonZeroMask(norm)
+	denom := e.safeDenominator(e.NormAxes(x, op.Axes, true), zero)
+	d := e.Mul(e.Div(x, denom), e.constant(nonZero))
+	return []*graph.Node{e.Mul(e.expandNode(grad, op.KeepShape, x.Value.Shape), d)}
+}
+
+// d/dx_i = g_i - softmax_i · Σ_j g_j
+func (op *LogSoftmaxOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	soft := e.Exp(e.LogSoftmax(op.Parents[0]))
+	return []*graph.Node{e.Sub(grad, e.Mul(soft, e.SumAxes(grad, []int{-1}, true)))}
+}
+
+// d/dx_i = s_i · (g_i - Σ_j g_j·s_j) по последней оси.
+func (op *SoftmaxOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	s := e.Softmax(op.input)
+	dot := e.SumAxes(e.Mul(grad, s), []int{-1}, true)
+	return []*graph.Node{e.Mul(s, e.Sub(grad, dot))}
+}
+
+// dL/dx = (softmax(x) - target) · grad построчно.
+func (op *SoftmaxCrossEntropyOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	diff := e.Sub(e.Softmax(op.input), e.constant(op.target))
+	return []*graph.Node{e.Mul(diff, grad)}
+}
+
+// dL/dlogits = (softmax(logits) - target) / batch_size · grad
+func (op *CrossEntropyLogitsOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	diff := e.Sub(e.Softmax(op.logits), e.constant(op.target))
+	return []*graph.Node{e.Mul(diff, e.Scale(grad, 1/float64(op.logits.Value.Shape[0])))}
+}
+
+// Локальные производные кусочно-линейных функций (общие для CreateGraph и JVP).
+
+func (op *ReLUOp) deriv(x float64) float64 {
+	if x > 0 {
+		return 1
+	}
+	return 0
+}
+
+func (op *LeakyReLUOp) deriv(x float64) float64 {
+	if x > 0 {
+		return 1
+	}
+	return op.slope
+}
+
+func (op *ClampOp) deriv(x float64) float64 {
+	if x >= op.Min && x <= op.Max {
+		return 1
+	}
+	return 0
+}
+
+func (op *AbsOp) deriv(x float64) float64 {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	}
+	return 0
+}
+
+func (op *ReLUOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	return []*graph.Node{e.maskGrad(grad, op.input.Value, op.deriv)}
+}
+
+func (op *LeakyReLUOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	return []*graph.Node{e.maskGrad(grad, op.input.Value, op.deriv)}
+}
+
+// σ'(x) = σ(x) - σ(x)²
+func (op *SigmoidOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	s := e.Sigmoid(op.input)
+	return []*graph.Node{e.Mul(grad, e.Sub(s, e.Mul(s, s)))}
+}
+
+// tanh'(x) = 1 - tanh(x)²
+func (op *TanhOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	t := e.Tanh(op.input)
+	return []*graph.Node{e.Sub(grad, e.Mul(grad, e.Mul(t, t)))}
+}
+
+func (op *SoftPlusOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	return []*graph.Node{e.Mul(grad, e.Sigmoid(op.input))}
+}
+
+func (op *ClampOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	return []*graph.Node{e.maskGrad(grad, op.Parents[0].Value, op.deriv)}
+}
+
+func (op *AbsOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	return []*graph.Node{e.maskGrad(grad, op.Parents[0].Value, op.deriv)}
+}
+
+func (op *SqrtOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	return []*graph.Node{e.Div(e.Scale(grad, 0.5), e.Sqrt(op.Parents[0]))}
+}
+
+func (op *PowScalarOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	if op.P == 0 {
+		return []*graph.Node{nil}
+	}
+	local := e.Scale(e.PowScalar(op.Parents[0], op.P-1), op.P)
+	return []*graph.Node{e.Mul(grad, local)}
+}
+
+// dL/dpred = 2·(pred - target)/n · grad
+func (op *MSELossOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	diff := e.Sub(op.pred, e.constant(op.target))
+	g := e.BroadcastTo(grad, op.pred.Value.Shape)
+	return []*graph.Node{e.Mul(g, e.Scale(diff, 2/op.n))}
+}
+
+// d/da a^b = b·a^(b-1), d/db a^b = a^b·ln(a); как в Backward, первая равна
+// нулю при b = 0, вторая — при a <= 0.
+func (op *PowOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	a, b := op.Parents[0], op.Parents[1]
+	nonZeroB := binaryLocal(a.Value, b.Value, func(_, y float64) float64 { return indicator(y != 0) })
+	positiveA := binaryLocal(a.Value, b.Value, func(x, _ float64) float64 { return indicator(x > 0) })
+	zeros := e.constant(tensor.Zeros(1))
+	ones := e.constant(tensor.Ones(1))
+
+	dA := e.Mul(b, e.Pow(a, e.Sub(b, ones)))
+	dA = e.Where(nonZeroB, dA, zeros)
+	// ln берётся от a, где a <= 0 заменены единицами, чтобы не получить NaN.
+	logA := e.Log(e.Where(positiveA, a, ones))
+	dB := e.Where(positiveA, e.Mul(e.Pow(a, b), logA), zeros)
+	return []*graph.Node{
+		e.unbroadcastNode(e.Mul(grad, dA), a),
+		e.unbroadcastNode(e.Mul(grad, dB), b),
+	}
+}
+
+// Maximum/Minimum кусочно-линейны: вторая производная равна нулю.
+func (op *ExtremumOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	localA, localB := op.locals()
+	a, b := op.Parents[0], op.Parents[1]
+	return []*graph.Node{
+		e.unbroadcastNode(e.Mul(grad, e.constant(localA)), a),
+		e.unbroadcastNode(e.Mul(grad, e.constant(localB)), b),
+	}
+}
+
+// BackwardGraph: часть градиента каждого входа выбирается через IndexSelect.
+func (op *ConcatenateOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	res := make([]*graph.Node, len(op.parents))
+	offset := 0
+	for i, p := range op.parents {
+		n := p.Value.Shape[op.axis]
+		res[i] = e.IndexSelect(grad, op.axis, rangeIndex(offset, n))
+		offset += n
+	}
+	return res
+}
+
+// ELU'(x) = 1 при x > 0, иначе α·exp(x). Экспонента берётся от x·[x <= 0],
+// чтобы не переполниться на больших положительных x.
+func (op *ELUOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	x := op.input
+	pos := tensor.Apply(x.Value, func(v float64) float64 { return indicator(v > 0) })
+	neg := tensor.Apply(pos, func(v float64) float64 { return 1 - v })
+	negPart := e.Scale(e.Mul(e.Exp(e.Mul(x, e.constant(neg))), e.constant(neg)), op.alpha)
+	return []*graph.Node{e.Mul(grad, e.Add(e.constant(pos), negPart))}
+}
+
+// GELU'(x) = Φ(x) + x·φ(x).
+func (op *GELUOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	x := op.input
+	return []*graph.Node{e.Mul(grad, e.Add(e.normalCDF(x), e.Mul(x, e.normalPDF(x))))}
+}
+
+// normalCDFOp — функция распределения N(0,1) как узел графа: Φ'(x) = φ(x)
+// выражается операциями Engine, поэтому GELU дифференцируется любое число раз.
+type normalCDFOp struct {
+	input *graph.Node
+}
+
+func (op *normalCDFOp) Backward(grad *tensor.Tensor) {
+	g, _ := tensor.Mul(grad, tensor.Apply(op.input.Value, geluNormalPDF))
+	accumulateGrad(op.input, g)
+}
+
+func (op *normalCDFOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	return []*graph.Node{e.Mul(grad, e.normalPDF(op.input))}
+}
+
+func (e *Engine) normalCDF(x *graph.Node) *graph.Node {
+	n := e.newNode(tensor.Apply(x.Value, geluNormalCDF), []*graph.Node{x}, &normalCDFOp{input: x})
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// normalPDF — плотность N(0,1): exp(-x²/2) / √(2π).
+func (e *Engine) normalPDF(x *graph.Node) *graph.Node {
+	return e.Scale(e.Exp(e.Scale(e.Mul(x, x), -0.5)), 1/math.Sqrt(2*math.Pi))
+}
+
+// BackwardGraph: градиент возвращается в выбранные позиции через ScatterAdd.
+func (op *TopKOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	zeros := e.constant(tensor.Zeros(op.Parents[0].Value.Shape...))
+	return []*graph.Node{e.ScatterAdd(zeros, op.Axis, op.Indices, grad)}
+}
+
+// Σ grad - CumSum(grad) + grad, как в Backward.
+func (op *CumSumOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	rest := e.Sub(e.SumAxes(grad, []int{op.Axis}, true), e.CumSum(grad, op.Axis))
+	return []*graph.Node{e.Add(rest, grad)}
+}
+
+// Hinge loss кусочно-линейна: dL/dpred = -target/n там, где margin > 0.
+func (op *HingeLossOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	local := tensor.Zeros(op.pred.Value.Shape...)
+	for i, m := range op.margin.Data {
+		if m > 0 {
+			local.Data[i] = -op.target.Data[i] / op.n
+		}
+	}
+	g := e.BroadcastTo(grad, op.pred.Value.Shape)
+	return []*graph.Node{e.Mul(g, e.constant(local))}
+}
+
+// dL/dp = (p - y) / (p·(1 - p)) / n · grad, p ограничено [eps, 1-eps], как в Forward.
+func (op *BinaryCrossEntropyOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	const eps = 1e-15
+	p := e.Clamp(op.pred, eps, 1-eps)
+	ones := e.constant(tensor.Ones(1))
+	target, err := tensor.Reshape(op.target, op.pred.Value.Shape)
+	if err != nil {
+		panic(err)
+	}
+	local := e.Div(e.Sub(p, e.constant(target)), e.Mul(p, e.Sub(ones, p)))
+	g := e.BroadcastTo(grad, op.pred.Value.Shape)
+	return []*graph.Node{e.Mul(g, e.Scale(local, 1/op.n))}
+}
+
+// indicator переводит условие в 1 или 0 для постоянных масок.
+func indicator(ok bool) float64 {
+	if ok {
+		return 1
+	}
+	return 0
+}
+
+// Einsum, перестановки элементов (remap_ops.go) и индексация (index_ops.go)
+// линейны по входу: их обратный проход — такие же операции над grad либо пара
+// Gather/ScatterAdd, дифференцируемых друг через друга.
+
+// BackwardGraph: та же свёртка, записанная через Engine.Einsum.
+func (op *EinsumOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	res := make([]*graph.Node, len(op.Parents))
+	for k, p := range op.Parents {
+		spec, keepShape, full := op.gradSpec(k)
+		others := make([]*graph.Node, 0, len(op.Parents))
+		for j, q := range op.Parents {
+			if j != k {
+				others = append(others, q)
+			}
+		}
+		res[k] = e.Einsum(spec, append(others, grad)...)
+		if !full {
+			res[k] = e.expandNode(res[k], keepShape, p.Value.Shape)
+		}
+	}
+	return res
+}
+
+// BackwardGraph: Pad с теми же полями, применённый к номерам элементов входа,
+// даёт для каждой позиции выхода номер её источника (константы — номер
+// лишней ячейки); градиенты суммируются по этим номерам через ScatterAdd.
+func (op *PadOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	in := op.Parents[0].Value.Shape
+	size := int(op.Parents[0].Value.Size())
+	src, err := tensor.Pad(positions(in...), op.Pads, op.Mode, float64(size))
+	if err != nil {
+		panic(err)
+	}
+	src, _ = tensor.Reshape(src, []int{int(src.Size())})
+	sums := e.ScatterAdd(e.constant(tensor.Zeros(size+1)), 0, src, e.Reshape(grad, src.Shape))
+	return []*graph.Node{e.Reshape(e.IndexSelect(sums, 0, rangeIndex(0, size)), in)}
+}
+
+func (op *TileOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	split, axes := op.split(grad.Value.Shape)
+	g := e.SumAxes(e.Reshape(grad, split), axes, false)
+	return []*graph.Node{e.Reshape(g, op.Parents[0].Value.Shape)}
+}
+
+func (op *RepeatOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	split, axis := op.split(grad.Value.Shape)
+	return []*graph.Node{e.SumAxes(e.Reshape(grad, split), []int{axis}, false)}
+}
+
+func (op *FlipOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	return []*graph.Node{e.Flip(grad, op.Axes...)}
+}
+
+func (op *RollOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	return []*graph.Node{e.Roll(grad, -op.Shift, op.Axis)}
+}
+
+// BackwardGraph: срез по новой оси выбирается через IndexSelect.
+func (op *StackOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	res := make([]*graph.Node, len(op.Parents))
+	for i, p := range op.Parents {
+		res[i] = e.Reshape(e.IndexSelect(grad, op.Axis, rangeIndex(i, 1)), p.Value.Shape)
+	}
+	return res
+}
+
+func (op *GatherOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	zeros := e.constant(tensor.Zeros(op.Parents[0].Value.Shape...))
+	return []*graph.Node{e.ScatterAdd(zeros, op.Axis, op.Index, grad)}
+}
+
+func (op *ScatterAddOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	return []*graph.Node{grad, e.Gather(grad, op.Axis, op.Index)}
+}
+
+// BackwardGraph: IndexAdd — это ScatterAdd с индексом, растянутым до формы grad.
+func (op *IndexSelectOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	zeros := e.constant(tensor.Zeros(op.Parents[0].Value.Shape...))
+	index := indexAlong(grad.Value.Shape, op.Axis, op.Index)
+	return []*graph.Node{e.ScatterAdd(zeros, op.Axis, index, grad)}
+}
+
+func (op *MaskedFillOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	return []*graph.Node{e.MaskedFill(grad, op.Mask, 0)}
+}
+
+func (op *WhereOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	a, b := op.Parents[0], op.Parents[1]
+	zeros := e.constant(tensor.Zeros(1))
+	return []*graph.Node{
+		e.unbroadcastNode(e.Where(op.Cond, grad, zeros), a),
+		e.unbroadcastNode(e.Where(op.Cond, zeros, grad), b),
+	}
+}
+
+// BackwardGraph: MaskedSelect от номеров элементов даёт позиции выбранных
+// элементов, и градиент раскладывается по ним через ScatterAdd.
+func (op *MaskedSelectOp) BackwardGraph(e *Engine, grad *graph.Node) []*graph.Node {
+	shape := op.Parents[0].Value.Shape
+	pos, err := tensor.MaskedSelect(positions(shape...), op.Mask)
+	if err != nil {
+		panic(err)
+	}
+	size := int(op.Parents[0].Value.Size())
+	flat := e.ScatterAdd(e.constant(tensor.Zeros(size)), 0, pos, grad)
+	return []*graph.Node{e.Reshape(flat, shape)}
+}
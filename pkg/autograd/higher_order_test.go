@@ -0,0 +1,331 @@
+package autograd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+// secondOrder возвращает взвешенную сумму градиентов out по inputs, построенных
+// через Engine.Grad: CheckGradientEngine от неё проверяет вторые производные.
+func secondOrder(e *Engine, out *graph.Node, inputs []*graph.Node) *graph.Node {
+	if out == nil {
+		return nil
+	}
+	grads, err := e.Grad([]*graph.Node{e.Sum(weighted(e, out))}, inputs)
+	if err != nil {
+		return nil
+	}
+	var total *graph.Node
+	for _, g := range grads {
+		s := e.Sum(weighted(e, g))
+		if total == nil {
+			total = s
+		} else {
+			total = e.Add(total, s)
+		}
+	}
+	return total
+}
+
+func TestSecondOrderGradientCheck(t *testing.T) {
+	x := tensor.Randn([]int{3, 4}, 2101)
+	positive := tensor.Apply(x, func(v float64) float64 { return math.Abs(v) + 0.5 })
+	row := tensor.Apply(tensor.Randn([]int{4}, 2102), func(v float64) float64 { return math.Abs(v) + 0.5 })
+	w := tensor.Randn([]int{4, 2}, 2103)
+	target := tensor.Randn([]int{3, 4}, 2104)
+	onehot := newTensor([]float64{0, 1, 0, 0, 1, 0, 0, 0, 0, 0, 0, 1}, 3, 4)
+	withZeros := newTensor([]float64{0, 1.5, -2, 0.5, 0, 0, 1, 2, 0.7, -1.2, 0.9, 1.1, 0, 0, 0, 3}, 4, 4)
+	y := tensor.Randn([]int{2, 4}, 2106)
+	signs := tensor.Apply(onehot, func(v float64) float64 { return 2*v - 1 })
+	src := tensor.Randn([]int{3, 2}, 2107)
+	labels := newTensor([]float64{1, 3, 0}, 3, 1)
+	pairs := newTensor([]float64{0, 3, 2, 2, 1, 0}, 3, 2)
+	tokens := newTensor([]float64{2, 0, 2, 1}, 4)
+	mask := newTensor([]float64{0, 1, 0, 1}, 4)
+	cond := newTensor([]float64{1, 0, 1}, 3, 1)
+	square := func(e *Engine, n *graph.Node) *graph.Node { return e.Mul(n, n) }
+
+	checkGradCases(t, []gradCase{
+		unaryCase("Sigmoid", x, func(e *Engine, in *graph.Node) *graph.Node { return e.Sigmoid(in) }),
+		unaryCase("Tanh", x, func(e *Engine, in *graph.Node) *graph.Node { return e.Tanh(in) }),
+		unaryCase("SoftPlus", x, func(e *Engine, in *graph.Node) *graph.Node { return e.SoftPlus(in) }),
+		unaryCase("Exp", x, func(e *Engine, in *graph.Node) *graph.Node { return e.Exp(in) }),
+		unaryCase("Log", positive, func(e *Engine, in *graph.Node) *graph.Node { return e.Log(in) }),
+		unaryCase("Sqrt", positive, func(e *Engine, in *graph.Node) *graph.Node { return e.Sqrt(in) }),
+		unaryCase("PowScalar", positive, func(e *Engine, in *graph.Node) *graph.Node { return e.PowScalar(in, 2.5) }),
+		unaryCase("ReLUSquare", x, func(e *Engine, in *graph.Node) *graph.Node {
+			r := e.ReLU(in)
+			return e.Mul(r, r)
+		}),
+		unaryCase("LeakyReLUCube", x, func(e *Engine, in *graph.Node) *graph.Node {
+			r := e.LeakyReLU(in, 0.1)
+			return e.Mul(e.Mul(r, r), r)
+		}),
+		unaryCase("AbsClamp", x, func(e *Engine, in *graph.Node) *graph.Node {
+			return e.Mul(e.Abs(in), e.Clamp(in, -0.5, 0.5))
+		}),
+		unaryCase("SumAxes", x, func(e *Engine, in *graph.Node) *graph.Node {
+			s := e.SumAxes(e.Mul(in, in), []int{1}, false)
+			return e.Mul(s, s)
+		}),
+		unaryCase("MeanAxes", x, func(e *Engine, in *graph.Node) *graph.Node {
+			m := e.MeanAxes(e.Exp(in), []int{0}, true)
+			return e.Mul(m, m)
+		}),
+		unaryCase("ReshapeTranspose", x, func(e *Engine, in *graph.Node) *graph.Node {
+			r := e.Transpose(e.Reshape(in, []int{4, 3}))
+			return e.Mul(r, e.Exp(in))
+		}),
+		unaryCase("Scale", x, func(e *Engine, in *graph.Node) *graph.Node { return e.Tanh(e.Scale(in, -1.5)) }),
+		unaryCase("SumSquared", x, func(e *Engine, in *graph.Node) *graph.Node {
+			s := e.Sum(e.Sigmoid(in))
+			return e.Mul(s, s)
+		}),
+		unaryCase("MSELoss", x, func(e *Engine, in *graph.Node) *graph.Node {
+			return e.MSELoss(e.Tanh(in), target)
+		}),
+		unaryCase("ProdAxes", x, func(e *Engine, in *graph.Node) *graph.Node { return e.ProdAxes(in, []int{1}, false) }),
+		unaryCase("ProdAxesZeros", withZeros, func(e *Engine, in *graph.Node) *graph.Node {
+			return e.ProdAxes(in, []int{1}, true)
+		}),
+		unaryCase("MaxAxes", x, func(e *Engine, in *graph.Node) *graph.Node {
+			m := e.MaxAxes(e.Mul(in, in), []int{0}, false)
+			return e.Mul(m, m)
+		}),
+		unaryCase("VarAxes", x, func(e *Engine, in *graph.Node) *graph.Node { return e.VarAxes(in, []int{1}, false, 1) }),
+		unaryCase("StdAxes", x, func(e *Engine, in *graph.Node) *graph.Node { return e.StdAxes(in, []int{0, 1}, true, 0) }),
+		unaryCase("LogSumExpAxes", x, func(e *Engine, in *graph.Node) *graph.Node {
+			return e.LogSumExpAxes(in, []int{1}, false)
+		}),
+		unaryCase("NormAxes", x, func(e *Engine, in *graph.Node) *graph.Node { return e.NormAxes(in, []int{0}, true) }),
+		unaryCase("Softmax", x, func(e *Engine, in *graph.Node) *graph.Node { return e.Softmax(in) }),
+		unaryCase("LogSoftmax", x, func(e *Engine, in *graph.Node) *graph.Node { return e.LogSoftmax(in) }),
+		unaryCase("SoftmaxCrossEntropy", x, func(e *Engine, in *graph.Node) *graph.Node {
+			return e.SoftmaxCrossEntropy(in, onehot)
+		}),
+		unaryCase("CrossEntropyLoss", x, func(e *Engine, in *graph.Node) *graph.Node {
+			return e.CrossEntropyLoss(e.Tanh(in), onehot)
+		}),
+		binaryCase("Add", x, row, func(e *Engine, a, b *graph.Node) *graph.Node {
+			s := e.Add(a, b)
+			return e.Mul(s, s)
+		}),
+		binaryCase("Sub", x, row, func(e *Engine, a, b *graph.Node) *graph.Node { return e.Exp(e.Sub(a, b)) }),
+		binaryCase("Mul", x, row, func(e *Engine, a, b *graph.Node) *graph.Node { return e.Mul(e.Mul(a, b), a) }),
+		binaryCase("Div", x, row, func(e *Engine, a, b *graph.Node) *graph.Node { return e.Div(e.Mul(a, a), b) }),
+		binaryCase("MatMul", x, w, func(e *Engine, a, b *graph.Node) *graph.Node { return e.Tanh(e.MatMul(a, b)) }),
+		unaryCase("ELU", x, func(e *Engine, in *graph.Node) *graph.Node { return e.ELU(in, 0.7) }),
+		unaryCase("GELU", x, func(e *Engine, in *graph.Node) *graph.Node { return e.GELU(in) }),
+		binaryCase("Pow", positive, x, func(e *Engine, a, b *graph.Node) *graph.Node { return e.Pow(a, b) }),
+		binaryCase("PowBroadcast", positive, row, func(e *Engine, a, b *graph.Node) *graph.Node { return e.Pow(a, b) }),
+		binaryCase("Maximum", x, row, func(e *Engine, a, b *graph.Node) *graph.Node { return square(e, e.Maximum(a, b)) }),
+		binaryCase("Minimum", x, row, func(e *Engine, a, b *graph.Node) *graph.Node { return square(e, e.Minimum(a, b)) }),
+		binaryCase("Concatenate", x, y, func(e *Engine, a, b *graph.Node) *graph.Node {
+			return square(e, e.Concatenate([]*graph.Node{e.Exp(a), b}, 0))
+		}),
+		binaryCase("Einsum", x, w, func(e *Engine, a, b *graph.Node) *graph.Node {
+			return e.Tanh(e.Einsum("ij,jk->ik", a, b))
+		}),
+		binaryCase("EinsumOuterSum", x, w, func(e *Engine, a, b *graph.Node) *graph.Node {
+			return square(e, e.Einsum("ij,kl->ik", a, b))
+		}),
+		unaryCase("PadReflect", x, func(e *Engine, in *graph.Node) *graph.Node {
+			return square(e, e.Pad(in, [][2]int{{1, 1}, {2, 0}}, tensor.PadReflect, 0))
+		}),
+		unaryCase("PadConstant", x, func(e *Engine, in *graph.Node) *graph.Node {
+			return square(e, e.Pad(in, [][2]int{{0, 2}, {1, 1}}, tensor.PadConstant, 0.5))
+		}),
+		unaryCase("Tile", x, func(e *Engine, in *graph.Node) *graph.Node { return square(e, e.Tile(in, 2, 1, 2)) }),
+		unaryCase("Repeat", x, func(e *Engine, in *graph.Node) *graph.Node { return square(e, e.Repeat(in, 2, 0)) }),
+		unaryCase("FlipRoll", x, func(e *Engine, in *graph.Node) *graph.Node {
+			return e.Mul(e.Flip(in, 1), e.Roll(e.Exp(in), 1, 0))
+		}),
+		binaryCase("Stack", x, positive, func(e *Engine, a, b *graph.Node) *graph.Node {
+			return square(e, e.Stack([]*graph.Node{a, e.Log(b)}, 1))
+		}),
+		unaryCase("Gather", x, func(e *Engine, in *graph.Node) *graph.Node { return square(e, e.Gather(in, 1, labels)) }),
+		binaryCase("ScatterAdd", x, src, func(e *Engine, a, b *graph.Node) *graph.Node {
+			return square(e, e.ScatterAdd(a, 1, pairs, b))
+		}),
+		unaryCase("IndexSelect", x, func(e *Engine, in *graph.Node) *graph.Node {
+			return square(e, e.IndexSelect(in, 1, tokens))
+		}),
+		unaryCase("MaskedFill", x, func(e *Engine, in *graph.Node) *graph.Node { return square(e, e.MaskedFill(in, mask, 5)) }),
+		unaryCase("MaskedSelect", x, func(e *Engine, in *graph.Node) *graph.Node {
+			return square(e, e.MaskedSelect(in, mask))
+		}),
+		binaryCase("Where", x, row, func(e *Engine, a, b *graph.Node) *graph.Node { return square(e, e.Where(cond, a, b)) }),
+		unaryCase("TopK", x, func(e *Engine, in *graph.Node) *graph.Node {
+			top, _ := e.TopK(in, 2, 1, true)
+			return square(e, top)
+		}),
+		unaryCase("CumSum", x, func(e *Engine, in *graph.Node) *graph.Node { return square(e, e.CumSum(in, 1)) }),
+		unaryCase("HingeLoss", x, func(e *Engine, in *graph.Node) *graph.Node { return e.HingeLoss(e.Tanh(in), signs) }),
+		unaryCase("BinaryCrossEntropy", x, func(e *Engine, in *graph.Node) *graph.Node {
+			return e.BinaryCrossEntropy(e.Sigmoid(in), onehot)
+		}),
+	}, secondOrder)
+}
+
+func TestGradMatchesBackward(t *testing.T) {
+	x := newTensor([]float64{0.5, -1, 2, 0.3, -0.7, 1.1}, 2, 3)
+	w := tensor.Randn([]int{3, 2}, 2105)
+	target := newTensor([]float64{1, 0, 0, 1}, 2, 2)
+	build := func(e *Engine, xn, wn *graph.Node) *graph.Node {
+		return e.MSELoss(e.Sigmoid(e.MatMul(xn, wn)), target)
+	}
+
+	ref := NewEngine()
+	xr, wr := ref.RequireGrad(x.Clone()), ref.RequireGrad(w.Clone())
+	ref.Backward(build(ref, xr, wr))
+
+	e := NewEngine()
+	xn, wn := e.RequireGrad(x.Clone()), e.RequireGrad(w.Clone())
+	grads, err := e.Grad([]*graph.Node{build(e, xn, wn)}, []*graph.Node{xn, wn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []*tensor.Tensor{xr.Grad, wr.Grad} {
+		for j, v := range want.Data {
+			if math.Abs(grads[i].Value.Data[j]-v) > 1e-12 {
+				t.Fatalf("grad[%d][%d] = %v, want %v", i, j, grads[i].Value.Data[j], v)
+			}
+		}
+	}
+	for _, n := range []*graph.Node{xn, wn} {
+		if n.GradNode != nil || tensor.Sum(n.Grad).Data[0] != 0 {
+			t.Fatalf("Grad не должен изменять Grad и GradNode узлов")
+		}
+	}
+}
+
+func TestBackwardCreateGraphHessianVectorProduct(t *testing.T) {
+	// f(x) = xᵀ·A·x: ∇f = (A + Aᵀ)·x, ∇²f = A + Aᵀ.
+	a := newTensor([]float64{1, 2, 0, -1, 3, 1, 0.5, 0, 2}, 3, 3)
+	sym := []float64{2, 1, 0.5, 1, 6, 1, 0.5, 1, 4}
+	xv := []float64{1, -2, 0.5}
+	v := []float64{0.3, 1, -1}
+
+	e := NewEngine()
+	x := e.RequireGrad(newTensor(append([]float64{}, xv...), 3, 1))
+	an := graph.NewNode(a, nil, nil)
+	f := e.Sum(e.Mul(x, e.MatMul(an, x)))
+	e.Backward(f, CreateGraph(true))
+
+	if x.GradNode == nil {
+		t.Fatal("GradNode не заполнен")
+	}
+	for i := 0; i < 3; i++ {
+		want := 0.0
+		for j := 0; j < 3; j++ {
+			want += sym[i*3+j] * xv[j]
+		}
+		if math.Abs(x.Grad.Data[i]-want) > 1e-12 || math.Abs(x.GradNode.Value.Data[i]-want) > 1e-12 {
+			t.Fatalf("grad[%d] = %v / %v, want %v", i, x.Grad.Data[i], x.GradNode.Value.Data[i], want)
+		}
+	}
+
+	// ZeroGrad обнуляет и градиенты промежуточных узлов первого прохода,
+	// иначе второй Backward распространил бы их повторно.
+	gn := x.GradNode
+	e.ZeroGrad()
+	if x.GradNode != nil {
+		t.Fatal("ZeroGrad должен сбрасывать GradNode")
+	}
+	e.Backward(e.Sum(e.Mul(gn, graph.NewNode(newTensor(v, 3, 1), nil, nil))))
+	for i := 0; i < 3; i++ {
+		want := 0.0
+		for j := 0; j < 3; j++ {
+			want += sym[i*3+j] * v[j]
+		}
+		if math.Abs(x.Grad.Data[i]-want) > 1e-12 {
+			t.Fatalf("Hv[%d] = %v, want %v", i, x.Grad.Data[i], want)
+		}
+	}
+}
+
+func TestGradientPenaltyGradientCheck(t *testing.T) {
+	// WGAN-GP: штраф (‖∇ₓD(x)‖ - 1)² по батчу, дифференцируемый по x и весам D.
+	x := tensor.Randn([]int{4, 3}, 2106)
+	w1 := tensor.Randn([]int{3, 5}, 2107)
+	w2 := tensor.Randn([]int{5, 1}, 2108)
+	build := func(e *Engine, in []*graph.Node) *graph.Node {
+		xn, w1n, w2n := in[0], in[1], in[2]
+		d := e.Sum(e.MatMul(e.LeakyReLU(e.Tanh(e.MatMul(xn, w1n)), 0.2), w2n))
+		grads, err := e.Grad([]*graph.Node{d}, []*graph.Node{xn})
+		if err != nil {
+			return nil
+		}
+		norm := e.Sqrt(e.SumAxes(e.Mul(grads[0], grads[0]), []int{1}, false))
+		dev := e.Sub(norm, graph.NewNode(tensor.Ones(1), nil, nil))
+		return e.MeanAxes(e.Mul(dev, dev), nil, false)
+	}
+	inputs := []*graph.Node{graph.NewNode(x, nil, nil), graph.NewNode(w1, nil, nil), graph.NewNode(w2, nil, nil)}
+	if !CheckGradientEngine(build, inputs, 1e-6, 1e-4) {
+		t.Error("gradient penalty: second-order gradient check failed")
+	}
+}
+
+func TestMAMLGradientCheck(t *testing.T) {
+	// Один внутренний шаг SGD и внешний лосс на другой выборке:
+	// градиент по исходным весам проходит через ∇L_inner.
+	xIn := tensor.Randn([]int{5, 3}, 2109)
+	yIn := tensor.Randn([]int{5, 1}, 2110)
+	xOut := tensor.Randn([]int{4, 3}, 2111)
+	yOut := tensor.Randn([]int{4, 1}, 2112)
+	w := tensor.Randn([]int{3, 1}, 2113)
+	build := func(e *Engine, in []*graph.Node) *graph.Node {
+		wn := in[0]
+		inner := e.MSELoss(e.Tanh(e.MatMul(graph.NewNode(xIn, nil, nil), wn)), yIn)
+		grads, err := e.Grad([]*graph.Node{inner}, []*graph.Node{wn})
+		if err != nil {
+			return nil
+		}
+		adapted := e.Sub(wn, e.Scale(grads[0], 0.5))
+		return e.MSELoss(e.Tanh(e.MatMul(graph.NewNode(xOut, nil, nil), adapted)), yOut)
+	}
+	if !CheckGradientEngine(build, []*graph.Node{graph.NewNode(w, nil, nil)}, 1e-6, 1e-4) {
+		t.Error("MAML: second-order gradient check failed")
+	}
+}
+
+func TestGradUnsupportedOperation(t *testing.T) {
+	e := NewEngine()
+	x := e.RequireGrad(tensor.Randn([]int{2, 3}, 2114))
+	c := graph.NewNode(tensor.Randn([]int{2, 3}, 2115), nil, nil)
+	// Det не реализует BackwardGraph.
+	gramDet := func(n *graph.Node) *graph.Node { return e.Det(e.MatMul(n, e.Transpose(n))) }
+
+	if _, err := e.Grad([]*graph.Node{gramDet(x)}, []*graph.Node{x}); err == nil {
+		t.Fatal("ожидалась ошибка для Det без BackwardGraph")
+	}
+
+	// Ветвь, не зависящая от x, не мешает.
+	out := e.Add(e.Sum(e.Mul(x, x)), gramDet(c))
+	grads, err := e.Grad([]*graph.Node{out}, []*graph.Node{x})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range x.Value.Data {
+		if math.Abs(grads[0].Value.Data[i]-2*v) > 1e-12 {
+			t.Fatalf("grad[%d] = %v, want %v", i, grads[0].Value.Data[i], 2*v)
+		}
+	}
+
+	unused, err := e.Grad([]*graph.Node{e.Sum(c)}, []*graph.Node{x})
+	if err != nil || tensor.Sum(unused[0].Value).Data[0] != 0 {
+		t.Fatalf("для неиспользуемого входа ожидался нулевой градиент, err = %v", err)
+	}
+
+	// Backward с CreateGraph возвращает ошибку и не трогает градиенты.
+	before := x.Grad
+	if err := e.Backward(gramDet(x), CreateGraph(true)); err == nil {
+		t.Fatal("Backward с CreateGraph должен вернуть ошибку на Det")
+	}
+	if x.Grad != before || x.GradNode != nil {
+		t.Fatal("после ошибки Backward градиенты x изменены")
+	}
+}
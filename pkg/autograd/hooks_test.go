@@ -0,0 +1,63 @@
+package autograd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+func TestBackwardHooks(t *testing.T) {
+	e := NewEngine()
+	x := e.RequireGrad(newTensor([]float64{1, -2, 3}, 3))
+	h := e.Mul(x, x)
+	loss := e.Sum(h)
+
+	// Хук промежуточного узла видит его полный градиент и может его изменить:
+	// обрезаем градиент h до [-0.5, 0.5], и родители получают уже обрезанный.
+	var seen *tensor.Tensor
+	clip := h.RegisterHook(func(g *tensor.Tensor) *tensor.Tensor {
+		seen = g.Clone()
+		return tensor.Apply(g, func(v float64) float64 { return min(max(v, -0.5), 0.5) })
+	})
+	var leafCalls int
+	leaf := x.RegisterHook(func(g *tensor.Tensor) *tensor.Tensor {
+		leafCalls++
+		return nil
+	})
+
+	e.Backward(loss)
+	assertClose(t, "seen", seen.Data, []float64{1, 1, 1}, 0)
+	assertClose(t, "dx", x.Grad.Data, []float64{1, -2, 3}, 1e-12)
+	if leafCalls != 1 {
+		t.Fatalf("хук листа вызван %d раз", leafCalls)
+	}
+
+	clip.Remove()
+	clip.Remove()
+	leaf.Remove()
+	e.ZeroGrad()
+	e.Backward(loss)
+	assertClose(t, "dx без хуков", x.Grad.Data, []float64{2, -4, 6}, 1e-12)
+	if leafCalls != 1 || h.HasHooks() || x.HasHooks() {
+		t.Fatal("снятые хуки не должны вызываться")
+	}
+}
+
+func TestGradHooksInGraphMode(t *testing.T) {
+	e := NewEngine()
+	x := e.RequireGrad(newTensor([]float64{1, 2}, 2))
+	h := e.Exp(x)
+	h.RegisterHook(func(g *tensor.Tensor) *tensor.Tensor {
+		s := g.Clone()
+		tensor.ScaleInPlace(2, s)
+		return s
+	})
+	grads, err := e.Grad([]*graph.Node{e.Sum(h)}, []*graph.Node{x})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := tensor.Apply(x.Value, func(v float64) float64 { return 2 * math.Exp(v) })
+	assertClose(t, "dx", grads[0].Value.Data, want.Data, 1e-12)
+}
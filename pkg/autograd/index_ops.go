@@ -0,0 +1,225 @@
+package autograd
+
+import (
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+// Индексация внутри графа. Индексы и маски — обычные тензоры (не узлы):
+// по ним градиент не распространяется.
+
+// rangeIndex возвращает одномерный индекс start, start+1, …, start+n-1.
+func rangeIndex(start, n int) *tensor.Tensor {
+	idx := tensor.Zeros(n)
+	for i := range idx.Data {
+		idx.Data[i] = float64(start + i)
+	}
+	return idx
+}
+
+// positions возвращает тензор формы shape с номерами элементов в row-major порядке.
+func positions(shape ...int) *tensor.Tensor {
+	pos := tensor.Zeros(shape...)
+	for i := range pos.Data {
+		pos.Data[i] = float64(i)
+	}
+	return pos
+}
+
+// indexAlong растягивает одномерный index до формы shape: элемент с номером j
+// по оси axis равен index[j].
+func indexAlong(shape []int, axis int, index *tensor.Tensor) *tensor.Tensor {
+	if axis < 0 {
+		axis += len(shape)
+	}
+	inner := 1
+	for _, size := range shape[axis+1:] {
+		inner *= size
+	}
+	idx := index.Contiguous().Data
+	out := tensor.Zeros(shape...)
+	for i := range out.Data {
+		out.Data[i] = idx[(i/inner)%shape[axis]]
+	}
+	return out
+}
+
+// Gather
+type GatherOp struct {
+	Parents []*graph.Node
+	Axis    int
+	Index   *tensor.Tensor
+}
+
+// Backward: градиент возвращается в позиции, из которых были взяты элементы.
+func (op *GatherOp) Backward(grad *tensor.Tensor) {
+	p := op.Parents[0]
+	gLocal, err := tensor.ScatterAdd(tensor.Zeros(p.Value.Shape...), op.Axis, op.Index, grad)
+	if err != nil {
+		panic(err)
+	}
+	accumulateGrad(p, gLocal)
+}
+
+// Gather собирает элементы a вдоль оси axis по индексам index (см. tensor.Gather).
+// Например, выбор логита правильного класса: e.Gather(logits, 1, labels) с labels формы [N, 1].
+func (e *Engine) Gather(a *graph.Node, axis int, index *tensor.Tensor) *graph.Node {
+	val, err := tensor.Gather(a.Value, axis, index)
+	if err != nil {
+		return nil
+	}
+	op := &GatherOp{Parents: []*graph.Node{a}, Axis: axis, Index: index}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// ScatterAdd
+type ScatterAddOp struct {
+	Parents []*graph.Node
+	Axis    int
+	Index   *tensor.Tensor
+}
+
+// Backward: dst получает градиент без изменений, src — градиент, собранный по тем же индексам.
+func (op *ScatterAddOp) Backward(grad *tensor.Tensor) {
+	dst, src := op.Parents[0], op.Parents[1]
+	accumulateGrad(dst, grad)
+	gSrc, err := tensor.Gather(grad, op.Axis, op.Index)
+	if err != nil {
+		panic(err)
+	}
+	accumulateGrad(src, gSrc)
+}
+
+// ScatterAdd добавляет элементы src в копию dst вдоль оси axis по индексам index.
+func (e *Engine) ScatterAdd(dst *graph.Node, axis int, index *tensor.Tensor, src *graph.Node) *graph.Node {
+	val, err := tensor.ScatterAdd(dst.Value, axis, index, src.Value)
+	if err != nil {
+		return nil
+	}
+	op := &ScatterAddOp{Parents: []*graph.Node{dst, src}, Axis: axis, Index: index}
+	n := e.newNode(val, []*graph.Node{dst, src}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// IndexSelect
+type IndexSelectOp struct {
+	Parents []*graph.Node
+	Axis    int
+	Index   *tensor.Tensor
+}
+
+// Backward: градиенты выбранных срезов суммируются в исходные позиции
+// (повторяющиеся индексы накапливаются).
+func (op *IndexSelectOp) Backward(grad *tensor.Tensor) {
+	p := op.Parents[0]
+	gLocal, err := tensor.IndexAdd(tensor.Zeros(p.Value.Shape...), op.Axis, op.Index, grad)
+	if err != nil {
+		panic(err)
+	}
+	accumulateGrad(p, gLocal)
+}
+
+// IndexSelect выбирает срезы a вдоль оси axis по одномерному индексу.
+// Для таблицы эмбеддингов [V, D] и токенов [T] возвращает [T, D].
+func (e *Engine) IndexSelect(a *graph.Node, axis int, index *tensor.Tensor) *graph.Node {
+	val, err := tensor.IndexSelect(a.Value, axis, index)
+	if err != nil {
+		return nil
+	}
+	op := &IndexSelectOp{Parents: []*graph.Node{a}, Axis: axis, Index: index}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// MaskedFill
+type MaskedFillOp struct {
+	Parents []*graph.Node
+	Mask    *tensor.Tensor
+}
+
+// Backward: заполненные позиции — константы, их градиент равен нулю.
+func (op *MaskedFillOp) Backward(grad *tensor.Tensor) {
+	p := op.Parents[0]
+	gLocal, err := tensor.MaskedFill(grad, op.Mask, 0)
+	if err != nil {
+		panic(err)
+	}
+	accumulateGrad(p, gLocal)
+}
+
+// MaskedFill заменяет элементы a под ненулевой маской на value
+// (например, -Inf для маскирования внимания перед Softmax).
+func (e *Engine) MaskedFill(a *graph.Node, mask *tensor.Tensor, value float64) *graph.Node {
+	val, err := tensor.MaskedFill(a.Value, mask, value)
+	if err != nil {
+		return nil
+	}
+	op := &MaskedFillOp{Parents: []*graph.Node{a}, Mask: mask}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// Where
+type WhereOp struct {
+	Parents []*graph.Node
+	Cond    *tensor.Tensor
+}
+
+// Backward: a получает градиент в позициях cond != 0, b — в остальных;
+// затем градиенты сводятся к формам операндов (broadcasting).
+func (op *WhereOp) Backward(grad *tensor.Tensor) {
+	a, b := op.Parents[0], op.Parents[1]
+	zeros := tensor.Zeros(1)
+	gA, err := tensor.Where(op.Cond, grad, zeros)
+	if err != nil {
+		panic(err)
+	}
+	gB, _ := tensor.Where(op.Cond, zeros, grad)
+	accumulateGrad(a, unbroadcast(gA, a))
+	accumulateGrad(b, unbroadcast(gB, b))
+}
+
+// Where выбирает элементы a там, где cond ненулевой, и b в остальных позициях.
+func (e *Engine) Where(cond *tensor.Tensor, a, b *graph.Node) *graph.Node {
+	val, err := tensor.Where(cond, a.Value, b.Value)
+	if err != nil {
+		return nil
+	}
+	op := &WhereOp{Parents: []*graph.Node{a, b}, Cond: cond}
+	n := e.newNode(val, []*graph.Node{a, b}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// MaskedSelect
+type MaskedSelectOp struct {
+	Parents []*graph.Node
+	Mask    *tensor.Tensor
+}
+
+// Backward: градиент одномерного результата раскладывается обратно по позициям маски.
+func (op *MaskedSelectOp) Backward(grad *tensor.Tensor) {
+	p := op.Parents[0]
+	gLocal, err := tensor.MaskedScatter(tensor.Zeros(p.Value.Shape...), op.Mask, grad)
+	if err != nil {
+		panic(err)
+	}
+	accumulateGrad(p, gLocal)
+}
+
+// MaskedSelect возвращает одномерный узел из элементов a под ненулевой маской.
+func (e *Engine) MaskedSelect(a *graph.Node, mask *tensor.Tensor) *graph.Node {
+	val, err := tensor.MaskedSelect(a.Value, mask)
+	if err != nil {
+		return nil
+	}
+	op := &MaskedSelectOp{Parents: []*graph.Node{a}, Mask: mask}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
@@ -0,0 +1,57 @@
+package autograd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+func TestIndexOpsGradientCheck(t *testing.T) {
+	x := tensor.Randn([]int{3, 4}, 505)
+	y := tensor.Randn([]int{3, 4}, 506)
+	src := tensor.Randn([]int{3, 2}, 507)
+	labels := newTensor([]float64{1, 3, 0}, 3, 1)
+	pairs := newTensor([]float64{0, 3, 2, 2, 1, 0}, 3, 2)
+	tokens := newTensor([]float64{2, 0, 2, 1}, 4)
+	mask := newTensor([]float64{0, 1, 0, 1}, 4)
+	cond := newTensor([]float64{1, 0, 1}, 3, 1)
+
+	checkGradCases(t, []gradCase{
+		{"Gather", []*tensor.Tensor{x}, func(e *Engine, in []*graph.Node) *graph.Node {
+			return e.Gather(in[0], 1, labels)
+		}},
+		{"ScatterAdd", []*tensor.Tensor{x, src}, func(e *Engine, in []*graph.Node) *graph.Node {
+			// Mul делает градиент зависящим от позиции
+			return e.Mul(e.ScatterAdd(in[0], 1, pairs, in[1]), graph.NewNode(y, nil, nil))
+		}},
+		{"IndexSelect", []*tensor.Tensor{x}, func(e *Engine, in []*graph.Node) *graph.Node {
+			sel := e.IndexSelect(in[0], 0, tokens)
+			return e.Mul(sel, sel)
+		}},
+		{"MaskedFill", []*tensor.Tensor{x}, func(e *Engine, in []*graph.Node) *graph.Node {
+			filled := e.MaskedFill(in[0], mask, 5)
+			return e.Mul(filled, filled)
+		}},
+		{"Where", []*tensor.Tensor{x, y}, func(e *Engine, in []*graph.Node) *graph.Node {
+			w := e.Where(cond, in[0], in[1])
+			return e.Mul(w, w)
+		}},
+		{"MaskedSelect", []*tensor.Tensor{x}, func(e *Engine, in []*graph.Node) *graph.Node {
+			sel := e.MaskedSelect(in[0], mask)
+			return e.Mul(sel, sel)
+		}},
+	}, nil)
+}
+
+func TestMaskedFillAttentionMask(t *testing.T) {
+	e := NewEngine()
+	scores := e.RequireGrad(newTensor([]float64{1, 2, 3, 4}, 2, 2))
+	causal := newTensor([]float64{0, 1, 0, 0}, 2, 2)
+	probs := e.Softmax(e.MaskedFill(scores, causal, math.Inf(-1)))
+
+	if probs.Value.Data[0] != 1 || probs.Value.Data[1] != 0 {
+		t.Fatalf("masked row = %v, want [1 0]", probs.Value.Data[:2])
+	}
+}
@@ -0,0 +1,200 @@
+package autograd
+
+import (
+	"math"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/linalg"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+// Дифференцируемые операции линейной алгебры (см. пакет linalg).
+
+// transposed возвращает плотную транспонированную матрицу.
+func transposed(a *tensor.Tensor) *tensor.Tensor {
+	t, err := tensor.Transpose(a)
+	if err != nil {
+		panic(err)
+	}
+	return t.Contiguous()
+}
+
+func mustMatMul(a, b *tensor.Tensor) *tensor.Tensor {
+	c, err := tensor.MatMul(a, b)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// asColumn приводит вектор [n] к матрице [n, 1]; матрицы возвращаются без изменений.
+func asColumn(t *tensor.Tensor) *tensor.Tensor {
+	if len(t.Shape) == 2 {
+		return t
+	}
+	c, _ := tensor.Reshape(t, []int{t.Shape[0], 1})
+	return c
+}
+
+// Solve
+type SolveOp struct {
+	Parents []*graph.Node
+	X       *tensor.Tensor
+}
+
+// Backward: для X = A⁻¹·B  dB = A⁻ᵀ·G,  dA = -dB·Xᵀ.
+func (op *SolveOp) Backward(grad *tensor.Tensor) {
+	a, b := op.Parents[0], op.Parents[1]
+	gB, err := linalg.Solve(transposed(a.Value), grad)
+	if err != nil {
+		panic(err)
+	}
+	gA := mustMatMul(asColumn(gB), transposed(asColumn(op.X)))
+	tensor.ScaleInPlace(-1, gA)
+	accumulateGrad(a, gA)
+	accumulateGrad(b, gB)
+}
+
+// Solve решает A·X = B; b — вектор [n] или матрица [n, k].
+func (e *Engine) Solve(a, b *graph.Node) *graph.Node {
+	val, err := linalg.Solve(a.Value, b.Value)
+	if err != nil {
+		return nil
+	}
+	op := &SolveOp{Parents: []*graph.Node{a, b}, X: val}
+	n := e.newNode(val, []*graph.Node{a, b}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// Inverse
+type InverseOp struct {
+	Parents []*graph.Node
+	Inv     *tensor.Tensor
+}
+
+// Backward: для Y = A⁻¹  dA = -Yᵀ·G·Yᵀ.
+func (op *InverseOp) Backward(grad *tensor.Tensor) {
+	p := op.Parents[0]
+	invT := transposed(op.Inv)
+	gLocal := mustMatMul(mustMatMul(invT, grad), invT)
+	tensor.ScaleInPlace(-1, gLocal)
+	accumulateGrad(p, gLocal)
+}
+
+// Inverse возвращает обратную матрицу.
+func (e *Engine) Inverse(a *graph.Node) *graph.Node {
+	val, err := linalg.Inverse(a.Value)
+	if err != nil {
+		return nil
+	}
+	op := &InverseOp{Parents: []*graph.Node{a}, Inv: val}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// Det
+type DetOp struct {
+	Parents []*graph.Node
+	Det     float64
+}
+
+// Backward: d det(A)/dA = det(A)·A⁻ᵀ.
+func (op *DetOp) Backward(grad *tensor.Tensor) {
+	p := op.Parents[0]
+	inv, err := linalg.Inverse(p.Value)
+	if err != nil {
+		panic(err)
+	}
+	gLocal := transposed(inv)
+	tensor.ScaleInPlace(grad.Data[grad.Offset]*op.Det, gLocal)
+	accumulateGrad(p, gLocal)
+}
+
+// Det вычисляет определитель квадратной матрицы (скаляр формы [1]).
+// Градиент определён только для невырожденных матриц.
+func (e *Engine) Det(a *graph.Node) *graph.Node {
+	det, err := linalg.Det(a.Value)
+	if err != nil {
+		return nil
+	}
+	val := &tensor.Tensor{Data: []float64{det}, Shape: []int{1}, Strides: []int{1}}
+	op := &DetOp{Parents: []*graph.Node{a}, Det: det}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// LogDet
+type LogDetOp struct {
+	Parents []*graph.Node
+}
+
+// Backward: d log|det(A)|/dA = A⁻ᵀ.
+func (op *LogDetOp) Backward(grad *tensor.Tensor) {
+	p := op.Parents[0]
+	inv, err := linalg.Inverse(p.Value)
+	if err != nil {
+		panic(err)
+	}
+	gLocal := transposed(inv)
+	tensor.ScaleInPlace(grad.Data[grad.Offset], gLocal)
+	accumulateGrad(p, gLocal)
+}
+
+// LogDet вычисляет log|det(A)| (скаляр формы [1]), например для правдоподобия
+// гауссовских процессов. Для вырожденной матрицы возвращает nil.
+func (e *Engine) LogDet(a *graph.Node) *graph.Node {
+	_, logAbs, err := linalg.LogDet(a.Value)
+	if err != nil || math.IsInf(logAbs, -1) {
+		return nil
+	}
+	val := &tensor.Tensor{Data: []float64{logAbs}, Shape: []int{1}, Strides: []int{1}}
+	op := &LogDetOp{Parents: []*graph.Node{a}}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// Cholesky
+type CholeskyOp struct {
+	Parents []*graph.Node
+	L       *tensor.Tensor
+}
+
+// Backward: dA = sym(L⁻ᵀ·Φ(Lᵀ·G)·L⁻¹), где Φ берёт нижний треугольник с половиной
+// диагонали, а sym(S) = (S + Sᵀ)/2. Градиент симметричен, так как A предполагается
+// симметричной.
+func (op *CholeskyOp) Backward(grad *tensor.Tensor) {
+	p := op.Parents[0]
+	n := op.L.Shape[0]
+	phi := mustMatMul(transposed(op.L), grad.Contiguous())
+	for i := 0; i < n; i++ {
+		phi.Data[i*n+i] /= 2
+		for j := i + 1; j < n; j++ {
+			phi.Data[i*n+j] = 0
+		}
+	}
+	lInv, err := linalg.Inverse(op.L)
+	if err != nil {
+		panic(err)
+	}
+	s := mustMatMul(mustMatMul(transposed(lInv), phi), lInv)
+	gLocal, _ := tensor.Add(s, transposed(s))
+	tensor.ScaleInPlace(0.5, gLocal)
+	accumulateGrad(p, gLocal)
+}
+
+// Cholesky вычисляет множитель Холецкого L симметричной положительно определённой
+// матрицы (A = L·Lᵀ). Для матрицы, не являющейся положительно определённой, возвращает nil.
+func (e *Engine) Cholesky(a *graph.Node) *graph.Node {
+	val, err := linalg.Cholesky(a.Value)
+	if err != nil {
+		return nil
+	}
+	op := &CholeskyOp{Parents: []*graph.Node{a}, L: val}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
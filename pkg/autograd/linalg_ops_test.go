@@ -0,0 +1,55 @@
+package autograd
+
+import (
+	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+func TestLinalgGradientCheck(t *testing.T) {
+	// Хорошо обусловленная матрица: случайная плюс 3·I
+	a := tensor.Randn([]int{3, 3}, 801)
+	for i := 0; i < 3; i++ {
+		a.Data[i*3+i] += 3
+	}
+	b := tensor.Randn([]int{3, 2}, 802)
+	v := tensor.Randn([]int{3}, 803)
+	x := tensor.Randn([]int{3, 3}, 804)
+	w := graph.NewNode(tensor.Randn([]int{3, 3}, 805), nil, nil)
+	shift := graph.NewNode(newTensor([]float64{3, 0, 0, 0, 3, 0, 0, 0, 3}, 3, 3), nil, nil)
+
+	checkGradCases(t, []gradCase{
+		{"Solve", []*tensor.Tensor{a, b}, func(e *Engine, in []*graph.Node) *graph.Node {
+			s := e.Solve(in[0], in[1])
+			return e.Mul(s, s)
+		}},
+		{"SolveVector", []*tensor.Tensor{a, v}, func(e *Engine, in []*graph.Node) *graph.Node {
+			s := e.Solve(in[0], in[1])
+			return e.Mul(s, s)
+		}},
+		{"Inverse", []*tensor.Tensor{a}, func(e *Engine, in []*graph.Node) *graph.Node {
+			return e.Mul(e.Inverse(in[0]), w)
+		}},
+		{"Det", []*tensor.Tensor{a}, func(e *Engine, in []*graph.Node) *graph.Node {
+			d := e.Det(in[0])
+			return e.Mul(d, d)
+		}},
+		{"LogDet", []*tensor.Tensor{a}, func(e *Engine, in []*graph.Node) *graph.Node {
+			return e.LogDet(in[0])
+		}},
+		{"Cholesky", []*tensor.Tensor{x}, func(e *Engine, in []*graph.Node) *graph.Node {
+			// A = X·Xᵀ + 3·I симметрична и положительно определена
+			spd := e.Add(e.MatMul(in[0], e.Transpose(in[0])), shift)
+			return e.Mul(e.Cholesky(spd), w)
+		}},
+	}, nil)
+}
+
+func TestLinalgOpsRejectSingular(t *testing.T) {
+	e := NewEngine()
+	singular := graph.NewNode(newTensor([]float64{1, 2, 2, 4}, 2, 2), nil, nil)
+	if e.Inverse(singular) != nil || e.LogDet(singular) != nil || e.Cholesky(singular) != nil {
+		t.Fatal("expected nil for singular matrix")
+	}
+}
@@ -2,12 +2,23 @@ package autograd
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/Hirogava/Go-NN-Learn/pkg/matrix"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
 )
 
+// unbroadcast сводит градиент бинарной операции к форме операнда p:
+// оси, по которым p был расширен broadcasting-ом, суммируются.
+func unbroadcast(grad *tensor.Tensor, p *graph.Node) *tensor.Tensor {
+	g, err := tensor.SumToShape(grad, p.Value.Shape)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
 // Add
 type Add struct{ Parents []*graph.Node }
 
@@ -16,7 +27,7 @@ func (op *Add) Backward(grad *tensor.Tensor) {
 		if p.Grad == nil {
 			p.Grad = tensor.Zeros(p.Value.Shape...)
 		}
-		g, _ := tensor.Add(p.Grad, grad)
+		g, _ := tensor.Add(p.Grad, unbroadcast(grad, p))
 		p.Grad = g
 	}
 }
@@ -27,7 +38,7 @@ func (e *Engine) Add(a, b *graph.Node) *graph.Node {
 		return nil
 	}
 	op := &Add{Parents: []*graph.Node{a, b}}
-	n := graph.NewNode(val, []*graph.Node{a, b}, op)
+	n := e.newNode(val, []*graph.Node{a, b}, op)
 	e.Nodes = append(e.Nodes, n)
 	return n
 }
@@ -45,7 +56,7 @@ func (op *MulOperation) Backward(grad *tensor.Tensor) {
 		op.Parents[0].Grad = tensor.Zeros(op.Parents[0].Value.Shape...)
 	}
 	gA_local, _ := tensor.Mul(op.B, grad)
-	gA, _ := tensor.Add(op.Parents[0].Grad, gA_local)
+	gA, _ := tensor.Add(op.Parents[0].Grad, unbroadcast(gA_local, op.Parents[0]))
 	op.Parents[0].Grad = gA
 
 	// для B
@@ -53,7 +64,7 @@ func (op *MulOperation) Backward(grad *tensor.Tensor) {
 		op.Parents[1].Grad = tensor.Zeros(op.Parents[1].Value.Shape...)
 	}
 	gB_local, _ := tensor.Mul(op.A, grad)
-	gB, _ := tensor.Add(op.Parents[1].Grad, gB_local)
+	gB, _ := tensor.Add(op.Parents[1].Grad, unbroadcast(gB_local, op.Parents[1]))
 	op.Parents[1].Grad = gB
 }
 
@@ -63,11 +74,173 @@ func (e *Engine) Mul(a, b *graph.Node) *graph.Node {
 		return nil
 	}
 	op := &MulOperation{Parents: []*graph.Node{a, b}, A: a.Value, B: b.Value}
-	n := graph.NewNode(val, []*graph.Node{a, b}, op)
+	n := e.newNode(val, []*graph.Node{a, b}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// binaryBackward накапливает градиенты бинарной операции с broadcasting:
+// dA = grad * localA, dB = grad * localB (localA/localB — локальные производные
+// формы результата), затем каждый градиент сводится к форме своего операнда.
+func binaryBackward(parents []*graph.Node, grad, localA, localB *tensor.Tensor) {
+	for i, local := range []*tensor.Tensor{localA, localB} {
+		p := parents[i]
+		if p.Grad == nil {
+			p.Grad = tensor.Zeros(p.Value.Shape...)
+		}
+		gLocal, _ := tensor.Mul(local, grad)
+		g, _ := tensor.Add(p.Grad, unbroadcast(gLocal, p))
+		p.Grad = g
+	}
+}
+
+// binaryLocal вычисляет локальную производную поэлементно по значениям операндов.
+func binaryLocal(a, b *tensor.Tensor, f func(x, y float64) float64) *tensor.Tensor {
+	local, err := tensor.ZipWith(a, b, f)
+	if err != nil {
+		panic(err)
+	}
+	return local
+}
+
+// Sub
+type SubOp struct{ Parents []*graph.Node }
+
+func (op *SubOp) Backward(grad *tensor.Tensor) {
+	a, b := op.Parents[0].Value, op.Parents[1].Value
+	binaryBackward(op.Parents, grad,
+		binaryLocal(a, b, func(_, _ float64) float64 { return 1 }),
+		binaryLocal(a, b, func(_, _ float64) float64 { return -1 }))
+}
+
+func (e *Engine) Sub(a, b *graph.Node) *graph.Node {
+	val, err := tensor.Sub(a.Value, b.Value)
+	if err != nil {
+		return nil
+	}
+	op := &SubOp{Parents: []*graph.Node{a, b}}
+	n := e.newNode(val, []*graph.Node{a, b}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// Div
+type DivOp struct{ Parents []*graph.Node }
+
+// d/da (a / b) = 1 / b, d/db (a / b) = -a / b²
+func (op *DivOp) Backward(grad *tensor.Tensor) {
+	a, b := op.Parents[0].Value, op.Parents[1].Value
+	binaryBackward(op.Parents, grad,
+		binaryLocal(a, b, func(_, y float64) float64 { return 1 / y }),
+		binaryLocal(a, b, func(x, y float64) float64 { return -x / (y * y) }))
+}
+
+func (e *Engine) Div(a, b *graph.Node) *graph.Node {
+	val, err := tensor.Div(a.Value, b.Value)
+	if err != nil {
+		return nil
+	}
+	op := &DivOp{Parents: []*graph.Node{a, b}}
+	n := e.newNode(val, []*graph.Node{a, b}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// Pow
+type PowOp struct {
+	Parents []*graph.Node
+	Out     *tensor.Tensor
+}
+
+// d/da a^b = b·a^(b-1), d/db a^b = a^b·ln(a) (для a <= 0 принимается 0).
+func (op *PowOp) Backward(grad *tensor.Tensor) {
+	a, b := op.Parents[0].Value, op.Parents[1].Value
+	dA := binaryLocal(a, b, func(x, y float64) float64 {
+		if y == 0 {
+			return 0
+		}
+		return y * math.Pow(x, y-1)
+	})
+	// a^b уже посчитано в прямом проходе: dB = Out·ln(a).
+	logA := binaryLocal(a, b, func(x, _ float64) float64 {
+		if x <= 0 {
+			return 0
+		}
+		return math.Log(x)
+	})
+	dB, _ := tensor.Mul(op.Out, logA)
+	binaryBackward(op.Parents, grad, dA, dB)
+}
+
+func (e *Engine) Pow(a, b *graph.Node) *graph.Node {
+	val, err := tensor.Pow(a.Value, b.Value)
+	if err != nil {
+		return nil
+	}
+	op := &PowOp{Parents: []*graph.Node{a, b}, Out: val}
+	n := e.newNode(val, []*graph.Node{a, b}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// Maximum / Minimum
+type ExtremumOp struct {
+	Parents []*graph.Node
+	Max     bool
+}
+
+// Градиент проходит в операнд, выбранный экстремумом; при равенстве делится пополам.
+func (op *ExtremumOp) Backward(grad *tensor.Tensor) {
+	localA, localB := op.locals()
+	binaryBackward(op.Parents, grad, localA, localB)
+}
+
+// locals возвращает доли градиента, приходящиеся на первый и второй операнды.
+func (op *ExtremumOp) locals() (*tensor.Tensor, *tensor.Tensor) {
+	a, b := op.Parents[0].Value, op.Parents[1].Value
+	pick := func(first bool) func(x, y float64) float64 {
+		return func(x, y float64) float64 {
+			if x == y {
+				return 0.5
+			}
+			// aWins — экстремум достигается на первом операнде
+			aWins := (x > y) == op.Max
+			if aWins == first {
+				return 1
+			}
+			return 0
+		}
+	}
+	return binaryLocal(a, b, pick(true)), binaryLocal(a, b, pick(false))
+}
+
+func (e *Engine) extremum(a, b *graph.Node, isMax bool) *graph.Node {
+	var val *tensor.Tensor
+	var err error
+	if isMax {
+		val, err = tensor.Maximum(a.Value, b.Value)
+	} else {
+		val, err = tensor.Minimum(a.Value, b.Value)
+	}
+	if err != nil {
+		return nil
+	}
+	op := &ExtremumOp{Parents: []*graph.Node{a, b}, Max: isMax}
+	n := e.newNode(val, []*graph.Node{a, b}, op)
 	e.Nodes = append(e.Nodes, n)
 	return n
 }
 
+// Maximum возвращает поэлементный максимум a и b (с broadcasting).
+func (e *Engine) Maximum(a, b *graph.Node) *graph.Node {
+	return e.extremum(a, b, true)
+}
+
+// Minimum возвращает поэлементный минимум a и b (с broadcasting).
+func (e *Engine) Minimum(a, b *graph.Node) *graph.Node {
+	return e.extremum(a, b, false)
+}
+
 type MatMul struct {
 	Parents []*graph.Node
 	A       *tensor.Tensor
@@ -116,6 +289,8 @@ func (op *TransposeOp) Backward(grad *tensor.Tensor) {
 	if err != nil {
 		panic(err)
 	}
+	// Transpose возвращает view; градиент храним плотным
+	gradIn = gradIn.Contiguous()
 
 	if p.Grad == nil {
 		p.Grad = gradIn
@@ -137,7 +312,7 @@ func (e *Engine) MatMul(a, b *graph.Node) *graph.Node {
 	}
 	val := matrix.MatrixToTensor(valM)
 	op := &MatMul{Parents: []*graph.Node{a, b}, A: a.Value, B: b.Value}
-	n := graph.NewNode(val, []*graph.Node{a, b}, op)
+	n := e.newNode(val, []*graph.Node{a, b}, op)
 	e.Nodes = append(e.Nodes, n)
 	return n
 }
@@ -150,7 +325,7 @@ func (e *Engine) Transpose(a *graph.Node) *graph.Node {
 	}
 	val := matrix.MatrixToTensor(valM)
 	op := &TransposeOp{Parents: []*graph.Node{a}}
-	n := graph.NewNode(val, []*graph.Node{a}, op)
+	n := e.newNode(val, []*graph.Node{a}, op)
 	e.Nodes = append(e.Nodes, n)
 	return n
 }
@@ -190,7 +365,7 @@ func (e *Engine) Sum(a *graph.Node) *graph.Node {
 	val := tensor.Sum(a.Value) // скаляр [1]
 	inShape := append([]int{}, a.Value.Shape...)
 	op := &Sum{Parents: []*graph.Node{a}, InputShape: inShape}
-	n := graph.NewNode(val, []*graph.Node{a}, op)
+	n := e.newNode(val, []*graph.Node{a}, op)
 	e.Nodes = append(e.Nodes, n)
 	return n
 }
@@ -214,7 +389,7 @@ func (op *Exp) Backward(grad *tensor.Tensor) {
 func (e *Engine) Exp(a *graph.Node) *graph.Node {
 	val := tensor.Exp(a.Value)
 	op := &Exp{Parents: []*graph.Node{a}, Out: val}
-	n := graph.NewNode(val, []*graph.Node{a}, op)
+	n := e.newNode(val, []*graph.Node{a}, op)
 	e.Nodes = append(e.Nodes, n)
 	return n
 }
@@ -246,7 +421,7 @@ func (op *Log) Backward(grad *tensor.Tensor) {
 func (e *Engine) Log(a *graph.Node) *graph.Node {
 	val := tensor.Log(a.Value)
 	op := &Log{Parents: []*graph.Node{a}, In: a.Value, Eps: 1e-12}
-	n := graph.NewNode(val, []*graph.Node{a}, op)
+	n := e.newNode(val, []*graph.Node{a}, op)
 	e.Nodes = append(e.Nodes, n)
 	return n
 }
@@ -282,7 +457,7 @@ func (e *Engine) Reshape(a *graph.Node, newShape []int) *graph.Node {
 		InShape:  append([]int{}, a.Value.Shape...),
 		OutShape: append([]int{}, newShape...),
 	}
-	n := graph.NewNode(val, []*graph.Node{a}, op)
+	n := e.newNode(val, []*graph.Node{a}, op)
 	e.Nodes = append(e.Nodes, n)
 	return n
 }
@@ -330,7 +505,7 @@ func (e *Engine) Concatenate(inputs []*graph.Node, axis int) *graph.Node {
 	}
 
 	// Регистрация в графе
-	node := graph.NewNode(resultValue, inputs, op)
+	node := e.newNode(resultValue, inputs, op)
 	e.Nodes = append(e.Nodes, node)
 	return node
 }
@@ -0,0 +1,385 @@
+package autograd
+
+import (
+	"math"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+// Редукции по осям (Sum/Mean/Prod/Max/Min/Var/Std/LogSumExp/Norm) и LogSoftmax.
+// В backward градиент приводится к форме keepDims и broadcast-ится на форму входа.
+
+// expandGrad приводит градиент редукции к форме входа.
+func expandGrad(grad *tensor.Tensor, keepShape, inShape []int) *tensor.Tensor {
+	g, err := tensor.Reshape(grad, keepShape)
+	if err != nil {
+		panic(err)
+	}
+	g, err = tensor.Expand(g, inShape...)
+	if err != nil {
+		panic(err)
+	}
+	return g.Contiguous()
+}
+
+// accumulateGrad добавляет gLocal к градиенту узла p.
+func accumulateGrad(p *graph.Node, gLocal *tensor.Tensor) {
+	if p.Grad == nil {
+		p.Grad = tensor.Zeros(p.Value.Shape...)
+	}
+	g, _ := tensor.Add(p.Grad, gLocal)
+	p.Grad = g
+}
+
+// SumAxes
+type SumAxesOp struct {
+	Parents   []*graph.Node
+	KeepShape []int
+}
+
+func (op *SumAxesOp) Backward(grad *tensor.Tensor) {
+	p := op.Parents[0]
+	accumulateGrad(p, expandGrad(grad, op.KeepShape, p.Value.Shape))
+}
+
+// SumAxes суммирует a по осям axes (пустой список — по всем осям).
+func (e *Engine) SumAxes(a *graph.Node, axes []int, keepDims bool) *graph.Node {
+	val, err := tensor.SumAxes(a.Value, axes, keepDims)
+	if err != nil {
+		return nil
+	}
+	keep, _ := tensor.KeepDimsShape(a.Value.Shape, axes)
+	op := &SumAxesOp{Parents: []*graph.Node{a}, KeepShape: keep}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// MeanAxes
+type MeanAxesOp struct {
+	Parents   []*graph.Node
+	KeepShape []int
+	Count     int
+}
+
+func (op *MeanAxesOp) Backward(grad *tensor.Tensor) {
+	p := op.Parents[0]
+	g := expandGrad(grad, op.KeepShape, p.Value.Shape)
+	tensor.ScaleInPlace(1/float64(op.Count), g)
+	accumulateGrad(p, g)
+}
+
+// MeanAxes вычисляет среднее a по осям axes.
+func (e *Engine) MeanAxes(a *graph.Node, axes []int, keepDims bool) *graph.Node {
+	val, err := tensor.MeanAxes(a.Value, axes, keepDims)
+	if err != nil {
+		return nil
+	}
+	keep, _ := tensor.KeepDimsShape(a.Value.Shape, axes)
+	op := &MeanAxesOp{
+		Parents:   []*graph.Node{a},
+		KeepShape: keep,
+		Count:     int(a.Value.Size() / val.Size()),
+	}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// ProdAxes
+type ProdAxesOp struct {
+	Parents   []*graph.Node
+	KeepShape []int
+	Axes      []int
+}
+
+// Backward: d(Πx)/dx_i = Π_{j≠i} x_j. Считаем без деления на x_i,
+// чтобы корректно обрабатывать нули: по каждой группе учитываем число нулей
+// и произведение ненулевых элементов.
+func (op *ProdAxesOp) Backward(grad *tensor.Tensor) {
+	p := op.Parents[0]
+	x := p.Value
+	isZero := tensor.Apply(x, func(v float64) float64 {
+		if v == 0 {
+			return 1
+		}
+		return 0
+	})
+	nonZero := tensor.Apply(x, func(v float64) float64 {
+		if v == 0 {
+			return 1
+		}
+		return v
+	})
+	zeros, _ := tensor.SumAxes(isZero, op.Axes, true)
+	prodNZ, _ := tensor.ProdAxes(nonZero, op.Axes, true)
+	zeros = expandGrad(zeros, op.KeepShape, x.Shape)
+	prodNZ = expandGrad(prodNZ, op.KeepShape, x.Shape)
+	g := expandGrad(grad, op.KeepShape, x.Shape)
+
+	xd := x.Contiguous()
+	for i, v := range xd.Data {
+		var d float64
+		switch {
+		case zeros.Data[i] == 0:
+			d = prodNZ.Data[i] / v
+		case zeros.Data[i] == 1 && v == 0:
+			d = prodNZ.Data[i]
+		}
+		g.Data[i] *= d
+	}
+	accumulateGrad(p, g)
+}
+
+// ProdAxes вычисляет произведение элементов a по осям axes.
+func (e *Engine) ProdAxes(a *graph.Node, axes []int, keepDims bool) *graph.Node {
+	val, err := tensor.ProdAxes(a.Value, axes, keepDims)
+	if err != nil {
+		return nil
+	}
+	keep, _ := tensor.KeepDimsShape(a.Value.Shape, axes)
+	op := &ProdAxesOp{Parents: []*graph.Node{a}, KeepShape: keep, Axes: append([]int{}, axes...)}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// MaxAxes / MinAxes
+type ExtremumAxesOp struct {
+	Parents   []*graph.Node
+	KeepShape []int
+	Axes      []int
+	Out       *tensor.Tensor
+}
+
+// Backward: градиент получают элементы, равные экстремуму группы.
+// При нескольких равных экстремумах градиент делится между ними поровну.
+func (op *ExtremumAxesOp) Backward(grad *tensor.Tensor) {
+	p := op.Parents[0]
+	g := expandGrad(grad, op.KeepShape, p.Value.Shape)
+	for i, w := range op.weights().Data {
+		g.Data[i] *= w
+	}
+	accumulateGrad(p, g)
+}
+
+// weights возвращает локальную производную по входу: 1/k для каждого из k
+// элементов, равных экстремуму группы, и 0 для остальных.
+func (op *ExtremumAxesOp) weights() *tensor.Tensor {
+	x := op.Parents[0].Value.Contiguous()
+	out := expandGrad(op.Out, op.KeepShape, x.Shape)
+	mask := tensor.Zeros(x.Shape...)
+	for i, v := range x.Data {
+		if v == out.Data[i] {
+			mask.Data[i] = 1
+		}
+	}
+	counts, _ := tensor.SumAxes(mask, op.Axes, true)
+	counts = expandGrad(counts, op.KeepShape, x.Shape)
+	for i := range mask.Data {
+		mask.Data[i] /= counts.Data[i]
+	}
+	return mask
+}
+
+func (e *Engine) extremumAxes(a *graph.Node, axes []int, keepDims bool, reduce func(*tensor.Tensor, []int, bool) (*tensor.Tensor, error)) *graph.Node {
+	val, err := reduce(a.Value, axes, keepDims)
+	if err != nil {
+		return nil
+	}
+	keep, _ := tensor.KeepDimsShape(a.Value.Shape, axes)
+	op := &ExtremumAxesOp{
+		Parents:   []*graph.Node{a},
+		KeepShape: keep,
+		Axes:      append([]int{}, axes...),
+		Out:       val,
+	}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// MaxAxes возвращает максимум a по осям axes.
+func (e *Engine) MaxAxes(a *graph.Node, axes []int, keepDims bool) *graph.Node {
+	return e.extremumAxes(a, axes, keepDims, tensor.MaxAxes)
+}
+
+// MinAxes возвращает минимум a по осям axes.
+func (e *Engine) MinAxes(a *graph.Node, axes []int, keepDims bool) *graph.Node {
+	return e.extremumAxes(a, axes, keepDims, tensor.MinAxes)
+}
+
+// VarAxes / StdAxes
+type VarAxesOp struct {
+	Parents   []*graph.Node
+	KeepShape []int
+	Axes      []int
+	Count     int // число элементов в группе
+	DDof      int
+	Std       *tensor.Tensor // не nil для StdAxes
+}
+
+// Backward: dVar/dx_i = 2(x_i - mean) / (N - ddof), dStd/dx_i = dVar/dx_i / (2·std).
+func (op *VarAxesOp) Backward(grad *tensor.Tensor) {
+	p := op.Parents[0]
+	x := p.Value.Contiguous()
+	mean, _ := tensor.MeanAxes(x, op.Axes, true)
+	mean = expandGrad(mean, op.KeepShape, x.Shape)
+
+	g := expandGrad(grad, op.KeepShape, x.Shape)
+	scale := 2 / float64(op.Count-op.DDof)
+	var std *tensor.Tensor
+	if op.Std != nil {
+		std = expandGrad(op.Std, op.KeepShape, x.Shape)
+	}
+	for i, v := range x.Data {
+		d := scale * (v - mean.Data[i])
+		if std != nil {
+			if std.Data[i] == 0 {
+				d = 0
+			} else {
+				d /= 2 * std.Data[i]
+			}
+		}
+		g.Data[i] *= d
+	}
+	accumulateGrad(p, g)
+}
+
+// VarAxes вычисляет дисперсию a по осям axes (ddof — поправка знаменателя N - ddof).
+func (e *Engine) VarAxes(a *graph.Node, axes []int, keepDims bool, ddof int) *graph.Node {
+	val, err := tensor.VarAxes(a.Value, axes, keepDims, ddof)
+	if err != nil {
+		return nil
+	}
+	return e.varNode(a, val, axes, ddof, nil)
+}
+
+// StdAxes вычисляет стандартное отклонение a по осям axes.
+func (e *Engine) StdAxes(a *graph.Node, axes []int, keepDims bool, ddof int) *graph.Node {
+	val, err := tensor.StdAxes(a.Value, axes, keepDims, ddof)
+	if err != nil {
+		return nil
+	}
+	return e.varNode(a, val, axes, ddof, val)
+}
+
+func (e *Engine) varNode(a *graph.Node, val *tensor.Tensor, axes []int, ddof int, std *tensor.Tensor) *graph.Node {
+	keep, _ := tensor.KeepDimsShape(a.Value.Shape, axes)
+	op := &VarAxesOp{
+		Parents:   []*graph.Node{a},
+		KeepShape: keep,
+		Axes:      append([]int{}, axes...),
+		Count:     int(a.Value.Size() / val.Size()),
+		DDof:      ddof,
+		Std:       std,
+	}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// LogSumExpAxes
+type LogSumExpAxesOp struct {
+	Parents   []*graph.Node
+	KeepShape []int
+	Axes      []int
+	Out       *tensor.Tensor
+}
+
+// Backward: d(lse)/dx_i = exp(x_i - lse) (softmax по группе).
+func (op *LogSumExpAxesOp) Backward(grad *tensor.Tensor) {
+	p := op.Parents[0]
+	x := p.Value.Contiguous()
+	lse := expandGrad(op.Out, op.KeepShape, x.Shape)
+	g := expandGrad(grad, op.KeepShape, x.Shape)
+	for i, v := range x.Data {
+		g.Data[i] *= math.Exp(v - lse.Data[i])
+	}
+	accumulateGrad(p, g)
+}
+
+// LogSumExpAxes вычисляет log(Σ exp(a)) по осям axes.
+func (e *Engine) LogSumExpAxes(a *graph.Node, axes []int, keepDims bool) *graph.Node {
+	val, err := tensor.LogSumExpAxes(a.Value, axes, keepDims)
+	if err != nil {
+		return nil
+	}
+	keep, _ := tensor.KeepDimsShape(a.Value.Shape, axes)
+	op := &LogSumExpAxesOp{Parents: []*graph.Node{a}, KeepShape: keep, Axes: append([]int{}, axes...), Out: val}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// LogSoftmax
+type LogSoftmaxOp struct {
+	Parents []*graph.Node
+	Out     *tensor.Tensor
+}
+
+// Backward: d/dx_i = g_i - softmax_i · Σ_j g_j (по последней оси).
+func (op *LogSoftmaxOp) Backward(grad *tensor.Tensor) {
+	sum, err := tensor.SumAxes(grad, []int{-1}, true)
+	if err != nil {
+		panic(err)
+	}
+	soft, _ := tensor.Mul(tensor.Exp(op.Out), sum)
+	g, _ := tensor.Sub(grad, soft)
+	accumulateGrad(op.Parents[0], g)
+}
+
+// LogSoftmax вычисляет log(softmax(a)) по последней оси как a - logsumexp(a),
+// без переполнения exp для больших логитов.
+func (e *Engine) LogSoftmax(a *graph.Node) *graph.Node {
+	lse, err := tensor.LogSumExpAxes(a.Value, []int{-1}, true)
+	if err != nil {
+		return nil
+	}
+	val, err := tensor.Sub(a.Value, lse)
+	if err != nil {
+		return nil
+	}
+	op := &LogSoftmaxOp{Parents: []*graph.Node{a}, Out: val}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// NormAxes
+type NormAxesOp struct {
+	Parents   []*graph.Node
+	KeepShape []int
+	Axes      []int
+	Out       *tensor.Tensor
+}
+
+// Backward: d‖x‖/dx_i = x_i / ‖x‖ (для нулевой нормы градиент принимается равным 0).
+func (op *NormAxesOp) Backward(grad *tensor.Tensor) {
+	p := op.Parents[0]
+	x := p.Value.Contiguous()
+	norm := expandGrad(op.Out, op.KeepShape, x.Shape)
+	g := expandGrad(grad, op.KeepShape, x.Shape)
+	for i, v := range x.Data {
+		if norm.Data[i] == 0 {
+			g.Data[i] = 0
+			continue
+		}
+		g.Data[i] *= v / norm.Data[i]
+	}
+	accumulateGrad(p, g)
+}
+
+// NormAxes вычисляет L2-норму a по осям axes.
+func (e *Engine) NormAxes(a *graph.Node, axes []int, keepDims bool) *graph.Node {
+	val, err := tensor.NormAxes(a.Value, axes, keepDims)
+	if err != nil {
+		return nil
+	}
+	keep, _ := tensor.KeepDimsShape(a.Value.Shape, axes)
+	op := &NormAxesOp{Parents: []*graph.Node{a}, KeepShape: keep, Axes: append([]int{}, axes...), Out: val}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
@@ -0,0 +1,55 @@
+package autograd
+
+import (
+	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+func TestReduceAxesGradientCheck(t *testing.T) {
+	x := tensor.Randn([]int{2, 3, 4}, 303)
+
+	checkGradCases(t, []gradCase{
+		unaryCase("SumAxes", x, func(e *Engine, in *graph.Node) *graph.Node { return e.SumAxes(in, []int{1}, false) }),
+		unaryCase("SumAxesAll", x, func(e *Engine, in *graph.Node) *graph.Node { return e.SumAxes(in, nil, false) }),
+		unaryCase("MeanAxes", x, func(e *Engine, in *graph.Node) *graph.Node { return e.MeanAxes(in, []int{0, 2}, true) }),
+		unaryCase("ProdAxes", x, func(e *Engine, in *graph.Node) *graph.Node { return e.ProdAxes(in, []int{2}, false) }),
+		unaryCase("MaxAxes", x, func(e *Engine, in *graph.Node) *graph.Node { return e.MaxAxes(in, []int{-1}, false) }),
+		unaryCase("MinAxes", x, func(e *Engine, in *graph.Node) *graph.Node { return e.MinAxes(in, []int{0}, true) }),
+		unaryCase("VarAxes", x, func(e *Engine, in *graph.Node) *graph.Node { return e.VarAxes(in, []int{1, 2}, false, 1) }),
+		unaryCase("StdAxes", x, func(e *Engine, in *graph.Node) *graph.Node { return e.StdAxes(in, []int{2}, false, 0) }),
+		unaryCase("LogSumExpAxes", x, func(e *Engine, in *graph.Node) *graph.Node { return e.LogSumExpAxes(in, []int{1}, true) }),
+		unaryCase("NormAxes", x, func(e *Engine, in *graph.Node) *graph.Node { return e.NormAxes(in, []int{0}, false) }),
+	}, nil)
+}
+
+func TestProdAxesGradWithZeros(t *testing.T) {
+	e := NewEngine()
+	x := e.RequireGrad(newTensor([]float64{0, 2, 3, 0, 0, 5, 1, 2, 4}, 3, 3))
+	y := e.ProdAxes(x, []int{1}, false)
+	e.Backward(y)
+
+	// Строка с одним нулём: градиент только у нулевого элемента,
+	// строка с двумя нулями: нулевой градиент, строка без нулей: prod/x.
+	want := []float64{6, 0, 0, 0, 0, 0, 8, 4, 2}
+	for i, w := range want {
+		if x.Grad.Data[i] != w {
+			t.Fatalf("grad[%d] = %v, want %v", i, x.Grad.Data[i], w)
+		}
+	}
+}
+
+func TestMaxAxesGradSplitsTies(t *testing.T) {
+	e := NewEngine()
+	x := e.RequireGrad(newTensor([]float64{3, 3, 1, 0, 2, 1}, 2, 3))
+	y := e.MaxAxes(x, []int{1}, false)
+	e.Backward(y)
+
+	want := []float64{0.5, 0.5, 0, 0, 1, 0}
+	for i, w := range want {
+		if x.Grad.Data[i] != w {
+			t.Fatalf("grad[%d] = %v, want %v", i, x.Grad.Data[i], w)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package autograd
+
+import (
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+// Копирование элементов по осям (Pad, Tile, Repeat, Flip, Roll, Stack).
+// Backward суммирует градиенты всех копий элемента в его исходную позицию.
+
+// Pad
+type PadOp struct {
+	Parents []*graph.Node
+	Pads    [][2]int
+	Mode    tensor.PadMode
+}
+
+func (op *PadOp) Backward(grad *tensor.Tensor) {
+	gLocal, err := tensor.Unpad(grad, op.Pads, op.Mode)
+	if err != nil {
+		panic(err)
+	}
+	accumulateGrad(op.Parents[0], gLocal)
+}
+
+// Pad дополняет a полями pads в режиме mode (см. tensor.Pad).
+func (e *Engine) Pad(a *graph.Node, pads [][2]int, mode tensor.PadMode, value float64) *graph.Node {
+	val, err := tensor.Pad(a.Value, pads, mode, value)
+	if err != nil {
+		return nil
+	}
+	op := &PadOp{Parents: []*graph.Node{a}, Pads: append([][2]int{}, pads...), Mode: mode}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// Tile
+type TileOp struct {
+	Parents []*graph.Node
+}
+
+// Backward: каждая ось выхода d размера r·n раскладывается на (r, n),
+// и градиент суммируется по осям повторов.
+func (op *TileOp) Backward(grad *tensor.Tensor) {
+	p := op.Parents[0]
+	in := p.Value.Shape
+	split, axes := op.split(grad.Shape)
+	g, err := tensor.Reshape(grad, split)
+	if err != nil {
+		panic(err)
+	}
+	g, err = tensor.SumAxes(g, axes, false)
+	if err != nil {
+		panic(err)
+	}
+	g, err = tensor.Reshape(g, in)
+	if err != nil {
+		panic(err)
+	}
+	accumulateGrad(p, g)
+}
+
+// split возвращает форму выхода с осями, разложенными на (r, n), и оси повторов.
+func (op *TileOp) split(out []int) (split, axes []int) {
+	in := op.Parents[0].Value.Shape
+	lead := len(out) - len(in)
+	split = make([]int, 0, 2*len(out))
+	axes = make([]int, 0, len(out))
+	for d, size := range out {
+		n := 1
+		if d >= lead {
+			n = in[d-lead]
+		}
+		axes = append(axes, len(split))
+		split = append(split, size/n, n)
+	}
+	return split, axes
+}
+
+// Tile повторяет a целиком reps[d] раз вдоль каждой оси (см. tensor.Tile).
+func (e *Engine) Tile(a *graph.Node, reps ...int) *graph.Node {
+	val, err := tensor.Tile(a.Value, reps...)
+	if err != nil {
+		return nil
+	}
+	op := &TileOp{Parents: []*graph.Node{a}}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// Repeat
+type RepeatOp struct {
+	Parents []*graph.Node
+	Repeats int
+	Axis    int
+}
+
+// Backward: ось axis раскладывается на (n, repeats), градиент суммируется по повторам.
+func (op *RepeatOp) Backward(grad *tensor.Tensor) {
+	split, axis := op.split(grad.Shape)
+	g, err := tensor.Reshape(grad, split)
+	if err != nil {
+		panic(err)
+	}
+	g, err = tensor.SumAxes(g, []int{axis}, false)
+	if err != nil {
+		panic(err)
+	}
+	accumulateGrad(op.Parents[0], g)
+}
+
+// split возвращает форму выхода с осью Axis, разложенной на (n, repeats),
+// и номер оси повторов.
+func (op *RepeatOp) split(out []int) ([]int, int) {
+	axis := op.Axis
+	if axis < 0 {
+		axis += len(out)
+	}
+	split := append([]int{}, out[:axis]...)
+	split = append(split, op.Parents[0].Value.Shape[axis], op.Repeats)
+	split = append(split, out[axis+1:]...)
+	return split, axis + 1
+}
+
+// Repeat повторяет каждый элемент a repeats раз вдоль оси axis (см. tensor.Repeat).
+func (e *Engine) Repeat(a *graph.Node, repeats, axis int) *graph.Node {
+	val, err := tensor.Repeat(a.Value, repeats, axis)
+	if err != nil {
+		return nil
+	}
+	op := &RepeatOp{Parents: []*graph.Node{a}, Repeats: repeats, Axis: axis}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// Flip
+type FlipOp struct {
+	Parents []*graph.Node
+	Axes    []int
+}
+
+// Backward: отражение — само себе обратное.
+func (op *FlipOp) Backward(grad *tensor.Tensor) {
+	gLocal, err := tensor.Flip(grad, op.Axes...)
+	if err != nil {
+		panic(err)
+	}
+	accumulateGrad(op.Parents[0], gLocal)
+}
+
+// Flip переставляет элементы a в обратном порядке вдоль осей axes (см. tensor.Flip).
+func (e *Engine) Flip(a *graph.Node, axes ...int) *graph.Node {
+	val, err := tensor.Flip(a.Value, axes...)
+	if err != nil {
+		return nil
+	}
+	op := &FlipOp{Parents: []*graph.Node{a}, Axes: append([]int{}, axes...)}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// Roll
+type RollOp struct {
+	Parents []*graph.Node
+	Shift   int
+	Axis    int
+}
+
+// Backward: градиент сдвигается обратно на -Shift.
+func (op *RollOp) Backward(grad *tensor.Tensor) {
+	gLocal, err := tensor.Roll(grad, -op.Shift, op.Axis)
+	if err != nil {
+		panic(err)
+	}
+	accumulateGrad(op.Parents[0], gLocal)
+}
+
+// Roll циклически сдвигает элементы a на shift позиций вдоль оси axis (см. tensor.Roll).
+func (e *Engine) Roll(a *graph.Node, shift, axis int) *graph.Node {
+	val, err := tensor.Roll(a.Value, shift, axis)
+	if err != nil {
+		return nil
+	}
+	op := &RollOp{Parents: []*graph.Node{a}, Shift: shift, Axis: axis}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// Stack
+type StackOp struct {
+	Parents []*graph.Node
+	Axis    int
+}
+
+// Backward: i-й вход получает срез градиента с индексом i по новой оси.
+func (op *StackOp) Backward(grad *tensor.Tensor) {
+	for i, p := range op.Parents {
+		part, err := tensor.Narrow(grad, op.Axis, i, 1)
+		if err != nil {
+			panic(err)
+		}
+		part, err = tensor.Squeeze(part, op.Axis)
+		if err != nil {
+			panic(err)
+		}
+		accumulateGrad(p, part.Contiguous())
+	}
+}
+
+// Stack объединяет узлы одинаковой формы вдоль новой оси axis (см. tensor.Stack).
+func (e *Engine) Stack(inputs []*graph.Node, axis int) *graph.Node {
+	tensors := make([]*tensor.Tensor, len(inputs))
+	for i, n := range inputs {
+		tensors[i] = n.Value
+	}
+	val, err := tensor.Stack(tensors, axis)
+	if err != nil {
+		return nil
+	}
+	parents := append([]*graph.Node{}, inputs...)
+	op := &StackOp{Parents: parents, Axis: axis}
+	n := e.newNode(val, parents, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
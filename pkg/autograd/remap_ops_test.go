@@ -0,0 +1,61 @@
+package autograd
+
+import (
+	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+// weighted умножает выход на фиксированные разные веса, чтобы проверка градиента
+// через сумму выходов различала перестановки элементов.
+func weighted(e *Engine, out *graph.Node) *graph.Node {
+	if out == nil {
+		return nil
+	}
+	w := tensor.Zeros(out.Value.Shape...)
+	for i := range w.Data {
+		w.Data[i] = float64(i%7) - 2.5
+	}
+	return e.Mul(out, graph.NewNode(w, nil, nil))
+}
+
+func TestRemapOpsGradientCheck(t *testing.T) {
+	x := tensor.Randn([]int{2, 3}, 505)
+	y := tensor.Randn([]int{2, 3}, 506)
+
+	xy := []*tensor.Tensor{x, y}
+	checkGradCases(t, []gradCase{
+		{"PadConstant", xy, func(e *Engine, in []*graph.Node) *graph.Node {
+			return e.Pad(in[0], [][2]int{{1, 0}, {2, 1}}, tensor.PadConstant, 0.5)
+		}},
+		{"PadReflect", xy, func(e *Engine, in []*graph.Node) *graph.Node {
+			return e.Pad(in[0], [][2]int{{1, 1}, {2, 4}}, tensor.PadReflect, 0)
+		}},
+		{"PadReplicate", xy, func(e *Engine, in []*graph.Node) *graph.Node {
+			return e.Pad(in[0], [][2]int{{0, 2}, {1, 1}}, tensor.PadReplicate, 0)
+		}},
+		{"PadCircular", xy, func(e *Engine, in []*graph.Node) *graph.Node {
+			return e.Pad(in[0], [][2]int{{2, 0}, {0, 3}}, tensor.PadCircular, 0)
+		}},
+		{"Tile", xy, func(e *Engine, in []*graph.Node) *graph.Node { return e.Tile(in[0], 2, 2, 1) }},
+		{"Repeat", xy, func(e *Engine, in []*graph.Node) *graph.Node { return e.Repeat(in[0], 3, -1) }},
+		{"Flip", xy, func(e *Engine, in []*graph.Node) *graph.Node { return e.Flip(in[0], 0) }},
+		{"Roll", xy, func(e *Engine, in []*graph.Node) *graph.Node { return e.Roll(in[0], 2, 1) }},
+		{"Stack", xy, func(e *Engine, in []*graph.Node) *graph.Node { return e.Stack(in, 1) }},
+	}, weightedOutput)
+}
+
+func TestPadReflectGradAccumulatesCopies(t *testing.T) {
+	e := NewEngine()
+	x := e.RequireGrad(newTensor([]float64{1, 2, 3}, 3))
+	y := e.Pad(x, [][2]int{{2, 2}}, tensor.PadReflect, 0) // [3 2 1 2 3 2 1]
+	e.Backward(e.Sum(y))
+
+	want := []float64{2, 3, 2}
+	for i, w := range want {
+		if x.Grad.Data[i] != w {
+			t.Fatalf("grad[%d] = %v, want %v", i, x.Grad.Data[i], w)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package autograd
+
+import (
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+// TopK
+type TopKOp struct {
+	Parents []*graph.Node
+	Axis    int
+	Indices *tensor.Tensor
+}
+
+// Backward: градиент возвращается в позиции выбранных элементов, остальные получают 0.
+func (op *TopKOp) Backward(grad *tensor.Tensor) {
+	p := op.Parents[0]
+	gLocal, err := tensor.ScatterAdd(tensor.Zeros(p.Value.Shape...), op.Axis, op.Indices, grad)
+	if err != nil {
+		panic(err)
+	}
+	accumulateGrad(p, gLocal)
+}
+
+// TopK выбирает k наибольших (largest=true) или наименьших элементов a вдоль оси axis
+// (см. tensor.TopK). Возвращает узел значений и индексы; градиент идёт только по значениям.
+func (e *Engine) TopK(a *graph.Node, k, axis int, largest bool) (*graph.Node, *tensor.Tensor) {
+	val, indices, err := tensor.TopK(a.Value, k, axis, largest)
+	if err != nil {
+		return nil, nil
+	}
+	op := &TopKOp{Parents: []*graph.Node{a}, Axis: axis, Indices: indices}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n, indices
+}
+
+// CumSum
+type CumSumOp struct {
+	Parents []*graph.Node
+	Axis    int
+}
+
+// Backward: элемент i входит во все суммы j >= i, поэтому его градиент —
+// накопленная сумма grad в обратном порядке: Σ grad - CumSum(grad) + grad.
+func (op *CumSumOp) Backward(grad *tensor.Tensor) {
+	p := op.Parents[0]
+	cum, err := tensor.CumSum(grad, op.Axis)
+	if err != nil {
+		panic(err)
+	}
+	total, err := tensor.SumAxes(grad, []int{op.Axis}, true)
+	if err != nil {
+		panic(err)
+	}
+	rest, _ := tensor.Sub(total, cum)
+	gLocal, _ := tensor.Add(rest, grad)
+	accumulateGrad(p, gLocal)
+}
+
+// CumSum возвращает накопленные суммы a вдоль оси axis.
+func (e *Engine) CumSum(a *graph.Node, axis int) *graph.Node {
+	val, err := tensor.CumSum(a.Value, axis)
+	if err != nil {
+		return nil
+	}
+	op := &CumSumOp{Parents: []*graph.Node{a}, Axis: axis}
+	n := e.newNode(val, []*graph.Node{a}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
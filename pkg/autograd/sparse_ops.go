@@ -0,0 +1,36 @@
+package autograd
+
+import (
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+// SparseMatMul
+type SparseMatMulOp struct {
+	Parents []*graph.Node
+}
+
+// Backward: разреженный вход — константа, градиент получает только b: dB = Aᵀ·G.
+func (op *SparseMatMulOp) Backward(grad *tensor.Tensor) {
+	a, b := op.Parents[0], op.Parents[1]
+	gB, err := tensor.SparseDenseMatMulTransposeA(a.Sparse, grad)
+	if err != nil {
+		panic(err)
+	}
+	accumulateGrad(b, gB)
+}
+
+// SparseMatMul умножает разреженный узел a (см. graph.NewSparseNode) на плотный b.
+func (e *Engine) SparseMatMul(a, b *graph.Node) *graph.Node {
+	if a.Sparse == nil {
+		return nil
+	}
+	val, err := tensor.SparseDenseMatMul(a.Sparse, b.Value)
+	if err != nil {
+		return nil
+	}
+	op := &SparseMatMulOp{Parents: []*graph.Node{a, b}}
+	n := e.newNode(val, []*graph.Node{a, b}, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
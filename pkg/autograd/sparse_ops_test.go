@@ -0,0 +1,31 @@
+package autograd
+
+import (
+	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+func TestSparseMatMulGradientCheck(t *testing.T) {
+	coo, err := tensor.NewCOO(3, 4, []int{0, 0, 1, 2, 2}, []int{1, 3, 0, 2, 3}, []float64{1.5, -2, 0.5, 3, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := graph.NewSparseNode(coo.ToCSR())
+	w := graph.NewNode(tensor.Randn([]int{3, 2}, 901), nil, nil)
+
+	build := func(e *Engine, in []*graph.Node) *graph.Node {
+		out := e.SparseMatMul(a, in[0])
+		return e.Mul(out, w)
+	}
+	b := graph.NewNode(tensor.Randn([]int{4, 2}, 902), nil, nil)
+	if !CheckGradientEngine(build, []*graph.Node{b}, 1e-6, 1e-4) {
+		t.Error("SparseMatMul gradient check failed")
+	}
+
+	e := NewEngine()
+	if e.SparseMatMul(b, b) != nil {
+		t.Fatal("expected nil for dense left operand")
+	}
+}
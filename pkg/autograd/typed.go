@@ -0,0 +1,264 @@
+package autograd
+
+import (
+	"math"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+// TypedEngine — движок autograd над graph.NodeOf[T]. С T = float32 прямой и
+// обратный проход целиком идут во float32: значения, градиенты и
+// промежуточные тензоры имеют тип T (см. layers.DenseOf, optimizers.SGDOf).
+// Набор операций меньше, чем у Engine: линейные слои, поэлементные
+// арифметика и активации, редукции и функции потерь.
+type TypedEngine[T tensor.Float] struct {
+	Nodes []*graph.NodeOf[T]
+}
+
+func NewTypedEngine[T tensor.Float]() *TypedEngine[T] {
+	return &TypedEngine[T]{Nodes: make([]*graph.NodeOf[T], 0)}
+}
+
+// RequireGrad оборачивает тензор в листовой узел, включаемый в граф.
+func (e *TypedEngine[T]) RequireGrad(t *tensor.TensorOf[T]) *graph.NodeOf[T] {
+	n := graph.NewNodeOf[T](t, nil, nil)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// ZeroGrad обнуляет градиенты всех узлов движка.
+func (e *TypedEngine[T]) ZeroGrad() {
+	for _, n := range e.Nodes {
+		n.ZeroGrad()
+	}
+}
+
+// Backward выполняет обратное распространение от finalNode: градиент
+// конечного узла инициализируется единицами.
+func (e *TypedEngine[T]) Backward(finalNode *graph.NodeOf[T]) {
+	finalNode.Grad = tensor.OnesOf[T](finalNode.Value.Shape...)
+
+	visited := make(map[*graph.NodeOf[T]]bool)
+	var order []*graph.NodeOf[T]
+	var dfs func(*graph.NodeOf[T])
+	dfs = func(n *graph.NodeOf[T]) {
+		if visited[n] {
+			return
+		}
+		visited[n] = true
+		for _, p := range n.Parents {
+			dfs(p)
+		}
+		order = append(order, n)
+	}
+	dfs(finalNode)
+
+	for i := len(order) - 1; i >= 0; i-- {
+		if n := order[i]; n.Operation != nil && n.Grad != nil {
+			n.Operation.Backward(n.Grad)
+		}
+	}
+}
+
+func (e *TypedEngine[T]) node(value *tensor.TensorOf[T], parents []*graph.NodeOf[T], op graph.OperationOf[T]) *graph.NodeOf[T] {
+	n := graph.NewNodeOf(value, parents, op)
+	e.Nodes = append(e.Nodes, n)
+	return n
+}
+
+// mustOf возвращает результат тензорной операции или паникует с её ошибкой,
+// как Dense при несовместимых формах.
+func mustOf[T tensor.Float](t *tensor.TensorOf[T], err error) *tensor.TensorOf[T] {
+	if err != nil {
+		panic("autograd: " + err.Error())
+	}
+	return t
+}
+
+// accumulateOf прибавляет g к градиенту p, сводя broadcasting к форме p.
+func accumulateOf[T tensor.Float](p *graph.NodeOf[T], g *tensor.TensorOf[T]) {
+	p.AccumulateGrad(mustOf(tensor.SumToShapeOf(g, p.Value.Shape)))
+}
+
+// binaryOfOp — поэлементная бинарная операция с broadcasting; local
+// возвращает производные по a и по b в точке (x, y).
+type binaryOfOp[T tensor.Float] struct {
+	a, b  *graph.NodeOf[T]
+	local func(x, y T) (T, T)
+}
+
+func (op *binaryOfOp[T]) Backward(grad *tensor.TensorOf[T]) {
+	a := mustOf(tensor.BroadcastToOf(op.a.Value, grad.Shape))
+	b := mustOf(tensor.BroadcastToOf(op.b.Value, grad.Shape))
+	ga := tensor.ZerosOf[T](grad.Shape...)
+	gb := tensor.ZerosOf[T](grad.Shape...)
+	for i, g := range grad.Data {
+		da, db := op.local(a.Data[i], b.Data[i])
+		ga.Data[i] = g * da
+		gb.Data[i] = g * db
+	}
+	accumulateOf(op.a, ga)
+	accumulateOf(op.b, gb)
+}
+
+func (e *TypedEngine[T]) binary(a, b *graph.NodeOf[T], val *tensor.TensorOf[T], local func(x, y T) (T, T)) *graph.NodeOf[T] {
+	return e.node(val, []*graph.NodeOf[T]{a, b}, &binaryOfOp[T]{a: a, b: b, local: local})
+}
+
+// Add вычисляет a + b с broadcasting.
+func (e *TypedEngine[T]) Add(a, b *graph.NodeOf[T]) *graph.NodeOf[T] {
+	return e.binary(a, b, mustOf(tensor.AddOf(a.Value, b.Value)), func(_, _ T) (T, T) { return 1, 1 })
+}
+
+// Sub вычисляет a - b с broadcasting.
+func (e *TypedEngine[T]) Sub(a, b *graph.NodeOf[T]) *graph.NodeOf[T] {
+	return e.binary(a, b, mustOf(tensor.SubOf(a.Value, b.Value)), func(_, _ T) (T, T) { return 1, -1 })
+}
+
+// Mul вычисляет a * b (поэлементно) с broadcasting.
+func (e *TypedEngine[T]) Mul(a, b *graph.NodeOf[T]) *graph.NodeOf[T] {
+	return e.binary(a, b, mustOf(tensor.MulOf(a.Value, b.Value)), func(x, y T) (T, T) { return y, x })
+}
+
+type matMulOfOp[T tensor.Float] struct {
+	a, b *graph.NodeOf[T]
+}
+
+// dA = grad · Bᵀ, dB = Aᵀ · grad
+func (op *matMulOfOp[T]) Backward(grad *tensor.TensorOf[T]) {
+	bT := mustOf(tensor.TransposeOf(op.b.Value))
+	aT := mustOf(tensor.TransposeOf(op.a.Value))
+	op.a.AccumulateGrad(mustOf(tensor.MatMulOf(grad, bT)))
+	op.b.AccumulateGrad(mustOf(tensor.MatMulOf(aT, grad)))
+}
+
+// MatMul вычисляет произведение матриц a[m,n] · b[n,p].
+func (e *TypedEngine[T]) MatMul(a, b *graph.NodeOf[T]) *graph.NodeOf[T] {
+	val := mustOf(tensor.MatMulOf(a.Value, b.Value))
+	return e.node(val, []*graph.NodeOf[T]{a, b}, &matMulOfOp[T]{a: a, b: b})
+}
+
+// unaryOfOp — поэлементная функция; deriv получает вход x и выход y.
+type unaryOfOp[T tensor.Float] struct {
+	x     *graph.NodeOf[T]
+	out   *tensor.TensorOf[T]
+	deriv func(x, y T) T
+}
+
+func (op *unaryOfOp[T]) Backward(grad *tensor.TensorOf[T]) {
+	g := tensor.ZerosOf[T](grad.Shape...)
+	for i, v := range grad.Data {
+		g.Data[i] = v * op.deriv(op.x.Value.Data[i], op.out.Data[i])
+	}
+	op.x.AccumulateGrad(g)
+}
+
+func (e *TypedEngine[T]) unary(x *graph.NodeOf[T], f func(T) T, deriv func(x, y T) T) *graph.NodeOf[T] {
+	out := tensor.ApplyOf(x.Value, f)
+	return e.node(out, []*graph.NodeOf[T]{x}, &unaryOfOp[T]{x: x, out: out, deriv: deriv})
+}
+
+func (e *TypedEngine[T]) ReLU(x *graph.NodeOf[T]) *graph.NodeOf[T] {
+	return e.unary(x,
+		func(v T) T { return max(v, 0) },
+		func(v, _ T) T {
+			if v > 0 {
+				return 1
+			}
+			return 0
+		})
+}
+
+func (e *TypedEngine[T]) Sigmoid(x *graph.NodeOf[T]) *graph.NodeOf[T] {
+	return e.unary(x,
+		func(v T) T { return T(1 / (1 + math.Exp(-float64(v)))) },
+		func(_, y T) T { return y * (1 - y) })
+}
+
+func (e *TypedEngine[T]) Tanh(x *graph.NodeOf[T]) *graph.NodeOf[T] {
+	return e.unary(x,
+		func(v T) T { return T(math.Tanh(float64(v))) },
+		func(_, y T) T { return 1 - y*y })
+}
+
+// scaleOfOp — сумма всех элементов x, умноженная на scale.
+type scaleOfOp[T tensor.Float] struct {
+	x     *graph.NodeOf[T]
+	scale T
+}
+
+func (op *scaleOfOp[T]) Backward(grad *tensor.TensorOf[T]) {
+	g := tensor.ZerosOf[T](op.x.Value.Shape...)
+	tensor.FillInPlaceOf(g, grad.Data[0]*op.scale)
+	op.x.AccumulateGrad(g)
+}
+
+// Sum возвращает сумму всех элементов x (тензор формы [1]).
+func (e *TypedEngine[T]) Sum(x *graph.NodeOf[T]) *graph.NodeOf[T] {
+	return e.node(tensor.SumOf(x.Value), []*graph.NodeOf[T]{x}, &scaleOfOp[T]{x: x, scale: 1})
+}
+
+// Mean возвращает среднее всех элементов x (тензор формы [1]).
+func (e *TypedEngine[T]) Mean(x *graph.NodeOf[T]) *graph.NodeOf[T] {
+	scale := 1 / T(len(x.Value.Data))
+	out := tensor.SumOf(x.Value)
+	out.Data[0] *= scale
+	return e.node(out, []*graph.NodeOf[T]{x}, &scaleOfOp[T]{x: x, scale: scale})
+}
+
+// MSELoss возвращает mean((pred - target)²), как Engine.MSELoss.
+func (e *TypedEngine[T]) MSELoss(pred *graph.NodeOf[T], target *tensor.TensorOf[T]) *graph.NodeOf[T] {
+	diff := e.Sub(pred, &graph.NodeOf[T]{Value: target})
+	return e.Mean(e.Mul(diff, diff))
+}
+
+type crossEntropyOfOp[T tensor.Float] struct {
+	logits  *graph.NodeOf[T]
+	target  *tensor.TensorOf[T]
+	softmax *tensor.TensorOf[T]
+}
+
+// dL/dlogits = (softmax - target) / batch
+func (op *crossEntropyOfOp[T]) Backward(grad *tensor.TensorOf[T]) {
+	scale := grad.Data[0] / T(op.logits.Value.Shape[0])
+	g := tensor.ZerosOf[T](op.logits.Value.Shape...)
+	for i := range g.Data {
+		g.Data[i] = (op.softmax.Data[i] - op.target.Data[i]) * scale
+	}
+	op.logits.AccumulateGrad(g)
+}
+
+// CrossEntropyLoss возвращает среднюю по батчу cross-entropy softmax(logits)
+// и target (one-hot или вероятности) формы [batch, classes], как
+// Engine.CrossEntropyLoss. Экспоненты и логарифм считаются во float64.
+func (e *TypedEngine[T]) CrossEntropyLoss(logits *graph.NodeOf[T], target *tensor.TensorOf[T]) *graph.NodeOf[T] {
+	if len(logits.Value.Shape) != 2 {
+		panic("autograd: CrossEntropyLoss ожидает logits формы [batch, classes]")
+	}
+	rows, cols := logits.Value.Shape[0], logits.Value.Shape[1]
+	softmax := tensor.ZerosOf[T](rows, cols)
+	loss := 0.0
+	for i := 0; i < rows; i++ {
+		row := logits.Value.Data[i*cols : (i+1)*cols]
+		maxVal := float64(row[0])
+		for _, v := range row {
+			maxVal = max(maxVal, float64(v))
+		}
+		sum := 0.0
+		for _, v := range row {
+			sum += math.Exp(float64(v) - maxVal)
+		}
+		for j, v := range row {
+			p := math.Exp(float64(v)-maxVal) / sum
+			softmax.Data[i*cols+j] = T(p)
+			if t := float64(target.Data[i*cols+j]); t > 0 {
+				loss -= t * math.Log(math.Max(p, 1e-15))
+			}
+		}
+	}
+	out := tensor.ZerosOf[T](1)
+	out.Data[0] = T(loss / float64(rows))
+	op := &crossEntropyOfOp[T]{logits: logits, target: target, softmax: softmax}
+	return e.node(out, []*graph.NodeOf[T]{logits}, op)
+}
@@ -0,0 +1,68 @@
+package autograd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+// TestTypedEngineGradients сверяет градиенты TypedEngine с численными для
+// композиции всех его операций (в том числе broadcasting в Add и Mul).
+func TestTypedEngineGradients(t *testing.T) {
+	x := &tensor.TensorOf[float64]{Data: []float64{0.5, -1, 2, 0.3, 1.5, -0.7}, Shape: []int{2, 3}, Strides: []int{3, 1}}
+	w := &tensor.TensorOf[float64]{Data: []float64{0.2, -0.4, 0.1, 0.3, -0.5, 0.6}, Shape: []int{3, 2}, Strides: []int{2, 1}}
+	b := &tensor.TensorOf[float64]{Data: []float64{0.1, -0.2}, Shape: []int{2}, Strides: []int{1}}
+	target := &tensor.TensorOf[float64]{Data: []float64{1, 0, 0, 1}, Shape: []int{2, 2}, Strides: []int{2, 1}}
+
+	f := func(e *TypedEngine[float64], x, w, b *graph.NodeOf[float64]) *graph.NodeOf[float64] {
+		h := e.Add(e.MatMul(x, w), b)
+		g := e.Mul(e.Tanh(h), e.Sigmoid(e.ReLU(h)))
+		loss := e.Add(e.CrossEntropyLoss(g, target), e.MSELoss(e.Sub(g, b), target))
+		return e.Add(loss, e.Sum(e.Mul(b, b)))
+	}
+
+	e := NewTypedEngine[float64]()
+	leaves := []*graph.NodeOf[float64]{e.RequireGrad(x), e.RequireGrad(w), e.RequireGrad(b)}
+	e.Backward(f(e, leaves[0], leaves[1], leaves[2]))
+
+	eval := func() float64 {
+		e := NewTypedEngine[float64]()
+		return f(e, e.RequireGrad(x), e.RequireGrad(w), e.RequireGrad(b)).Value.Data[0]
+	}
+	const h = 1e-6
+	for li, leaf := range leaves {
+		for i := range leaf.Value.Data {
+			orig := leaf.Value.Data[i]
+			leaf.Value.Data[i] = orig + h
+			plus := eval()
+			leaf.Value.Data[i] = orig - h
+			minus := eval()
+			leaf.Value.Data[i] = orig
+			num := (plus - minus) / (2 * h)
+			if got := leaf.Grad.Data[i]; math.Abs(got-num) > 1e-5 {
+				t.Fatalf("вход %d[%d]: градиент %v, численный %v", li, i, got, num)
+			}
+		}
+	}
+
+	e.ZeroGrad()
+	for _, leaf := range leaves {
+		for _, v := range leaf.Grad.Data {
+			if v != 0 {
+				t.Fatal("ZeroGrad должен обнулять градиенты узлов движка")
+			}
+		}
+	}
+}
+
+func TestTypedEngineNoGradScope(t *testing.T) {
+	e := NewTypedEngine[float32]()
+	s := graph.NewScope(graph.ModeNoGrad)
+	x := &graph.NodeOf[float32]{Value: tensor.OnesOf[float32](2, 2), Scope: s}
+	out := e.ReLU(e.MatMul(x, e.RequireGrad(tensor.OnesOf[float32](2, 2))))
+	if out.Operation != nil || out.Parents != nil || out.Scope != s {
+		t.Fatal("узлы из входа области NoGrad не должны строить граф")
+	}
+}
@@ -1,8 +1,6 @@
 package dataloader
 
 import (
-	"math/rand"
-
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
 )
 
@@ -20,7 +18,7 @@ type DataLoader struct {
 	batchSize  int      // Размер мини-батча
 	shuffle    bool     // Перемешивать ли данные перед каждой эпохой
 	dropLast   bool     // Отбрасывать ли последний неполный батч
-	rng        *rand.Rand // Генератор случайных чисел для shuffle
+	rng        *tensor.Generator // Генератор случайных чисел для shuffle
 
 	// Внутреннее состояние итератора
 	indices    []int    // Порядок индексов для текущей эпохи
@@ -33,6 +31,7 @@ type DataLoaderConfig struct {
 	Shuffle   bool      // Перемешивать данные (по умолчанию false)
 	DropLast  bool      // Отбрасывать последний неполный батч (по умолчанию false)
 	Seed      int64     // Seed для генератора случайных чисел (по умолчанию 0)
+	Generator *tensor.Generator // Генератор для shuffle; если задан, Seed игнорируется
 }
 
 // NewDataLoader создает новый DataLoader с заданной конфигурацией.
@@ -58,7 +57,10 @@ func NewDataLoader(dataset Dataset, config DataLoaderConfig) *DataLoader {
 	}
 
 	// Создаем генератор случайных чисел
-	rng := rand.New(rand.NewSource(config.Seed))
+	rng := config.Generator
+	if rng == nil {
+		rng = tensor.NewGenerator(config.Seed)
+	}
 
 	// Инициализируем порядок индексов
 	indices := make([]int, dataset.Len())
@@ -94,6 +96,12 @@ func (dl *DataLoader) shuffleIndices() {
 	}
 }
 
+// SetGenerator заменяет генератор перемешивания; новый порядок
+// применяется со следующего Reset.
+func (dl *DataLoader) SetGenerator(gen *tensor.Generator) {
+	dl.rng = gen
+}
+
 // Reset сбрасывает итератор в начало и перемешивает данные (если shuffle=true).
 // Используется для начала новой эпохи обучения.
 func (dl *DataLoader) Reset() {
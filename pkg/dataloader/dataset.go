@@ -1,6 +1,10 @@
 package dataloader
 
-import "github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+import (
+	"fmt"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+)
 
 // Dataset представляет абстрактную коллекцию данных.
 // Предоставляет интерфейс для доступа к отдельным примерам по индексу.
@@ -44,6 +48,32 @@ func NewSimpleDataset(features, targets *tensor.Tensor) *SimpleDataset {
 	}
 }
 
+// NewSimpleDatasetFromNPZ создаёт SimpleDataset из архива NumPy .npz
+// (np.savez(path, x=features, y=targets)): featuresKey и targetsKey — имена
+// массивов в архиве. Массивы в порядке Fortran приводятся к порядку C.
+//
+// Пример:
+//
+//	dataset, err := NewSimpleDatasetFromNPZ("train.npz", "x", "y")
+func NewSimpleDatasetFromNPZ(path, featuresKey, targetsKey string) (*SimpleDataset, error) {
+	arrays, err := tensor.ReadNPZ(path)
+	if err != nil {
+		return nil, err
+	}
+	features, ok := arrays[featuresKey]
+	if !ok {
+		return nil, fmt.Errorf("массив %q не найден в %s", featuresKey, path)
+	}
+	targets, ok := arrays[targetsKey]
+	if !ok {
+		return nil, fmt.Errorf("массив %q не найден в %s", targetsKey, path)
+	}
+	if features.Shape[0] != targets.Shape[0] {
+		return nil, fmt.Errorf("число примеров features и targets не совпадает: %d != %d", features.Shape[0], targets.Shape[0])
+	}
+	return NewSimpleDataset(features.Contiguous(), targets.Contiguous()), nil
+}
+
 // Get возвращает пример данных по индексу.
 // Для SimpleDataset возвращает slice из оригинальных тензоров.
 func (ds *SimpleDataset) Get(index int) (*tensor.Tensor, *tensor.Tensor) {
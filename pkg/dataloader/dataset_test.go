@@ -1,6 +1,7 @@
 package dataloader
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
@@ -214,3 +215,33 @@ func BenchmarkSimpleDatasetGet(b *testing.B) {
 		dataset.Get(i % 1000)
 	}
 }
+
+// TestSimpleDatasetFromNPZ проверяет создание датасета из архива .npz,
+// в том числе с признаками в порядке Fortran.
+func TestSimpleDatasetFromNPZ(t *testing.T) {
+	// features [[1, 2], [3, 4], [5, 6]] хранятся по столбцам
+	features := &tensor.Tensor{Data: []float64{1, 3, 5, 2, 4, 6}, Shape: []int{3, 2}, Strides: []int{1, 3}}
+	targets := &tensor.Tensor{Data: []float64{0, 1, 2}, Shape: []int{3}, Strides: []int{1}}
+	path := filepath.Join(t.TempDir(), "train.npz")
+	err := tensor.WriteNPZ(path, map[string]*tensor.Tensor{"x": features, "y": targets},
+		tensor.WithNPYDType(tensor.Int32), tensor.WithNPYFortranOrder())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dataset, err := NewSimpleDatasetFromNPZ(path, "x", "y")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dataset.Len() != 3 {
+		t.Fatalf("Expected 3 samples, got %d", dataset.Len())
+	}
+	x, y := dataset.Get(1)
+	if x.Data[0] != 3 || x.Data[1] != 4 || y.Data[0] != 1 {
+		t.Fatalf("Unexpected sample 1: features %v, target %v", x.Data, y.Data)
+	}
+
+	if _, err := NewSimpleDatasetFromNPZ(path, "x", "labels"); err == nil {
+		t.Error("Expected error for missing array")
+	}
+}
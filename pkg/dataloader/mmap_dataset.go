@@ -0,0 +1,67 @@
+package dataloader
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+)
+
+// MmapDataset — Dataset поверх отображённых в память файлов (tensor.MmapTensor).
+// В отличие от SimpleDataset, признаки не загружаются в кучу: Get копирует
+// только запрошенный пример, поэтому датасет может быть больше оперативной памяти.
+//
+// Пример:
+//
+//	features, _ := tensor.OpenMmap("features.f64", tensor.Float64, []int{n, 784})
+//	targets, _ := tensor.OpenMmapNPY("labels.npy")
+//	dataset, err := NewMmapDataset(features, targets)
+//	defer dataset.Close()
+type MmapDataset struct {
+	features   *tensor.MmapTensor
+	targets    *tensor.MmapTensor
+	numSamples int
+}
+
+// NewMmapDataset создаёт датасет из отображённых признаков и целевых значений
+// с одинаковым числом примеров (первая размерность).
+func NewMmapDataset(features, targets *tensor.MmapTensor) (*MmapDataset, error) {
+	fs, ts := features.Shape(), targets.Shape()
+	if fs[0] != ts[0] {
+		return nil, fmt.Errorf("число примеров features и targets не совпадает: %d != %d", fs[0], ts[0])
+	}
+	return &MmapDataset{features: features, targets: targets, numSamples: fs[0]}, nil
+}
+
+// Get возвращает копию примера с индексом index в float64.
+// Формы совпадают с SimpleDataset.Get: [shape[1:]...], для 1D — [1].
+func (ds *MmapDataset) Get(index int) (*tensor.Tensor, *tensor.Tensor) {
+	if index < 0 || index >= ds.numSamples {
+		panic("index out of bounds")
+	}
+	return mmapSample(ds.features, index), mmapSample(ds.targets, index)
+}
+
+// Len возвращает общее количество примеров в датасете.
+func (ds *MmapDataset) Len() int {
+	return ds.numSamples
+}
+
+// Close освобождает отображения признаков и целевых значений.
+func (ds *MmapDataset) Close() error {
+	return errors.Join(ds.features.Close(), ds.targets.Close())
+}
+
+// mmapSample копирует строку index и убирает ведущую ось.
+func mmapSample(m *tensor.MmapTensor, index int) *tensor.Tensor {
+	row, err := m.Rows(index, index+1)
+	if err != nil {
+		panic(err)
+	}
+	if len(row.Shape) == 1 {
+		return row // [1]
+	}
+	row.Shape = row.Shape[1:]
+	row.Strides = row.Strides[1:]
+	return row
+}
@@ -0,0 +1,91 @@
+package dataloader
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+)
+
+// TestMmapDatasetMatchesSimpleDataset проверяет, что батчи MmapDataset
+// совпадают с батчами SimpleDataset на тех же данных.
+func TestMmapDatasetMatchesSimpleDataset(t *testing.T) {
+	dir := t.TempDir()
+	features := tensor.Randn([]int{10, 4}, 42)
+	targets := &tensor.Tensor{Data: []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, Shape: []int{10}, Strides: []int{1}}
+
+	// Признаки — «сырой» файл float64, метки — .npy с uint8.
+	var raw bytes.Buffer
+	binary.Write(&raw, binary.LittleEndian, features.Data)
+	featuresPath := filepath.Join(dir, "features.f64")
+	if err := os.WriteFile(featuresPath, raw.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var npy bytes.Buffer
+	if err := tensor.WriteNPY(&npy, targets, tensor.WithNPYDType(tensor.Uint8)); err != nil {
+		t.Fatal(err)
+	}
+	targetsPath := filepath.Join(dir, "labels.npy")
+	if err := os.WriteFile(targetsPath, npy.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	mf, err := tensor.OpenMmap(featuresPath, tensor.Float64, []int{10, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mt, err := tensor.OpenMmapNPY(targetsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dataset, err := NewMmapDataset(mf, mt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dataset.Close()
+
+	if dataset.Len() != 10 {
+		t.Fatalf("Expected 10 samples, got %d", dataset.Len())
+	}
+
+	config := DataLoaderConfig{BatchSize: 4, Shuffle: true, Seed: 7}
+	want := NewDataLoader(NewSimpleDataset(features, targets), config)
+	got := NewDataLoader(dataset, config)
+	for want.HasNext() {
+		wb, gb := want.Next(), got.Next()
+		for i, v := range wb.Features.Data {
+			if gb.Features.Data[i] != v {
+				t.Fatalf("Features mismatch at %d: %v != %v", i, gb.Features.Data[i], v)
+			}
+		}
+		for i, v := range wb.Targets.Data {
+			if gb.Targets.Data[i] != v {
+				t.Fatalf("Targets mismatch at %d: %v != %v", i, gb.Targets.Data[i], v)
+			}
+		}
+	}
+}
+
+// TestMmapDatasetMismatchedSamples проверяет ошибку при разном числе примеров.
+func TestMmapDatasetMismatchedSamples(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "x.u8")
+	if err := os.WriteFile(path, make([]byte, 6), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	a, err := tensor.OpenMmap(path, tensor.Uint8, []int{6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Close()
+	b, err := tensor.OpenMmap(path, tensor.Uint8, []int{3, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+	if _, err := NewMmapDataset(a, b); err == nil {
+		t.Error("Expected error for mismatched samples")
+	}
+}
@@ -0,0 +1,289 @@
+// Package fft реализует быстрое преобразование Фурье на чистом Go поверх tensor.Tensor:
+// FFT/IFFT, RFFT/IRFFT, двумерные FFT2/IFFT2, STFT/ISTFT с оконными функциями
+// и мел-фильтрбанк для спектральных признаков аудио и сигналов датчиков.
+//
+// Комплексные тензоры хранятся как обычные float64-тензоры с последней осью
+// размера 2 (действительная и мнимая части), как torch.view_as_real:
+// сигнал из n комплексных отсчётов имеет форму [..., n, 2].
+// Преобразования выполняются вдоль последней (комплексной) оси независимо
+// для всех ведущих осей. Длина может быть любой: степени двойки считаются
+// алгоритмом Кули–Тьюки, остальные — алгоритмом Блюстейна за O(n log n).
+//
+// Нормировка как в NumPy: прямое преобразование без множителя, обратное — с 1/n.
+// Дифференцируемые версии — методы autograd.Engine (FFT, RFFT, STFT и др.).
+package fft
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"math/cmplx"
+	"sync"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+)
+
+// plan хранит предвычисленные данные преобразования длины n.
+type plan struct {
+	n       int
+	twiddle []complex128 // exp(-2πik/n), k < n/2 (только для степеней двойки)
+	// Алгоритм Блюстейна для длин, не являющихся степенью двойки.
+	chirp []complex128 // exp(-iπk²/n)
+	conv  *plan        // план длины m >= 2n-1 (степень двойки)
+	kern  []complex128 // FFT длины m от сопряжённого chirp
+}
+
+var plans sync.Map // int -> *plan
+
+func planFor(n int) *plan {
+	if p, ok := plans.Load(n); ok {
+		return p.(*plan)
+	}
+	p := &plan{n: n}
+	if n&(n-1) == 0 {
+		p.twiddle = make([]complex128, n/2)
+		for k := range p.twiddle {
+			s, c := math.Sincos(-2 * math.Pi * float64(k) / float64(n))
+			p.twiddle[k] = complex(c, s)
+		}
+	} else {
+		m := 1 << bits.Len(uint(2*n-2))
+		p.conv = planFor(m)
+		p.chirp = make([]complex128, n)
+		p.kern = make([]complex128, m)
+		for k := 0; k < n; k++ {
+			// k² берётся по модулю 2n, чтобы не терять точность на больших k.
+			kk := (k * k) % (2 * n)
+			s, c := math.Sincos(-math.Pi * float64(kk) / float64(n))
+			p.chirp[k] = complex(c, s)
+			p.kern[k] = cmplx.Conj(p.chirp[k])
+			if k > 0 {
+				p.kern[m-k] = p.kern[k]
+			}
+		}
+		p.conv.forward(p.kern)
+	}
+	actual, _ := plans.LoadOrStore(n, p)
+	return actual.(*plan)
+}
+
+// forward выполняет на месте прямое ДПФ x (len(x) == p.n) без нормировки.
+func (p *plan) forward(x []complex128) {
+	if p.n <= 1 {
+		return
+	}
+	if p.conv != nil {
+		p.bluestein(x)
+		return
+	}
+	// Итеративный Кули–Тьюки: перестановка с обращением битов, затем бабочки.
+	n := p.n
+	shift := bits.UintSize - bits.Len(uint(n-1))
+	for i := 0; i < n; i++ {
+		j := int(bits.Reverse(uint(i)) >> shift)
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	for size := 2; size <= n; size <<= 1 {
+		half, step := size/2, n/size
+		for start := 0; start < n; start += size {
+			for k := 0; k < half; k++ {
+				t := p.twiddle[k*step] * x[start+k+half]
+				x[start+k+half] = x[start+k] - t
+				x[start+k] += t
+			}
+		}
+	}
+}
+
+// bluestein сводит ДПФ длины n к циклической свёртке длины m (степень двойки).
+func (p *plan) bluestein(x []complex128) {
+	m := p.conv.n
+	a := make([]complex128, m)
+	for k, v := range x {
+		a[k] = v * p.chirp[k]
+	}
+	p.conv.forward(a)
+	for i := range a {
+		a[i] *= p.kern[i]
+	}
+	p.conv.inverse(a)
+	scale := complex(1/float64(m), 0)
+	for k := range x {
+		x[k] = a[k] * p.chirp[k] * scale
+	}
+}
+
+// inverse выполняет на месте обратное ДПФ без множителя 1/n: conj(F(conj(x))).
+func (p *plan) inverse(x []complex128) {
+	for i := range x {
+		x[i] = cmplx.Conj(x[i])
+	}
+	p.forward(x)
+	for i := range x {
+		x[i] = cmplx.Conj(x[i])
+	}
+}
+
+// complexShape проверяет, что x — комплексный тензор [..., 2] хотя бы с одной
+// комплексной осью, и возвращает форму без последней оси.
+func complexShape(x *tensor.Tensor, minAxes int) ([]int, error) {
+	if len(x.Shape) < minAxes+1 || x.Shape[len(x.Shape)-1] != 2 {
+		return nil, fmt.Errorf("fft: ожидается комплексный тензор формы [..., 2] с %d комплексными осями, получена форма %v", minAxes, x.Shape)
+	}
+	return x.Shape[:len(x.Shape)-1], nil
+}
+
+// toComplex копирует комплексный тензор [..., 2] в срез complex128.
+func toComplex(x *tensor.Tensor) []complex128 {
+	c := x.Contiguous()
+	out := make([]complex128, len(c.Data)/2)
+	for i := range out {
+		out[i] = complex(c.Data[2*i], c.Data[2*i+1])
+	}
+	return out
+}
+
+// fromComplex оборачивает комплексные данные в тензор формы [shape..., 2].
+func fromComplex(data []complex128, shape []int) *tensor.Tensor {
+	out := tensor.Zeros(append(append([]int{}, shape...), 2)...)
+	for i, v := range data {
+		out.Data[2*i], out.Data[2*i+1] = real(v), imag(v)
+	}
+	return out
+}
+
+// transformAxis выполняет ДПФ вдоль оси axis комплексного массива формы shape.
+func transformAxis(data []complex128, shape []int, axis int, inverse bool) {
+	n := shape[axis]
+	if n == 0 || len(data) == 0 {
+		return
+	}
+	inner := 1
+	for _, d := range shape[axis+1:] {
+		inner *= d
+	}
+	p := planFor(n)
+	lane := make([]complex128, n)
+	for base := 0; base < len(data); base += n * inner {
+		for i := 0; i < inner; i++ {
+			for k := range lane {
+				lane[k] = data[base+i+k*inner]
+			}
+			if inverse {
+				p.inverse(lane)
+				scale := complex(1/float64(n), 0)
+				for k := range lane {
+					lane[k] *= scale
+				}
+			} else {
+				p.forward(lane)
+			}
+			for k, v := range lane {
+				data[base+i+k*inner] = v
+			}
+		}
+	}
+}
+
+func transformAxes(x *tensor.Tensor, naxes int, inverse bool) (*tensor.Tensor, error) {
+	shape, err := complexShape(x, naxes)
+	if err != nil {
+		return nil, err
+	}
+	data := toComplex(x)
+	for d := len(shape) - naxes; d < len(shape); d++ {
+		transformAxis(data, shape, d, inverse)
+	}
+	return fromComplex(data, shape), nil
+}
+
+// FFT вычисляет дискретное преобразование Фурье комплексного сигнала x [..., n, 2]
+// вдоль оси n: X_k = Σ x_j·exp(-2πijk/n).
+func FFT(x *tensor.Tensor) (*tensor.Tensor, error) {
+	return transformAxes(x, 1, false)
+}
+
+// IFFT вычисляет обратное преобразование с нормировкой 1/n: IFFT(FFT(x)) = x.
+func IFFT(x *tensor.Tensor) (*tensor.Tensor, error) {
+	return transformAxes(x, 1, true)
+}
+
+// FFT2 вычисляет двумерное преобразование Фурье по двум последним комплексным осям
+// x [..., h, w, 2] (например, для изображений).
+func FFT2(x *tensor.Tensor) (*tensor.Tensor, error) {
+	return transformAxes(x, 2, false)
+}
+
+// IFFT2 — обратное к FFT2 с нормировкой 1/(h·w).
+func IFFT2(x *tensor.Tensor) (*tensor.Tensor, error) {
+	return transformAxes(x, 2, true)
+}
+
+// RFFT вычисляет преобразование Фурье действительного сигнала x [..., n].
+// В силу эрмитовой симметрии возвращаются только n/2+1 неотрицательных частот:
+// результат имеет форму [..., n/2+1, 2].
+func RFFT(x *tensor.Tensor) (*tensor.Tensor, error) {
+	if len(x.Shape) == 0 {
+		return nil, fmt.Errorf("fft: rfft требует хотя бы одну ось")
+	}
+	c := x.Contiguous()
+	n := x.Shape[len(x.Shape)-1]
+	bins := n/2 + 1
+	shape := append(append([]int{}, x.Shape[:len(x.Shape)-1]...), bins)
+	if n == 0 {
+		return nil, fmt.Errorf("fft: rfft пустого сигнала")
+	}
+	p := planFor(n)
+	lane := make([]complex128, n)
+	out := make([]complex128, 0, len(c.Data)/n*bins)
+	for base := 0; base < len(c.Data); base += n {
+		for k := range lane {
+			lane[k] = complex(c.Data[base+k], 0)
+		}
+		p.forward(lane)
+		out = append(out, lane[:bins]...)
+	}
+	return fromComplex(out, shape), nil
+}
+
+// IRFFT — обратное к RFFT: по спектру x [..., m, 2] восстанавливает действительный
+// сигнал длины n (n <= 0 означает 2(m-1)). Недостающие частоты считаются нулевыми,
+// лишние отбрасываются; мнимые части нулевой и (для чётного n) найквистовой частот
+// игнорируются, как в numpy.fft.irfft.
+func IRFFT(x *tensor.Tensor, n int) (*tensor.Tensor, error) {
+	shape, err := complexShape(x, 1)
+	if err != nil {
+		return nil, err
+	}
+	m := shape[len(shape)-1]
+	if n <= 0 {
+		n = 2 * (m - 1)
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("fft: некорректная длина сигнала %d для irfft", n)
+	}
+	data := toComplex(x)
+	p := planFor(n)
+	lane := make([]complex128, n)
+	outShape := append(append([]int{}, shape[:len(shape)-1]...), n)
+	out := tensor.Zeros(outShape...)
+	used := min(m, n/2+1)
+	for b := 0; b*m < len(data); b++ {
+		clear(lane)
+		copy(lane, data[b*m:b*m+used])
+		lane[0] = complex(real(lane[0]), 0)
+		if n%2 == 0 {
+			lane[n/2] = complex(real(lane[n/2]), 0)
+		}
+		for k := 1; k < (n+1)/2; k++ {
+			lane[n-k] = cmplx.Conj(lane[k])
+		}
+		p.inverse(lane)
+		for j, v := range lane {
+			out.Data[b*n+j] = real(v) / float64(n)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,216 @@
+package fft
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+)
+
+// naiveDFT вычисляет ДПФ по определению за O(n²).
+func naiveDFT(x []complex128, inverse bool) []complex128 {
+	n := len(x)
+	sign := -1.0
+	if inverse {
+		sign = 1
+	}
+	out := make([]complex128, n)
+	for k := range out {
+		for j, v := range x {
+			out[k] += v * cmplx.Exp(complex(0, sign*2*math.Pi*float64(j*k)/float64(n)))
+		}
+		if inverse {
+			out[k] /= complex(float64(n), 0)
+		}
+	}
+	return out
+}
+
+func assertClose(t *testing.T, name string, got, want []float64, tol float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: длина %d, ожидалась %d", name, len(got), len(want))
+	}
+	for i := range got {
+		if math.Abs(got[i]-want[i]) > tol {
+			t.Fatalf("%s: [%d] = %v, ожидалось %v", name, i, got[i], want[i])
+		}
+	}
+}
+
+func TestFFTMatchesNaiveDFT(t *testing.T) {
+	for _, n := range []int{1, 2, 7, 8, 12, 17} {
+		x := tensor.Randn([]int{3, n, 2}, int64(n))
+		got, err := FFT(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var want []complex128
+		src := toComplex(x)
+		for b := 0; b < 3; b++ {
+			want = append(want, naiveDFT(src[b*n:(b+1)*n], false)...)
+		}
+		assertClose(t, "FFT", got.Data, fromComplex(want, []int{3, n}).Data, 1e-9)
+
+		back, err := IFFT(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertClose(t, "IFFT(FFT)", back.Data, x.Data, 1e-9)
+	}
+	if _, err := FFT(tensor.Zeros(4, 3)); err == nil {
+		t.Fatal("ожидалась ошибка: последняя ось не равна 2")
+	}
+}
+
+func TestRFFTAndIRFFT(t *testing.T) {
+	for _, n := range []int{6, 9, 16} {
+		x := tensor.Randn([]int{2, n}, 11)
+		spec, err := RFFT(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if spec.Shape[1] != n/2+1 || spec.Shape[2] != 2 {
+			t.Fatalf("RFFT: форма %v", spec.Shape)
+		}
+		for b := 0; b < 2; b++ {
+			lane := make([]complex128, n)
+			for j := range lane {
+				lane[j] = complex(x.Data[b*n+j], 0)
+			}
+			want := fromComplex(naiveDFT(lane, false)[:n/2+1], []int{n/2 + 1}).Data
+			assertClose(t, "RFFT", spec.Data[b*(n/2+1)*2:(b+1)*(n/2+1)*2], want, 1e-9)
+		}
+		back, err := IRFFT(spec, n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertClose(t, "IRFFT(RFFT)", back.Data, x.Data, 1e-9)
+	}
+}
+
+func TestFFT2MatchesRowColumnDFT(t *testing.T) {
+	x := tensor.Randn([]int{3, 5, 2}, 21)
+	got, err := FFT2(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Эталон: ДПФ строк, затем столбцов.
+	data := toComplex(x)
+	for r := 0; r < 3; r++ {
+		copy(data[r*5:], naiveDFT(data[r*5:(r+1)*5], false))
+	}
+	for c := 0; c < 5; c++ {
+		col := []complex128{data[c], data[5+c], data[10+c]}
+		for r, v := range naiveDFT(col, false) {
+			data[r*5+c] = v
+		}
+	}
+	assertClose(t, "FFT2", got.Data, fromComplex(data, []int{3, 5}).Data, 1e-9)
+
+	back, err := IFFT2(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertClose(t, "IFFT2(FFT2)", back.Data, x.Data, 1e-9)
+}
+
+func TestWindows(t *testing.T) {
+	assertClose(t, "Hann", HannWindow(4).Data, []float64{0, 0.5, 1, 0.5}, 1e-12)
+	assertClose(t, "Hamming", HammingWindow(4).Data, []float64{0.08, 0.54, 1, 0.54}, 1e-12)
+	assertClose(t, "Blackman", BlackmanWindow(4).Data, []float64{0, 0.34, 1, 0.34}, 1e-12)
+}
+
+func TestFrameAndOverlapAdd(t *testing.T) {
+	x := &tensor.Tensor{Data: []float64{1, 2, 3, 4, 5, 6, 7}, Shape: []int{7}, Strides: []int{1}}
+	frames, err := Frame(x, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if frames.Shape[0] != 3 || frames.Shape[1] != 3 {
+		t.Fatalf("Frame: форма %v", frames.Shape)
+	}
+	assertClose(t, "Frame", frames.Data, []float64{1, 2, 3, 3, 4, 5, 5, 6, 7}, 0)
+
+	sum, err := OverlapAdd(frames, 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertClose(t, "OverlapAdd", sum.Data, []float64{1, 2, 6, 4, 10, 6, 7}, 0)
+	if _, err := Frame(x, 8, 1); err == nil {
+		t.Fatal("ожидалась ошибка: кадр длиннее сигнала")
+	}
+}
+
+func TestSTFTRoundTrip(t *testing.T) {
+	x := tensor.Randn([]int{2, 100}, 31)
+	for _, cfg := range []STFTConfig{
+		{NFFT: 16, Center: true},
+		{NFFT: 15, Hop: 5, Window: HammingWindow(15), Center: true},
+	} {
+		spec, err := STFT(x, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, _ := cfg.Resolve()
+		frames := 1 + (100+2*(cfg.NFFT/2)-cfg.NFFT)/r.Hop
+		if spec.Shape[0] != 2 || spec.Shape[1] != frames || spec.Shape[2] != cfg.NFFT/2+1 || spec.Shape[3] != 2 {
+			t.Fatalf("STFT: форма %v", spec.Shape)
+		}
+		back, err := ISTFT(spec, cfg, 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertClose(t, "ISTFT(STFT)", back.Data, x.Data, 1e-9)
+	}
+	if _, err := STFT(x, STFTConfig{NFFT: 8, Window: HannWindow(4)}); err == nil {
+		t.Fatal("ожидалась ошибка длины окна")
+	}
+}
+
+func TestMelSpectrogramPeaksAtTone(t *testing.T) {
+	const sr, nfft = 8000.0, 256
+	fb, err := MelFilterBank(20, nfft, sr, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fb.Shape[0] != 20 || fb.Shape[1] != nfft/2+1 {
+		t.Fatalf("фильтрбанк: форма %v", fb.Shape)
+	}
+	for m := 0; m < 20; m++ {
+		peak := 0.0
+		for k := 0; k < nfft/2+1; k++ {
+			v := fb.Data[m*(nfft/2+1)+k]
+			if v < 0 || v > 1 {
+				t.Fatalf("вес фильтра %d вне [0, 1]: %v", m, v)
+			}
+			peak = math.Max(peak, v)
+		}
+		if peak == 0 {
+			t.Fatalf("фильтр %d пуст", m)
+		}
+	}
+
+	// Синусоида 1 кГц должна давать максимум в фильтре, центр которого ближе всего к 1 кГц.
+	x := tensor.Zeros(2048)
+	for i := range x.Data {
+		x.Data[i] = math.Sin(2 * math.Pi * 1000 * float64(i) / sr)
+	}
+	mel, err := MelSpectrogram(x, STFTConfig{NFFT: nfft, Center: true}, fb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	frame := mel.Shape[0] / 2
+	best := 0
+	for m := 1; m < 20; m++ {
+		if mel.At(frame, m) > mel.At(frame, best) {
+			best = m
+		}
+	}
+	step := HzToMel(sr/2) / 21
+	want := int(math.Round(HzToMel(1000)/step)) - 1
+	if best != want {
+		t.Fatalf("максимум в фильтре %d, ожидался %d", best, want)
+	}
+}
@@ -0,0 +1,76 @@
+package fft
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+)
+
+// HzToMel переводит частоту в герцах в мелы по формуле HTK: 2595·log10(1 + f/700).
+func HzToMel(hz float64) float64 {
+	return 2595 * math.Log10(1+hz/700)
+}
+
+// MelToHz — обратное к HzToMel преобразование.
+func MelToHz(mel float64) float64 {
+	return 700 * (math.Pow(10, mel/2595) - 1)
+}
+
+// MelFilterBank строит матрицу треугольных мел-фильтров [nMels, nFFT/2+1]
+// (шкала HTK, без нормировки площади, как torchaudio.functional.melscale_fbanks).
+// Центры фильтров равномерно распределены по мел-шкале между fMin и fMax;
+// fMax <= 0 означает частоту Найквиста sampleRate/2.
+func MelFilterBank(nMels, nFFT int, sampleRate, fMin, fMax float64) (*tensor.Tensor, error) {
+	if fMax <= 0 {
+		fMax = sampleRate / 2
+	}
+	if nMels <= 0 || nFFT <= 0 || sampleRate <= 0 || fMin < 0 || fMin >= fMax {
+		return nil, fmt.Errorf("fft: некорректные параметры мел-фильтрбанка: nMels=%d nFFT=%d sampleRate=%v fMin=%v fMax=%v",
+			nMels, nFFT, sampleRate, fMin, fMax)
+	}
+	bins := nFFT/2 + 1
+	lo, hi := HzToMel(fMin), HzToMel(fMax)
+	edges := make([]float64, nMels+2)
+	for i := range edges {
+		edges[i] = MelToHz(lo + (hi-lo)*float64(i)/float64(nMels+1))
+	}
+	fb := tensor.Zeros(nMels, bins)
+	for m := 0; m < nMels; m++ {
+		left, center, right := edges[m], edges[m+1], edges[m+2]
+		for k := 0; k < bins; k++ {
+			f := float64(k) * sampleRate / float64(nFFT)
+			w := math.Min((f-left)/(center-left), (right-f)/(right-center))
+			fb.Data[m*bins+k] = math.Max(0, w)
+		}
+	}
+	return fb, nil
+}
+
+// MelSpectrogram вычисляет мел-спектрограмму сигнала x [..., T]:
+// спектр мощности STFT, свёрнутый с фильтрбанком fb [nMels, NFFT/2+1].
+// Результат имеет форму [..., F, nMels].
+func MelSpectrogram(x *tensor.Tensor, cfg STFTConfig, fb *tensor.Tensor) (*tensor.Tensor, error) {
+	spec, err := STFT(x, cfg)
+	if err != nil {
+		return nil, err
+	}
+	power, err := Power(spec)
+	if err != nil {
+		return nil, err
+	}
+	bins := power.Shape[len(power.Shape)-1]
+	if len(fb.Shape) != 2 || fb.Shape[1] != bins {
+		return nil, fmt.Errorf("fft: фильтрбанк формы %v не соответствует %d частотам", fb.Shape, bins)
+	}
+	rows, err := tensor.Reshape(power, []int{len(power.Data) / bins, bins})
+	if err != nil {
+		return nil, err
+	}
+	mel, err := tensor.MatMulTransposeB(rows, fb.Contiguous())
+	if err != nil {
+		return nil, err
+	}
+	shape := append(append([]int{}, power.Shape[:len(power.Shape)-1]...), fb.Shape[0])
+	return tensor.Reshape(mel, shape)
+}
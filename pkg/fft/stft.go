@@ -0,0 +1,245 @@
+package fft
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+)
+
+// cosineWindow строит периодическое окно Σ a_i·cos(2πik/n) со знаками (-1)^i,
+// как scipy.signal.get_window с fftbins=True (подходит для STFT).
+func cosineWindow(n int, coeffs ...float64) *tensor.Tensor {
+	w := tensor.Zeros(n)
+	for k := range w.Data {
+		for i, a := range coeffs {
+			if i%2 == 1 {
+				a = -a
+			}
+			w.Data[k] += a * math.Cos(2*math.Pi*float64(i*k)/float64(n))
+		}
+	}
+	return w
+}
+
+// HannWindow возвращает периодическое окно Ханна длины n.
+func HannWindow(n int) *tensor.Tensor {
+	return cosineWindow(n, 0.5, 0.5)
+}
+
+// HammingWindow возвращает периодическое окно Хэмминга длины n.
+func HammingWindow(n int) *tensor.Tensor {
+	return cosineWindow(n, 0.54, 0.46)
+}
+
+// BlackmanWindow возвращает периодическое окно Блэкмана длины n.
+func BlackmanWindow(n int) *tensor.Tensor {
+	return cosineWindow(n, 0.42, 0.5, 0.08)
+}
+
+// Frame нарезает последнюю ось x [..., T] на перекрывающиеся кадры длины frameLen
+// с шагом hop: результат [..., 1+(T-frameLen)/hop, frameLen]. Хвост, не вошедший
+// в целый кадр, отбрасывается.
+func Frame(x *tensor.Tensor, frameLen, hop int) (*tensor.Tensor, error) {
+	if len(x.Shape) == 0 {
+		return nil, fmt.Errorf("fft: frame требует хотя бы одну ось")
+	}
+	t := x.Shape[len(x.Shape)-1]
+	if frameLen <= 0 || hop <= 0 || t < frameLen {
+		return nil, fmt.Errorf("fft: нельзя нарезать сигнал длины %d на кадры %d с шагом %d", t, frameLen, hop)
+	}
+	c := x.Contiguous()
+	frames := 1 + (t-frameLen)/hop
+	out := tensor.Zeros(append(append([]int{}, x.Shape[:len(x.Shape)-1]...), frames, frameLen)...)
+	for b := 0; b*t < numel(x.Shape); b++ {
+		for f := 0; f < frames; f++ {
+			copy(out.Data[(b*frames+f)*frameLen:], c.Data[b*t+f*hop:b*t+f*hop+frameLen])
+		}
+	}
+	return out, nil
+}
+
+// OverlapAdd — операция, обратная к Frame: кадры frames [..., F, L], сдвинутые
+// на hop, суммируются в сигнал [..., length]. length <= 0 означает полную длину
+// (F-1)·hop+L; отсчёты за пределами length отбрасываются.
+func OverlapAdd(frames *tensor.Tensor, hop, length int) (*tensor.Tensor, error) {
+	if len(frames.Shape) < 2 || hop <= 0 {
+		return nil, fmt.Errorf("fft: overlap-add требует кадры [..., F, L] и hop > 0, получены %v и %d", frames.Shape, hop)
+	}
+	nf, l := frames.Shape[len(frames.Shape)-2], frames.Shape[len(frames.Shape)-1]
+	if length <= 0 {
+		length = (nf-1)*hop + l
+	}
+	c := frames.Contiguous()
+	out := tensor.Zeros(append(append([]int{}, frames.Shape[:len(frames.Shape)-2]...), length)...)
+	for b := 0; b*nf*l < numel(frames.Shape); b++ {
+		for f := 0; f < nf; f++ {
+			src := c.Data[(b*nf+f)*l : (b*nf+f+1)*l]
+			for k, v := range src {
+				if pos := f*hop + k; pos < length {
+					out.Data[b*length+pos] += v
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
+func numel(shape []int) int {
+	n := 1
+	for _, d := range shape {
+		n *= d
+	}
+	return n
+}
+
+// STFTConfig задаёт параметры STFT и ISTFT.
+type STFTConfig struct {
+	// NFFT — длина кадра и размер преобразования.
+	NFFT int
+	// Hop — шаг между кадрами; 0 означает NFFT/4.
+	Hop int
+	// Window — окно длины NFFT; nil означает HannWindow(NFFT).
+	Window *tensor.Tensor
+	// Center дополняет сигнал отражением на NFFT/2 с обеих сторон, чтобы
+	// кадр t был центрирован в отсчёте t·Hop (как center=True в torch.stft).
+	Center bool
+}
+
+// Resolve возвращает копию конфигурации с заполненными значениями
+// по умолчанию и проверяет параметры.
+func (c STFTConfig) Resolve() (STFTConfig, error) {
+	if c.NFFT <= 0 {
+		return c, fmt.Errorf("fft: NFFT должен быть положительным, получено %d", c.NFFT)
+	}
+	if c.Hop == 0 {
+		c.Hop = max(c.NFFT/4, 1)
+	}
+	if c.Hop < 0 {
+		return c, fmt.Errorf("fft: отрицательный шаг %d", c.Hop)
+	}
+	if c.Window == nil {
+		c.Window = HannWindow(c.NFFT)
+	}
+	if len(c.Window.Shape) != 1 || c.Window.Shape[0] != c.NFFT {
+		return c, fmt.Errorf("fft: окно формы %v не соответствует NFFT=%d", c.Window.Shape, c.NFFT)
+	}
+	return c, nil
+}
+
+// CenterPads возвращает поля Pad, которые STFT добавляет к сигналу формы shape
+// при Center (нулевые, если Center не задан).
+func (c STFTConfig) CenterPads(shape []int) [][2]int {
+	pads := make([][2]int, len(shape))
+	if c.Center {
+		pads[len(pads)-1] = [2]int{c.NFFT / 2, c.NFFT / 2}
+	}
+	return pads
+}
+
+// STFT вычисляет оконное преобразование Фурье действительного сигнала x [..., T].
+// Результат — комплексный тензор [..., F, NFFT/2+1, 2]: ось времени (кадров)
+// идёт перед осью частот, что удобно для RNN и свёрток по спектрограмме.
+func STFT(x *tensor.Tensor, cfg STFTConfig) (*tensor.Tensor, error) {
+	cfg, err := cfg.Resolve()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Center {
+		if x, err = tensor.Pad(x, cfg.CenterPads(x.Shape), tensor.PadReflect, 0); err != nil {
+			return nil, err
+		}
+	}
+	frames, err := Frame(x, cfg.NFFT, cfg.Hop)
+	if err != nil {
+		return nil, err
+	}
+	frames, err = tensor.Mul(frames, cfg.Window)
+	if err != nil {
+		return nil, err
+	}
+	return RFFT(frames)
+}
+
+// WindowEnvelope возвращает сумму квадратов окна по F кадрам с шагом Hop
+// (длины (F-1)·Hop+NFFT), на которую ISTFT делит результат overlap-add.
+func (c STFTConfig) WindowEnvelope(frames int) *tensor.Tensor {
+	sq := tensor.Apply(c.Window, func(v float64) float64 { return v * v })
+	tiled, _ := tensor.Tile(sq, frames, 1)
+	env, _ := OverlapAdd(tiled, c.Hop, 0)
+	return env
+}
+
+// EnvelopeFloor — минимальное значение огибающей окна, на которое ещё делится ISTFT;
+// отсчёты, не покрытые окном, обнуляются.
+const EnvelopeFloor = 1e-11
+
+// ISTFT восстанавливает сигнал [..., length] по спектру spec [..., F, NFFT/2+1, 2]
+// методом overlap-add с нормировкой на огибающую окна. Для точного восстановления
+// используйте ту же конфигурацию, что и в STFT. length <= 0 означает полную длину
+// (без полей Center); при большей длине сигнал дополняется нулями.
+func ISTFT(spec *tensor.Tensor, cfg STFTConfig, length int) (*tensor.Tensor, error) {
+	cfg, err := cfg.Resolve()
+	if err != nil {
+		return nil, err
+	}
+	frames, err := IRFFT(spec, cfg.NFFT)
+	if err != nil {
+		return nil, err
+	}
+	if len(frames.Shape) < 2 {
+		return nil, fmt.Errorf("fft: istft ожидает спектр [..., F, bins, 2], получена форма %v", spec.Shape)
+	}
+	if frames, err = tensor.Mul(frames, cfg.Window); err != nil {
+		return nil, err
+	}
+	nf := frames.Shape[len(frames.Shape)-2]
+	signal, err := OverlapAdd(frames, cfg.Hop, 0)
+	if err != nil {
+		return nil, err
+	}
+	env := cfg.WindowEnvelope(nf)
+	total := len(env.Data)
+	start := 0
+	if cfg.Center {
+		start = cfg.NFFT / 2
+	}
+	if length <= 0 {
+		length = total - 2*start
+	}
+	out := tensor.Zeros(append(append([]int{}, signal.Shape[:len(signal.Shape)-1]...), length)...)
+	for b := 0; b*length < len(out.Data); b++ {
+		for j := 0; j < length && start+j < total; j++ {
+			if e := env.Data[start+j]; e > EnvelopeFloor {
+				out.Data[b*length+j] = signal.Data[b*total+start+j] / e
+			}
+		}
+	}
+	return out, nil
+}
+
+// Magnitude возвращает модуль комплексного тензора z [..., 2]: форма [...].
+func Magnitude(z *tensor.Tensor) (*tensor.Tensor, error) {
+	return complexMap(z, math.Hypot)
+}
+
+// Power возвращает квадрат модуля комплексного тензора z [..., 2] (спектр мощности).
+func Power(z *tensor.Tensor) (*tensor.Tensor, error) {
+	return complexMap(z, func(re, im float64) float64 { return re*re + im*im })
+}
+
+func complexMap(z *tensor.Tensor, f func(re, im float64) float64) (*tensor.Tensor, error) {
+	shape, err := complexShape(z, 0)
+	if err != nil {
+		return nil, err
+	}
+	c := z.Contiguous()
+	if len(shape) == 0 {
+		shape = []int{1}
+	}
+	out := tensor.Zeros(append([]int{}, shape...)...)
+	for i := range out.Data {
+		out.Data[i] = f(c.Data[2*i], c.Data[2*i+1])
+	}
+	return out, nil
+}
@@ -0,0 +1,174 @@
+package gnn_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/api"
+	"github.com/Hirogava/Go-NN-Learn/pkg/autograd"
+	"github.com/Hirogava/Go-NN-Learn/pkg/gnn"
+	"github.com/Hirogava/Go-NN-Learn/pkg/layers"
+	"github.com/Hirogava/Go-NN-Learn/pkg/optimizers"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+func newMLP(seed int64) *simpleModule {
+	gen := tensor.NewGenerator(seed)
+	return &simpleModule{layers: []layers.Layer{
+		layers.NewDense(4, 8, layers.XavierUniform(4, 8, gen), layers.ZeroInit()),
+		layers.NewReLU(),
+		layers.NewDense(8, 1, layers.XavierUniform(8, 1, gen), layers.ZeroInit()),
+		layers.NewTanh(),
+	}}
+}
+
+// Обучение и предсказания разных моделей в параллельных горутинах:
+// режим no_grad предсказателей не должен влиять на граф обучения и наоборот.
+// Запускается также с go test -race; без cgo-BLAS (cblas.h) —
+// go test -race -tags noblas ./pkg/gnn/.
+func TestNoGrad_ConcurrentTrainerAndPredictors(t *testing.T) {
+	x := tensor.Randn([]int{16, 4}, 2301)
+	target := tensor.Apply(tensor.Randn([]int{16, 1}, 2302), func(v float64) float64 { return v / 4 })
+
+	trainer := newMLP(1)
+	predictor := newMLP(2)
+	sgd := optimizers.NewSGD(0.1)
+
+	var wg sync.WaitGroup
+	errs := make(chan string, 16)
+	stop := make(chan struct{})
+
+	predict := func(mode func(func(*graph.Scope))) {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			var out *graph.Node
+			mode(func(s *graph.Scope) {
+				out = api.Predict(predictor, s.Input(&graph.Node{Value: x}))
+			})
+			if out.Operation != nil || out.Parents != nil || out.Grad != nil {
+				errs <- "предсказание построило граф"
+				return
+			}
+		}
+	}
+	wg.Add(2)
+	go predict(gnn.NoGradScope)
+	go predict(gnn.InferenceMode)
+
+	var first, last float64
+	for step := 0; step < 30; step++ {
+		ctx := autograd.NewGraph()
+		autograd.SetGraph(ctx)
+		loss := ctx.Engine().MSELoss(trainer.Forward(&graph.Node{Value: x}), target)
+		if loss.Operation == nil || loss.Grad == nil {
+			t.Fatalf("шаг %d: граф обучения не построен", step)
+		}
+		ctx.Backward(loss)
+		sgd.Step(trainer.Params())
+		sgd.ZeroGrad(trainer.Params())
+		if step == 0 {
+			first = loss.Value.Data[0]
+		}
+		last = loss.Value.Data[0]
+	}
+	close(stop)
+	wg.Wait()
+	close(errs)
+	for msg := range errs {
+		t.Fatal(msg)
+	}
+	if !(last < first) {
+		t.Fatalf("loss не уменьшился: %v -> %v", first, last)
+	}
+}
+
+// Предсказатель под NoGrad(func()) в соседней горутине не выключает граф
+// обучения, входы которого созданы через GraphContext, как в Trainer.
+func TestNoGrad_CompatibleWithGraphContextTrainer(t *testing.T) {
+	x := tensor.Randn([]int{16, 4}, 2311)
+	target := tensor.Apply(tensor.Randn([]int{16, 1}, 2312), func(v float64) float64 { return v / 4 })
+
+	trainer := newMLP(3)
+	predictor := newMLP(4)
+	sgd := optimizers.NewSGD(0.1)
+
+	var wg sync.WaitGroup
+	errs := make(chan string, 1)
+	stop := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			var out, reg *graph.Node
+			gnn.NoGrad(func() {
+				out = api.Predict(predictor, &graph.Node{Value: x})
+				// Узел только из параметров тоже не строит граф.
+				w := predictor.Params()[0]
+				reg = autograd.EngineOf(w).Sum(autograd.EngineOf(w).Mul(w, w))
+			})
+			if out.Operation != nil || out.Grad != nil || reg.Operation != nil || reg.Grad != nil {
+				errs <- "предсказание под NoGrad построило граф"
+				return
+			}
+		}
+	}()
+
+	var first, last float64
+	for step := 0; step < 30; step++ {
+		ctx := autograd.NewGraph()
+		loss := ctx.Engine().MSELoss(trainer.Forward(ctx.Input(x)), target)
+		if loss.Operation == nil || loss.Grad == nil {
+			t.Fatalf("шаг %d: граф обучения не построен", step)
+		}
+		ctx.Backward(loss)
+		sgd.Step(trainer.Params())
+		sgd.ZeroGrad(trainer.Params())
+		if step == 0 {
+			first = loss.Value.Data[0]
+		}
+		last = loss.Value.Data[0]
+	}
+	close(stop)
+	wg.Wait()
+	close(errs)
+	for msg := range errs {
+		t.Fatal(msg)
+	}
+	if !(last < first) {
+		t.Fatalf("loss не уменьшился: %v -> %v", first, last)
+	}
+}
+
+func TestInferenceMode_EnableGradDoesNotBuildGraph(t *testing.T) {
+	val := &tensor.Tensor{Data: []float64{1, 2}, Shape: []int{2}}
+	parent := &graph.Node{Value: val}
+
+	var inNoGrad, inInference *graph.Node
+	gnn.NoGradScope(func(s *graph.Scope) {
+		gnn.EnableGrad(s, func(s *graph.Scope) {
+			inNoGrad = s.NewNode(val, []*graph.Node{parent}, nil)
+		})
+	})
+	gnn.InferenceMode(func(s *graph.Scope) {
+		gnn.EnableGrad(s, func(s *graph.Scope) {
+			inInference = s.NewNode(val, []*graph.Node{parent}, nil)
+		})
+	})
+	if inNoGrad.Grad == nil || len(inNoGrad.Parents) != 1 {
+		t.Fatal("EnableGrad внутри NoGrad должен включать граф")
+	}
+	if inInference.Grad != nil || inInference.Parents != nil {
+		t.Fatal("EnableGrad внутри InferenceMode не должен включать граф")
+	}
+}
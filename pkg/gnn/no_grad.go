@@ -4,8 +4,9 @@ import (
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
 )
 
-// NoGrad выполняет f с отключённым autograd: внутри f вызов graph.NewNode
-// не строит граф (нет Parents и Operation) и не аллоцирует тензоры Grad.
+// NoGrad выполняет f с отключённым autograd: внутри f узлы без области
+// (graph.Scope) не строят граф (нет Parents и Operation) и не аллоцируют
+// тензоры Grad — в том числе узлы, вычисленные только из параметров.
 // Используйте при инференсе, чтобы не строить граф вычислений и не аллоцировать под autograd:
 //
 //	gnn.NoGrad(func() {
@@ -14,9 +15,45 @@ import (
 //	})
 //
 // DoD: граф не создаётся; нет аллокаций autograd.
-// Не безопасно для конкурентного вызова из нескольких горутин (состояние no_grad глобальное).
+// Режим действует на все узлы процесса без области, но не на граф,
+// входы которого созданы через autograd.GraphContext (Input) — так обучает
+// Trainer, и предсказатель в соседней горутине его не выключает. Если
+// обучение в других горутинах строит узлы без области, используйте NoGradScope.
 func NoGrad(f func()) {
 	graph.EnterNoGrad()
 	defer graph.ExitNoGrad()
 	f()
 }
+
+// NoGradScope выполняет f в новой области с отключённым autograd: узлы,
+// вычисленные из входов этой области (s.Input), не строят граф. Режим
+// хранится в области, а не в глобальном состоянии, поэтому NoGradScope не
+// влияет на вычисления других горутин. Узлы только из параметров создавайте
+// через autograd.NewEngineIn(s). После возврата f область закрывается, и
+// вычисления из её выходов снова строят граф.
+//
+//	gnn.NoGradScope(func(s *graph.Scope) {
+//	    out := api.Predict(model, s.Input(x))
+//	})
+func NoGradScope(f func(s *graph.Scope)) {
+	run(graph.NewScope(graph.ModeNoGrad), f)
+}
+
+// EnableGrad выполняет f во вложенной в s области с включённым autograd,
+// например, внутри NoGradScope. Входы f нужно создавать через её область;
+// внутри InferenceMode граф не включается.
+func EnableGrad(s *graph.Scope, f func(s *graph.Scope)) {
+	run(s.With(graph.ModeGrad), f)
+}
+
+// InferenceMode выполняет f в области инференса: как NoGradScope, но
+// вложенный EnableGrad не включает граф обратно, а область не закрывается,
+// так что тензоры f гарантированно не попадут в граф обучения.
+func InferenceMode(f func(s *graph.Scope)) {
+	run(graph.NewScope(graph.ModeInference), f)
+}
+
+func run(s *graph.Scope, f func(s *graph.Scope)) {
+	defer s.Close()
+	f(s)
+}
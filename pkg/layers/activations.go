@@ -13,7 +13,7 @@ func NewReLU() *ReLULayer {
 }
 
 func (l *ReLULayer) Forward(x *graph.Node) *graph.Node {
-	return autograd.GetGraph().Engine().ReLU(x)
+	return autograd.EngineOf(x).ReLU(x)
 }
 
 func (l *ReLULayer) Params() []*graph.Node { return nil }
@@ -28,7 +28,7 @@ func NewSigmoid() *SigmoidLayer {
 }
 
 func (l *SigmoidLayer) Forward(x *graph.Node) *graph.Node {
-	return autograd.GetGraph().Engine().Sigmoid(x)
+	return autograd.EngineOf(x).Sigmoid(x)
 }
 
 func (l *SigmoidLayer) Params() []*graph.Node { return nil }
@@ -43,7 +43,7 @@ func NewTanh() *TanhLayer {
 }
 
 func (l *TanhLayer) Forward(x *graph.Node) *graph.Node {
-	return autograd.GetGraph().Engine().Tanh(x)
+	return autograd.EngineOf(x).Tanh(x)
 }
 
 func (l *TanhLayer) Params() []*graph.Node { return nil }
@@ -60,7 +60,7 @@ func NewLeakyReLU(slope float64) *LeakyReLULayer {
 }
 
 func (l *LeakyReLULayer) Forward(x *graph.Node) *graph.Node {
-	return autograd.GetGraph().Engine().LeakyReLU(x, l.Slope)
+	return autograd.EngineOf(x).LeakyReLU(x, l.Slope)
 }
 
 func (l *LeakyReLULayer) Params() []*graph.Node { return nil }
@@ -77,7 +77,7 @@ func NewELU(alpha float64) *ELULayer {
 }
 
 func (l *ELULayer) Forward(x *graph.Node) *graph.Node {
-	return autograd.GetGraph().Engine().ELU(x, l.Alpha)
+	return autograd.EngineOf(x).ELU(x, l.Alpha)
 }
 
 func (l *ELULayer) Params() []*graph.Node { return nil }
@@ -92,7 +92,7 @@ func NewSoftPlus() *SoftPlusLayer {
 }
 
 func (l *SoftPlusLayer) Forward(x *graph.Node) *graph.Node {
-	return autograd.GetGraph().Engine().SoftPlus(x)
+	return autograd.EngineOf(x).SoftPlus(x)
 }
 
 func (l *SoftPlusLayer) Params() []*graph.Node { return nil }
@@ -107,7 +107,7 @@ func NewGELU() *GELULayer {
 }
 
 func (l *GELULayer) Forward(x *graph.Node) *graph.Node {
-	return autograd.GetGraph().Engine().GELU(x)
+	return autograd.EngineOf(x).GELU(x)
 }
 
 func (l *GELULayer) Params() []*graph.Node { return nil }
@@ -122,7 +122,7 @@ func NewSoftmax() *SoftmaxLayer {
 }
 
 func (l *SoftmaxLayer) Forward(x *graph.Node) *graph.Node {
-	return autograd.GetGraph().Engine().Softmax(x)
+	return autograd.EngineOf(x).Softmax(x)
 }
 
 func (l *SoftmaxLayer) Params() []*graph.Node { return nil }
@@ -387,6 +387,28 @@ func BenchmarkBatchNormForward(b *testing.B) {
 	}
 }
 
+// TestBatchNormParamGradients проверяет, что γ и β получают градиенты через broadcasting-операции
+func TestBatchNormParamGradients(t *testing.T) {
+	engine := autograd.NewEngine()
+	bn := NewBatchNorm(3, engine)
+	bn.Train()
+
+	inputData := tensor.Zeros(2, 3)
+	inputData.Data = []float64{1, 2, 3, 4, 5, 9}
+	output := bn.Forward(graph.NewNode(inputData, nil, nil))
+	engine.Backward(engine.Sum(output))
+
+	// dL/dβ = Σ_batch 1 = 2, dL/dγ = Σ_batch x̂ = 0 (x̂ центрирован по батчу)
+	for j := 0; j < 3; j++ {
+		if math.Abs(bn.beta.Grad.Data[j]-2) > 1e-9 {
+			t.Errorf("beta grad[%d] = %v, want 2", j, bn.beta.Grad.Data[j])
+		}
+		if math.Abs(bn.gamma.Grad.Data[j]) > 1e-9 {
+			t.Errorf("gamma grad[%d] = %v, want 0", j, bn.gamma.Grad.Data[j])
+		}
+	}
+}
+
 // ExampleBatchNorm демонстрирует использование BatchNorm
 func ExampleBatchNorm() {
 	engine := autograd.NewEngine()
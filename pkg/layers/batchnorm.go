@@ -127,29 +127,11 @@ func (bn *BatchNorm) Forward(x *graph.Node) *graph.Node {
 // forwardTraining выполняет forward pass в режиме обучения.
 func (bn *BatchNorm) forwardTraining(x *graph.Node, batchSize, numFeatures int) *graph.Node {
 	// Вычисляем mean и variance по батчу для каждого признака
-	batchMean := tensor.Zeros(numFeatures)
-	batchVar := tensor.Zeros(numFeatures)
+	// 1. Среднее: μ = (1/N) Σ x
+	batchMean, _ := tensor.MeanAxes(x.Value, []int{0}, false)
 
-	// 1. Вычисляем среднее: μ = (1/N) Σ x
-	for j := range numFeatures {
-		sum := 0.0
-		for i := range batchSize {
-			idx := i*numFeatures + j
-			sum += x.Value.Data[idx]
-		}
-		batchMean.Data[j] = sum / float64(batchSize)
-	}
-
-	// 2. Вычисляем дисперсию: σ² = (1/N) Σ (x - μ)²
-	for j := range numFeatures {
-		sum := 0.0
-		for i := range batchSize {
-			idx := i*numFeatures + j
-			diff := x.Value.Data[idx] - batchMean.Data[j]
-			sum += diff * diff
-		}
-		batchVar.Data[j] = sum / float64(batchSize)
-	}
+	// 2. Дисперсия: σ² = (1/N) Σ (x - μ)²
+	batchVar, _ := tensor.VarAxes(x.Value, []int{0}, false, 0)
 
 	// 3. Обновляем running mean/var экспоненциальным скользящим средним
 	for j := range numFeatures {
@@ -170,11 +152,11 @@ func (bn *BatchNorm) forwardTraining(x *graph.Node, batchSize, numFeatures int)
 	// Используем autograd операции для автоматического вычисления градиентов
 	normalizedNode := graph.NewNode(normalized, []*graph.Node{x}, nil)
 
-	// γ * x̂
-	scaled := bn.multiplyWithBroadcast(normalizedNode, bn.gamma, batchSize, numFeatures)
+	// γ * x̂ ([N,F] * [F] — broadcasting, градиент γ суммируется по батчу)
+	scaled := bn.engine.Mul(normalizedNode, bn.gamma)
 
 	// γ * x̂ + β
-	output := bn.addWithBroadcast(scaled, bn.beta, batchSize, numFeatures)
+	output := bn.engine.Add(scaled, bn.beta)
 
 	return output
 }
@@ -193,37 +175,12 @@ func (bn *BatchNorm) forwardInference(x *graph.Node, batchSize, numFeatures int)
 	normalizedNode := graph.NewNode(normalized, []*graph.Node{x}, nil)
 
 	// Применяем γ и β
-	scaled := bn.multiplyWithBroadcast(normalizedNode, bn.gamma, batchSize, numFeatures)
-	output := bn.addWithBroadcast(scaled, bn.beta, batchSize, numFeatures)
+	scaled := bn.engine.Mul(normalizedNode, bn.gamma)
+	output := bn.engine.Add(scaled, bn.beta)
 
 	return output
 }
 
-// multiplyWithBroadcast умножает входной тензор [batch_size, num_features]
-// на вектор параметров [num_features] с broadcasting.
-func (bn *BatchNorm) multiplyWithBroadcast(x *graph.Node, param *graph.Node, batchSize, numFeatures int) *graph.Node {
-	result := tensor.Zeros(x.Value.Shape...)
-	for i := range batchSize {
-		for j := range numFeatures {
-			idx := i*numFeatures + j
-			result.Data[idx] = x.Value.Data[idx] * param.Value.Data[j]
-		}
-	}
-	return graph.NewNode(result, []*graph.Node{x, param}, nil)
-}
-
-// addWithBroadcast добавляет вектор параметров [num_features] к тензору [batch_size, num_features].
-func (bn *BatchNorm) addWithBroadcast(x *graph.Node, param *graph.Node, batchSize, numFeatures int) *graph.Node {
-	result := tensor.Zeros(x.Value.Shape...)
-	for i := range batchSize {
-		for j := range numFeatures {
-			idx := i*numFeatures + j
-			result.Data[idx] = x.Value.Data[idx] + param.Value.Data[j]
-		}
-	}
-	return graph.NewNode(result, []*graph.Node{x, param}, nil)
-}
-
 // Params возвращает обучаемые параметры слоя (gamma и beta).
 func (bn *BatchNorm) Params() []*graph.Node {
 	return []*graph.Node{bn.gamma, bn.beta}
@@ -242,6 +199,18 @@ func (bn *BatchNorm) Params() []*graph.Node {
 func (bn *BatchNorm) Train() { bn.training = true }
 func (bn *BatchNorm) Eval()  { bn.training = false }
 
+// SaveState возвращает копию running mean/var (см. StatefulLayer).
+func (bn *BatchNorm) SaveState() any {
+	return [2]*tensor.Tensor{bn.runningMean.Clone(), bn.runningVar.Clone()}
+}
+
+// RestoreState восстанавливает running mean/var, сохранённые SaveState.
+func (bn *BatchNorm) RestoreState(state any) {
+	s := state.([2]*tensor.Tensor)
+	copy(bn.runningMean.Data, s[0].Data)
+	copy(bn.runningVar.Data, s[1].Data)
+}
+
 // SetMomentum устанавливает коэффициент momentum для обновления running mean/var.
 func (bn *BatchNorm) SetMomentum(momentum float64) {
 	bn.momentum = momentum
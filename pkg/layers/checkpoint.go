@@ -0,0 +1,58 @@
+package layers
+
+import (
+	"github.com/Hirogava/Go-NN-Learn/pkg/autograd"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+// StatefulLayer — слой, Forward которого меняет состояние между вызовами
+// (скрытое состояние RNN, running-статистики, позиция генератора Dropout).
+// Checkpointed сохраняет это состояние, чтобы пересчёт в Backward повторил
+// исходный прямой проход.
+type StatefulLayer interface {
+	Layer
+	SaveState() any
+	RestoreState(state any)
+}
+
+// CheckpointedLayer — обёртка слоя с чекпоинтингом активаций (см. autograd.Checkpoint).
+type CheckpointedLayer struct {
+	Layer
+	opts []autograd.CheckpointOption
+}
+
+// Checkpointed оборачивает l так, что его промежуточные активации не хранятся
+// до Backward, а пересчитываются в нём. Состояние l и вложенных слоёв
+// (если l — модуль с Layers()), реализующих StatefulLayer, воспроизводится
+// при пересчёте; opts добавляют, например, autograd.PreserveRNG. Dropout без
+// своего генератора получает поток, отделённый от tensor.DefaultGenerator,
+// иначе пересчёт взял бы маски из общего генератора процесса.
+func Checkpointed(l Layer, opts ...autograd.CheckpointOption) *CheckpointedLayer {
+	var stateful []StatefulLayer
+	Walk(l, func(l Layer) {
+		if d, ok := l.(*Dropout); ok && d.gen == nil {
+			d.gen = tensor.DefaultGenerator().Split()
+		}
+		if s, ok := l.(StatefulLayer); ok {
+			stateful = append(stateful, s)
+		}
+	})
+
+	for _, s := range stateful {
+		opts = append(opts, autograd.PreserveState(func() func() {
+			state := s.SaveState()
+			return func() { s.RestoreState(state) }
+		}))
+	}
+	return &CheckpointedLayer{Layer: l, opts: opts}
+}
+
+// Unwrap возвращает обёрнутый слой.
+func (c *CheckpointedLayer) Unwrap() Layer {
+	return c.Layer
+}
+
+func (c *CheckpointedLayer) Forward(x *graph.Node) *graph.Node {
+	return autograd.Checkpoint(c.Layer.Forward, x, c.opts...)
+}
@@ -0,0 +1,88 @@
+package layers
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/autograd"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+type layerStack []Layer
+
+func (s layerStack) Layers() []Layer { return s }
+func (s layerStack) Forward(x *graph.Node) *graph.Node {
+	for _, l := range s {
+		x = l.Forward(x)
+	}
+	return x
+}
+func (s layerStack) Params() []*graph.Node {
+	var ps []*graph.Node
+	for _, l := range s {
+		ps = append(ps, l.Params()...)
+	}
+	return ps
+}
+func (s layerStack) Train() {}
+func (s layerStack) Eval()  {}
+
+func TestCheckpointedMatchesPlain(t *testing.T) {
+	// LSTM с ненулевым начальным состоянием и Dropout со своим генератором:
+	// пересчёт должен начать с того же состояния и тех же масок.
+	run := func(checkpoint bool) (out *graph.Node, x *graph.Node, l *LSTM, gen *tensor.Generator) {
+		l = NewLSTM(4, 5, false, XavierUniform(4, 5, tensor.NewGenerator(1)))
+		l.SetHiddenState(tensor.Randn([]int{2, 5}, 2511))
+		l.SetCellState(tensor.Randn([]int{2, 5}, 2512))
+		gen = tensor.NewGenerator(2)
+		var model Layer = layerStack{WithHooks(l), NewDropout(0.3, gen)}
+		if checkpoint {
+			model = Checkpointed(model)
+		}
+		x = graph.NewNode(tensor.Randn([]int{2, 3, 4}, 2513), nil, nil)
+		out = model.Forward(x)
+		e := autograd.NewEngine()
+		e.Backward(e.Sum(e.Mul(out, out)))
+		return out, x, l, gen
+	}
+	wantOut, wantX, wantLSTM, wantGen := run(false)
+	out, x, l, gen := run(true)
+
+	closeTo := func(name string, got, want []float64) {
+		t.Helper()
+		for i := range want {
+			if math.Abs(got[i]-want[i]) > 1e-12 {
+				t.Fatalf("%s[%d] = %v, без чекпоинта %v", name, i, got[i], want[i])
+			}
+		}
+	}
+	closeTo("выход", out.Value.Data, wantOut.Value.Data)
+	closeTo("dx", x.Grad.Data, wantX.Grad.Data)
+	for i, p := range l.Params() {
+		closeTo("dparam", p.Grad.Data, wantLSTM.Params()[i].Grad.Data)
+	}
+	closeTo("скрытое состояние", l.GetHiddenState().Data, wantLSTM.GetHiddenState().Data)
+	if gen.Position() != wantGen.Position() {
+		t.Fatalf("позиция генератора %d, без чекпоинта %d", gen.Position(), wantGen.Position())
+	}
+	if out.Parents[0] != x {
+		t.Fatal("выход Checkpointed должен зависеть только от входа")
+	}
+}
+
+func TestCheckpointedDropoutWithoutGenerator(t *testing.T) {
+	// Dropout без генератора: Checkpointed выдаёт ему собственный поток,
+	// и пересчёт в Backward повторяет маску прямого прохода.
+	model := Checkpointed(NewDropout(0.5))
+	x := graph.NewNode(tensor.Ones(4, 8), nil, nil)
+	out := model.Forward(x)
+	e := autograd.NewEngine()
+	e.Backward(e.Sum(out))
+
+	for i, v := range out.Value.Data {
+		if x.Grad.Data[i] != v {
+			t.Fatalf("dx[%d] = %v, маска прямого прохода %v", i, x.Grad.Data[i], v)
+		}
+	}
+}
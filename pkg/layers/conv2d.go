@@ -181,7 +181,7 @@ func (c *Conv2D) Forward(x *graph.Node) *graph.Node {
 		Strides: outputStrides,
 	}
 
-	if autograd.GradEnabled() {
+	if autograd.GradEnabledFor(x) {
 		op := &conv2dOp{
 			x:       x,
 			conv2d:  c,
@@ -198,7 +198,7 @@ func (c *Conv2D) Forward(x *graph.Node) *graph.Node {
 		return graph.NewNode(outputTensor, []*graph.Node{x, c.weights, c.bias}, op)
 	}
 
-	return &graph.Node{Value: outputTensor}
+	return &graph.Node{Value: outputTensor, Scope: x.Scope}
 }
 
 func (c *Conv2D) Params() []*graph.Node {
@@ -319,6 +319,67 @@ func (op *conv2dOp) Backward(grad *tensor.Tensor) {
 	}
 }
 
+// BackwardGraph записывает backward свёртки операциями Engine, чтобы Conv2D
+// поддерживал Backward с autograd.CreateGraph(true) и Engine.Grad. Матрица
+// im2col строится из входа через IndexSelect по номерам исходных элементов
+// (sourceIndex), поэтому градиент по ней дифференцируем и по x.
+func (op *conv2dOp) BackwardGraph(e *autograd.Engine, grad *graph.Node) []*graph.Node {
+	c := op.conv2d
+	x, w := op.x, c.weights
+	size := int(x.Value.Size())
+	src := op.sourceIndex()
+
+	flatX := e.Concatenate([]*graph.Node{e.Reshape(x, []int{size}), graph.NewNode(tensor.Zeros(1), nil, nil)}, 0)
+	col := e.Reshape(e.IndexSelect(flatX, 0, src), []int{op.colRows, op.colCols})
+	gradMat := e.Reshape(e.Einsum("nchw->cnhw", grad), []int{c.outChannels, op.colCols})
+	w2D := e.Reshape(w, []int{c.outChannels, op.colRows})
+
+	dCol := e.Reshape(e.MatMul(e.Transpose(w2D), gradMat), src.Shape)
+	sums := e.ScatterAdd(graph.NewNode(tensor.Zeros(size+1), nil, nil), 0, src, dCol)
+	dx := e.Reshape(e.IndexSelect(sums, 0, firstN(size)), x.Value.Shape)
+	return []*graph.Node{
+		dx,
+		e.Reshape(e.MatMul(gradMat, e.Transpose(col)), w.Value.Shape),
+		e.SumAxes(grad, []int{0, 2, 3}, false),
+	}
+}
+
+// sourceIndex возвращает для каждого элемента матрицы im2col (построчно) номер
+// элемента входа, из которого он скопирован, а для нулевых полей — размер входа.
+func (op *conv2dOp) sourceIndex() *tensor.Tensor {
+	c := op.conv2d
+	shape := op.x.Value.Shape
+	N, C, H, W := shape[0], shape[1], shape[2], shape[3]
+	dilation := c.dilation
+	if dilation <= 0 {
+		dilation = 1
+	}
+	// Номера сдвинуты на 1, чтобы нулевые поля pad4D отличались от элемента 0.
+	pos := make([]float64, N*C*H*W)
+	for i := range pos {
+		pos[i] = float64(i + 1)
+	}
+	padded := pad4D(pos, N, C, H, W, op.padTop, op.padH-H-op.padTop, op.padLeft, op.padW-W-op.padLeft)
+	col := im2col(padded, N, C, op.padH, op.padW, c.kernelSize, c.kernelSize, c.stride, dilation, op.outH, op.outW)
+	for i, v := range col {
+		if v == 0 {
+			col[i] = float64(len(pos))
+		} else {
+			col[i] = v - 1
+		}
+	}
+	return &tensor.Tensor{Data: col, Shape: []int{len(col)}, Strides: []int{1}}
+}
+
+// firstN возвращает одномерный индекс 0, 1, …, n-1.
+func firstN(n int) *tensor.Tensor {
+	idx := tensor.Zeros(n)
+	for i := range idx.Data {
+		idx.Data[i] = float64(i)
+	}
+	return idx
+}
+
 func normalizeConvPadding(padding string) string {
 	padding = strings.ToLower(strings.TrimSpace(padding))
 	switch padding {
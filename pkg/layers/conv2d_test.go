@@ -355,6 +355,64 @@ func TestConv2DNumericalGradient_SameStrideDilation(t *testing.T) {
 	}
 }
 
+func TestConv2DSecondOrderGradientCheck(t *testing.T) {
+	autograd.SetGraph(autograd.NewGraph())
+	defer autograd.ClearGraph()
+
+	// Gradient penalty через Conv2D: штраф ‖∇L‖² по входу и весам
+	// дифференцируется ещё раз для явного и "same" паддинга со stride и dilation.
+	configs := []Conv2DConfig{
+		{InChannels: 2, OutChannels: 2, KernelSize: 2, Stride: 1, Padding: "valid"},
+		{InChannels: 2, OutChannels: 2, KernelSize: 2, Stride: 2, Padding: "same", Dilation: 2},
+	}
+	for _, cfg := range configs {
+		cfg.WInit, cfg.BInit = initFuncFixed, ZeroInit()
+		build := func(e *autograd.Engine, in []*graph.Node) *graph.Node {
+			conv := NewConv2DWithConfig(cfg)
+			conv.weights, conv.bias = in[1], in[2]
+			loss := e.Sum(e.Tanh(conv.Forward(in[0])))
+			grads, err := e.Grad([]*graph.Node{loss}, in[:2])
+			if err != nil {
+				return nil
+			}
+			penalty := e.Add(e.Sum(e.Mul(grads[0], grads[0])), e.Sum(e.Mul(grads[1], grads[1])))
+			return e.Add(loss, penalty)
+		}
+		inputs := []*graph.Node{
+			graph.NewNode(tensor.Randn([]int{2, 2, 4, 4}, 41), nil, nil),
+			graph.NewNode(tensor.Randn([]int{2, 2, 2, 2}, 42), nil, nil),
+			graph.NewNode(tensor.Randn([]int{2}, 43), nil, nil),
+		}
+		if !autograd.CheckGradientEngine(build, inputs, 1e-6, 1e-4) {
+			t.Errorf("Conv2D %q: second-order gradient check failed", cfg.Padding)
+		}
+	}
+
+	// Backward с CreateGraph(true) проходит через Conv2D и совпадает с обычным.
+	conv := NewConv2D(1, 2, 2, 1, 1, initFuncFixed, ZeroInit())
+	x := graph.NewNode(tensor.Randn([]int{1, 1, 3, 3}, 44), nil, nil)
+	e := autograd.NewEngine()
+	if err := e.Backward(e.Sum(e.Tanh(conv.Forward(x))), autograd.CreateGraph(true)); err != nil {
+		t.Fatalf("Backward(CreateGraph): %v", err)
+	}
+	w := conv.Params()[0]
+	if w.GradNode == nil || x.GradNode == nil {
+		t.Fatal("GradNode не построен")
+	}
+	graphGrad := w.Grad.Clone()
+
+	for _, p := range append(conv.Params(), x) {
+		p.ZeroGrad()
+	}
+	e = autograd.NewEngine()
+	e.Backward(e.Sum(e.Tanh(conv.Forward(x))))
+	for i, v := range w.Grad.Data {
+		if math.Abs(v-graphGrad.Data[i]) > 1e-12 {
+			t.Fatalf("grad[%d] = %v, с CreateGraph %v", i, v, graphGrad.Data[i])
+		}
+	}
+}
+
 func sameInts(a, b []int) bool {
 	if len(a) != len(b) {
 		return false
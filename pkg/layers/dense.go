@@ -1,6 +1,7 @@
 package layers
 
 import (
+	"github.com/Hirogava/Go-NN-Learn/pkg/autograd"
 	"github.com/Hirogava/Go-NN-Learn/pkg/matrix"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
@@ -43,7 +44,12 @@ func NewDense(inDim, outDim int, wInit, bInit Initializer) *Dense {
 }
 
 func (d *Dense) Forward(x *graph.Node) *graph.Node {
-	xTensor := x.Value
+	if x.Sparse != nil {
+		return d.forwardSparse(x)
+	}
+	// Matrix читает Data подряд, поэтому представления (срезы, транспонирование)
+	// материализуются.
+	xTensor := x.Value.Contiguous()
 	var xMat *tensor.Matrix
 	if len(xTensor.Shape) == 1 {
 		xMat = &tensor.Matrix{
@@ -103,6 +109,26 @@ func (d *Dense) Forward(x *graph.Node) *graph.Node {
 	return graph.NewNode(resultTensor, []*graph.Node{x, d.weights, d.bias}, op)
 }
 
+// forwardSparse вычисляет x·W + b для разреженного входа [batch, inDim]
+// (см. graph.NewSparseNode); стоимость пропорциональна числу ненулевых элементов x.
+func (d *Dense) forwardSparse(x *graph.Node) *graph.Node {
+	if x.Sparse.Cols != d.inDim {
+		panic("Input dimension mismatch")
+	}
+	out, err := tensor.SparseDenseMatMul(x.Sparse, d.weights.Value)
+	if err != nil {
+		panic("Matrix multiplication failed: " + err.Error())
+	}
+	bVec := d.bias.Value.Data
+	for i := 0; i < out.Shape[0]; i++ {
+		for j := 0; j < d.outDim; j++ {
+			out.Data[i*d.outDim+j] += bVec[j]
+		}
+	}
+	op := &sparseDenseOp{x: x, w: d.weights, b: d.bias}
+	return graph.NewNode(out, []*graph.Node{x, d.weights, d.bias}, op)
+}
+
 func (d *Dense) Params() []*graph.Node {
 	return []*graph.Node{d.weights, d.bias}
 }
@@ -117,85 +143,223 @@ type denseOp struct {
 }
 
 func (op *denseOp) Backward(grad *tensor.Tensor) {
+	grad = grad.Contiguous()
 	gradMat := &tensor.Matrix{
 		Data: grad.Data,
 		Rows: grad.Shape[0],
 		Cols: grad.Shape[1],
 	}
 
-	xTensor := op.x.Value
+	xTensor := op.x.Value.Contiguous()
 	xRows := 1
 	xCols := xTensor.Shape[0]
 	if len(xTensor.Shape) == 2 {
 		xRows = xTensor.Shape[0]
 		xCols = xTensor.Shape[1]
 	}
-	xMat := &tensor.Matrix{
-		Data: xTensor.Data,
-		Rows: xRows,
-		Cols: xCols,
+	x2D := &tensor.Tensor{
+		Data:    xTensor.Data,
+		Shape:   []int{xRows, xCols},
+		Strides: []int{xCols, 1},
+	}
+	grad2D := &tensor.Tensor{
+		Data:    grad.Data,
+		Shape:   []int{gradMat.Rows, gradMat.Cols},
+		Strides: []int{gradMat.Cols, 1},
 	}
 
-	wTensor := op.w.Value
-	wMat := &tensor.Matrix{
-		Data: wTensor.Data,
-		Rows: wTensor.Shape[0],
-		Cols: wTensor.Shape[1],
+	// 1. Градиент по входу x: dL/dx += grad * w^T (накопление прямо в x.Grad)
+	if op.x.Grad == nil {
+		op.x.Grad = tensor.Zeros(xRows, xCols)
+	}
+	xGrad := &tensor.Tensor{
+		Data:    op.x.Grad.Data,
+		Shape:   []int{xRows, xCols},
+		Strides: []int{xCols, 1},
+	}
+	if err := tensor.Gemm(false, true, 1, grad2D, op.w.Value, 1, xGrad); err != nil {
+		panic("Matrix multiplication failed: " + err.Error())
 	}
 
-	// 1. Градиент по входу x: dL/dx = grad * w^T
-	wMatT, err := matrix.Transposition(wMat)
-	if err != nil {
-		panic("Transposition failed: " + err.Error())
+	// 2. Градиент по весам w: dL/dw += x^T * grad
+	if op.w.Grad == nil {
+		op.w.Grad = tensor.Zeros(op.w.Value.Shape...)
 	}
-	xGradMat, err := matrix.MatMul(gradMat, wMatT)
-	if err != nil {
+	if err := tensor.Gemm(true, false, 1, x2D, grad2D, 1, op.w.Grad); err != nil {
 		panic("Matrix multiplication failed: " + err.Error())
 	}
 
-	if op.x.Grad == nil {
-		op.x.Grad = &tensor.Tensor{
-			Data:    xGradMat.Data,
-			Shape:   []int{xGradMat.Rows, xGradMat.Cols},
-			Strides: []int{xGradMat.Cols, 1},
-		}
-	} else {
-		for i := range op.x.Grad.Data {
-			op.x.Grad.Data[i] += xGradMat.Data[i]
+	// 3. Градиент по смещению b: dL/db = sum(grad, axis=0)
+	if op.b.Grad == nil {
+		op.b.Grad = tensor.Zeros(gradMat.Cols)
+	}
+	for j := 0; j < gradMat.Cols; j++ {
+		sum := 0.0
+		for i := 0; i < gradMat.Rows; i++ {
+			sum += gradMat.Data[i*gradMat.Cols+j]
 		}
+		op.b.Grad.Data[j] += sum
 	}
+}
 
-	// 2. Градиент по весам w: dL/dw = x^T * grad
-	xMatT, err := matrix.Transposition(xMat)
+// BackwardGraph выражает backward через операции Engine, чтобы Dense
+// поддерживал Backward с autograd.CreateGraph(true) и Engine.Grad.
+func (op *denseOp) BackwardGraph(e *autograd.Engine, grad *graph.Node) []*graph.Node {
+	x := op.x
+	if len(x.Value.Shape) == 1 {
+		x = e.Reshape(x, []int{1, x.Value.Shape[0]})
+	}
+	gx := e.MatMul(grad, e.Transpose(op.w))
+	if len(op.x.Value.Shape) == 1 {
+		gx = e.Reshape(gx, op.x.Value.Shape)
+	}
+	return []*graph.Node{
+		gx,
+		e.MatMul(e.Transpose(x), grad),
+		e.SumAxes(grad, []int{0}, false),
+	}
+}
+
+// JVP — правило прямого режима (autograd.JVP): ẏ = ẋ·W + x·Ẇ + ḃ.
+func (op *denseOp) JVP(out *tensor.Tensor, t []*tensor.Tensor) *tensor.Tensor {
+	x, tx := op.x.Value, t[0]
+	if len(x.Shape) == 1 {
+		row := []int{1, x.Shape[0]}
+		x, _ = tensor.Reshape(x.Contiguous(), row)
+		tx, _ = tensor.Reshape(tx.Contiguous(), row)
+	}
+	res, err := tensor.MatMul(tx, op.w.Value)
 	if err != nil {
-		panic("Transposition failed: " + err.Error())
+		panic("Matrix multiplication failed: " + err.Error())
 	}
-	wGradMat, err := matrix.MatMul(xMatT, gradMat)
+	xw, err := tensor.MatMul(x, t[1])
 	if err != nil {
 		panic("Matrix multiplication failed: " + err.Error())
 	}
+	res, _ = tensor.Add(res, xw)
+	res, _ = tensor.Add(res, t[2])
+	return res
+}
 
+// sparseDenseOp — backward Dense для разреженного входа.
+// Вход — данные, градиент по нему не вычисляется.
+type sparseDenseOp struct {
+	x *graph.Node
+	w *graph.Node
+	b *graph.Node
+}
+
+func (op *sparseDenseOp) Backward(grad *tensor.Tensor) {
+	// dL/dw = x^T * grad
+	wGrad, err := tensor.SparseDenseMatMulTransposeA(op.x.Sparse, grad)
+	if err != nil {
+		panic("Matrix multiplication failed: " + err.Error())
+	}
 	if op.w.Grad == nil {
-		op.w.Grad = &tensor.Tensor{
-			Data:    wGradMat.Data,
-			Shape:   []int{wGradMat.Rows, wGradMat.Cols},
-			Strides: []int{wGradMat.Cols, 1},
-		}
+		op.w.Grad = wGrad
 	} else {
 		for i := range op.w.Grad.Data {
-			op.w.Grad.Data[i] += wGradMat.Data[i]
+			op.w.Grad.Data[i] += wGrad.Data[i]
 		}
 	}
 
-	// 3. Градиент по смещению b: dL/db = sum(grad, axis=0)
+	// dL/db = sum(grad, axis=0)
+	bGrad, _ := tensor.SumAxes(grad, []int{0}, false)
 	if op.b.Grad == nil {
-		op.b.Grad = tensor.Zeros(gradMat.Cols)
+		op.b.Grad = tensor.Zeros(len(bGrad.Data))
 	}
-	for j := 0; j < gradMat.Cols; j++ {
-		sum := 0.0
-		for i := 0; i < gradMat.Rows; i++ {
-			sum += gradMat.Data[i*gradMat.Cols+j]
+	for j, v := range bGrad.Data {
+		op.b.Grad.Data[j] += v
+	}
+}
+
+// DenseOf — полносвязный слой над graph.NodeOf[T], например во float32
+// (см. autograd.TypedEngine): веса, выход и градиенты имеют тип T.
+// Инициализаторы те же, что у Dense; значения приводятся к T.
+type DenseOf[T tensor.Float] struct {
+	weights *graph.NodeOf[T]
+	bias    *graph.NodeOf[T]
+	inDim   int
+	outDim  int
+}
+
+func NewDenseOf[T tensor.Float](inDim, outDim int, wInit, bInit Initializer) *DenseOf[T] {
+	return &DenseOf[T]{
+		weights: paramOf[T](wInit, inDim, outDim),
+		bias:    paramOf[T](bInit, outDim),
+		inDim:   inDim,
+		outDim:  outDim,
+	}
+}
+
+// paramOf создаёт параметр формы shape, заполненный init и приведённый к T.
+func paramOf[T tensor.Float](init Initializer, shape ...int) *graph.NodeOf[T] {
+	v := tensor.ZerosOf[T](shape...)
+	data := make([]float64, len(v.Data))
+	init(data)
+	for i, x := range data {
+		v.Data[i] = T(x)
+	}
+	return &graph.NodeOf[T]{Value: v}
+}
+
+// Forward вычисляет x·W + b для x формы [batch, inDim] или [inDim].
+func (d *DenseOf[T]) Forward(x *graph.NodeOf[T]) *graph.NodeOf[T] {
+	x2D := x.Value
+	if len(x2D.Shape) == 1 {
+		x2D = &tensor.TensorOf[T]{Data: x2D.Data, Shape: []int{1, x2D.Shape[0]}, Strides: []int{x2D.Shape[0], 1}}
+	}
+	if len(x2D.Shape) != 2 {
+		panic("Dense layer expects 1D or 2D tensor input")
+	}
+	if x2D.Shape[1] != d.inDim {
+		panic("Input dimension mismatch")
+	}
+	out, err := tensor.MatMulOf(x2D, d.weights.Value)
+	if err != nil {
+		panic("Matrix multiplication failed: " + err.Error())
+	}
+	bVec := d.bias.Value.Data
+	for i := 0; i < out.Shape[0]; i++ {
+		row := out.Data[i*d.outDim : (i+1)*d.outDim]
+		for j := range row {
+			row[j] += bVec[j]
 		}
-		op.b.Grad.Data[j] += sum
 	}
+	op := &denseOfOp[T]{x: x, x2D: x2D, w: d.weights, b: d.bias}
+	return graph.NewNodeOf(out, []*graph.NodeOf[T]{x, d.weights, d.bias}, op)
+}
+
+func (d *DenseOf[T]) Params() []*graph.NodeOf[T] {
+	return []*graph.NodeOf[T]{d.weights, d.bias}
+}
+
+type denseOfOp[T tensor.Float] struct {
+	x   *graph.NodeOf[T]
+	x2D *tensor.TensorOf[T]
+	w   *graph.NodeOf[T]
+	b   *graph.NodeOf[T]
+}
+
+func (op *denseOfOp[T]) Backward(grad *tensor.TensorOf[T]) {
+	wT, _ := tensor.TransposeOf(op.w.Value)
+	xT, _ := tensor.TransposeOf(op.x2D)
+
+	// dL/dx = grad · Wᵀ, dL/dW = xᵀ · grad, dL/db = sum(grad, axis=0)
+	gx, err := tensor.MatMulOf(grad, wT)
+	if err != nil {
+		panic("Matrix multiplication failed: " + err.Error())
+	}
+	gw, err := tensor.MatMulOf(xT, grad)
+	if err != nil {
+		panic("Matrix multiplication failed: " + err.Error())
+	}
+	gb, _ := tensor.SumToShapeOf(grad, op.b.Value.Shape)
+
+	if len(op.x.Value.Shape) == 1 {
+		gx, _ = tensor.ReshapeOf(gx, op.x.Value.Shape)
+	}
+	op.x.AccumulateGrad(gx)
+	op.w.AccumulateGrad(gw)
+	op.b.AccumulateGrad(gb)
 }
@@ -2,8 +2,10 @@ package layers
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
+	"github.com/Hirogava/Go-NN-Learn/pkg/autograd"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
 )
@@ -72,3 +74,153 @@ func TestDenseBackward(t *testing.T) {
 	output.Operation.Backward(grad)
 	// ... дальше проверки fmt.Println ...
 }
+
+func TestDenseViewInputMatchesContiguous(t *testing.T) {
+	// Вход — транспонированное представление [2,3] буфера [3,2].
+	src := &tensor.Tensor{Data: []float64{1, 4, 2, 5, 3, 6}, Shape: []int{3, 2}, Strides: []int{2, 1}}
+	view, err := tensor.Transpose(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	run := func(x *tensor.Tensor) (*graph.Node, *graph.Node) {
+		dense := NewDense(3, 2, initFuncFixed, ZeroInit())
+		in := graph.NewNode(x, nil, nil)
+		out := dense.Forward(in)
+		// Градиент тоже представление: транспонированный [2,2].
+		g, _ := tensor.Transpose(&tensor.Tensor{Data: []float64{1, 3, 2, 4}, Shape: []int{2, 2}, Strides: []int{2, 1}})
+		out.Operation.Backward(g)
+		return out, in
+	}
+	wantOut, wantIn := run(view.Contiguous())
+	gotOut, gotIn := run(view)
+	for i, v := range wantOut.Value.Data {
+		if gotOut.Value.Data[i] != v {
+			t.Fatalf("выход Dense над представлением: %v, ожидалось %v", gotOut.Value.Data, wantOut.Value.Data)
+		}
+	}
+	for i, v := range wantIn.Grad.Data {
+		if gotIn.Grad.Data[i] != v {
+			t.Fatalf("градиент входа над представлением: %v, ожидалось %v", gotIn.Grad.Data, wantIn.Grad.Data)
+		}
+	}
+}
+
+func TestDenseSecondOrderGradientCheck(t *testing.T) {
+	// Gradient penalty через двухслойный Dense: штраф ‖∇L‖² по входу и весам
+	// первого слоя дифференцируется ещё раз, в том числе через CrossEntropyLoss.
+	target := &tensor.Tensor{Data: []float64{1, 0, 0, 1, 1, 0, 0, 1}, Shape: []int{4, 2}, Strides: []int{2, 1}}
+	build := func(e *autograd.Engine, in []*graph.Node) *graph.Node {
+		l1 := &Dense{weights: in[1], bias: in[2], inDim: 3, outDim: 5}
+		l2 := &Dense{weights: in[3], bias: in[4], inDim: 5, outDim: 2}
+		loss := e.CrossEntropyLoss(l2.Forward(e.Tanh(l1.Forward(in[0]))), target)
+		grads, err := e.Grad([]*graph.Node{loss}, in[:2])
+		if err != nil {
+			return nil
+		}
+		penalty := e.Add(e.Sum(e.Mul(grads[0], grads[0])), e.Sum(e.Mul(grads[1], grads[1])))
+		return e.Add(loss, penalty)
+	}
+	inputs := []*graph.Node{
+		graph.NewNode(tensor.Randn([]int{4, 3}, 31), nil, nil),
+		graph.NewNode(tensor.Randn([]int{3, 5}, 32), nil, nil),
+		graph.NewNode(tensor.Randn([]int{5}, 33), nil, nil),
+		graph.NewNode(tensor.Randn([]int{5, 2}, 34), nil, nil),
+		graph.NewNode(tensor.Randn([]int{2}, 35), nil, nil),
+	}
+	if !autograd.CheckGradientEngine(build, inputs, 1e-6, 1e-4) {
+		t.Error("Dense: second-order gradient check failed")
+	}
+
+	// Backward с CreateGraph(true) проходит через Dense и совпадает с обычным.
+	dense := NewDense(3, 2, initFuncFixed, ZeroInit())
+	x := graph.NewNode(tensor.Randn([]int{4, 3}, 36), nil, nil)
+	e := autograd.NewEngine()
+	e.Backward(e.Sum(e.Tanh(dense.Forward(x))), autograd.CreateGraph(true))
+	w := dense.Params()[0]
+	if w.GradNode == nil {
+		t.Fatal("GradNode весов не построен")
+	}
+	graphGrad := w.Grad.Clone()
+
+	for _, p := range append(dense.Params(), x) {
+		p.ZeroGrad()
+	}
+	e = autograd.NewEngine()
+	e.Backward(e.Sum(e.Tanh(dense.Forward(x))))
+	for i, v := range w.Grad.Data {
+		if diff := v - graphGrad.Data[i]; diff > 1e-12 || diff < -1e-12 {
+			t.Fatalf("grad[%d] = %v, с CreateGraph %v", i, v, graphGrad.Data[i])
+		}
+	}
+}
+
+func TestDenseJVPMatchesFiniteDifferences(t *testing.T) {
+	// JVP через двухслойную сеть на Dense по входу и параметрам обоих слоёв.
+	f := func(e *autograd.Engine, in []*graph.Node) *graph.Node {
+		l1 := &Dense{weights: in[1], bias: in[2], inDim: 3, outDim: 4}
+		l2 := &Dense{weights: in[3], bias: in[4], inDim: 4, outDim: 2}
+		return e.Softmax(l2.Forward(e.Tanh(l1.Forward(in[0]))))
+	}
+	for _, xShape := range [][]int{{5, 3}, {3}} {
+		shapes := [][]int{xShape, {3, 4}, {4}, {4, 2}, {2}}
+		inputs := make([]*tensor.Tensor, len(shapes))
+		tangents := make([]*tensor.Tensor, len(shapes))
+		for i, s := range shapes {
+			inputs[i] = tensor.Randn(s, int64(40+i))
+			tangents[i] = tensor.Randn(s, int64(50+i))
+		}
+		_, jvp, err := autograd.JVP(f, inputs, tangents)
+		if err != nil {
+			t.Fatal(err)
+		}
+		eval := func(sign float64) []float64 {
+			e := autograd.NewEngine()
+			in := make([]*graph.Node, len(inputs))
+			for i, x := range inputs {
+				shifted := x.Clone()
+				for k := range shifted.Data {
+					shifted.Data[k] += sign * 1e-6 * tangents[i].Data[k]
+				}
+				in[i] = e.RequireGrad(shifted)
+			}
+			return f(e, in).Value.Data
+		}
+		plus, minus := eval(1), eval(-1)
+		for k, v := range jvp.Data {
+			if fd := (plus[k] - minus[k]) / 2e-6; math.Abs(fd-v) > 1e-6 {
+				t.Fatalf("вход %v: JVP[%d] = %v, конечные разности %v", xShape, k, v, fd)
+			}
+		}
+	}
+}
+
+func TestDenseSparseInputMatchesDense(t *testing.T) {
+	xData := &tensor.Tensor{Data: []float64{
+		0, 2, 0,
+		1, 0, 0,
+	}, Shape: []int{2, 3}, Strides: []int{3, 1}}
+	xSparse, _ := tensor.DenseToCSR(xData)
+	grad := &tensor.Tensor{Data: []float64{1, -1, 0.5, 2}, Shape: []int{2, 2}, Strides: []int{2, 1}}
+
+	dense := NewDense(3, 2, initFuncFixed, initFuncFixed)
+	outDense := dense.Forward(graph.NewNode(xData, nil, nil))
+	outDense.Operation.Backward(grad)
+
+	sparse := NewDense(3, 2, initFuncFixed, initFuncFixed)
+	outSparse := sparse.Forward(graph.NewSparseNode(xSparse))
+	outSparse.Operation.Backward(grad)
+
+	for i := range outDense.Value.Data {
+		if outDense.Value.Data[i] != outSparse.Value.Data[i] {
+			t.Fatalf("output[%d]: dense %v, sparse %v", i, outDense.Value.Data[i], outSparse.Value.Data[i])
+		}
+	}
+	for p, param := range dense.Params() {
+		want, got := param.Grad.Data, sparse.Params()[p].Grad.Data
+		for i := range want {
+			if want[i] != got[i] {
+				t.Fatalf("param %d grad[%d]: dense %v, sparse %v", p, i, want[i], got[i])
+			}
+		}
+	}
+}
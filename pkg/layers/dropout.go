@@ -1,8 +1,7 @@
 package layers
 
 import (
-	"math/rand"
-
+	"github.com/Hirogava/Go-NN-Learn/pkg/autograd"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
 )
@@ -12,16 +11,42 @@ type Dropout struct {
 	rate     float64 // вероятность отключения нейрона (dropout rate)
 	training bool    // флаг режима обучения
 	mask     *tensor.Tensor
+	gen      *tensor.Generator // генератор масок; nil — tensor.DefaultGenerator()
 }
 
 // NewDropout создает новый слой Dropout
 // rate - вероятность отключения нейрона (например, 0.5 означает 50% нейронов будут отключены)
-func NewDropout(rate float64) *Dropout {
-	return &Dropout{
+// gen - необязательный генератор случайных чисел для масок
+func NewDropout(rate float64, gen ...*tensor.Generator) *Dropout {
+	d := &Dropout{
 		rate:     rate,
 		training: true,
 		mask:     nil,
 	}
+	if len(gen) > 0 {
+		d.gen = gen[0]
+	}
+	return d
+}
+
+// SetGenerator задаёт генератор случайных чисел для масок.
+func (d *Dropout) SetGenerator(gen *tensor.Generator) {
+	d.gen = gen
+}
+
+// SaveState возвращает позицию генератора масок (см. StatefulLayer).
+func (d *Dropout) SaveState() any {
+	if d.gen == nil {
+		return nil
+	}
+	return d.gen.Position()
+}
+
+// RestoreState восстанавливает позицию, сохранённую SaveState.
+func (d *Dropout) RestoreState(state any) {
+	if pos, ok := state.(uint64); ok && d.gen != nil {
+		d.gen.Seek(pos)
+	}
 }
 
 // SetTraining устанавливает режим работы слоя
@@ -39,9 +64,10 @@ func (d *Dropout) Forward(x *graph.Node) *graph.Node {
 	maskData := make([]float64, len(xTensor.Data))
 	keepProb := 1.0 - d.rate
 	scale := 1.0 / keepProb
+	g := generatorOf([]*tensor.Generator{d.gen})
 
 	for i := range maskData {
-		if rand.Float64() < keepProb {
+		if g.Float64() < keepProb {
 			maskData[i] = scale
 		} else {
 			maskData[i] = 0.0
@@ -100,6 +126,11 @@ func (op *dropoutOp) Backward(grad *tensor.Tensor) {
 	}
 }
 
+// BackwardGraph: маска постоянна, градиент умножается на неё же.
+func (op *dropoutOp) BackwardGraph(e *autograd.Engine, grad *graph.Node) []*graph.Node {
+	return []*graph.Node{e.Mul(grad, graph.NewNode(op.mask, nil, nil))}
+}
+
 func (d *Dropout) Train() {
 	d.training = true
 }
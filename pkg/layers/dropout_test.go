@@ -2,15 +2,15 @@ package layers
 
 import (
 	"fmt"
-	"math/rand"
 	"testing"
 
+	"github.com/Hirogava/Go-NN-Learn/pkg/autograd"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
 )
 
 func TestDropoutForward(t *testing.T) {
-	rand.Seed(42)
+	tensor.ManualSeed(42)
 
 	// Правильная инициализация ноды через конструктор
 	input := graph.NewNode(&tensor.Tensor{
@@ -63,7 +63,7 @@ func TestDropoutInference(t *testing.T) {
 }
 
 func TestDropoutBackward(t *testing.T) {
-	rand.Seed(42)
+	tensor.ManualSeed(42)
 
 	input := graph.NewNode(&tensor.Tensor{
 		Data:    []float64{1.0, 2.0, 3.0, 4.0},
@@ -95,11 +95,30 @@ func TestDropoutBackward(t *testing.T) {
 	fmt.Println("Gradient output (accumulated in input):", input.Grad.Data)
 }
 
+func TestDropoutSecondOrderGradientCheck(t *testing.T) {
+	// Маска фиксируется генератором с одним и тем же seed на каждом прогоне,
+	// поэтому штраф ‖∇L‖² по входу можно проверить численно.
+	build := func(e *autograd.Engine, in []*graph.Node) *graph.Node {
+		dropout := NewDropout(0.5, tensor.NewGenerator(7))
+		dropout.Train()
+		loss := e.Sum(e.Tanh(e.Mul(dropout.Forward(in[0]), in[0])))
+		grads, err := e.Grad([]*graph.Node{loss}, in)
+		if err != nil {
+			return nil
+		}
+		return e.Add(loss, e.Sum(e.Mul(grads[0], grads[0])))
+	}
+	inputs := []*graph.Node{graph.NewNode(tensor.Randn([]int{3, 4}, 51), nil, nil)}
+	if !autograd.CheckGradientEngine(build, inputs, 1e-6, 1e-4) {
+		t.Error("Dropout: second-order gradient check failed")
+	}
+}
+
 func TestDropoutRates(t *testing.T) {
 	rates := []float64{0.2, 0.5, 0.8}
 
 	for _, rate := range rates {
-		rand.Seed(42)
+		tensor.ManualSeed(42)
 
 		data := make([]float64, 1000)
 		for i := range data {
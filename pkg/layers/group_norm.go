@@ -84,13 +84,14 @@ func (gn *GroupNorm) forward2D(x *graph.Node) *graph.Node {
 	invStd := make([]float64, batchSize*gn.numGroups)
 
 	channelsPerGroup := gn.numChannels / gn.numGroups
+	means, variances := gn.groupStats(x.Value)
 
 	for n := range batchSize {
 		for g := range gn.numGroups {
 			cStart := g * channelsPerGroup
 			cEnd := cStart + channelsPerGroup
 
-			mean, variance := gn.stats2D(x.Value.Data, batchSize, numChannels, n, cStart, cEnd)
+			mean, variance := means[n*gn.numGroups+g], variances[n*gn.numGroups+g]
 			std := math.Sqrt(variance + gn.eps)
 			invStd[n*gn.numGroups+g] = 1.0 / std
 
@@ -128,13 +129,14 @@ func (gn *GroupNorm) forward4D(x *graph.Node) *graph.Node {
 	invStd := make([]float64, batchSize*gn.numGroups)
 
 	channelsPerGroup := gn.numChannels / gn.numGroups
+	means, variances := gn.groupStats(x.Value)
 
 	for n := range batchSize {
 		for g := range gn.numGroups {
 			cStart := g * channelsPerGroup
 			cEnd := cStart + channelsPerGroup
 
-			mean, variance := gn.stats4D(x.Value.Data, batchSize, numChannels, height, width, n, cStart, cEnd)
+			mean, variance := means[n*gn.numGroups+g], variances[n*gn.numGroups+g]
 			std := math.Sqrt(variance + gn.eps)
 			invStd[n*gn.numGroups+g] = 1.0 / std
 
@@ -170,48 +172,19 @@ func (gn *GroupNorm) validateChannels(numChannels int) {
 	}
 }
 
-func (gn *GroupNorm) stats2D(data []float64, batchSize, numChannels, n, cStart, cEnd int) (mean, variance float64) {
-	count := float64(cEnd - cStart)
-	sum := 0.0
-	for c := cStart; c < cEnd; c++ {
-		sum += data[n*numChannels+c]
+// groupStats вычисляет среднее и дисперсию для каждой пары (образец, группа).
+// Каналы группы (и их пространственные элементы) лежат в памяти подряд,
+// поэтому вход рассматривается как [N, G, -1] и редуцируется по последней оси.
+// Результаты упорядочены как n*numGroups + g.
+func (gn *GroupNorm) groupStats(x *tensor.Tensor) (means, variances []float64) {
+	batchSize := x.Shape[0]
+	grouped, err := tensor.Reshape(x, []int{batchSize, gn.numGroups, int(x.Size()) / (batchSize * gn.numGroups)})
+	if err != nil {
+		panic(err)
 	}
-	mean = sum / count
-
-	sumSq := 0.0
-	for c := cStart; c < cEnd; c++ {
-		diff := data[n*numChannels+c] - mean
-		sumSq += diff * diff
-	}
-	variance = sumSq / count
-	return mean, variance
-}
-
-func (gn *GroupNorm) stats4D(data []float64, batchSize, numChannels, height, width, n, cStart, cEnd int) (mean, variance float64) {
-	count := float64((cEnd - cStart) * height * width)
-	sum := 0.0
-	for c := cStart; c < cEnd; c++ {
-		for h := range height {
-			for w := range width {
-				idx := gn.index4D(batchSize, numChannels, height, width, n, c, h, w)
-				sum += data[idx]
-			}
-		}
-	}
-	mean = sum / count
-
-	sumSq := 0.0
-	for c := cStart; c < cEnd; c++ {
-		for h := range height {
-			for w := range width {
-				idx := gn.index4D(batchSize, numChannels, height, width, n, c, h, w)
-				diff := data[idx] - mean
-				sumSq += diff * diff
-			}
-		}
-	}
-	variance = sumSq / count
-	return mean, variance
+	mean, _ := tensor.MeanAxes(grouped, []int{2}, false)
+	variance, _ := tensor.VarAxes(grouped, []int{2}, false, 0)
+	return mean.Data, variance.Data
 }
 
 func (gn *GroupNorm) index4D(batchSize, numChannels, height, width, n, c, h, w int) int {
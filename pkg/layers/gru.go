@@ -69,7 +69,13 @@ func (g *GRU) ResetHiddenState() { g.hiddenState = nil }
 
 func (g *GRU) GetHiddenState() *tensor.Tensor { return g.hiddenState }
 
-func (g *GRU) SetHiddenState(h *tensor.Tensor) { g.hiddenState = h }
+func (g *GRU) SetHiddenState(h *tensor.Tensor) { g.hiddenState = tensor.DetachFromArena(h) }
+
+// SaveState возвращает скрытое состояние (см. StatefulLayer).
+func (g *GRU) SaveState() any { return g.hiddenState }
+
+// RestoreState восстанавливает состояние, сохранённое SaveState.
+func (g *GRU) RestoreState(state any) { g.hiddenState = state.(*tensor.Tensor) }
 
 func (g *GRU) GetInputSize() int  { return g.inputSize }
 
@@ -125,10 +131,9 @@ func (g *GRU) Forward(x *graph.Node) *graph.Node {
 
 	for t := 0; t < seqLen; t++ {
 		xt := extractSlice(x.Value, t)
-		xtM := matrix.TensorToMatrix(xt)
 
-		gatesIHM, _ := matrix.MatMul(xtM, wihT)
-		gatesIH, _ := tensor.Add(matrix.MatrixToTensor(gatesIHM), broadcastBias(bih, batchSize))
+		gatesIH, _ := tensor.MatMul(xt, matrix.MatrixToTensor(wihT))
+		gatesIH, _ = tensor.Add(gatesIH, broadcastBias(bih, batchSize))
 
 		hPrevM := matrix.TensorToMatrix(hPrev)
 		gatesHHM, _ := matrix.MatMul(hPrevM, whhT)
@@ -159,7 +164,7 @@ func (g *GRU) Forward(x *graph.Node) *graph.Node {
 		copySlice(outputVal, hT, t)
 	}
 
-	g.hiddenState = hPrev
+	g.hiddenState = tensor.DetachFromArena(hPrev)
 	if !g.training {
 		return graph.NewNode(outputVal, nil, nil)
 	}
@@ -221,9 +226,8 @@ func (op *gruOp) Backward(gradOutput *tensor.Tensor) {
 
 		dGatesIHM := matrix.TensorToMatrix(dGatesIH)
 		dGatesIHT, _ := matrix.Transposition(dGatesIHM)
-		xtM := matrix.TensorToMatrix(xt)
-		localDWih, _ := matrix.MatMul(dGatesIHT, xtM)
-		dWih, _ = tensor.Add(dWih, matrix.MatrixToTensor(localDWih))
+		localDWih, _ := tensor.MatMul(matrix.MatrixToTensor(dGatesIHT), xt)
+		dWih, _ = tensor.Add(dWih, localDWih)
 
 		dGatesHHM := matrix.TensorToMatrix(dGatesHH)
 		dGatesHHT, _ := matrix.Transposition(dGatesHHM)
@@ -0,0 +1,85 @@
+package layers
+
+import (
+	"slices"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+// ForwardPreHook вызывается перед Forward слоя l с его входом x.
+// Ненулевой результат заменяет вход.
+type ForwardPreHook func(l Layer, x *graph.Node) *graph.Node
+
+// ForwardHook вызывается после Forward слоя l со входом x и выходом out.
+// Ненулевой результат заменяет выход.
+type ForwardHook func(l Layer, x, out *graph.Node) *graph.Node
+
+// HookedLayer — обёртка слоя с forward-хуками. Params, Train и Eval
+// делегируются обёрнутому слою. Градиенты активаций можно перехватить,
+// зарегистрировав в ForwardHook хук на out (out.RegisterHook). С ареной
+// (Trainer.SetArena) значения шага после Backward переиспользуются, поэтому
+// сохраняемые тензоры выносите из неё через tensor.DetachFromArena.
+//
+//	conv := layers.WithHooks(layers.NewConv2D(...))
+//	h := conv.RegisterForwardHook(func(_ layers.Layer, _, out *graph.Node) *graph.Node {
+//	    featureMap = tensor.DetachFromArena(out.Value)
+//	    return nil
+//	})
+//	defer h.Remove()
+type HookedLayer struct {
+	Layer
+	pre  []*ForwardPreHook
+	post []*ForwardHook
+}
+
+// WithHooks оборачивает l в HookedLayer; уже обёрнутый слой возвращается как есть.
+func WithHooks(l Layer) *HookedLayer {
+	if h, ok := l.(*HookedLayer); ok {
+		return h
+	}
+	return &HookedLayer{Layer: l}
+}
+
+// Unwrap возвращает обёрнутый слой.
+func (h *HookedLayer) Unwrap() Layer {
+	return h.Layer
+}
+
+// RegisterForwardPreHook добавляет хук, вызываемый перед Forward.
+func (h *HookedLayer) RegisterForwardPreHook(hook ForwardPreHook) *graph.HookHandle {
+	p := &hook
+	h.pre = append(h.pre, p)
+	return graph.NewHookHandle(func() {
+		if i := slices.Index(h.pre, p); i >= 0 {
+			h.pre = slices.Delete(h.pre, i, i+1)
+		}
+	})
+}
+
+// RegisterForwardHook добавляет хук, вызываемый после Forward.
+func (h *HookedLayer) RegisterForwardHook(hook ForwardHook) *graph.HookHandle {
+	p := &hook
+	h.post = append(h.post, p)
+	return graph.NewHookHandle(func() {
+		if i := slices.Index(h.post, p); i >= 0 {
+			h.post = slices.Delete(h.post, i, i+1)
+		}
+	})
+}
+
+// Forward вызывает pre-хуки, Forward обёрнутого слоя и post-хуки
+// в порядке регистрации.
+func (h *HookedLayer) Forward(x *graph.Node) *graph.Node {
+	for _, hook := range slices.Clone(h.pre) {
+		if nx := (*hook)(h.Layer, x); nx != nil {
+			x = nx
+		}
+	}
+	out := h.Layer.Forward(x)
+	for _, hook := range slices.Clone(h.post) {
+		if nout := (*hook)(h.Layer, x, out); nout != nil {
+			out = nout
+		}
+	}
+	return out
+}
@@ -0,0 +1,58 @@
+package layers
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/autograd"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
+)
+
+func TestHookedLayer(t *testing.T) {
+	dense := NewDense(2, 2, initFuncFixed, ZeroInit())
+	l := WithHooks(dense)
+	if WithHooks(l) != l || l.Unwrap() != dense || len(l.Params()) != 2 {
+		t.Fatal("обёртка должна делегировать обёрнутому слою")
+	}
+
+	// pre-хук удваивает вход, post-хук сохраняет карту признаков и
+	// перехватывает её градиент.
+	var calls []string
+	var features, featureGrad *tensor.Tensor
+	pre := l.RegisterForwardPreHook(func(layer Layer, x *graph.Node) *graph.Node {
+		calls = append(calls, "pre")
+		if layer != Layer(dense) {
+			t.Fatal("хук должен получать обёрнутый слой")
+		}
+		return &graph.Node{Value: tensor.Apply(x.Value, func(v float64) float64 { return 2 * v })}
+	})
+	post := l.RegisterForwardHook(func(_ Layer, _, out *graph.Node) *graph.Node {
+		calls = append(calls, "post")
+		features = out.Value
+		out.RegisterHook(func(g *tensor.Tensor) *tensor.Tensor {
+			featureGrad = g.Clone()
+			return nil
+		})
+		return nil
+	})
+
+	// W = [[1 2] [3 4]], x = [1 1] → 2x·W = [8 12].
+	x := &graph.Node{Value: &tensor.Tensor{Data: []float64{1, 1}, Shape: []int{1, 2}, Strides: []int{2, 1}}}
+	e := autograd.NewEngine()
+	out := l.Forward(x)
+	e.Backward(e.Sum(e.Mul(out, out)))
+	if len(calls) != 2 || calls[0] != "pre" || calls[1] != "post" {
+		t.Fatalf("порядок хуков %v", calls)
+	}
+	if !slices.Equal(features.Data, []float64{8, 12}) || !slices.Equal(featureGrad.Data, []float64{16, 24}) {
+		t.Fatalf("карта признаков %v, её градиент %v", features.Data, featureGrad.Data)
+	}
+
+	pre.Remove()
+	post.Remove()
+	calls = nil
+	if got := l.Forward(x).Value.Data; !slices.Equal(got, []float64{4, 6}) || calls != nil {
+		t.Fatalf("после Remove хуки вызваны (%v), выход %v", calls, got)
+	}
+}
@@ -2,12 +2,23 @@ package layers
 
 import (
 	"math"
-	"math/rand"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
 )
 
 // Initializer - функция для инициализации слайса данных.
+// Случайные инициализаторы принимают необязательный генератор;
+// без него используется tensor.DefaultGenerator() на момент вызова.
 type Initializer func([]float64)
 
+// generatorOf возвращает переданный генератор или генератор по умолчанию.
+func generatorOf(gen []*tensor.Generator) *tensor.Generator {
+	if len(gen) > 0 && gen[0] != nil {
+		return gen[0]
+	}
+	return tensor.DefaultGenerator()
+}
+
 // ZeroInit инициализирует все значения нулями.
 func ZeroInit() Initializer {
 	return func(w []float64) {
@@ -19,52 +30,56 @@ func ZeroInit() Initializer {
 
 // HeInit (He Normal) - специально для слоев с активацией ReLU.
 // std = sqrt(2 / fanIn)
-func HeInit(fanIn int) Initializer {
+func HeInit(fanIn int, gen ...*tensor.Generator) Initializer {
 	std := math.Sqrt(2.0 / float64(fanIn))
 	return func(w []float64) {
+		g := generatorOf(gen)
 		for i := range w {
-			w[i] = rand.NormFloat64() * std
+			w[i] = g.NormFloat64() * std
 		}
 	}
 }
 
 // XavierInit (Xavier Normal) - для слоев с Sigmoid/Tanh.
 // std = sqrt(2 / (fanIn + fanOut))
-func XavierInit(fanIn, fanOut int) Initializer {
+func XavierInit(fanIn, fanOut int, gen ...*tensor.Generator) Initializer {
 	std := math.Sqrt(2.0 / float64(fanIn+fanOut))
 	return func(w []float64) {
+		g := generatorOf(gen)
 		for i := range w {
-			w[i] = rand.NormFloat64() * std
+			w[i] = g.NormFloat64() * std
 		}
 	}
 }
 
 // HeNormal - алиас для HeInit
-func HeNormal(fanIn int) Initializer {
-	return HeInit(fanIn)
+func HeNormal(fanIn int, gen ...*tensor.Generator) Initializer {
+	return HeInit(fanIn, gen...)
 }
 
 // HeUniform инициализация.
-func HeUniform(fanIn int) Initializer {
+func HeUniform(fanIn int, gen ...*tensor.Generator) Initializer {
 	limit := math.Sqrt(6.0 / float64(fanIn))
 	return func(w []float64) {
+		g := generatorOf(gen)
 		for i := range w {
-			w[i] = rand.Float64()*2*limit - limit
+			w[i] = g.Float64()*2*limit - limit
 		}
 	}
 }
 
 // XavierNormal - алиас для XavierInit
-func XavierNormal(fanIn, fanOut int) Initializer {
-	return XavierInit(fanIn, fanOut)
+func XavierNormal(fanIn, fanOut int, gen ...*tensor.Generator) Initializer {
+	return XavierInit(fanIn, fanOut, gen...)
 }
 
 // XavierUniform инициализация.
-func XavierUniform(fanIn, fanOut int) Initializer {
+func XavierUniform(fanIn, fanOut int, gen ...*tensor.Generator) Initializer {
 	limit := math.Sqrt(6.0 / float64(fanIn+fanOut))
 	return func(w []float64) {
+		g := generatorOf(gen)
 		for i := range w {
-			w[i] = rand.Float64()*2*limit - limit
+			w[i] = g.Float64()*2*limit - limit
 		}
 	}
 }
@@ -3,6 +3,8 @@ package layers
 import (
 	"math"
 	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
 )
 
 func TestInitializers(t *testing.T) {
@@ -57,3 +59,26 @@ func TestInitializers(t *testing.T) {
 		}
 	})
 }
+
+func TestInitializersWithGenerator(t *testing.T) {
+	a := make([]float64, 16)
+	b := make([]float64, 16)
+	HeUniform(8, tensor.NewGenerator(3))(a)
+	HeUniform(8, tensor.NewGenerator(3))(b)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("same generator seed gave different weights at %d: %v != %v", i, a[i], b[i])
+		}
+	}
+
+	// Без явного генератора используется tensor.DefaultGenerator()
+	tensor.ManualSeed(5)
+	XavierInit(4, 4)(a)
+	tensor.ManualSeed(5)
+	XavierInit(4, 4)(b)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("ManualSeed did not make XavierInit reproducible at %d", i)
+		}
+	}
+}
@@ -74,26 +74,13 @@ func (ln *LayerNorm) Forward(x *graph.Node) *graph.Node {
 		panic("Input features dimension doesn't match LayerNorm numFeatures")
 	}
 
-	mean := make([]float64, batchSize)
-	variance := make([]float64, batchSize)
+	meanT, _ := tensor.MeanAxes(x.Value, []int{1}, false)
+	varT, _ := tensor.VarAxes(x.Value, []int{1}, false, 0)
+	mean, variance := meanT.Data, varT.Data
 	xhat := tensor.Zeros(x.Value.Shape...)
 
-	d := float64(numFeatures)
 	for i := 0; i < batchSize; i++ {
-		var sum float64
 		base := i * numFeatures
-		for j := 0; j < numFeatures; j++ {
-			sum += x.Value.Data[base+j]
-		}
-		mean[i] = sum / d
-
-		var v float64
-		for j := 0; j < numFeatures; j++ {
-			diff := x.Value.Data[base+j] - mean[i]
-			v += diff * diff
-		}
-		variance[i] = v / d
-
 		std := math.Sqrt(variance[i] + ln.eps)
 		for j := 0; j < numFeatures; j++ {
 			idx := base + j
@@ -24,6 +24,20 @@ type Module interface {
 	Eval()
 }
 
+// Walk вызывает fn для l и всех вложенных слоёв: обёрнутого (Unwrap) и
+// слоёв модуля (Layers).
+func Walk(l Layer, fn func(Layer)) {
+	fn(l)
+	if w, ok := l.(interface{ Unwrap() Layer }); ok {
+		Walk(w.Unwrap(), fn)
+	}
+	if m, ok := l.(interface{ Layers() []Layer }); ok {
+		for _, inner := range m.Layers() {
+			Walk(inner, fn)
+		}
+	}
+}
+
 type TrainEval interface {
 	Train()
 	Eval()
@@ -174,8 +174,17 @@ func (l *LSTM) ResetState() {
 func (l *LSTM) GetHiddenState() *tensor.Tensor { return l.hiddenState }
 func (l *LSTM) GetCellState() *tensor.Tensor   { return l.cellState }
 
-func (l *LSTM) SetHiddenState(h *tensor.Tensor) { l.hiddenState = h }
-func (l *LSTM) SetCellState(c *tensor.Tensor)   { l.cellState = c }
+func (l *LSTM) SetHiddenState(h *tensor.Tensor) { l.hiddenState = tensor.DetachFromArena(h) }
+func (l *LSTM) SetCellState(c *tensor.Tensor)   { l.cellState = tensor.DetachFromArena(c) }
+
+// SaveState возвращает скрытое состояние и состояние ячейки (см. StatefulLayer).
+func (l *LSTM) SaveState() any { return [2]*tensor.Tensor{l.hiddenState, l.cellState} }
+
+// RestoreState восстанавливает состояние, сохранённое SaveState.
+func (l *LSTM) RestoreState(state any) {
+	s := state.([2]*tensor.Tensor)
+	l.hiddenState, l.cellState = s[0], s[1]
+}
 
 func (l *LSTM) GetInputSize() int     { return l.inputSize }
 func (l *LSTM) GetHiddenSize() int    { return l.hiddenSize }
@@ -260,8 +269,8 @@ func (l *LSTM) runDirection(
 			hPrev, cPrev = step.h, step.c
 		}
 		if !l.bidirectional || dir == l.fwd {
-			l.hiddenState = hPrev
-			l.cellState = cPrev
+			l.hiddenState = tensor.DetachFromArena(hPrev)
+			l.cellState = tensor.DetachFromArena(cPrev)
 		}
 	} else {
 		for t := seqLen - 1; t >= 0; t-- {
@@ -299,8 +308,8 @@ func (l *LSTM) runDirectionInference(
 			hPrev, cPrev = step.h, step.c
 		}
 		if !l.bidirectional || dir == l.fwd {
-			l.hiddenState = hPrev
-			l.cellState = cPrev
+			l.hiddenState = tensor.DetachFromArena(hPrev)
+			l.cellState = tensor.DetachFromArena(cPrev)
 		}
 	} else {
 		for t := seqLen - 1; t >= 0; t-- {
@@ -451,9 +460,8 @@ func lstmLinear(x, hPrev *tensor.Tensor, Wx, Wh, b *graph.Node, batchSize int) *
 	wxT, _ := matrix.Transposition(matrix.TensorToMatrix(Wx.Value))
 	whT, _ := matrix.Transposition(matrix.TensorToMatrix(Wh.Value))
 
-	xM := matrix.TensorToMatrix(x)
-	ixM, _ := matrix.MatMul(xM, wxT)
-	ix := matrix.MatrixToTensor(ixM)
+	// x может быть view на шаг последовательности — tensor.MatMul читает его по strides
+	ix, _ := tensor.MatMul(x, matrix.MatrixToTensor(wxT))
 
 	hM := matrix.TensorToMatrix(hPrev)
 	hhM, _ := matrix.MatMul(hM, whT)
@@ -469,40 +477,36 @@ func lstmAccumGateGrad(
 	Wx, Wh, _ *graph.Node,
 	dWx, dWh, db *tensor.Tensor,
 ) {
-	dPreM := matrix.TensorToMatrix(dPre)
-	dPreT, _ := matrix.Transposition(dPreM)
-
-	xM := matrix.TensorToMatrix(x)
-	localWx, _ := matrix.MatMul(dPreT, xM)
-	accumulateTensor(dWx, matrix.MatrixToTensor(localWx))
-
-	hM := matrix.TensorToMatrix(hPrev)
-	localWh, _ := matrix.MatMul(dPreT, hM)
-	accumulateTensor(dWh, matrix.MatrixToTensor(localWh))
+	// dW += dPre^T * x; x может быть view на шаг последовательности
+	lstmGemm(true, false, dPre, x, dWx)
+	lstmGemm(true, false, dPre, hPrev, dWh)
 
 	accumulateTensor(db, sumAlongBatch(dPre))
 }
 
+// lstmGemm накапливает c += op(a)·op(b); ошибка формы — паника, как в Dense.
+func lstmGemm(transA, transB bool, a, b, c *tensor.Tensor) {
+	if err := tensor.Gemm(transA, transB, 1, a, b, 1, c); err != nil {
+		panic("Matrix multiplication failed: " + err.Error())
+	}
+}
+
 func accumulateTensor(dst, src *tensor.Tensor) {
 	for i := range dst.Data {
 		dst.Data[i] += src.Data[i]
 	}
 }
 
+// lstmAddDhPrev накапливает acc += dPre * Wh на месте и возвращает acc.
 func lstmAddDhPrev(acc, dPre *tensor.Tensor, Wh *graph.Node) *tensor.Tensor {
-	dPreM := matrix.TensorToMatrix(dPre)
-	whM := matrix.TensorToMatrix(Wh.Value)
-	dhM, _ := matrix.MatMul(dPreM, whM)
-	part, _ := tensor.Add(acc, matrix.MatrixToTensor(dhM))
-	return part
+	lstmGemm(false, false, dPre, Wh.Value, acc)
+	return acc
 }
 
+// lstmAddDxGate накапливает acc += dPre * Wx на месте и возвращает acc.
 func lstmAddDxGate(acc, dPre *tensor.Tensor, Wx *graph.Node) *tensor.Tensor {
-	dPreM := matrix.TensorToMatrix(dPre)
-	wxM := matrix.TensorToMatrix(Wx.Value)
-	dxM, _ := matrix.MatMul(dPreM, wxM)
-	part, _ := tensor.Add(acc, matrix.MatrixToTensor(dxM))
-	return part
+	lstmGemm(false, false, dPre, Wx.Value, acc)
+	return acc
 }
 
 func sigmoidTensor(t *tensor.Tensor) *tensor.Tensor {
@@ -513,10 +517,6 @@ func tanhTensor(t *tensor.Tensor) *tensor.Tensor {
 	return tensor.Apply(t, math.Tanh)
 }
 
-func sigmoid(x float64) float64 {
-	return 1.0 / (1.0 + math.Exp(-x))
-}
-
 func sigmoidGradFromOutput(out, dOut *tensor.Tensor) *tensor.Tensor {
 	deriv, _ := tensor.Mul(out, tensor.Apply(out, func(v float64) float64 { return 1 - v }))
 	res, _ := tensor.Mul(deriv, dOut)
@@ -539,21 +539,13 @@ func cloneTensor(t *tensor.Tensor) *tensor.Tensor {
 }
 
 func copySliceOffset(dest *tensor.Tensor, src *tensor.Tensor, step, featureOffset int) {
-	b, s, f := dest.Shape[0], dest.Shape[1], dest.Shape[2]
-	h := src.Shape[1]
-	for i := 0; i < b; i++ {
-		destStart := i*s*f + step*f + featureOffset
-		copy(dest.Data[destStart:destStart+h], src.Data[i*h:(i+1)*h])
-	}
+	_ = tensor.CopyInto(extractSliceOffset(dest, step, featureOffset, src.Shape[1]), src)
 }
 
+// extractSliceOffset возвращает view [batch, hiddenSize] на признаки
+// [featureOffset, featureOffset+hiddenSize) шага step без копирования.
 func extractSliceOffset(t *tensor.Tensor, step, featureOffset, hiddenSize int) *tensor.Tensor {
-	b, s, f := t.Shape[0], t.Shape[1], t.Shape[2]
-	_ = f
-	res := make([]float64, b*hiddenSize)
-	for i := 0; i < b; i++ {
-		srcStart := i*s*f + step*f + featureOffset
-		copy(res[i*hiddenSize:(i+1)*hiddenSize], t.Data[srcStart:srcStart+hiddenSize])
-	}
-	return &tensor.Tensor{Data: res, Shape: []int{b, hiddenSize}, Strides: []int{hiddenSize, 1}}
+	view := extractSlice(t, step)
+	view, _ = tensor.Narrow(view, 1, featureOffset, hiddenSize)
+	return view
 }
@@ -75,8 +75,9 @@ func (m *MaxPooling2D) Forward(x *graph.Node) *graph.Node {
 
 	out := &graph.Node{
 		Value: output,
+		Scope: x.Scope,
 	}
-	if autograd.GradEnabled() {
+	if autograd.GradEnabledFor(x) {
 		out.Operation = &maxPooling2DOp{
 			x:          x,
 			inputShape: append([]int{}, x.Value.Shape...),
@@ -146,7 +146,17 @@ func (r *RNN) GetHiddenState() *tensor.Tensor {
 
 // SetHiddenState устанавливает скрытое состояние (полезно для инициализации).
 func (r *RNN) SetHiddenState(h *tensor.Tensor) {
-	r.hiddenState = h
+	r.hiddenState = tensor.DetachFromArena(h)
+}
+
+// SaveState возвращает скрытое состояние (см. StatefulLayer).
+func (r *RNN) SaveState() any {
+	return r.hiddenState
+}
+
+// RestoreState восстанавливает состояние, сохранённое SaveState.
+func (r *RNN) RestoreState(state any) {
+	r.hiddenState = state.(*tensor.Tensor)
 }
 
 // GetInputSize возвращает размер входного вектора.
@@ -210,11 +220,9 @@ func (r *RNN) Forward(x *graph.Node) *graph.Node {
 
 	for t := 0; t < seqLen; t++ {
 		xt := extractSlice(x.Value, t)
-		xtM := matrix.TensorToMatrix(xt)
 
-		// ih = xt * Wih^T
-		ihM, _ := matrix.MatMul(xtM, wihT)
-		ih := matrix.MatrixToTensor(ihM)
+		// ih = xt * Wih^T (xt — view, tensor.MatMul читает его по strides)
+		ih, _ := tensor.MatMul(xt, matrix.MatrixToTensor(wihT))
 
 		// hh = h_{t-1} * Whh^T
 		hPrevM := matrix.TensorToMatrix(hPrev)
@@ -236,7 +244,7 @@ func (r *RNN) Forward(x *graph.Node) *graph.Node {
 		copySlice(outputVal, h_t, t)
 	}
 
-	r.hiddenState = hPrev
+	r.hiddenState = tensor.DetachFromArena(hPrev)
 	if !r.training {
 		// В inference режиме не строим граф и не держим состояние для BPTT.
 		return graph.NewNode(outputVal, nil, nil)
@@ -286,9 +294,8 @@ func (op *rnnOp) Backward(gradOutput *tensor.Tensor) {
 		dtanhT, _ := matrix.Transposition(dtanhM)
 
 		xt := extractSlice(op.x.Value, t)
-		xtM := matrix.TensorToMatrix(xt)
-		localDWih, _ := matrix.MatMul(dtanhT, xtM)
-		dWih, _ = tensor.Add(dWih, matrix.MatrixToTensor(localDWih))
+		localDWih, _ := tensor.MatMul(matrix.MatrixToTensor(dtanhT), xt)
+		dWih, _ = tensor.Add(dWih, localDWih)
 
 		hPrevM := matrix.TensorToMatrix(op.hStates[t])
 		localDWhh, _ := matrix.MatMul(dtanhT, hPrevM)
@@ -326,23 +333,17 @@ func accumulate(n *graph.Node, g *tensor.Tensor) {
 	n.Grad, _ = tensor.Add(n.Grad, g)
 }
 
+// extractSlice возвращает view [batch, features] на шаг step тензора [batch, seq, features].
+// Данные не копируются.
 func extractSlice(t *tensor.Tensor, step int) *tensor.Tensor {
-	b, s, f := t.Shape[0], t.Shape[1], t.Shape[2]
-	res := make([]float64, b*f)
-	for i := 0; i < b; i++ {
-		srcStart := i*s*f + step*f
-		// Указываем [цель : до куда] и [источник : до куда]
-		copy(res[i*f:(i+1)*f], t.Data[srcStart:srcStart+f])
-	}
-	return &tensor.Tensor{Data: res, Shape: []int{b, f}, Strides: []int{f, 1}}
+	view, _ := tensor.Narrow(t, 1, step, 1)
+	view, _ = tensor.Squeeze(view, 1)
+	return view
 }
 
+// copySlice записывает src [batch, features] в шаг step тензора dest [batch, seq, features].
 func copySlice(dest *tensor.Tensor, src *tensor.Tensor, step int) {
-	b, s, f := dest.Shape[0], dest.Shape[1], dest.Shape[2]
-	for i := 0; i < b; i++ {
-		destStart := i*s*f + step*f
-		copy(dest.Data[destStart:destStart+f], src.Data[i*f:(i+1)*f])
-	}
+	_ = tensor.CopyInto(extractSlice(dest, step), src)
 }
 
 func sumAlongBatch(t *tensor.Tensor) *tensor.Tensor {
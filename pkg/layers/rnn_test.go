@@ -3,6 +3,7 @@ package layers
 import (
 	"testing"
 
+	"github.com/Hirogava/Go-NN-Learn/pkg/autograd"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
 )
@@ -90,3 +91,53 @@ func TestRNN_ForwardBackward(t *testing.T) {
 		t.Log("Successfully computed input gradient")
 	}
 }
+
+// TestRecurrentStateSurvivesArenaReset проверяет, что скрытое состояние,
+// которое слой хранит между шагами, не выделено из арены: после её сброса
+// следующий шаг переиспользует буферы и не должен затереть его.
+func TestRecurrentStateSurvivesArenaReset(t *testing.T) {
+	type recurrent interface {
+		Forward(x *graph.Node) *graph.Node
+		GetHiddenState() *tensor.Tensor
+	}
+	cases := map[string]func() recurrent{
+		"RNN":  func() recurrent { return NewRNN(4, 5, 1, false, simpleInit) },
+		"LSTM": func() recurrent { return NewLSTM(4, 5, false, simpleInit) },
+		"GRU":  func() recurrent { return NewGRU(4, 5, simpleInit) },
+	}
+	x := tensor.Randn([]int{2, 3, 4}, 61)
+	run := func(l recurrent, arena *tensor.Arena) (held *tensor.Tensor, heldData []float64, last []float64) {
+		for step := 0; step < 3; step++ {
+			ctx := autograd.NewGraph()
+			if arena != nil {
+				ctx.UseArena(arena)
+			}
+			out := l.Forward(ctx.Input(x))
+			if step == 0 {
+				held = l.GetHiddenState()
+				heldData = append([]float64{}, held.Data...)
+			}
+			last = append([]float64{}, out.Value.Data...)
+			ctx.Backward(ctx.Engine().Sum(out))
+		}
+		return held, heldData, last
+	}
+	for name, newLayer := range cases {
+		arena := tensor.NewArena()
+		held, heldData, got := run(newLayer(), arena)
+		_, _, want := run(newLayer(), nil)
+		if arena.Stats().Reused == 0 {
+			t.Fatalf("%s: арена не переиспользовала буферы между шагами", name)
+		}
+		for i, v := range heldData {
+			if held.Data[i] != v {
+				t.Fatalf("%s: состояние шага 0 изменилось после сброса арены: [%d] %v -> %v", name, i, v, held.Data[i])
+			}
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("%s: выход с ареной [%d] = %v, без неё %v", name, i, got[i], want[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+)
+
+// Cholesky вычисляет нижнетреугольную матрицу L с положительной диагональю,
+// такую что A = L·Lᵀ. Используется только нижний треугольник A.
+// Возвращает ошибку, если матрица не положительно определена.
+func Cholesky(a *tensor.Tensor) (*tensor.Tensor, error) {
+	data, n, err := denseSquare(a)
+	if err != nil {
+		return nil, err
+	}
+	l := make([]float64, n*n)
+	for j := 0; j < n; j++ {
+		d := data[j*n+j]
+		for k := 0; k < j; k++ {
+			d -= l[j*n+k] * l[j*n+k]
+		}
+		if d <= 0 || math.IsNaN(d) {
+			return nil, fmt.Errorf("матрица не положительно определена")
+		}
+		d = math.Sqrt(d)
+		l[j*n+j] = d
+		for i := j + 1; i < n; i++ {
+			s := data[i*n+j]
+			for k := 0; k < j; k++ {
+				s -= l[i*n+k] * l[j*n+k]
+			}
+			l[i*n+j] = s / d
+		}
+	}
+	return fromDense(l, n, n), nil
+}
+
+// CholeskySolve решает A·X = B по готовому множителю Холецкого L (A = L·Lᵀ).
+// B может быть вектором [n] или матрицей [n, k].
+func CholeskySolve(l, b *tensor.Tensor) (*tensor.Tensor, error) {
+	ld, n, err := denseSquare(l)
+	if err != nil {
+		return nil, err
+	}
+	if len(b.Shape) == 0 || len(b.Shape) > 2 || b.Shape[0] != n {
+		return nil, fmt.Errorf("форма правой части %v несовместима с матрицей %v", b.Shape, l.Shape)
+	}
+	out := b.Clone()
+	x := out.Data
+	nrhs := len(x) / n
+	for i := 0; i < n; i++ {
+		for k := 0; k < i; k++ {
+			for j := 0; j < nrhs; j++ {
+				x[i*nrhs+j] -= ld[i*n+k] * x[k*nrhs+j]
+			}
+		}
+		for j := 0; j < nrhs; j++ {
+			x[i*nrhs+j] /= ld[i*n+i]
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		for k := i + 1; k < n; k++ {
+			for j := 0; j < nrhs; j++ {
+				x[i*nrhs+j] -= ld[k*n+i] * x[k*nrhs+j]
+			}
+		}
+		for j := 0; j < nrhs; j++ {
+			x[i*nrhs+j] /= ld[i*n+i]
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,91 @@
+package linalg
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+)
+
+// Eigh вычисляет спектральное разложение симметричной матрицы A = V·diag(w)·Vᵀ
+// циклическим методом Якоби. Собственные значения w ([n]) упорядочены по возрастанию,
+// столбцы vectors ([n, n]) — соответствующие ортонормированные собственные векторы.
+// Несимметричный вход предварительно симметризуется: (A + Aᵀ)/2.
+func Eigh(a *tensor.Tensor) (values, vectors *tensor.Tensor, err error) {
+	data, n, err := denseSquare(a)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			s := (data[i*n+j] + data[j*n+i]) / 2
+			data[i*n+j], data[j*n+i] = s, s
+		}
+	}
+	v := eye(n)
+
+	for sweep := 0; sweep < maxSweeps; sweep++ {
+		off, total := 0.0, 0.0
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				x := data[i*n+j] * data[i*n+j]
+				total += x
+				if i != j {
+					off += x
+				}
+			}
+		}
+		if off <= eps*eps*total {
+			break
+		}
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				apq := data[p*n+q]
+				if apq == 0 {
+					continue
+				}
+				theta := (data[q*n+q] - data[p*n+p]) / (2 * apq)
+				t := sign(theta) / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				rotateCols(data, n, n, p, q, c, s)
+				rotateRows(data, n, p, q, c, s)
+				rotateCols(v, n, n, p, q, c, s)
+			}
+		}
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return data[order[i]*n+order[i]] < data[order[j]*n+order[j]] })
+	w := make([]float64, n)
+	vecs := make([]float64, n*n)
+	for c, src := range order {
+		w[c] = data[src*n+src]
+		for r := 0; r < n; r++ {
+			vecs[r*n+c] = v[r*n+src]
+		}
+	}
+	return &tensor.Tensor{Data: w, Shape: []int{n}, Strides: []int{1}}, fromDense(vecs, n, n), nil
+}
+
+// rotateCols заменяет столбцы p и q матрицы rows×cols на
+// (c·col_p - s·col_q, s·col_p + c·col_q).
+func rotateCols(data []float64, rows, cols, p, q int, c, s float64) {
+	for k := 0; k < rows; k++ {
+		xp, xq := data[k*cols+p], data[k*cols+q]
+		data[k*cols+p] = c*xp - s*xq
+		data[k*cols+q] = s*xp + c*xq
+	}
+}
+
+// rotateRows — то же для строк p и q квадратной матрицы n×n.
+func rotateRows(data []float64, n, p, q int, c, s float64) {
+	for k := 0; k < n; k++ {
+		xp, xq := data[p*n+k], data[q*n+k]
+		data[p*n+k] = c*xp - s*xq
+		data[q*n+k] = s*xp + c*xq
+	}
+}
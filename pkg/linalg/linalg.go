@@ -0,0 +1,73 @@
+// Package linalg реализует плотную линейную алгебру на чистом Go (без cgo):
+// разложения LU, QR, Холецкого, симметричное спектральное разложение, тонкое SVD,
+// а также Solve, Inverse, Det/LogDet и Pinv.
+//
+// Все функции принимают двумерные тензоры (views допускаются) и возвращают новые
+// плотные тензоры; входные данные не изменяются.
+package linalg
+
+import (
+	"fmt"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+)
+
+// eps — машинная точность float64, используется в критериях сходимости и отсечения.
+const eps = 2.220446049250313e-16
+
+// maxSweeps ограничивает число проходов итерационных методов Якоби.
+const maxSweeps = 100
+
+// dense копирует двумерный тензор в плотный row-major срез.
+func dense(a *tensor.Tensor) ([]float64, int, int, error) {
+	if a == nil || len(a.Shape) != 2 {
+		return nil, 0, 0, fmt.Errorf("ожидается двумерный тензор")
+	}
+	c := a.Clone()
+	return c.Data, a.Shape[0], a.Shape[1], nil
+}
+
+// denseSquare копирует квадратную матрицу.
+func denseSquare(a *tensor.Tensor) ([]float64, int, error) {
+	data, rows, cols, err := dense(a)
+	if err != nil {
+		return nil, 0, err
+	}
+	if rows != cols {
+		return nil, 0, fmt.Errorf("ожидается квадратная матрица, получена форма %v", a.Shape)
+	}
+	return data, rows, nil
+}
+
+// fromDense оборачивает row-major данные в тензор [rows, cols].
+func fromDense(data []float64, rows, cols int) *tensor.Tensor {
+	return &tensor.Tensor{Data: data, Shape: []int{rows, cols}, Strides: []int{cols, 1}}
+}
+
+// eye возвращает единичную матрицу n×n в виде среза.
+func eye(n int) []float64 {
+	out := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		out[i*n+i] = 1
+	}
+	return out
+}
+
+// transpose транспонирует row-major матрицу rows×cols.
+func transpose(data []float64, rows, cols int) []float64 {
+	out := make([]float64, len(data))
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			out[j*rows+i] = data[i*cols+j]
+		}
+	}
+	return out
+}
+
+// sign возвращает знак x, считая sign(0) = 1 (так выбираются отражения и вращения).
+func sign(x float64) float64 {
+	if x < 0 {
+		return -1
+	}
+	return 1
+}
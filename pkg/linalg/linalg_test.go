@@ -0,0 +1,282 @@
+package linalg
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+)
+
+const tol = 1e-9
+
+func mat(rows, cols int, data ...float64) *tensor.Tensor {
+	return &tensor.Tensor{Data: data, Shape: []int{rows, cols}, Strides: []int{cols, 1}}
+}
+
+func mustMatMul(t *testing.T, a, b *tensor.Tensor) *tensor.Tensor {
+	t.Helper()
+	c, err := tensor.MatMul(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func transposed(a *tensor.Tensor) *tensor.Tensor {
+	at, _ := tensor.Transpose(a)
+	return at.Contiguous()
+}
+
+func assertClose(t *testing.T, name string, got, want *tensor.Tensor) {
+	t.Helper()
+	got, want = got.Contiguous(), want.Contiguous()
+	if len(got.Data) != len(want.Data) {
+		t.Fatalf("%s: size %d, want %d", name, len(got.Data), len(want.Data))
+	}
+	for i := range want.Data {
+		if math.Abs(got.Data[i]-want.Data[i]) > tol*math.Max(1, math.Abs(want.Data[i])) {
+			t.Fatalf("%s: data[%d] = %v, want %v", name, i, got.Data[i], want.Data[i])
+		}
+	}
+}
+
+func identity(n int) *tensor.Tensor {
+	return fromDense(eye(n), n, n)
+}
+
+// spd строит симметричную положительно определённую матрицу X·Xᵀ + n·I.
+func spd(n int, seed int64) *tensor.Tensor {
+	x := tensor.Randn([]int{n, n}, seed)
+	xxt, _ := tensor.MatMul(x, transposed(x))
+	for i := 0; i < n; i++ {
+		xxt.Set(xxt.At(i, i)+float64(n), i, i)
+	}
+	return xxt
+}
+
+func TestLUSolveInverseDet(t *testing.T) {
+	a := mat(3, 3,
+		0, 2, 1,
+		1, 1, 0,
+		3, 0, 1)
+
+	p, l, u, err := LU(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertClose(t, "P·A = L·U", mustMatMul(t, p, a), mustMatMul(t, l, u))
+
+	det, _ := Det(a)
+	if math.Abs(det-(-5)) > tol {
+		t.Fatalf("Det = %v, want -5", det)
+	}
+	sign, logAbs, _ := LogDet(a)
+	if sign != -1 || math.Abs(logAbs-math.Log(5)) > tol {
+		t.Fatalf("LogDet = (%v, %v), want (-1, log 5)", sign, logAbs)
+	}
+
+	b := &tensor.Tensor{Data: []float64{3, 2, 4}, Shape: []int{3}, Strides: []int{1}}
+	x, err := Solve(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, _ := tensor.Reshape(x, []int{3, 1})
+	assertClose(t, "A·x = b", mustMatMul(t, a, col), mat(3, 1, 3, 2, 4))
+
+	inv, err := Inverse(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertClose(t, "A·A⁻¹", mustMatMul(t, a, inv), identity(3))
+
+	// Транспонированный view обрабатывается как обычная матрица
+	at, _ := tensor.Transpose(a)
+	dt, _ := Det(at)
+	if math.Abs(dt-det) > tol {
+		t.Fatalf("Det(Aᵀ) = %v, want %v", dt, det)
+	}
+
+	singular := mat(2, 2, 1, 2, 2, 4)
+	if _, err := Inverse(singular); err == nil {
+		t.Fatal("expected singular matrix error")
+	}
+	if d, _ := Det(singular); d != 0 {
+		t.Fatalf("Det(singular) = %v, want 0", d)
+	}
+	if _, err := Det(mat(2, 3, 1, 2, 3, 4, 5, 6)); err == nil {
+		t.Fatal("expected non-square error")
+	}
+}
+
+func TestQR(t *testing.T) {
+	for _, shape := range [][2]int{{4, 3}, {3, 3}, {2, 4}} {
+		a := tensor.Randn([]int{shape[0], shape[1]}, 7)
+		q, r, err := QR(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		k := min(shape[0], shape[1])
+		assertClose(t, "Q·R", mustMatMul(t, q, r), a)
+		assertClose(t, "QᵀQ", mustMatMul(t, transposed(q), q), identity(k))
+		for i := 0; i < k; i++ {
+			for j := 0; j < i; j++ {
+				if r.At(i, j) != 0 {
+					t.Fatalf("R[%d][%d] = %v, want 0", i, j, r.At(i, j))
+				}
+			}
+		}
+	}
+}
+
+func TestCholesky(t *testing.T) {
+	a := spd(4, 11)
+	l, err := Cholesky(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertClose(t, "L·Lᵀ", mustMatMul(t, l, transposed(l)), a)
+
+	b := tensor.Randn([]int{4, 2}, 12)
+	x, err := CholeskySolve(l, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertClose(t, "A·X = B", mustMatMul(t, a, x), b)
+
+	if _, err := Cholesky(mat(2, 2, 1, 2, 2, 1)); err == nil {
+		t.Fatal("expected not positive definite error")
+	}
+}
+
+func TestEigh(t *testing.T) {
+	a := mat(2, 2, 2, 1, 1, 2)
+	w, v, err := Eigh(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertClose(t, "eigenvalues", w, &tensor.Tensor{Data: []float64{1, 3}, Shape: []int{2}, Strides: []int{1}})
+
+	a = spd(5, 21)
+	w, v, err = Eigh(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i < 5; i++ {
+		if w.Data[i] < w.Data[i-1] {
+			t.Fatalf("eigenvalues not ascending: %v", w.Data)
+		}
+	}
+	assertClose(t, "VᵀV", mustMatMul(t, transposed(v), v), identity(5))
+	vw := v.Clone()
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			vw.Set(vw.At(i, j)*w.Data[j], i, j)
+		}
+	}
+	assertClose(t, "V·diag(w)·Vᵀ", mustMatMul(t, vw, transposed(v)), a)
+}
+
+func TestSVDAndPinv(t *testing.T) {
+	for _, shape := range [][2]int{{5, 3}, {3, 5}, {4, 4}} {
+		a := tensor.Randn([]int{shape[0], shape[1]}, 31)
+		u, s, vt, err := SVD(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		k := min(shape[0], shape[1])
+		for i := 1; i < k; i++ {
+			if s.Data[i] > s.Data[i-1] || s.Data[i] < 0 {
+				t.Fatalf("singular values not descending: %v", s.Data)
+			}
+		}
+		assertClose(t, "UᵀU", mustMatMul(t, transposed(u), u), identity(k))
+		assertClose(t, "Vᵀ·V", mustMatMul(t, vt, transposed(vt)), identity(k))
+		us := u.Clone()
+		for i := 0; i < shape[0]; i++ {
+			for j := 0; j < k; j++ {
+				us.Set(us.At(i, j)*s.Data[j], i, j)
+			}
+		}
+		assertClose(t, "U·S·Vᵀ", mustMatMul(t, us, vt), a)
+
+		p, err := Pinv(a, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertClose(t, "A·A⁺·A", mustMatMul(t, mustMatMul(t, a, p), a), a)
+	}
+
+	// Ранг 1: U дополняется до ортонормированного базиса
+	a := mat(3, 2, 1, 2, 2, 4, 3, 6)
+	u, s, _, _ := SVD(a)
+	if s.Data[1] > 1e-12 {
+		t.Fatalf("rank-1 matrix: s = %v", s.Data)
+	}
+	assertClose(t, "rank-1 UᵀU", mustMatMul(t, transposed(u), u), identity(2))
+	p, _ := Pinv(a, 0)
+	assertClose(t, "rank-1 A·A⁺·A", mustMatMul(t, mustMatMul(t, a, p), a), a)
+}
+
+func TestMatrixWrappers(t *testing.T) {
+	m := &tensor.Matrix{Data: []float64{4, 7, 2, 6}, Rows: 2, Cols: 2}
+	inv, err := InverseMatrix(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []float64{0.6, -0.7, -0.2, 0.4}
+	for i := range want {
+		if math.Abs(inv.Data[i]-want[i]) > tol {
+			t.Fatalf("InverseMatrix[%d] = %v, want %v", i, inv.Data[i], want[i])
+		}
+	}
+	if d, _ := DetMatrix(m); math.Abs(d-10) > tol {
+		t.Fatalf("DetMatrix = %v, want 10", d)
+	}
+	if sign, logAbs, _ := LogDetMatrix(m); sign != 1 || math.Abs(logAbs-math.Log(10)) > tol {
+		t.Fatalf("LogDetMatrix = %v, %v", sign, logAbs)
+	}
+
+	mul := func(a, b *tensor.Matrix) *tensor.Matrix {
+		t.Helper()
+		c, err := a.MatMul(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	p, l, u, err := LUMatrix(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertClose(t, "LUMatrix P·A = L·U", mul(p, m).AsTensor(), mul(l, u).AsTensor())
+	q, r, err := QRMatrix(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertClose(t, "QRMatrix Q·R", mul(q, r).AsTensor(), m.AsTensor())
+	us, s, vt, err := SVDMatrix(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sv := &tensor.Matrix{Data: make([]float64, 4), Rows: 2, Cols: 2}
+	sv.Set(0, 0, s[0])
+	sv.Set(1, 1, s[1])
+	assertClose(t, "SVDMatrix U·S·Vᵀ", mul(mul(us, sv), vt).AsTensor(), m.AsTensor())
+
+	sym := &tensor.Matrix{Data: []float64{2, 1, 1, 2}, Rows: 2, Cols: 2}
+	w, v, err := EighMatrix(sym)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(w[0]-1) > tol || math.Abs(w[1]-3) > tol {
+		t.Fatalf("EighMatrix values = %v, want [1 3]", w)
+	}
+	av := mul(sym, v)
+	for j, wj := range w {
+		for i := 0; i < 2; i++ {
+			if math.Abs(av.At(i, j)-wj*v.At(i, j)) > tol {
+				t.Fatalf("EighMatrix: A·v%d != w%d·v%d", j, j, j)
+			}
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+)
+
+// luFactors — упакованное LU-разложение с частичным выбором ведущего элемента:
+// под диагональю lu хранится L (с единичной диагональю), на диагонали и выше — U.
+// Строка i матрицы P·A — строка piv[i] исходной матрицы.
+type luFactors struct {
+	lu       []float64
+	n        int
+	piv      []int
+	sign     float64 // знак перестановки, ±1
+	singular bool
+}
+
+func luDecompose(data []float64, n int) *luFactors {
+	f := &luFactors{lu: data, n: n, piv: make([]int, n), sign: 1}
+	for i := range f.piv {
+		f.piv[i] = i
+	}
+	a := f.lu
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(a[i*n+k]) > math.Abs(a[p*n+k]) {
+				p = i
+			}
+		}
+		if p != k {
+			for j := 0; j < n; j++ {
+				a[k*n+j], a[p*n+j] = a[p*n+j], a[k*n+j]
+			}
+			f.piv[k], f.piv[p] = f.piv[p], f.piv[k]
+			f.sign = -f.sign
+		}
+		pivot := a[k*n+k]
+		if pivot == 0 {
+			f.singular = true
+			continue
+		}
+		for i := k + 1; i < n; i++ {
+			m := a[i*n+k] / pivot
+			a[i*n+k] = m
+			if m == 0 {
+				continue
+			}
+			for j := k + 1; j < n; j++ {
+				a[i*n+j] -= m * a[k*n+j]
+			}
+		}
+	}
+	return f
+}
+
+// solve решает A·X = B для B размера n×nrhs (row-major) и возвращает X.
+func (f *luFactors) solve(b []float64, nrhs int) ([]float64, error) {
+	if f.singular {
+		return nil, fmt.Errorf("матрица вырождена")
+	}
+	n, a := f.n, f.lu
+	x := make([]float64, n*nrhs)
+	for i, p := range f.piv {
+		copy(x[i*nrhs:(i+1)*nrhs], b[p*nrhs:(p+1)*nrhs])
+	}
+	// Прямой ход: L·Y = P·B
+	for i := 0; i < n; i++ {
+		for k := 0; k < i; k++ {
+			if m := a[i*n+k]; m != 0 {
+				for j := 0; j < nrhs; j++ {
+					x[i*nrhs+j] -= m * x[k*nrhs+j]
+				}
+			}
+		}
+	}
+	// Обратный ход: U·X = Y
+	for i := n - 1; i >= 0; i-- {
+		for k := i + 1; k < n; k++ {
+			if u := a[i*n+k]; u != 0 {
+				for j := 0; j < nrhs; j++ {
+					x[i*nrhs+j] -= u * x[k*nrhs+j]
+				}
+			}
+		}
+		d := a[i*n+i]
+		for j := 0; j < nrhs; j++ {
+			x[i*nrhs+j] /= d
+		}
+	}
+	return x, nil
+}
+
+// LU вычисляет разложение P·A = L·U квадратной матрицы с частичным выбором
+// ведущего элемента: P — матрица перестановки, L — нижнетреугольная с единичной
+// диагональю, U — верхнетреугольная. Для вырожденной матрицы разложение
+// существует, но U имеет нули на диагонали.
+func LU(a *tensor.Tensor) (p, l, u *tensor.Tensor, err error) {
+	data, n, err := denseSquare(a)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	f := luDecompose(data, n)
+	pd, ld, ud := make([]float64, n*n), make([]float64, n*n), make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		pd[i*n+f.piv[i]] = 1
+		for j := 0; j < n; j++ {
+			switch {
+			case j < i:
+				ld[i*n+j] = f.lu[i*n+j]
+			case j == i:
+				ld[i*n+j] = 1
+				ud[i*n+j] = f.lu[i*n+j]
+			default:
+				ud[i*n+j] = f.lu[i*n+j]
+			}
+		}
+	}
+	return fromDense(pd, n, n), fromDense(ld, n, n), fromDense(ud, n, n), nil
+}
+
+// Solve решает систему A·X = B. B может быть вектором [n] или матрицей [n, k];
+// результат имеет форму B.
+func Solve(a, b *tensor.Tensor) (*tensor.Tensor, error) {
+	data, n, err := denseSquare(a)
+	if err != nil {
+		return nil, err
+	}
+	nrhs := 1
+	switch {
+	case len(b.Shape) == 1 && b.Shape[0] == n:
+	case len(b.Shape) == 2 && b.Shape[0] == n:
+		nrhs = b.Shape[1]
+	default:
+		return nil, fmt.Errorf("форма правой части %v несовместима с матрицей %v", b.Shape, a.Shape)
+	}
+	x, err := luDecompose(data, n).solve(b.Clone().Data, nrhs)
+	if err != nil {
+		return nil, err
+	}
+	out := b.Clone()
+	copy(out.Data, x)
+	return out, nil
+}
+
+// Inverse возвращает обратную матрицу.
+func Inverse(a *tensor.Tensor) (*tensor.Tensor, error) {
+	data, n, err := denseSquare(a)
+	if err != nil {
+		return nil, err
+	}
+	x, err := luDecompose(data, n).solve(eye(n), n)
+	if err != nil {
+		return nil, err
+	}
+	return fromDense(x, n, n), nil
+}
+
+// Det вычисляет определитель квадратной матрицы через LU-разложение.
+func Det(a *tensor.Tensor) (float64, error) {
+	data, n, err := denseSquare(a)
+	if err != nil {
+		return 0, err
+	}
+	f := luDecompose(data, n)
+	det := f.sign
+	for i := 0; i < n; i++ {
+		det *= f.lu[i*n+i]
+	}
+	return det, nil
+}
+
+// LogDet вычисляет знак и логарифм модуля определителя: det = sign·exp(logAbs).
+// Устойчив к переполнению для больших матриц. Для вырожденной матрицы
+// возвращает sign=0 и logAbs=-Inf.
+func LogDet(a *tensor.Tensor) (sign, logAbs float64, err error) {
+	data, n, err := denseSquare(a)
+	if err != nil {
+		return 0, 0, err
+	}
+	f := luDecompose(data, n)
+	if f.singular {
+		return 0, math.Inf(-1), nil
+	}
+	sign = f.sign
+	for i := 0; i < n; i++ {
+		d := f.lu[i*n+i]
+		if d < 0 {
+			sign = -sign
+		}
+		logAbs += math.Log(math.Abs(d))
+	}
+	return sign, logAbs, nil
+}
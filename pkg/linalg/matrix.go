@@ -0,0 +1,97 @@
+package linalg
+
+import "github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+
+// Обёртки для tensor.Matrix. Matrix оборачивается в тензор без копирования,
+// результаты возвращаются как новые матрицы.
+
+func matrixTensor(m *tensor.Matrix) *tensor.Tensor {
+	return &tensor.Tensor{Data: m.Data, Shape: []int{m.Rows, m.Cols}, Strides: []int{m.Cols, 1}}
+}
+
+func tensorMatrix(t *tensor.Tensor) *tensor.Matrix {
+	return &tensor.Matrix{Data: t.Data, Rows: t.Shape[0], Cols: t.Shape[1]}
+}
+
+// SolveMatrix решает A·X = B для матриц.
+func SolveMatrix(a, b *tensor.Matrix) (*tensor.Matrix, error) {
+	x, err := Solve(matrixTensor(a), matrixTensor(b))
+	if err != nil {
+		return nil, err
+	}
+	return tensorMatrix(x), nil
+}
+
+// InverseMatrix возвращает обратную матрицу.
+func InverseMatrix(a *tensor.Matrix) (*tensor.Matrix, error) {
+	inv, err := Inverse(matrixTensor(a))
+	if err != nil {
+		return nil, err
+	}
+	return tensorMatrix(inv), nil
+}
+
+// DetMatrix вычисляет определитель матрицы.
+func DetMatrix(a *tensor.Matrix) (float64, error) {
+	return Det(matrixTensor(a))
+}
+
+// CholeskyMatrix вычисляет множитель Холецкого L (A = L·Lᵀ).
+func CholeskyMatrix(a *tensor.Matrix) (*tensor.Matrix, error) {
+	l, err := Cholesky(matrixTensor(a))
+	if err != nil {
+		return nil, err
+	}
+	return tensorMatrix(l), nil
+}
+
+// PinvMatrix вычисляет псевдообратную матрицу (см. Pinv).
+func PinvMatrix(a *tensor.Matrix, rcond float64) (*tensor.Matrix, error) {
+	p, err := Pinv(matrixTensor(a), rcond)
+	if err != nil {
+		return nil, err
+	}
+	return tensorMatrix(p), nil
+}
+
+// LUMatrix вычисляет разложение P·A = L·U (см. LU).
+func LUMatrix(a *tensor.Matrix) (p, l, u *tensor.Matrix, err error) {
+	pt, lt, ut, err := LU(matrixTensor(a))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return tensorMatrix(pt), tensorMatrix(lt), tensorMatrix(ut), nil
+}
+
+// QRMatrix вычисляет разложение A = Q·R (см. QR).
+func QRMatrix(a *tensor.Matrix) (q, r *tensor.Matrix, err error) {
+	qt, rt, err := QR(matrixTensor(a))
+	if err != nil {
+		return nil, nil, err
+	}
+	return tensorMatrix(qt), tensorMatrix(rt), nil
+}
+
+// EighMatrix вычисляет собственные значения (по возрастанию) и собственные
+// векторы-столбцы симметричной матрицы (см. Eigh).
+func EighMatrix(a *tensor.Matrix) (values []float64, vectors *tensor.Matrix, err error) {
+	w, v, err := Eigh(matrixTensor(a))
+	if err != nil {
+		return nil, nil, err
+	}
+	return w.Data, tensorMatrix(v), nil
+}
+
+// SVDMatrix вычисляет тонкое сингулярное разложение A = U·diag(s)·Vᵀ (см. SVD).
+func SVDMatrix(a *tensor.Matrix) (u *tensor.Matrix, s []float64, vt *tensor.Matrix, err error) {
+	ut, st, vtt, err := SVD(matrixTensor(a))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return tensorMatrix(ut), st.Data, tensorMatrix(vtt), nil
+}
+
+// LogDetMatrix возвращает знак и логарифм модуля определителя (см. LogDet).
+func LogDetMatrix(a *tensor.Matrix) (sign, logAbs float64, err error) {
+	return LogDet(matrixTensor(a))
+}
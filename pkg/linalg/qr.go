@@ -0,0 +1,77 @@
+package linalg
+
+import (
+	"math"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+)
+
+// QR вычисляет тонкое QR-разложение A = Q·R матрицы m×n отражениями Хаусхолдера.
+// При k = min(m, n) Q имеет форму [m, k] с ортонормированными столбцами,
+// R — верхнетреугольная формы [k, n].
+func QR(a *tensor.Tensor) (q, r *tensor.Tensor, err error) {
+	data, m, n, err := dense(a)
+	if err != nil {
+		return nil, nil, err
+	}
+	k := min(m, n)
+	vs := make([][]float64, k)
+	for j := 0; j < k; j++ {
+		// Вектор отражения, переводящего столбец j (от строки j) в кратное e_j.
+		v := make([]float64, m-j)
+		norm := 0.0
+		for i := j; i < m; i++ {
+			v[i-j] = data[i*n+j]
+			norm += v[i-j] * v[i-j]
+		}
+		norm = math.Sqrt(norm)
+		if norm == 0 {
+			continue
+		}
+		v[0] += sign(v[0]) * norm
+		vn := 0.0
+		for _, x := range v {
+			vn += x * x
+		}
+		vn = math.Sqrt(vn)
+		for i := range v {
+			v[i] /= vn
+		}
+		vs[j] = v
+		reflect(v, data, j, j, m, n)
+	}
+
+	rd := make([]float64, k*n)
+	for i := 0; i < k; i++ {
+		copy(rd[i*n+i:(i+1)*n], data[i*n+i:(i+1)*n])
+	}
+	// Q = H_0·H_1·…·H_{k-1} применяется к первым k столбцам единичной матрицы.
+	qd := make([]float64, m*k)
+	for i := 0; i < k; i++ {
+		qd[i*k+i] = 1
+	}
+	for j := k - 1; j >= 0; j-- {
+		if vs[j] != nil {
+			reflect(vs[j], qd, j, 0, m, k)
+		}
+	}
+	return fromDense(qd, m, k), fromDense(rd, k, n), nil
+}
+
+// reflect применяет отражение H = I - 2·v·vᵀ к строкам row0.. и столбцам col0..
+// матрицы data (rows×cols, row-major).
+func reflect(v, data []float64, row0, col0, rows, cols int) {
+	for c := col0; c < cols; c++ {
+		dot := 0.0
+		for i := row0; i < rows; i++ {
+			dot += v[i-row0] * data[i*cols+c]
+		}
+		if dot == 0 {
+			continue
+		}
+		dot *= 2
+		for i := row0; i < rows; i++ {
+			data[i*cols+c] -= dot * v[i-row0]
+		}
+	}
+}
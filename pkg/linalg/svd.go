@@ -0,0 +1,164 @@
+package linalg
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+)
+
+// SVD вычисляет тонкое сингулярное разложение A = U·diag(s)·Vᵀ матрицы m×n
+// односторонним методом Якоби. При k = min(m, n): u — [m, k], s — [k]
+// (по убыванию, неотрицательные), vt — [k, n]. Столбцы u и строки vt ортонормированы.
+func SVD(a *tensor.Tensor) (u, s, vt *tensor.Tensor, err error) {
+	data, m, n, err := dense(a)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if m < n {
+		// Aᵀ = U'·S·V'ᵀ  =>  A = V'·S·U'ᵀ
+		ut, st, vtt, err := SVD(fromDense(transpose(data, m, n), n, m))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		u, _ = tensor.Transpose(vtt)
+		vt, _ = tensor.Transpose(ut)
+		return u.Contiguous(), st, vt.Contiguous(), nil
+	}
+
+	// Столбцы data ортогонализуются вращениями; те же вращения накапливаются в v.
+	v := eye(n)
+	for sweep := 0; sweep < maxSweeps; sweep++ {
+		rotated := false
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				alpha, beta, gamma := 0.0, 0.0, 0.0
+				for i := 0; i < m; i++ {
+					xp, xq := data[i*n+p], data[i*n+q]
+					alpha += xp * xp
+					beta += xq * xq
+					gamma += xp * xq
+				}
+				if gamma == 0 || math.Abs(gamma) <= eps*math.Sqrt(alpha*beta) {
+					continue
+				}
+				rotated = true
+				zeta := (beta - alpha) / (2 * gamma)
+				t := sign(zeta) / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+				c := 1 / math.Sqrt(1+t*t)
+				rotateCols(data, m, n, p, q, c, t*c)
+				rotateCols(v, n, n, p, q, c, t*c)
+			}
+		}
+		if !rotated {
+			break
+		}
+	}
+
+	sv := make([]float64, n)
+	for j := 0; j < n; j++ {
+		norm := 0.0
+		for i := 0; i < m; i++ {
+			norm += data[i*n+j] * data[i*n+j]
+		}
+		sv[j] = math.Sqrt(norm)
+	}
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return sv[order[i]] > sv[order[j]] })
+
+	ud := make([]float64, m*n)
+	vtd := make([]float64, n*n)
+	sd := make([]float64, n)
+	for c, src := range order {
+		sd[c] = sv[src]
+		for i := 0; i < m; i++ {
+			if sd[c] > 0 {
+				ud[i*n+c] = data[i*n+src] / sd[c]
+			}
+		}
+		for i := 0; i < n; i++ {
+			vtd[c*n+i] = v[i*n+src]
+		}
+	}
+	completeBasis(ud, m, n, sd)
+	return fromDense(ud, m, n), &tensor.Tensor{Data: sd, Shape: []int{n}, Strides: []int{1}}, fromDense(vtd, n, n), nil
+}
+
+// completeBasis заменяет столбцы u, соответствующие нулевым сингулярным числам,
+// единичными векторами, ортогональными остальным (Грам — Шмидт).
+func completeBasis(u []float64, m, k int, s []float64) {
+	col := make([]float64, m)
+	for c := 0; c < k; c++ {
+		if s[c] > 0 {
+			continue
+		}
+		for e := 0; e < m; e++ {
+			for i := range col {
+				col[i] = 0
+			}
+			col[e] = 1
+			for o := 0; o < k; o++ {
+				if o == c || (s[o] == 0 && o > c) {
+					continue
+				}
+				dot := 0.0
+				for i := 0; i < m; i++ {
+					dot += u[i*k+o] * col[i]
+				}
+				for i := 0; i < m; i++ {
+					col[i] -= dot * u[i*k+o]
+				}
+			}
+			norm := 0.0
+			for _, x := range col {
+				norm += x * x
+			}
+			if norm > 0.5 {
+				norm = math.Sqrt(norm)
+				for i := 0; i < m; i++ {
+					u[i*k+c] = col[i] / norm
+				}
+				break
+			}
+		}
+	}
+}
+
+// Pinv вычисляет псевдообратную матрицу Мура — Пенроуза через SVD.
+// Сингулярные числа не больше rcond·max(s) считаются нулевыми;
+// при rcond <= 0 используется порог max(m, n)·eps.
+func Pinv(a *tensor.Tensor, rcond float64) (*tensor.Tensor, error) {
+	u, s, vt, err := SVD(a)
+	if err != nil {
+		return nil, err
+	}
+	m, n, k := a.Shape[0], a.Shape[1], len(s.Data)
+	if rcond <= 0 {
+		rcond = float64(max(m, n)) * eps
+	}
+	cutoff := 0.0
+	if k > 0 {
+		cutoff = rcond * s.Data[0]
+	}
+	// A⁺ = V·diag(1/s)·Uᵀ
+	out := make([]float64, n*m)
+	for c := 0; c < k; c++ {
+		if s.Data[c] <= cutoff {
+			continue
+		}
+		inv := 1 / s.Data[c]
+		for i := 0; i < n; i++ {
+			vi := vt.Data[c*n+i] * inv
+			if vi == 0 {
+				continue
+			}
+			for j := 0; j < m; j++ {
+				out[i*m+j] += vi * u.Data[j*k+c]
+			}
+		}
+	}
+	return fromDense(out, n, m), nil
+}
@@ -109,7 +109,7 @@ func TestMatMulErrors(t *testing.T) {
 // Бенчмарки производительности
 
 func BenchmarkMatMulSmall(b *testing.B) {
-	// Маленькие матрицы (используется matMulSimple)
+	// Маленькие матрицы (Gemm без распараллеливания)
 	x1 := &tensor.Matrix{
 		Data: make([]float64, 32*32),
 		Rows: 32,
@@ -133,7 +133,7 @@ func BenchmarkMatMulSmall(b *testing.B) {
 }
 
 func BenchmarkMatMulMedium(b *testing.B) {
-	// Средние матрицы (используется matMulBlocked)
+	// Средние матрицы (блочное ядро Gemm)
 	x1 := &tensor.Matrix{
 		Data: make([]float64, 128*256),
 		Rows: 128,
@@ -158,7 +158,7 @@ func BenchmarkMatMulMedium(b *testing.B) {
 }
 
 func BenchmarkMatMulLarge(b *testing.B) {
-	// Большие матрицы (используется matMulParallelBlocked)
+	// Большие матрицы (параллельное ядро Gemm)
 	// Типичный размер для нейронных сетей: batch_size=128, features=784
 	x1 := &tensor.Matrix{
 		Data: make([]float64, 128*784),
@@ -257,9 +257,9 @@ func TestMatMulAlgorithmsConsistency(t *testing.T) {
 	sizes := []struct {
 		rows1, cols1, rows2, cols2 int
 	}{
-		{32, 32, 32, 32},   // Маленькая - matMulSimple
-		{100, 100, 100, 100}, // Средняя - matMulBlocked
-		{200, 300, 300, 200}, // Большая - matMulParallelBlocked
+		{32, 32, 32, 32},   // Маленькая - последовательное ядро
+		{100, 100, 100, 100}, // Средняя - блочное ядро
+		{200, 300, 300, 200}, // Большая - параллельное ядро
 	}
 
 	for _, size := range sizes {
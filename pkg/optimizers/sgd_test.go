@@ -4,6 +4,8 @@ import (
 	"math"
 	"testing"
 
+	"github.com/Hirogava/Go-NN-Learn/pkg/autograd"
+	"github.com/Hirogava/Go-NN-Learn/pkg/layers"
 	"github.com/Hirogava/Go-NN-Learn/pkg/optimizers"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
@@ -281,3 +283,65 @@ func BenchmarkSGDZeroGrad(b *testing.B) {
 	}
 }
 
+
+// TestSGDOfFloat32TrainingMatchesFloat64 обучает одну и ту же двухслойную сеть
+// во float64 (Dense, Engine, SGD) и во float32 (DenseOf, TypedEngine, SGDOf):
+// loss и веса должны совпадать с точностью float32.
+func TestSGDOfFloat32TrainingMatchesFloat64(t *testing.T) {
+	xData := []float64{0, 0, 0, 1, 1, 0, 1, 1, 0.5, 0.2, 0.1, 0.9}
+	yData := []float64{1, 0, 0, 1, 0, 1, 1, 0, 1, 0, 0, 1}
+	const steps = 200
+
+	gen64, gen32 := tensor.NewGenerator(11), tensor.NewGenerator(11)
+
+	// float64
+	d1 := layers.NewDense(2, 8, layers.XavierUniform(2, 8, gen64), layers.ZeroInit())
+	d2 := layers.NewDense(8, 2, layers.XavierUniform(8, 2, gen64), layers.ZeroInit())
+	params := append(d1.Params(), d2.Params()...)
+	sgd := optimizers.NewSGD(0.5)
+	x64 := &tensor.Tensor{Data: xData, Shape: []int{6, 2}, Strides: []int{2, 1}}
+	y64 := &tensor.Tensor{Data: yData, Shape: []int{6, 2}, Strides: []int{2, 1}}
+	var loss64 float64
+	for i := 0; i < steps; i++ {
+		e := autograd.NewEngine()
+		loss := e.CrossEntropyLoss(d2.Forward(e.ReLU(d1.Forward(graph.NewNode(x64, nil, nil)))), y64)
+		e.Backward(loss)
+		sgd.Step(params)
+		sgd.ZeroGrad(params)
+		loss64 = loss.Value.Data[0]
+	}
+
+	// float32
+	f1 := layers.NewDenseOf[float32](2, 8, layers.XavierUniform(2, 8, gen32), layers.ZeroInit())
+	f2 := layers.NewDenseOf[float32](8, 2, layers.XavierUniform(8, 2, gen32), layers.ZeroInit())
+	params32 := append(f1.Params(), f2.Params()...)
+	sgd32 := optimizers.NewSGDOf[float32](0.5)
+	x32 := tensor.ToFloat32(x64)
+	y32 := tensor.ToFloat32(y64)
+	var first32, loss32 float32
+	for i := 0; i < steps; i++ {
+		e := autograd.NewTypedEngine[float32]()
+		loss := e.CrossEntropyLoss(f2.Forward(e.ReLU(f1.Forward(e.RequireGrad(x32)))), y32)
+		e.Backward(loss)
+		sgd32.Step(params32)
+		sgd32.ZeroGrad(params32)
+		loss32 = loss.Value.Data[0]
+		if i == 0 {
+			first32 = loss32
+		}
+	}
+
+	if loss32 >= first32/4 {
+		t.Fatalf("float32-обучение не сходится: loss %v -> %v", first32, loss32)
+	}
+	if math.Abs(float64(loss32)-loss64) > 1e-3 {
+		t.Fatalf("loss float32 %v, float64 %v", loss32, loss64)
+	}
+	for i, p := range params {
+		for j, v := range p.Value.Data {
+			if got := float64(params32[i].Value.Data[j]); math.Abs(got-v) > 1e-3 {
+				t.Fatalf("параметр %d[%d]: float32 %v, float64 %v", i, j, got, v)
+			}
+		}
+	}
+}
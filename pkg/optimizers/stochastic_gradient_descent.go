@@ -4,6 +4,7 @@ import (
 	"runtime"
 	"sync"
 
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
 )
 
@@ -106,3 +107,38 @@ func (s *StochasticGradientDescent) ZeroGrad(params []*graph.Node) {
 func (s *StochasticGradientDescent) SetLearningRate(lr float64) {
 	s.LearningRate = lr
 }
+
+// SGDOf — SGD для параметров graph.NodeOf[T], например слоёв layers.DenseOf
+// во float32. Обновление выполняется в типе T: param -= lr * grad.
+type SGDOf[T tensor.Float] struct {
+	LearningRate float64 // Скорость обучения
+}
+
+// NewSGDOf создает SGD для параметров типа T с заданным learning rate.
+func NewSGDOf[T tensor.Float](lr float64) *SGDOf[T] {
+	return &SGDOf[T]{LearningRate: lr}
+}
+
+// Step обновляет параметры: param.Value -= lr * param.Grad
+func (s *SGDOf[T]) Step(params []*graph.NodeOf[T]) {
+	for _, p := range params {
+		if p.Grad == nil {
+			continue
+		}
+		if err := tensor.AccumulateIntoOf(p.Value, p.Grad, -T(s.LearningRate)); err != nil {
+			panic("optimizers: " + err.Error())
+		}
+	}
+}
+
+// ZeroGrad обнуляет градиенты всех параметров
+func (s *SGDOf[T]) ZeroGrad(params []*graph.NodeOf[T]) {
+	for _, p := range params {
+		p.ZeroGrad()
+	}
+}
+
+// SetLearningRate устанавливает новый Learning Rate.
+func (s *SGDOf[T]) SetLearningRate(lr float64) {
+	s.LearningRate = lr
+}
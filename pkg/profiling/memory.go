@@ -0,0 +1,75 @@
+package profiling
+
+import "fmt"
+
+// MemoryStats — статистика памяти шагов, выделенной из арены тензоров
+// (tensor.Arena, см. autograd.GraphContext.UseArena). Размеры в байтах.
+type MemoryStats struct {
+	// Количество выданных буферов
+	Allocs int64
+
+	// Из них переиспользовано без новой аллокации
+	Reused int64
+
+	// Пик одновременно выданной памяти
+	PeakBytes int64
+
+	// Память, удерживаемая ареной
+	ReservedBytes int64
+
+	// Количество записанных шагов
+	Steps int64
+}
+
+// Счетчики Statistics, в которых хранится MemoryStats
+const (
+	CounterArenaAllocs        = "arena.allocs"
+	CounterArenaReused        = "arena.reused"
+	CounterArenaPeakBytes     = "arena.peak_bytes"
+	CounterArenaReservedBytes = "arena.reserved_bytes"
+	CounterArenaSteps         = "arena.steps"
+)
+
+// RecordMemory добавляет статистику одного шага: количества суммируются,
+// пиковая и удерживаемая память берутся максимумом по шагам
+func (s *Statistics) RecordMemory(m MemoryStats) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Counters[CounterArenaAllocs] += m.Allocs
+	s.Counters[CounterArenaReused] += m.Reused
+	if m.PeakBytes > s.Counters[CounterArenaPeakBytes] {
+		s.Counters[CounterArenaPeakBytes] = m.PeakBytes
+	}
+	if m.ReservedBytes > s.Counters[CounterArenaReservedBytes] {
+		s.Counters[CounterArenaReservedBytes] = m.ReservedBytes
+	}
+	s.Counters[CounterArenaSteps]++
+}
+
+// Memory возвращает накопленную статистику памяти арены
+func (s *Statistics) Memory() MemoryStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return MemoryStats{
+		Allocs:        s.Counters[CounterArenaAllocs],
+		Reused:        s.Counters[CounterArenaReused],
+		PeakBytes:     s.Counters[CounterArenaPeakBytes],
+		ReservedBytes: s.Counters[CounterArenaReservedBytes],
+		Steps:         s.Counters[CounterArenaSteps],
+	}
+}
+
+// printMemoryReport выводит статистику арены, если она записывалась
+func (s *Statistics) printMemoryReport() {
+	m := s.Memory()
+	if m.Steps == 0 {
+		return
+	}
+	fmt.Println("\n--- Arena Memory ---")
+	fmt.Printf("Steps = %d\n", m.Steps)
+	fmt.Printf("Allocs = %d (reused %d)\n", m.Allocs, m.Reused)
+	fmt.Printf("Peak = %v MB\n", bToMb(uint64(m.PeakBytes)))
+	fmt.Printf("Reserved = %v MB\n", bToMb(uint64(m.ReservedBytes)))
+}
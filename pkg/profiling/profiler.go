@@ -277,6 +277,8 @@ func (p *Profiler) PrintReport() {
 	fmt.Printf("Sys = %v MB\n", bToMb(m.Sys))
 	fmt.Printf("NumGC = %v\n", m.NumGC)
 
+	p.Stats.printMemoryReport()
+
 	fmt.Println("\n======================")
 }
 
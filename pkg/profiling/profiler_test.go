@@ -159,6 +159,25 @@ func TestStatistics(t *testing.T) {
 	}
 }
 
+func TestStatisticsRecordMemory(t *testing.T) {
+	stats := NewStatistics()
+
+	stats.RecordMemory(MemoryStats{Allocs: 10, PeakBytes: 800, ReservedBytes: 1000})
+	stats.RecordMemory(MemoryStats{Allocs: 10, Reused: 9, PeakBytes: 640, ReservedBytes: 1080})
+
+	m := stats.Memory()
+	if m.Steps != 2 || m.Allocs != 20 || m.Reused != 9 {
+		t.Errorf("Unexpected arena counters: %+v", m)
+	}
+	// Пики берутся максимумом по шагам, а не суммой
+	if m.PeakBytes != 800 || m.ReservedBytes != 1080 {
+		t.Errorf("Unexpected arena memory: %+v", m)
+	}
+	if stats.GetCounter(CounterArenaAllocs) != 20 {
+		t.Errorf("Expected arena allocs in counters, got %d", stats.GetCounter(CounterArenaAllocs))
+	}
+}
+
 func TestOperationMetricAverages(t *testing.T) {
 	metric := &OperationMetric{
 		Name:         "test",
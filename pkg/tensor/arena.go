@@ -0,0 +1,221 @@
+package tensor
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Arena — арена буферов с временем жизни в один шаг обучения.
+// Арена не глобальная: результаты операций (Add, Mul, MatMul, Apply,
+// Contiguous и т. д.) берут память из неё, только если хотя бы один операнд
+// выделен ареной в текущем шаге или привязан к нему через Attach. Вычисления,
+// не связанные с тензорами шага (в том числе в других горутинах), арену не
+// трогают. Reset возвращает все выданные за шаг буферы разом — вызывать
+// PutTensor для каждого промежуточного тензора не нужно. Буферы
+// переиспользуются по точному размеру, как в TensorPool, поэтому со второго
+// шага с теми же формами аллокаций нет.
+//
+// После Reset тензоры, выданные ареной, недействительны: то, что нужно
+// сохранить, следует скопировать через Detach или Clone.
+// Методы безопасны для конкурентного вызова.
+type Arena struct {
+	mu     sync.Mutex
+	free   map[int][][]float64
+	used   [][]float64
+	owned  map[*float64]struct{}
+	region *arenaRegion
+	live   int64
+	step   ArenaStats
+	total  ArenaStats
+}
+
+// arenaRegion — шаг арены, к которому привязан тензор (Tensor.region).
+// Reset закрывает шаг, и операции над его тензорами снова выделяют память
+// обычным образом.
+type arenaRegion struct {
+	arena  *Arena
+	closed atomic.Bool
+}
+
+// ArenaStats — статистика арены. Размеры в байтах.
+type ArenaStats struct {
+	Allocs        int64 // число выданных буферов
+	Reused        int64 // из них взято из свободного списка без аллокации
+	PeakBytes     int64 // максимум одновременно выданной памяти
+	ReservedBytes int64 // память, удерживаемая ареной (выданная и свободная)
+	Resets        int64 // число завершённых шагов
+}
+
+// NewArena создаёт пустую арену.
+func NewArena() *Arena {
+	a := &Arena{
+		free:  make(map[int][][]float64),
+		owned: make(map[*float64]struct{}),
+	}
+	a.region = &arenaRegion{arena: a}
+	return a
+}
+
+// Alloc возвращает обнулённый буфер длины n.
+func (a *Arena) Alloc(n int) []float64 {
+	if n == 0 {
+		return make([]float64, 0)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var buf []float64
+	if list := a.free[n]; len(list) > 0 {
+		buf = list[len(list)-1]
+		a.free[n] = list[:len(list)-1]
+		clear(buf)
+		a.step.Reused++
+	} else {
+		buf = make([]float64, n)
+		a.total.ReservedBytes += int64(n) * 8
+	}
+	a.used = append(a.used, buf)
+	a.owned[&buf[0]] = struct{}{}
+	a.step.Allocs++
+	a.live += int64(n) * 8
+	if a.live > a.step.PeakBytes {
+		a.step.PeakBytes = a.live
+	}
+	return buf
+}
+
+// Zeros создаёт в арене тензор, заполненный нулями и привязанный к текущему шагу.
+func (a *Arena) Zeros(shape ...int) *Tensor {
+	return a.currentRegion().zeros(shape...)
+}
+
+// Attach возвращает тензор с данными t, привязанный к текущему шагу арены:
+// результаты операций над ним выделяются из арены до Reset. Данные t не
+// копируются, сам t не меняется.
+func (a *Arena) Attach(t *Tensor) *Tensor {
+	out := *t
+	out.region = a.currentRegion()
+	return &out
+}
+
+func (a *Arena) currentRegion() *arenaRegion {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.region
+}
+
+// Owns сообщает, выдан ли буфер тензора t ареной в текущем шаге.
+func (a *Arena) Owns(t *Tensor) bool {
+	if t == nil || len(t.Data) == 0 {
+		return false
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, ok := a.owned[&t.Data[0]]
+	return ok
+}
+
+// Detach возвращает t, если его память не принадлежит арене, и плотную копию
+// вне арены иначе (независимо от того, активна ли арена).
+func (a *Arena) Detach(t *Tensor) *Tensor {
+	if !a.Owns(t) {
+		return t
+	}
+	data := make([]float64, numel(t.Shape))
+	i := 0
+	forEachStrided(t, func(off int) {
+		data[i] = t.Data[off]
+		i++
+	})
+	return &Tensor{Data: data, Shape: append([]int{}, t.Shape...), Strides: calculateStrides(t.Shape)}
+}
+
+// Reset завершает шаг: все выданные буферы возвращаются в свободный список.
+// Возвращает статистику завершённого шага.
+func (a *Arena) Reset() ArenaStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, buf := range a.used {
+		a.free[len(buf)] = append(a.free[len(buf)], buf)
+	}
+	a.used = a.used[:0]
+	clear(a.owned)
+	a.region.closed.Store(true)
+	a.region = &arenaRegion{arena: a}
+	a.live = 0
+
+	step := a.step
+	step.ReservedBytes = a.total.ReservedBytes
+	step.Resets = 1
+	a.total.Allocs += step.Allocs
+	a.total.Reused += step.Reused
+	if step.PeakBytes > a.total.PeakBytes {
+		a.total.PeakBytes = step.PeakBytes
+	}
+	a.total.Resets++
+	a.step = ArenaStats{}
+	return step
+}
+
+// Stats возвращает накопленную статистику, включая текущий незавершённый шаг.
+func (a *Arena) Stats() ArenaStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	s := a.total
+	s.Allocs += a.step.Allocs
+	s.Reused += a.step.Reused
+	if a.step.PeakBytes > s.PeakBytes {
+		s.PeakBytes = a.step.PeakBytes
+	}
+	return s
+}
+
+// ArenaOf возвращает арену, к незавершённому шагу которой привязан t, или nil.
+func ArenaOf(t *Tensor) *Arena {
+	if r := regionOf(t); r != nil {
+		return r.arena
+	}
+	return nil
+}
+
+// DetachFromArena возвращает t, если он не выделен из незавершённого шага
+// арены, и его плотную копию в обычной памяти иначе (см. Arena.Detach).
+// Слои вызывают её для тензоров, которые хранят между шагами: скрытого
+// состояния RNN, значений, сохранённых хуками.
+func DetachFromArena(t *Tensor) *Tensor {
+	if a := ArenaOf(t); a != nil {
+		return a.Detach(t)
+	}
+	return t
+}
+
+// regionOf возвращает незавершённый шаг арены первого привязанного операнда.
+func regionOf(operands ...*Tensor) *arenaRegion {
+	for _, t := range operands {
+		if t != nil && t.region != nil && !t.region.closed.Load() {
+			return t.region
+		}
+	}
+	return nil
+}
+
+// alloc выделяет обнулённый буфер для результата операции: из арены шага r,
+// если он есть.
+func (r *arenaRegion) alloc(n int) []float64 {
+	if r == nil {
+		return make([]float64, n)
+	}
+	return r.arena.Alloc(n)
+}
+
+// zeros создаёт плотный нулевой тензор в шаге r (nil — обычная память).
+func (r *arenaRegion) zeros(shape ...int) *Tensor {
+	return &Tensor{
+		Data:    r.alloc(calculateSize(shape)),
+		Shape:   shape,
+		Strides: calculateStrides(shape),
+		region:  r,
+	}
+}
@@ -0,0 +1,152 @@
+package tensor
+
+import "testing"
+
+func TestArenaReusesBuffersAfterReset(t *testing.T) {
+	a := NewArena()
+	x := a.Alloc(16)
+	y := a.Alloc(8)
+	x[0], y[0] = 1, 2
+
+	step := a.Reset()
+	if step.Allocs != 2 || step.Reused != 0 {
+		t.Fatalf("первый шаг: %+v", step)
+	}
+	if step.PeakBytes != 24*8 || step.ReservedBytes != 24*8 {
+		t.Fatalf("неверная память первого шага: %+v", step)
+	}
+
+	x2 := a.Alloc(16)
+	if &x2[0] != &x[0] {
+		t.Fatalf("буфер того же размера не переиспользован")
+	}
+	if x2[0] != 0 {
+		t.Fatalf("переиспользованный буфер не обнулён: %v", x2[0])
+	}
+	a.Alloc(4) // новый размер — новая аллокация
+
+	step = a.Reset()
+	if step.Allocs != 2 || step.Reused != 1 {
+		t.Fatalf("второй шаг: %+v", step)
+	}
+	if step.PeakBytes != 20*8 || step.ReservedBytes != 28*8 {
+		t.Fatalf("неверная память второго шага: %+v", step)
+	}
+
+	total := a.Stats()
+	if total.Allocs != 4 || total.Reused != 1 || total.Resets != 2 || total.PeakBytes != 24*8 {
+		t.Fatalf("накопленная статистика: %+v", total)
+	}
+}
+
+func TestArenaOwnsAndDetach(t *testing.T) {
+	a := NewArena()
+	owned := a.Zeros(2, 3)
+	owned.Data[4] = 7
+	heap := Zeros(2, 3)
+
+	if !a.Owns(owned) || a.Owns(heap) || a.Owns(nil) {
+		t.Fatalf("Owns определяет принадлежность неверно")
+	}
+	if a.Detach(heap) != heap {
+		t.Fatalf("Detach копирует тензор вне арены")
+	}
+	view, err := Transpose(owned)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := a.Detach(view)
+	if a.Owns(d) || len(d.Shape) != 2 || d.Shape[0] != 3 || d.At(1, 1) != 7 {
+		t.Fatalf("Detach вернул неверную копию: %+v", d)
+	}
+
+	a.Reset()
+	if a.Owns(owned) {
+		t.Fatalf("после Reset буфер всё ещё считается выданным")
+	}
+}
+
+func TestDetachFromArenaSurvivesReset(t *testing.T) {
+	a := NewArena()
+	x := a.Attach(Ones(2, 2))
+	y, _ := Add(x, x)
+	held := DetachFromArena(y)
+	if a.Owns(held) || !a.Owns(y) {
+		t.Fatalf("DetachFromArena должен копировать тензор арены")
+	}
+	if heap := Ones(2); DetachFromArena(heap) != heap {
+		t.Fatalf("DetachFromArena копирует тензор вне арены")
+	}
+
+	a.Reset()
+	// Следующий шаг получает буфер y и перезаписывает его.
+	z, _ := Add(a.Attach(Zeros(2, 2)), a.Attach(Zeros(2, 2)))
+	if &z.Data[0] != &y.Data[0] {
+		t.Fatalf("арена не переиспользовала буфер после Reset")
+	}
+	for i, v := range held.Data {
+		if v != 2 {
+			t.Fatalf("held[%d] = %v после Reset, ожидалось 2", i, v)
+		}
+	}
+}
+
+func TestArenaRoutesOpResultsOfAttachedTensors(t *testing.T) {
+	a := NewArena()
+	x := a.Zeros(2, 2)
+	y := a.Attach(Ones(2, 2))
+	sum, err := Add(x, y)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prod, err := MatMul(y, y)
+	if err != nil {
+		t.Fatal(err)
+	}
+	view, err := Transpose(prod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []*Tensor{x, sum, prod, view.Contiguous()} {
+		if !a.Owns(tt) {
+			t.Fatalf("результат операции над тензором шага не выделен из арены")
+		}
+	}
+	if a.Owns(y) || ArenaOf(y) != a {
+		t.Fatalf("Attach не должен копировать данные, но должен привязать тензор к арене")
+	}
+	if prod.Data[0] != 2 || sum.Data[3] != 1 {
+		t.Fatalf("неверные значения: %v %v", prod.Data, sum.Data)
+	}
+
+	// Тензоры, не связанные с шагом, арену не используют.
+	plain, _ := Add(Ones(2, 2), Ones(2, 2))
+	if a.Owns(plain) || a.Owns(Zeros(2, 2)) {
+		t.Fatalf("операции над обычными тензорами не должны брать память из арены")
+	}
+	if got := a.Stats().Allocs; got != 4 {
+		t.Fatalf("ожидалось 4 выделения из арены, получено %d", got)
+	}
+
+	// После Reset шаг закрыт: операции над его тензорами идут в обычную память.
+	a.Reset()
+	if after, _ := Add(y, y); a.Owns(after) || ArenaOf(y) != nil {
+		t.Fatalf("после Reset тензоры шага не должны выделять память из арены")
+	}
+	if a.Owns(y.Clone()) {
+		t.Fatalf("Clone не должен выделять память из арены")
+	}
+}
+
+func BenchmarkArenaStep(b *testing.B) {
+	a := NewArena()
+	ones := Ones(64, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		x := a.Attach(ones)
+		h, _ := MatMul(x, x)
+		h, _ = Add(h, x)
+		_ = Apply(h, func(v float64) float64 { return v * 0.5 })
+		a.Reset()
+	}
+}
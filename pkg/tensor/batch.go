@@ -2,7 +2,6 @@ package tensor
 
 import (
 	"fmt"
-	"sync"
 )
 
 // BatchOps содержит пакетные операции для обработки множества тензоров
@@ -15,6 +14,9 @@ func BatchMatMul(a, b *Tensor) (*Tensor, error) {
 		return nil, fmt.Errorf("батчевое умножение матриц требует 3D тензоры (batch, m, n), получены %dD и %dD", len(a.Shape), len(b.Shape))
 	}
 
+	// Ядра ниже читают Data подряд, поэтому представления материализуются.
+	a, b = a.Contiguous(), b.Contiguous()
+
 	batchSize := a.Shape[0]
 	m := a.Shape[1]
 	n := a.Shape[2]
@@ -35,45 +37,28 @@ func BatchMatMul(a, b *Tensor) (*Tensor, error) {
 	}
 
 	// Параллельная обработка батча
-	var wg sync.WaitGroup
-	numWorkers := min(batchSize, 8) // Ограничиваем количество воркеров
-
-	batchPerWorker := (batchSize + numWorkers - 1) / numWorkers
-
-	for w := 0; w < numWorkers; w++ {
-		startBatch := w * batchPerWorker
-		if startBatch >= batchSize {
-			break
-		}
-		endBatch := min((w+1)*batchPerWorker, batchSize)
-
-		wg.Add(1)
-		go func(start, end int) {
-			defer wg.Done()
-
-			for batchIdx := start; batchIdx < end; batchIdx++ {
-				// Извлекаем срезы для текущего батча
-				aOffset := batchIdx * m * n
-				bOffset := batchIdx * n * p
-				cOffset := batchIdx * m * p
-
-				aSlice := a.Data[aOffset : aOffset+m*n]
-				bSlice := b.Data[bOffset : bOffset+n*p]
-				cSlice := result.Data[cOffset : cOffset+m*p]
-
-				// Выполняем умножение матриц для этого элемента батча
-				if m >= ParallelThreshold || p >= ParallelThreshold {
-					matmulBlocked(aSlice, bSlice, cSlice, m, n, p)
-				} else if m >= BlockSize || p >= BlockSize {
-					matmulBlocked(aSlice, bSlice, cSlice, m, n, p)
-				} else {
-					matmulOptimized(aSlice, bSlice, cSlice, m, n, p)
-				}
+	DefaultComputePool().ParallelFor(batchSize, 1, func(start, end int) {
+		for batchIdx := start; batchIdx < end; batchIdx++ {
+			// Извлекаем срезы для текущего батча
+			aOffset := batchIdx * m * n
+			bOffset := batchIdx * n * p
+			cOffset := batchIdx * m * p
+
+			aSlice := a.Data[aOffset : aOffset+m*n]
+			bSlice := b.Data[bOffset : bOffset+n*p]
+			cSlice := result.Data[cOffset : cOffset+m*p]
+
+			// Выполняем умножение матриц для этого элемента батча
+			if m >= ParallelThreshold || p >= ParallelThreshold {
+				matmulBlocked(aSlice, bSlice, cSlice, m, n, p)
+			} else if m >= BlockSize || p >= BlockSize {
+				matmulBlocked(aSlice, bSlice, cSlice, m, n, p)
+			} else {
+				matmulOptimized(aSlice, bSlice, cSlice, m, n, p)
 			}
-		}(startBatch, endBatch)
-	}
+		}
+	})
 
-	wg.Wait()
 	return result, nil
 }
 
@@ -84,7 +69,7 @@ func BatchAdd(tensors []*Tensor) (*Tensor, error) {
 	}
 
 	baseShape := tensors[0].Shape
-	size := len(tensors[0].Data)
+	size := numel(baseShape)
 
 	// Проверяем что все тензоры одного размера
 	for i, t := range tensors {
@@ -92,39 +77,29 @@ func BatchAdd(tensors []*Tensor) (*Tensor, error) {
 			return nil, fmt.Errorf("тензор %d имеет другую форму: %v != %v", i, t.Shape, baseShape)
 		}
 	}
+	dense := make([]*Tensor, len(tensors))
+	for i, t := range tensors {
+		dense[i] = t.Contiguous()
+	}
+	tensors = dense
 
 	result := &Tensor{
 		Data:    make([]float64, size),
 		Shape:   append([]int{}, baseShape...),
-		Strides: append([]int{}, tensors[0].Strides...),
+		Strides: calculateStrides(baseShape),
 	}
 
 	// Параллельное сложение
-	numWorkers := 4
-	chunkSize := (size + numWorkers - 1) / numWorkers
-
-	var wg sync.WaitGroup
-	for w := 0; w < numWorkers; w++ {
-		start := w * chunkSize
-		if start >= size {
-			break
-		}
-		end := min((w+1)*chunkSize, size)
-
-		wg.Add(1)
-		go func(s, e int) {
-			defer wg.Done()
-			for i := s; i < e; i++ {
-				sum := 0.0
-				for _, t := range tensors {
-					sum += t.Data[i]
-				}
-				result.Data[i] = sum
+	DefaultComputePool().ParallelFor(size, elementwiseGrain, func(s, e int) {
+		for i := s; i < e; i++ {
+			sum := 0.0
+			for _, t := range tensors {
+				sum += t.Data[i]
 			}
-		}(start, end)
-	}
+			result.Data[i] = sum
+		}
+	})
 
-	wg.Wait()
 	return result, nil
 }
 
@@ -134,16 +109,11 @@ func BatchScale(tensors []*Tensor, scales []float64) error {
 		return fmt.Errorf("количество тензоров и коэффициентов должно совпадать: %d != %d", len(tensors), len(scales))
 	}
 
-	var wg sync.WaitGroup
-	for i, t := range tensors {
-		wg.Add(1)
-		go func(tensor *Tensor, scale float64) {
-			defer wg.Done()
-			ScaleInPlace(scale, tensor)
-		}(t, scales[i])
-	}
-
-	wg.Wait()
+	DefaultComputePool().ParallelFor(len(tensors), 1, func(start, end int) {
+		for i := start; i < end; i++ {
+			ScaleInPlace(scales[i], tensors[i])
+		}
+	})
 	return nil
 }
 
@@ -153,6 +123,7 @@ func BatchNorm(batch *Tensor, eps float64) (*Tensor, error) {
 	if len(batch.Shape) < 2 {
 		return nil, fmt.Errorf("батч-нормализация требует тензор размерности не менее 2D")
 	}
+	batch = batch.Contiguous()
 
 	batchSize := batch.Shape[0]
 	featureSize := 1
@@ -189,7 +160,7 @@ func BatchNorm(batch *Tensor, eps float64) (*Tensor, error) {
 	result := &Tensor{
 		Data:    make([]float64, len(batch.Data)),
 		Shape:   append([]int{}, batch.Shape...),
-		Strides: append([]int{}, batch.Strides...),
+		Strides: calculateStrides(batch.Shape),
 	}
 
 	for b := 0; b < batchSize; b++ {
@@ -225,6 +196,7 @@ func BatchMean(batch *Tensor, axis int) (*Tensor, error) {
 	if axis < 0 || axis >= len(batch.Shape) {
 		return nil, fmt.Errorf("некорректная ось %d для тензора с %d измерениями", axis, len(batch.Shape))
 	}
+	batch = batch.Contiguous()
 
 	// Создаем новую форму без указанной оси
 	newShape := make([]int, 0, len(batch.Shape)-1)
@@ -287,6 +259,9 @@ func BatchMatMulSIMD(a, b *Tensor) (*Tensor, error) {
 		return nil, fmt.Errorf("батчевое умножение матриц требует 3D тензоры")
 	}
 
+	// Ядра ниже читают Data подряд, поэтому представления материализуются.
+	a, b = a.Contiguous(), b.Contiguous()
+
 	batchSize := a.Shape[0]
 	m := a.Shape[1]
 	n := a.Shape[2]
@@ -303,38 +278,22 @@ func BatchMatMulSIMD(a, b *Tensor) (*Tensor, error) {
 	}
 
 	// Параллельная обработка с SIMD
-	var wg sync.WaitGroup
-	numWorkers := min(batchSize, 8)
-	batchPerWorker := (batchSize + numWorkers - 1) / numWorkers
-
-	for w := 0; w < numWorkers; w++ {
-		startBatch := w * batchPerWorker
-		if startBatch >= batchSize {
-			break
+	DefaultComputePool().ParallelFor(batchSize, 1, func(start, end int) {
+		for batchIdx := start; batchIdx < end; batchIdx++ {
+			aOffset := batchIdx * m * n
+			bOffset := batchIdx * n * p
+			cOffset := batchIdx * m * p
+
+			aSlice := a.Data[aOffset : aOffset+m*n]
+			bSlice := b.Data[bOffset : bOffset+n*p]
+			cSlice := result.Data[cOffset : cOffset+m*p]
+
+			// Используем SIMD-оптимизированное умножение
+			blockSize := chooseBlockSize(m, n, p)
+			matmulBlockedSIMD(aSlice, bSlice, cSlice, m, n, p, blockSize)
 		}
-		endBatch := min((w+1)*batchPerWorker, batchSize)
-
-		wg.Add(1)
-		go func(start, end int) {
-			defer wg.Done()
-
-			for batchIdx := start; batchIdx < end; batchIdx++ {
-				aOffset := batchIdx * m * n
-				bOffset := batchIdx * n * p
-				cOffset := batchIdx * m * p
-
-				aSlice := a.Data[aOffset : aOffset+m*n]
-				bSlice := b.Data[bOffset : bOffset+n*p]
-				cSlice := result.Data[cOffset : cOffset+m*p]
-
-				// Используем SIMD-оптимизированное умножение
-				blockSize := chooseBlockSize(m, n, p)
-				matmulBlockedSIMD(aSlice, bSlice, cSlice, m, n, p, blockSize)
-			}
-		}(startBatch, endBatch)
-	}
+	})
 
-	wg.Wait()
 	return result, nil
 }
 
@@ -392,6 +351,7 @@ func BatchConcat(tensors []*Tensor, axis int) (*Tensor, error) {
 	if axis == 0 {
 		offset := 0
 		for _, t := range tensors {
+			t = t.Contiguous()
 			copy(result.Data[offset:], t.Data)
 			offset += len(t.Data)
 		}
@@ -408,6 +368,7 @@ func BatchSplit(tensor *Tensor, numSplits int, axis int) ([]*Tensor, error) {
 	if axis < 0 || axis >= len(tensor.Shape) {
 		return nil, fmt.Errorf("некорректная ось %d", axis)
 	}
+	tensor = tensor.Contiguous()
 
 	if tensor.Shape[axis]%numSplits != 0 {
 		return nil, fmt.Errorf("размер %d вдоль оси %d не делится на %d", tensor.Shape[axis], axis, numSplits)
@@ -452,6 +413,7 @@ func BatchGather(tensor *Tensor, indices []int, axis int) (*Tensor, error) {
 	if axis < 0 || axis >= len(tensor.Shape) {
 		return nil, fmt.Errorf("некорректная ось %d", axis)
 	}
+	tensor = tensor.Contiguous()
 
 	// Проверяем индексы
 	for _, idx := range indices {
@@ -1,5 +1,5 @@
-//go:build cgo && !windows
-// +build cgo,!windows
+//go:build cgo && !windows && !noblas
+// +build cgo,!windows,!noblas
 
 package tensor
 
@@ -246,3 +246,31 @@ func MatrixVectorMultiplyBLAS(alpha float64, a *Tensor, x []float64, beta float6
 
 	return nil
 }
+
+// gemmBLAS вызывает cblas_dgemm для row-major матриц: C = alpha*op(A)*op(B) + beta*C.
+// m, n, k — размеры op(A) [m,k] и op(B) [k,n]; lda, ldb, ldc — длины строк хранения.
+func gemmBLAS(transA, transB bool, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	var ta, tb C.enum_CBLAS_TRANSPOSE = C.CblasNoTrans, C.CblasNoTrans
+	if transA {
+		ta = C.CblasTrans
+	}
+	if transB {
+		tb = C.CblasTrans
+	}
+	C.cblas_dgemm(
+		C.CblasRowMajor,
+		ta,
+		tb,
+		C.int(m),
+		C.int(n),
+		C.int(k),
+		C.double(alpha),
+		(*C.double)(unsafe.Pointer(&a[0])),
+		C.int(lda),
+		(*C.double)(unsafe.Pointer(&b[0])),
+		C.int(ldb),
+		C.double(beta),
+		(*C.double)(unsafe.Pointer(&c[0])),
+		C.int(ldc),
+	)
+}
@@ -1,9 +1,10 @@
-// +build !cgo
+//go:build !cgo || noblas
+// +build !cgo noblas
 
 package tensor
 
 // BLASAvailable указывает, доступна ли BLAS библиотека
-// В этой сборке без CGO BLAS недоступна
+// В этой сборке без CGO (или с тегом noblas) BLAS недоступна
 const BLASAvailable = false
 
 // MatMulBLAS - заглушка для сборки без CGO
@@ -67,3 +68,8 @@ func MatrixVectorMultiplyBLAS(alpha float64, a *Tensor, x []float64, beta float6
 
 	return nil
 }
+
+// gemmBLAS - заглушка для сборки без CGO; не вызывается, так как BLASAvailable = false
+func gemmBLAS(transA, transB bool, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	panic("BLAS недоступна в сборке без CGO")
+}
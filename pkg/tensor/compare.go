@@ -0,0 +1,109 @@
+package tensor
+
+import "math"
+
+// Поэлементные сравнения и логические операции.
+// Результат — тензор из 0 и 1 той же формы, что и broadcast операндов;
+// его можно использовать как маску в MaskedFill, Where и MaskedSelect.
+// Логические операции трактуют ненулевой элемент как true.
+// Сравнения с NaN, как и в IEEE 754, ложны (кроме Ne).
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Eq возвращает маску a == b.
+func Eq(a, b *Tensor) (*Tensor, error) {
+	return ZipWith(a, b, func(x, y float64) float64 { return boolToFloat(x == y) })
+}
+
+// Ne возвращает маску a != b.
+func Ne(a, b *Tensor) (*Tensor, error) {
+	return ZipWith(a, b, func(x, y float64) float64 { return boolToFloat(x != y) })
+}
+
+// Lt возвращает маску a < b.
+func Lt(a, b *Tensor) (*Tensor, error) {
+	return ZipWith(a, b, func(x, y float64) float64 { return boolToFloat(x < y) })
+}
+
+// Le возвращает маску a <= b.
+func Le(a, b *Tensor) (*Tensor, error) {
+	return ZipWith(a, b, func(x, y float64) float64 { return boolToFloat(x <= y) })
+}
+
+// Gt возвращает маску a > b.
+func Gt(a, b *Tensor) (*Tensor, error) {
+	return ZipWith(a, b, func(x, y float64) float64 { return boolToFloat(x > y) })
+}
+
+// Ge возвращает маску a >= b.
+func Ge(a, b *Tensor) (*Tensor, error) {
+	return ZipWith(a, b, func(x, y float64) float64 { return boolToFloat(x >= y) })
+}
+
+// LogicalAnd возвращает маску a && b.
+func LogicalAnd(a, b *Tensor) (*Tensor, error) {
+	return ZipWith(a, b, func(x, y float64) float64 { return boolToFloat(x != 0 && y != 0) })
+}
+
+// LogicalOr возвращает маску a || b.
+func LogicalOr(a, b *Tensor) (*Tensor, error) {
+	return ZipWith(a, b, func(x, y float64) float64 { return boolToFloat(x != 0 || y != 0) })
+}
+
+// LogicalNot возвращает маску !a.
+func LogicalNot(a *Tensor) *Tensor {
+	return Apply(a, func(x float64) float64 { return boolToFloat(x == 0) })
+}
+
+// Clamp ограничивает элементы a отрезком [lo, hi]. NaN остаётся NaN.
+func Clamp(a *Tensor, lo, hi float64) *Tensor {
+	return Apply(a, func(x float64) float64 {
+		if x < lo {
+			return lo
+		}
+		if x > hi {
+			return hi
+		}
+		return x
+	})
+}
+
+// Sign возвращает знак каждого элемента: -1, 0 или 1 (NaN для NaN).
+func Sign(a *Tensor) *Tensor {
+	return Apply(a, func(x float64) float64 {
+		switch {
+		case x > 0:
+			return 1
+		case x < 0:
+			return -1
+		}
+		return x // 0, -0 или NaN
+	})
+}
+
+// Abs возвращает модуль каждого элемента.
+func Abs(a *Tensor) *Tensor {
+	return Apply(a, math.Abs)
+}
+
+// Sqrt возвращает квадратный корень каждого элемента (NaN для отрицательных).
+func Sqrt(a *Tensor) *Tensor {
+	return Apply(a, math.Sqrt)
+}
+
+// PowScalar возводит каждый элемент a в степень p.
+// Для степени-тензора с broadcasting используйте Pow.
+func PowScalar(a *Tensor, p float64) *Tensor {
+	return Apply(a, func(x float64) float64 { return math.Pow(x, p) })
+}
+
+// Round округляет элементы до ближайшего целого; половины округляются
+// к чётному, как numpy.round.
+func Round(a *Tensor) *Tensor {
+	return Apply(a, math.RoundToEven)
+}
@@ -0,0 +1,76 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComparisonsBroadcast(t *testing.T) {
+	a := arange(2, 3)                                                          // [[0 1 2] [3 4 5]]
+	b := &Tensor{Data: []float64{1, 4, 2}, Shape: []int{3}, Strides: []int{1}} // строка broadcast-ится
+	cases := []struct {
+		name string
+		op   func(a, b *Tensor) (*Tensor, error)
+		want []float64
+	}{
+		{"Eq", Eq, []float64{0, 0, 1, 0, 1, 0}},
+		{"Ne", Ne, []float64{1, 1, 0, 1, 0, 1}},
+		{"Lt", Lt, []float64{1, 1, 0, 0, 0, 0}},
+		{"Le", Le, []float64{1, 1, 1, 0, 1, 0}},
+		{"Gt", Gt, []float64{0, 0, 0, 1, 0, 1}},
+		{"Ge", Ge, []float64{0, 0, 1, 1, 1, 1}},
+	}
+	for _, c := range cases {
+		got, err := c.op(a, b)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		assertShape(t, got, 2, 3)
+		if !floatsEqual(got.Data, c.want) {
+			t.Errorf("%s: получено %v, ожидалось %v", c.name, got.Data, c.want)
+		}
+	}
+	if _, err := Eq(arange(2, 3), arange(2)); err == nil {
+		t.Error("ожидалась ошибка несовместимых форм")
+	}
+}
+
+func TestLogicalOps(t *testing.T) {
+	a := &Tensor{Data: []float64{0, 0, 2, -1}, Shape: []int{4}, Strides: []int{1}}
+	b := &Tensor{Data: []float64{0, 1, 0, 3}, Shape: []int{4}, Strides: []int{1}}
+	and, _ := LogicalAnd(a, b)
+	or, _ := LogicalOr(a, b)
+	if !floatsEqual(and.Data, []float64{0, 0, 0, 1}) || !floatsEqual(or.Data, []float64{0, 1, 1, 1}) {
+		t.Fatalf("And %v, Or %v", and.Data, or.Data)
+	}
+	if not := LogicalNot(a); !floatsEqual(not.Data, []float64{1, 1, 0, 0}) {
+		t.Fatalf("Not %v", not.Data)
+	}
+}
+
+func TestElementwiseMath(t *testing.T) {
+	x := &Tensor{Data: []float64{-2.5, -0.5, 0, 0.5, 1.5, 4}, Shape: []int{6}, Strides: []int{1}}
+	cases := []struct {
+		name string
+		got  *Tensor
+		want []float64
+	}{
+		{"Clamp", Clamp(x, -1, 1), []float64{-1, -0.5, 0, 0.5, 1, 1}},
+		{"Sign", Sign(x), []float64{-1, -1, 0, 1, 1, 1}},
+		{"Abs", Abs(x), []float64{2.5, 0.5, 0, 0.5, 1.5, 4}},
+		{"Round", Round(x), []float64{-2, -0, 0, 0, 2, 4}},
+		{"PowScalar", PowScalar(x, 2), []float64{6.25, 0.25, 0, 0.25, 2.25, 16}},
+	}
+	for _, c := range cases {
+		if !floatsEqual(c.got.Data, c.want) {
+			t.Errorf("%s: получено %v, ожидалось %v", c.name, c.got.Data, c.want)
+		}
+	}
+	s := Sqrt(x)
+	if s.Data[5] != 2 || !math.IsNaN(s.Data[0]) {
+		t.Errorf("Sqrt: %v", s.Data)
+	}
+	if c := Clamp(&Tensor{Data: []float64{math.NaN()}, Shape: []int{1}}, 0, 1); !math.IsNaN(c.Data[0]) {
+		t.Errorf("Clamp(NaN) = %v", c.Data[0])
+	}
+}
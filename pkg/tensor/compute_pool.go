@@ -0,0 +1,148 @@
+package tensor
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ComputePool — пул потоков для параллельных ядер tensor (MatMul, Gemm,
+// поэлементные операции над большими тензорами). Один пул разделяется всеми
+// вызовами, поэтому несколько тренеров или inference-серверов в одном процессе
+// не создают горутины сверх заданного лимита.
+//
+// Работа делится на чанки фиксированного размера, не зависящего от числа
+// потоков, и каждый элемент результата вычисляется одним чанком, поэтому
+// результат воспроизводим при любом NumThreads.
+type ComputePool struct {
+	threads int
+	tasks   chan func()
+	quit    chan struct{}
+	once    sync.Once
+}
+
+// NewComputePool создаёт пул на numThreads потоков (numThreads <= 0 — GOMAXPROCS).
+// Вызывающая горутина участвует в работе сама, поэтому фоновых воркеров numThreads-1.
+func NewComputePool(numThreads int) *ComputePool {
+	if numThreads <= 0 {
+		numThreads = runtime.GOMAXPROCS(0)
+	}
+	p := &ComputePool{
+		threads: numThreads,
+		tasks:   make(chan func(), 4*numThreads),
+		quit:    make(chan struct{}),
+	}
+	for i := 1; i < numThreads; i++ {
+		go p.worker()
+	}
+	return p
+}
+
+func (p *ComputePool) worker() {
+	for {
+		select {
+		case <-p.quit:
+			return
+		case task := <-p.tasks:
+			task()
+		}
+	}
+}
+
+// NumThreads возвращает максимальное число потоков, выполняющих один вызов.
+func (p *ComputePool) NumThreads() int {
+	return p.threads
+}
+
+// Close останавливает фоновые воркеры. Закрытый пул продолжает работать,
+// но выполняет всё в вызывающей горутине.
+func (p *ComputePool) Close() {
+	p.once.Do(func() { close(p.quit) })
+}
+
+// ParallelFor вызывает f для чанков [start, end) длиной grain (последний может
+// быть короче), покрывающих [0, n). Чанки выполняются параллельно с work
+// stealing; границы чанков зависят только от n и grain.
+// Вложенные вызовы безопасны: вызывающая горутина сама забирает невзятые чанки.
+func (p *ComputePool) ParallelFor(n, grain int, f func(start, end int)) {
+	if n <= 0 {
+		return
+	}
+	if grain < 1 {
+		grain = 1
+	}
+	chunks := ceilDiv(n, grain)
+	workers := min(p.threads, chunks)
+	if workers <= 1 {
+		for start := 0; start < n; start += grain {
+			f(start, min(start+grain, n))
+		}
+		return
+	}
+
+	sched := newStealScheduler(chunks, workers)
+	var wg sync.WaitGroup
+	wg.Add(chunks)
+	run := func(id int) {
+		for {
+			c, ok := sched.next(id)
+			if !ok {
+				return
+			}
+			f(c*grain, min((c+1)*grain, n))
+			wg.Done()
+		}
+	}
+	for id := 1; id < workers; id++ {
+		task := func() { run(id) }
+		select {
+		case p.tasks <- task:
+		default:
+			// Очередь пула заполнена: чанки этого участника заберёт кто-то другой.
+		}
+	}
+	run(0)
+	wg.Wait()
+}
+
+var defaultComputePool atomic.Pointer[ComputePool]
+
+func init() {
+	defaultComputePool.Store(NewComputePool(0))
+}
+
+// DefaultComputePool возвращает глобальный пул, используемый ядрами tensor по умолчанию.
+func DefaultComputePool() *ComputePool {
+	return defaultComputePool.Load()
+}
+
+// SetNumThreads заменяет глобальный пул пулом на n потоков (n <= 0 — GOMAXPROCS).
+// Уже начатые вызовы доработают на прежнем пуле.
+func SetNumThreads(n int) {
+	old := defaultComputePool.Swap(NewComputePool(n))
+	old.Close()
+}
+
+// NumThreads возвращает число потоков глобального пула.
+func NumThreads() int {
+	return DefaultComputePool().NumThreads()
+}
+
+type computePoolKey struct{}
+
+// WithComputePool возвращает контекст, в котором вызовы *Context-функций
+// (MatMulContext, GemmContext, ...) используют пул pool вместо глобального.
+func WithComputePool(ctx context.Context, pool *ComputePool) context.Context {
+	return context.WithValue(ctx, computePoolKey{}, pool)
+}
+
+// ComputePoolFromContext возвращает пул из контекста или глобальный пул.
+func ComputePoolFromContext(ctx context.Context) *ComputePool {
+	if ctx != nil {
+		if pool, ok := ctx.Value(computePoolKey{}).(*ComputePool); ok && pool != nil {
+			return pool
+		}
+	}
+	return DefaultComputePool()
+}
@@ -0,0 +1,135 @@
+package tensor
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestComputePoolParallelForCoversRange(t *testing.T) {
+	pool := NewComputePool(4)
+	defer pool.Close()
+
+	const n = 1000
+	hits := make([]int32, n)
+	var mu sync.Mutex
+	var chunks [][2]int
+	pool.ParallelFor(n, 64, func(start, end int) {
+		mu.Lock()
+		chunks = append(chunks, [2]int{start, end})
+		mu.Unlock()
+		for i := start; i < end; i++ {
+			atomic.AddInt32(&hits[i], 1)
+		}
+	})
+	for i, h := range hits {
+		if h != 1 {
+			t.Fatalf("элемент %d обработан %d раз", i, h)
+		}
+	}
+	// Границы чанков зависят только от n и grain.
+	for _, c := range chunks {
+		if c[0]%64 != 0 || (c[1] != n && c[1]-c[0] != 64) {
+			t.Fatalf("неожиданный чанк %v", c)
+		}
+	}
+}
+
+func TestComputePoolLimitsConcurrency(t *testing.T) {
+	pool := NewComputePool(2)
+	defer pool.Close()
+
+	var active, peak atomic.Int32
+	pool.ParallelFor(64, 1, func(start, end int) {
+		cur := active.Add(1)
+		for {
+			old := peak.Load()
+			if cur <= old || peak.CompareAndSwap(old, cur) {
+				break
+			}
+		}
+		for i := 0; i < 10000; i++ {
+			_ = i * i
+		}
+		active.Add(-1)
+	})
+	if peak.Load() > 2 {
+		t.Fatalf("одновременно работало %d потоков при лимите 2", peak.Load())
+	}
+}
+
+func TestComputePoolNestedAndClosed(t *testing.T) {
+	pool := NewComputePool(2)
+	var total atomic.Int64
+	pool.ParallelFor(8, 1, func(start, end int) {
+		pool.ParallelFor(8, 1, func(s, e int) { total.Add(int64(e - s)) })
+	})
+	pool.Close()
+	pool.ParallelFor(8, 1, func(s, e int) { total.Add(int64(e - s)) })
+	if total.Load() != 72 {
+		t.Fatalf("total = %d, ожидалось 72", total.Load())
+	}
+}
+
+func TestSetNumThreadsAndContextOverride(t *testing.T) {
+	prev := NumThreads()
+	defer SetNumThreads(prev)
+
+	SetNumThreads(3)
+	if NumThreads() != 3 {
+		t.Fatalf("NumThreads() = %d, ожидалось 3", NumThreads())
+	}
+
+	single := NewComputePool(1)
+	defer single.Close()
+	ctx := WithComputePool(context.Background(), single)
+	if ComputePoolFromContext(ctx) != single {
+		t.Fatal("ComputePoolFromContext не вернул пул из контекста")
+	}
+	if ComputePoolFromContext(context.Background()) != DefaultComputePool() {
+		t.Fatal("без пула в контексте должен использоваться глобальный")
+	}
+}
+
+func TestParallelResultsReproducible(t *testing.T) {
+	prev := NumThreads()
+	defer SetNumThreads(prev)
+
+	g := NewGenerator(21)
+	a := g.Normal([]int{300, 70}, 0, 1)
+	b := g.Normal([]int{70, 260}, 0, 1)
+	x := g.Normal([]int{200, 300}, 0, 1)
+	row := g.Normal([]int{300}, 0, 1)
+
+	run := func() (mm, gm, sum *Tensor) {
+		mm, _ = MatMul(a, b)
+		gm = Zeros(70, 260)
+		_ = Gemm(true, false, 0.5, a, mm, 0, gm)
+		sum, _ = Add(x, row) // broadcasting, strided путь
+		return
+	}
+	SetNumThreads(1)
+	mm1, gm1, sum1 := run()
+	SetNumThreads(7)
+	mm7, gm7, sum7 := run()
+
+	for name, pair := range map[string][2]*Tensor{"MatMul": {mm1, mm7}, "Gemm": {gm1, gm7}, "Add": {sum1, sum7}} {
+		for i := range pair[0].Data {
+			if pair[0].Data[i] != pair[1].Data[i] {
+				t.Fatalf("%s[%d] зависит от числа потоков: %v != %v", name, i, pair[0].Data[i], pair[1].Data[i])
+			}
+		}
+	}
+
+	ctx := WithComputePool(context.Background(), NewComputePool(2))
+	mmCtx, err := MatMulContext(ctx, a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range mm1.Data {
+		if mmCtx.Data[i] != mm1.Data[i] {
+			t.Fatalf("MatMulContext[%d] = %v, ожидалось %v", i, mmCtx.Data[i], mm1.Data[i])
+		}
+	}
+}
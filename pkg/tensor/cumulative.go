@@ -0,0 +1,32 @@
+package tensor
+
+// CumSum возвращает накопленные суммы вдоль оси axis:
+// out[..., i, ...] = Σ a[..., j, ...] для j <= i. Форма результата совпадает с a.
+func CumSum(a *Tensor, axis int) (*Tensor, error) {
+	return scanAxis(a, axis, 0, func(acc, v float64) float64 { return acc + v })
+}
+
+// CumProd возвращает накопленные произведения вдоль оси axis.
+func CumProd(a *Tensor, axis int) (*Tensor, error) {
+	return scanAxis(a, axis, 1, func(acc, v float64) float64 { return acc * v })
+}
+
+// scanAxis выполняет включающий префиксный проход f вдоль оси axis.
+func scanAxis(a *Tensor, axis int, init float64, f func(acc, v float64) float64) (*Tensor, error) {
+	axis, err := normalizeAxis(axis, len(a.Shape))
+	if err != nil {
+		return nil, err
+	}
+	src := a.Contiguous()
+	out := Zeros(append([]int{}, a.Shape...)...)
+	n := a.Shape[axis]
+	lanes(a.Shape, axis, func(base, step int) {
+		acc := init
+		for k := 0; k < n; k++ {
+			off := base + k*step
+			acc = f(acc, src.Data[off])
+			out.Data[off] = acc
+		}
+	})
+	return out, nil
+}
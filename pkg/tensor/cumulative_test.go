@@ -0,0 +1,37 @@
+package tensor
+
+import "testing"
+
+func TestCumSumCumProd(t *testing.T) {
+	x := arange(2, 3) // [[0 1 2] [3 4 5]]
+	rows, err := CumSum(x, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !floatsEqual(rows.Data, []float64{0, 1, 3, 3, 7, 12}) {
+		t.Fatalf("CumSum по строкам: %v", rows.Data)
+	}
+	cols, err := CumSum(x, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !floatsEqual(cols.Data, []float64{0, 1, 2, 3, 5, 7}) {
+		t.Fatalf("CumSum по столбцам: %v", cols.Data)
+	}
+
+	view, err := Transpose(x) // [[0 3] [1 4] [2 5]]
+	if err != nil {
+		t.Fatal(err)
+	}
+	prod, err := CumProd(view, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertShape(t, prod, 3, 2)
+	if !floatsEqual(prod.Data, []float64{0, 3, 0, 12, 0, 60}) {
+		t.Fatalf("CumProd по view: %v", prod.Data)
+	}
+	if _, err := CumSum(x, -3); err == nil {
+		t.Fatal("ожидалась ошибка некорректной оси")
+	}
+}
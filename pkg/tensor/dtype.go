@@ -0,0 +1,71 @@
+package tensor
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// DType описывает тип элементов тензора.
+// Основной Tensor всегда хранит float64, обобщённый TensorOf — любой тип из Float.
+// Целочисленные типы используются только при обмене данными (см. ReadNPY):
+// в памяти их значения хранятся как float64.
+type DType int
+
+const (
+	// Float64 — 64-битное число с плавающей точкой (тип Tensor по умолчанию).
+	Float64 DType = iota
+	// Float32 — 32-битное число с плавающей точкой (вдвое меньше памяти и трафика).
+	Float32
+	// Int32 — 32-битное знаковое целое.
+	Int32
+	// Int64 — 64-битное знаковое целое.
+	Int64
+	// Uint8 — беззнаковый байт (изображения, метки классов).
+	Uint8
+)
+
+// Float — ограничение для типов элементов обобщённого тензора.
+type Float interface {
+	~float32 | ~float64
+}
+
+// String возвращает имя типа в стиле NumPy.
+func (d DType) String() string {
+	switch d {
+	case Float64:
+		return "float64"
+	case Float32:
+		return "float32"
+	case Int32:
+		return "int32"
+	case Int64:
+		return "int64"
+	case Uint8:
+		return "uint8"
+	default:
+		return fmt.Sprintf("DType(%d)", int(d))
+	}
+}
+
+// Size возвращает размер одного элемента в байтах.
+func (d DType) Size() int {
+	switch d {
+	case Float64, Int64:
+		return 8
+	case Float32, Int32:
+		return 4
+	case Uint8:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// DTypeOf возвращает DType, соответствующий параметру типа T.
+func DTypeOf[T Float]() DType {
+	var zero T
+	if unsafe.Sizeof(zero) == 4 {
+		return Float32
+	}
+	return Float64
+}
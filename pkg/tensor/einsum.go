@@ -0,0 +1,333 @@
+package tensor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Einsum вычисляет свёртку тензоров по нотации Эйнштейна, например:
+//
+//	Einsum("ij,jk->ik", a, b)        // MatMul
+//	Einsum("bij,bjk->bik", a, b)     // BatchMatMul
+//	Einsum("bqd,bkd->bqk", q, k)     // attention scores
+//	Einsum("bi,ij,bj->b", x, w, y)   // билинейная форма
+//	Einsum("i,j->ij", u, v)          // внешнее произведение
+//	Einsum("ii->i", a)               // диагональ
+//
+// Индексы — латинские буквы. Без "->" выход состоит из индексов, встречающихся
+// ровно один раз, в алфавитном порядке. Индексы, отсутствующие в выходе, суммируются.
+// Многооперандные выражения сворачиваются попарно слева направо; каждая пара
+// сводится к MatMul или BatchMatMul. Повторяющиеся индексы внутри одного операнда
+// (диагонали) вычисляются прямым перебором.
+// Полная свёртка в скаляр возвращает тензор формы [1].
+func Einsum(spec string, operands ...*Tensor) (*Tensor, error) {
+	es, err := parseEinsum(spec, operands)
+	if err != nil {
+		return nil, err
+	}
+	if es.hasRepeats() {
+		return einsumNaive(es, operands), nil
+	}
+
+	labels := es.inputs[0]
+	cur := operands[0]
+	if len(operands) == 1 {
+		labels, cur = einsumReduce(labels, cur, es.output)
+	}
+	for k := 1; k < len(operands); k++ {
+		// Оставляем индексы, нужные выходу или следующим операндам.
+		keep := map[rune]bool{}
+		for _, r := range es.output {
+			keep[r] = true
+		}
+		for _, in := range es.inputs[k+1:] {
+			for _, r := range in {
+				keep[r] = true
+			}
+		}
+		labels, cur, err = einsumPair(labels, cur, es.inputs[k], operands[k], keep, es.sizes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return einsumPermute(labels, cur, es.output)
+}
+
+// einsumSpec — разобранное выражение einsum.
+type einsumSpec struct {
+	inputs [][]rune
+	output []rune
+	sizes  map[rune]int
+}
+
+// EinsumLabels разбирает выражение einsum и возвращает индексы операндов и выхода
+// (с учётом неявного выхода). Используется autograd для построения backward.
+func EinsumLabels(spec string, nOperands int) (inputs []string, output string, err error) {
+	lhs, rhs, explicit := strings.Cut(strings.ReplaceAll(spec, " ", ""), "->")
+	parts := strings.Split(lhs, ",")
+	if len(parts) != nOperands {
+		return nil, "", fmt.Errorf("einsum: выражение %q описывает %d операндов, передано %d", spec, len(parts), nOperands)
+	}
+	counts := map[rune]int{}
+	for _, p := range parts {
+		for _, r := range p {
+			if !isEinsumLabel(r) {
+				return nil, "", fmt.Errorf("einsum: недопустимый индекс %q в %q", r, spec)
+			}
+			counts[r]++
+		}
+	}
+	if !explicit {
+		var out []rune
+		for r, c := range counts {
+			if c == 1 {
+				out = append(out, r)
+			}
+		}
+		sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+		rhs = string(out)
+	}
+	seen := map[rune]bool{}
+	for _, r := range rhs {
+		if counts[r] == 0 {
+			return nil, "", fmt.Errorf("einsum: выходной индекс %q отсутствует во входах", r)
+		}
+		if seen[r] {
+			return nil, "", fmt.Errorf("einsum: выходной индекс %q повторяется", r)
+		}
+		seen[r] = true
+	}
+	return parts, rhs, nil
+}
+
+func isEinsumLabel(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func parseEinsum(spec string, operands []*Tensor) (*einsumSpec, error) {
+	if len(operands) == 0 {
+		return nil, fmt.Errorf("einsum: нет операндов")
+	}
+	inputs, output, err := EinsumLabels(spec, len(operands))
+	if err != nil {
+		return nil, err
+	}
+	es := &einsumSpec{output: []rune(output), sizes: map[rune]int{}}
+	for k, in := range inputs {
+		labels := []rune(in)
+		shape := operands[k].Shape
+		if len(labels) == 0 && numel(shape) == 1 {
+			// Скаляр формы [1] с пустым списком индексов.
+			es.inputs = append(es.inputs, labels)
+			continue
+		}
+		if len(labels) != len(shape) {
+			return nil, fmt.Errorf("einsum: операнд %d имеет %d осей, а в выражении %d индексов", k, len(shape), len(labels))
+		}
+		for d, r := range labels {
+			if size, ok := es.sizes[r]; ok && size != shape[d] {
+				return nil, fmt.Errorf("einsum: индекс %q имеет разные размеры %d и %d", r, size, shape[d])
+			}
+			es.sizes[r] = shape[d]
+		}
+		es.inputs = append(es.inputs, labels)
+	}
+	return es, nil
+}
+
+func (es *einsumSpec) hasRepeats() bool {
+	for _, in := range es.inputs {
+		seen := map[rune]bool{}
+		for _, r := range in {
+			if seen[r] {
+				return true
+			}
+			seen[r] = true
+		}
+	}
+	return false
+}
+
+func indexOfRune(labels []rune, r rune) int {
+	for i, l := range labels {
+		if l == r {
+			return i
+		}
+	}
+	return -1
+}
+
+// einsumShape возвращает форму тензора с индексами labels (скаляр — [1]).
+func einsumShape(labels []rune, sizes map[rune]int) []int {
+	if len(labels) == 0 {
+		return []int{1}
+	}
+	shape := make([]int, len(labels))
+	for i, r := range labels {
+		shape[i] = sizes[r]
+	}
+	return shape
+}
+
+// einsumReduce суммирует оси одного операнда, отсутствующие в keep.
+func einsumReduce(labels []rune, t *Tensor, keep []rune) ([]rune, *Tensor) {
+	var axes []int
+	var rest []rune
+	for d, r := range labels {
+		if indexOfRune(keep, r) < 0 {
+			axes = append(axes, d)
+		} else {
+			rest = append(rest, r)
+		}
+	}
+	if len(axes) == 0 {
+		return labels, t
+	}
+	out, _ := SumAxes(t, axes, false)
+	return rest, out
+}
+
+// einsumPermute переставляет оси t с индексами labels в порядок order и
+// возвращает плотный результат.
+func einsumPermute(labels []rune, t *Tensor, order []rune) (*Tensor, error) {
+	if len(order) == 0 {
+		return t.Contiguous(), nil
+	}
+	dims := make([]int, len(order))
+	for i, r := range order {
+		dims[i] = indexOfRune(labels, r)
+	}
+	view, err := Permute(t, dims...)
+	if err != nil {
+		return nil, err
+	}
+	return view.Contiguous(), nil
+}
+
+// einsumPair сворачивает два операнда, оставляя индексы из keep.
+// Индексы делятся на batch (в обоих и в keep), left/right (только в одном и в keep)
+// и contract (в обоих, но не в keep); операнды переставляются в [batch, left, contract]
+// и [batch, contract, right] и перемножаются через MatMul/BatchMatMul.
+func einsumPair(aLabels []rune, a *Tensor, bLabels []rune, b *Tensor, keep map[rune]bool, sizes map[rune]int) ([]rune, *Tensor, error) {
+	// Индексы, встречающиеся только в одном операнде и не нужные дальше, суммируем сразу.
+	var aKeep, bKeep []rune
+	for _, r := range aLabels {
+		if keep[r] || indexOfRune(bLabels, r) >= 0 {
+			aKeep = append(aKeep, r)
+		}
+	}
+	for _, r := range bLabels {
+		if keep[r] || indexOfRune(aLabels, r) >= 0 {
+			bKeep = append(bKeep, r)
+		}
+	}
+	aLabels, a = einsumReduce(aLabels, a, aKeep)
+	bLabels, b = einsumReduce(bLabels, b, bKeep)
+
+	var batch, left, right, contract []rune
+	for _, r := range aLabels {
+		inB := indexOfRune(bLabels, r) >= 0
+		switch {
+		case inB && keep[r]:
+			batch = append(batch, r)
+		case inB:
+			contract = append(contract, r)
+		default:
+			left = append(left, r)
+		}
+	}
+	for _, r := range bLabels {
+		if indexOfRune(aLabels, r) < 0 {
+			right = append(right, r)
+		}
+	}
+
+	size := func(labels []rune) int {
+		n := 1
+		for _, r := range labels {
+			n *= sizes[r]
+		}
+		return n
+	}
+	nb, nl, nr, nc := size(batch), size(left), size(right), size(contract)
+
+	aOrder := append(append(append([]rune{}, batch...), left...), contract...)
+	bOrder := append(append(append([]rune{}, batch...), contract...), right...)
+	aP, err := einsumPermute(aLabels, a, aOrder)
+	if err != nil {
+		return nil, nil, err
+	}
+	bP, err := einsumPermute(bLabels, b, bOrder)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var out *Tensor
+	if len(batch) == 0 {
+		a2, _ := Reshape(aP, []int{nl, nc})
+		b2, _ := Reshape(bP, []int{nc, nr})
+		out, err = MatMul(a2, b2)
+	} else {
+		a3, _ := Reshape(aP, []int{nb, nl, nc})
+		b3, _ := Reshape(bP, []int{nb, nc, nr})
+		out, err = BatchMatMul(a3, b3)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	labels := append(append(append([]rune{}, batch...), left...), right...)
+	out, err = Reshape(out, einsumShape(labels, sizes))
+	if err != nil {
+		return nil, nil, err
+	}
+	return labels, out, nil
+}
+
+// einsumNaive вычисляет einsum прямым перебором всех значений индексов.
+// Используется для выражений с повторяющимися индексами внутри операнда.
+func einsumNaive(es *einsumSpec, operands []*Tensor) *Tensor {
+	var all []rune
+	for _, in := range es.inputs {
+		for _, r := range in {
+			if indexOfRune(all, r) < 0 {
+				all = append(all, r)
+			}
+		}
+	}
+	dims := einsumShape(all, es.sizes)
+	outShape := einsumShape(es.output, es.sizes)
+	out := Zeros(outShape...)
+	outStrides := calculateStrides(outShape)
+
+	// Для каждого операнда и выхода заранее вычисляем вклад каждого индекса в смещение.
+	contrib := func(labels []rune, strides []int) []int {
+		c := make([]int, len(all))
+		for d, r := range labels {
+			c[indexOfRune(all, r)] += strides[d]
+		}
+		return c
+	}
+	opContrib := make([][]int, len(operands))
+	for k, t := range operands {
+		opContrib[k] = contrib(es.inputs[k], stridesOf(t))
+	}
+	var outContrib []int
+	if len(es.output) > 0 {
+		outContrib = contrib(es.output, outStrides)
+	} else {
+		outContrib = make([]int, len(all))
+	}
+
+	idx := make([]int, len(all))
+	for n := numel(dims); n > 0; n-- {
+		prod := 1.0
+		for k, t := range operands {
+			prod *= t.Data[t.Offset+indexOffset(idx, opContrib[k])]
+		}
+		out.Data[indexOffset(idx, outContrib)] += prod
+		nextIndex(idx, dims)
+	}
+	return out
+}
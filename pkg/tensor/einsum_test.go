@@ -0,0 +1,96 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEinsumBasic(t *testing.T) {
+	a := arange(2, 3)
+	b := arange(3, 2)
+
+	mm, err := Einsum("ij,jk->ik", a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertData(t, "ij,jk->ik", mm, []int{2, 2}, []float64{10, 13, 28, 40})
+
+	tr, _ := Einsum("ij->ji", a)
+	assertData(t, "ij->ji", tr, []int{3, 2}, []float64{0, 3, 1, 4, 2, 5})
+
+	s, _ := Einsum("ij->", a)
+	assertData(t, "ij->", s, []int{1}, []float64{15})
+
+	implicit, _ := Einsum("ij,jk", a, b)
+	assertData(t, "ij,jk", implicit, []int{2, 2}, []float64{10, 13, 28, 40})
+
+	outer, _ := Einsum("i,j->ij", arange(2), arange(3))
+	assertData(t, "i,j->ij", outer, []int{2, 3}, []float64{0, 0, 0, 0, 1, 2})
+
+	diag, _ := Einsum("ii->i", arange(3, 3))
+	assertData(t, "ii->i", diag, []int{3}, []float64{0, 4, 8})
+
+	trace, _ := Einsum("ii", arange(3, 3))
+	assertData(t, "ii", trace, []int{1}, []float64{12})
+}
+
+// TestEinsumMatchesNaive сравнивает план через MatMul/BatchMatMul с прямым перебором.
+func TestEinsumMatchesNaive(t *testing.T) {
+	cases := []struct {
+		spec   string
+		shapes [][]int
+	}{
+		{"bij,bjk->bik", [][]int{{2, 3, 4}, {2, 4, 5}}},
+		{"bqd,bkd->bqk", [][]int{{2, 3, 4}, {2, 5, 4}}},
+		{"bi,ij,bj->b", [][]int{{3, 4}, {4, 5}, {3, 5}}},
+		{"bi,bj->bij", [][]int{{2, 3}, {2, 4}}},
+		{"ijk,jl->lik", [][]int{{2, 3, 4}, {3, 5}}},
+		{"ij,kl->", [][]int{{2, 3}, {4, 5}}},
+		{"abc,cd,de->ae", [][]int{{2, 3, 4}, {4, 3}, {3, 2}}},
+		{"i,i->", [][]int{{5}, {5}}},
+		{"ijk->kj", [][]int{{2, 3, 4}}},
+	}
+	for n, tc := range cases {
+		ops := make([]*Tensor, len(tc.shapes))
+		for i, s := range tc.shapes {
+			ops[i] = Randn(s, int64(100*n+i))
+		}
+		got, err := Einsum(tc.spec, ops...)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.spec, err)
+		}
+		es, _ := parseEinsum(tc.spec, ops)
+		want := einsumNaive(es, ops)
+		if !shapesEqual(got.Shape, want.Shape) {
+			t.Fatalf("%s: shape = %v, want %v", tc.spec, got.Shape, want.Shape)
+		}
+		for i := range want.Data {
+			if math.Abs(got.Data[i]-want.Data[i]) > 1e-9 {
+				t.Fatalf("%s: data[%d] = %v, want %v", tc.spec, i, got.Data[i], want.Data[i])
+			}
+		}
+	}
+}
+
+func TestEinsumViewsAndErrors(t *testing.T) {
+	a := arange(3, 2)
+	at, _ := Transpose(a)
+	got, err := Einsum("ij,jk->ik", at, arange(3, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertData(t, "transposed view", got, []int{2, 1}, []float64{10, 13})
+
+	if _, err := Einsum("ij,jk->ik", arange(2, 3), arange(2, 2)); err == nil {
+		t.Fatal("expected size mismatch error")
+	}
+	if _, err := Einsum("ij->ik", arange(2, 3)); err == nil {
+		t.Fatal("expected unknown output index error")
+	}
+	if _, err := Einsum("ij,jk", arange(2, 3)); err == nil {
+		t.Fatal("expected operand count error")
+	}
+	if _, err := Einsum("i...->i", arange(2, 3)); err == nil {
+		t.Fatal("expected invalid index error")
+	}
+}
@@ -0,0 +1,272 @@
+package tensor
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// PrintOptions управляет текстовым представлением тензоров (String, Format),
+// по аналогии с numpy.set_printoptions.
+type PrintOptions struct {
+	// Precision — число знаков после запятой.
+	Precision int
+	// Threshold — при большем числе элементов вывод сокращается многоточием.
+	Threshold int
+	// EdgeItems — сколько элементов в начале и конце каждой оси выводится при сокращении.
+	EdgeItems int
+}
+
+// DefaultPrintOptions возвращает настройки вывода по умолчанию.
+func DefaultPrintOptions() PrintOptions {
+	return PrintOptions{Precision: 4, Threshold: 1000, EdgeItems: 3}
+}
+
+var printOptions atomic.Pointer[PrintOptions]
+
+func init() {
+	o := DefaultPrintOptions()
+	printOptions.Store(&o)
+}
+
+// SetPrintOptions задаёт глобальные настройки вывода тензоров.
+func SetPrintOptions(o PrintOptions) {
+	printOptions.Store(&o)
+}
+
+// GetPrintOptions возвращает текущие настройки вывода тензоров.
+func GetPrintOptions() PrintOptions {
+	return *printOptions.Load()
+}
+
+// String возвращает тензор в виде вложенных скобок в стиле NumPy:
+//
+//	[[0.0000 1.0000 2.0000]
+//	 [3.0000 4.0000 5.0000]]
+//
+// Большие тензоры сокращаются многоточием (см. SetPrintOptions).
+func (t *Tensor) String() string {
+	if t == nil {
+		return "<nil>"
+	}
+	return t.render(GetPrintOptions(), 0)
+}
+
+// Format реализует fmt.Formatter:
+//
+//	%v, %s  — как String; точность задаётся как %.2v
+//	%f, %e, %g — принудительно фиксированная, экспоненциальная или общая запись
+//	%+v     — дополнительно форма: Tensor(shape=[2 3], [[...]])
+func (t *Tensor) Format(f fmt.State, verb rune) {
+	if t == nil {
+		io.WriteString(f, "<nil>")
+		return
+	}
+	opts := GetPrintOptions()
+	if p, ok := f.Precision(); ok {
+		opts.Precision = p
+	}
+	var mode byte
+	switch verb {
+	case 'v', 's':
+	case 'f', 'e', 'g':
+		mode = byte(verb)
+	default:
+		fmt.Fprintf(f, "%%!%c(*tensor.Tensor=%s)", verb, t.String())
+		return
+	}
+	body := t.render(opts, mode)
+	if verb == 'v' && f.Flag('+') {
+		prefix := fmt.Sprintf("Tensor(shape=%v, ", t.Shape)
+		lines := strings.Split(body, "\n")
+		for i := 1; i < len(lines); i++ {
+			if lines[i] != "" {
+				lines[i] = strings.Repeat(" ", len(prefix)) + lines[i]
+			}
+		}
+		body = strings.Join(lines, "\n")
+		fmt.Fprintf(f, "%s%s)", prefix, body)
+		return
+	}
+	io.WriteString(f, body)
+}
+
+// printer хранит состояние вывода одного тензора.
+type printer struct {
+	t         *Tensor
+	shape     []int
+	strides   []int
+	opts      PrintOptions
+	summarize bool
+	mode      byte
+	width     int
+}
+
+func (t *Tensor) render(opts PrintOptions, mode byte) string {
+	shape, strides := t.Shape, stridesOf(t)
+	if len(shape) == 0 {
+		// Тензор без формы трактуется как плоский буфер.
+		shape, strides = []int{len(t.Data) - t.Offset}, []int{1}
+	}
+	if numel(shape) == 0 {
+		return "[]"
+	}
+	p := &printer{t: t, shape: shape, strides: strides, opts: opts, mode: mode}
+	p.summarize = opts.Threshold >= 0 && numel(shape) > opts.Threshold
+	if p.opts.Precision < 0 {
+		p.opts.Precision = 0
+	}
+
+	// Первый проход: выбираем запись чисел и ширину колонки по выводимым элементам.
+	if p.mode == 0 {
+		maxAbs, minAbs := 0.0, math.Inf(1)
+		p.walk(0, t.Offset, func(v float64) {
+			if a := math.Abs(v); !math.IsInf(a, 0) && !math.IsNaN(a) {
+				maxAbs = math.Max(maxAbs, a)
+				if a > 0 {
+					minAbs = math.Min(minAbs, a)
+				}
+			}
+		})
+		p.mode = 'f'
+		if maxAbs >= 1e8 || minAbs < math.Pow(10, -float64(p.opts.Precision)) {
+			p.mode = 'e'
+		}
+	}
+	p.walk(0, t.Offset, func(v float64) {
+		p.width = max(p.width, len(p.formatValue(v)))
+	})
+
+	var b strings.Builder
+	p.writeDim(&b, 0, t.Offset)
+	return b.String()
+}
+
+// edges возвращает индексы, выводимые по оси dim, и позицию многоточия (-1 — без него).
+func (p *printer) edges(dim int) (idx []int, gap int) {
+	n, e := p.shape[dim], p.opts.EdgeItems
+	if !p.summarize || n <= 2*e {
+		idx = make([]int, n)
+		for i := range idx {
+			idx[i] = i
+		}
+		return idx, -1
+	}
+	for i := 0; i < e; i++ {
+		idx = append(idx, i)
+	}
+	for i := n - e; i < n; i++ {
+		idx = append(idx, i)
+	}
+	return idx, e
+}
+
+// walk обходит выводимые элементы.
+func (p *printer) walk(dim, off int, f func(float64)) {
+	idx, _ := p.edges(dim)
+	for _, i := range idx {
+		o := off + i*p.strides[dim]
+		if dim == len(p.shape)-1 {
+			f(p.t.Data[o])
+		} else {
+			p.walk(dim+1, o, f)
+		}
+	}
+}
+
+func (p *printer) writeDim(b *strings.Builder, dim, off int) {
+	b.WriteByte('[')
+	idx, gap := p.edges(dim)
+	last := dim == len(p.shape)-1
+	sep := " "
+	if !last {
+		sep = strings.Repeat("\n", len(p.shape)-dim-1) + strings.Repeat(" ", dim+1)
+	}
+	for k, i := range idx {
+		if k > 0 {
+			b.WriteString(sep)
+		}
+		if k == gap {
+			b.WriteString("...")
+			b.WriteString(sep)
+		}
+		o := off + i*p.strides[dim]
+		if last {
+			s := p.formatValue(p.t.Data[o])
+			b.WriteString(strings.Repeat(" ", p.width-len(s)))
+			b.WriteString(s)
+		} else {
+			p.writeDim(b, dim+1, o)
+		}
+	}
+	b.WriteByte(']')
+}
+
+func (p *printer) formatValue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "nan"
+	case math.IsInf(v, 1):
+		return "inf"
+	case math.IsInf(v, -1):
+		return "-inf"
+	}
+	return strconv.FormatFloat(v, p.mode, p.opts.Precision, 64)
+}
+
+// TensorStats — сводная статистика значений тензора.
+// Min, Max, Mean и Std считаются без учёта NaN; Std — стандартное отклонение
+// по генеральной совокупности (как numpy.std). Если все значения NaN или
+// тензор пуст, они равны NaN.
+type TensorStats struct {
+	Count int // число элементов
+	NaN   int // число NaN
+	Inf   int // число ±Inf
+	Min   float64
+	Max   float64
+	Mean  float64
+	Std   float64
+}
+
+// String возвращает статистику в одну строку.
+func (s TensorStats) String() string {
+	return fmt.Sprintf("count=%d min=%.4g max=%.4g mean=%.4g std=%.4g nan=%d inf=%d",
+		s.Count, s.Min, s.Max, s.Mean, s.Std, s.NaN, s.Inf)
+}
+
+// Stats вычисляет минимум, максимум, среднее, стандартное отклонение
+// и число NaN/Inf. Views обходятся по strides без копирования.
+func (t *Tensor) Stats() TensorStats {
+	s := TensorStats{Min: math.Inf(1), Max: math.Inf(-1)}
+	// Среднее и дисперсия — однопроходным алгоритмом Уэлфорда.
+	var n int
+	var mean, m2 float64
+	forEachStrided(t, func(off int) {
+		v := t.Data[off]
+		s.Count++
+		if math.IsNaN(v) {
+			s.NaN++
+			return
+		}
+		if math.IsInf(v, 0) {
+			s.Inf++
+		}
+		s.Min = math.Min(s.Min, v)
+		s.Max = math.Max(s.Max, v)
+		n++
+		d := v - mean
+		mean += d / float64(n)
+		m2 += d * (v - mean)
+	})
+	if n == 0 {
+		nan := math.NaN()
+		s.Min, s.Max, s.Mean, s.Std = nan, nan, nan, nan
+		return s
+	}
+	s.Mean = mean
+	s.Std = math.Sqrt(m2 / float64(n))
+	return s
+}
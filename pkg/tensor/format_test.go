@@ -0,0 +1,98 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestTensorStringNested(t *testing.T) {
+	got := arange(2, 2, 3).String()
+	want := "[[[ 0.0000  1.0000  2.0000]\n" +
+		"  [ 3.0000  4.0000  5.0000]]\n" +
+		"\n" +
+		" [[ 6.0000  7.0000  8.0000]\n" +
+		"  [ 9.0000 10.0000 11.0000]]]"
+	if got != want {
+		t.Fatalf("получено:\n%s\nожидалось:\n%s", got, want)
+	}
+}
+
+func TestTensorStringView(t *testing.T) {
+	x, err := Transpose(arange(2, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "[[0.0000 3.0000]\n [1.0000 4.0000]\n [2.0000 5.0000]]"
+	if got := x.String(); got != want {
+		t.Fatalf("получено:\n%s\nожидалось:\n%s", got, want)
+	}
+}
+
+func TestTensorStringSummarizes(t *testing.T) {
+	got := fmt.Sprint(arange(2000))
+	want := "[   0.0000    1.0000    2.0000 ... 1997.0000 1998.0000 1999.0000]"
+	if got != want {
+		t.Fatalf("получено %s, ожидалось %s", got, want)
+	}
+
+	rows := strings.Split(arange(40, 40).String(), "\n")
+	if len(rows) != 7 || rows[3] != " ..." {
+		t.Fatalf("ожидалось 6 строк и многоточие, получено:\n%s", strings.Join(rows, "\n"))
+	}
+}
+
+func TestTensorFormatVerbsAndOptions(t *testing.T) {
+	x := &Tensor{Data: []float64{1.5, -2, math.NaN(), math.Inf(1)}, Shape: []int{4}, Strides: []int{1}}
+	cases := []struct{ format, want string }{
+		{"%v", "[ 1.5000 -2.0000     nan     inf]"},
+		{"%.1f", "[ 1.5 -2.0  nan  inf]"},
+		{"%.2e", "[ 1.50e+00 -2.00e+00       nan       inf]"},
+		{"%+.0v", "Tensor(shape=[4], [  2  -2 nan inf])"},
+		{"%d", "%!d(*tensor.Tensor=[ 1.5000 -2.0000     nan     inf])"},
+	}
+	for _, c := range cases {
+		if got := fmt.Sprintf(c.format, x); got != c.want {
+			t.Errorf("%s: получено %q, ожидалось %q", c.format, got, c.want)
+		}
+	}
+	// Очень маленькие и очень большие значения выводятся в экспоненциальной записи.
+	if got := fmt.Sprint(&Tensor{Data: []float64{1e-6, 3e9}, Shape: []int{2}}); got != "[1.0000e-06 3.0000e+09]" {
+		t.Errorf("экспоненциальная запись: %s", got)
+	}
+
+	defer SetPrintOptions(GetPrintOptions())
+	SetPrintOptions(PrintOptions{Precision: 1, Threshold: 4, EdgeItems: 1})
+	if got := arange(6).String(); got != "[0.0 ... 5.0]" {
+		t.Errorf("SetPrintOptions не применился: %s", got)
+	}
+	var nilTensor *Tensor
+	if got := fmt.Sprint(nilTensor); got != "<nil>" {
+		t.Errorf("nil: %s", got)
+	}
+}
+
+func TestTensorStats(t *testing.T) {
+	x := &Tensor{Data: []float64{1, 2, math.NaN(), 3, 4}, Shape: []int{5}, Strides: []int{1}}
+	s := x.Stats()
+	if s.Count != 5 || s.NaN != 1 || s.Inf != 0 || s.Min != 1 || s.Max != 4 || s.Mean != 2.5 {
+		t.Fatalf("неверная статистика: %+v", s)
+	}
+	if math.Abs(s.Std-math.Sqrt(1.25)) > 1e-12 {
+		t.Fatalf("Std = %v, ожидалось %v", s.Std, math.Sqrt(1.25))
+	}
+
+	view, err := Transpose(arange(3, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vs := view.Stats(); vs.Max != 5 || vs.Mean != 2.5 {
+		t.Fatalf("статистика view: %+v", vs)
+	}
+
+	allNaN := (&Tensor{Data: []float64{math.NaN()}, Shape: []int{1}}).Stats()
+	if !math.IsNaN(allNaN.Mean) || !math.IsNaN(allNaN.Min) || allNaN.NaN != 1 {
+		t.Fatalf("статистика из одних NaN: %+v", allNaN)
+	}
+}
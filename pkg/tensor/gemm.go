@@ -0,0 +1,233 @@
+package tensor
+
+import (
+	"context"
+	"fmt"
+)
+
+// Gemm вычисляет C = alpha·op(A)·op(B) + beta·C в духе BLAS dgemm, где
+// op(X) = Xᵀ при transX и X иначе. Результат записывается в существующий
+// плотный тензор C [m, p], что позволяет накапливать градиенты без
+// промежуточных аллокаций:
+//
+//	Gemm(false, true, 1, grad, w, 1, x.Grad) // x.Grad += grad·Wᵀ
+//
+// A и B могут быть views (транспонирование, срезы). При beta == 0 прежнее
+// содержимое C игнорируется (в том числе NaN), как в BLAS.
+func Gemm(transA, transB bool, alpha float64, a, b *Tensor, beta float64, c *Tensor) error {
+	return gemmChecked(DefaultComputePool(), transA, transB, alpha, a, b, beta, c)
+}
+
+// GemmContext — Gemm, использующий пул потоков из ctx (см. WithComputePool).
+func GemmContext(ctx context.Context, transA, transB bool, alpha float64, a, b *Tensor, beta float64, c *Tensor) error {
+	return gemmChecked(ComputePoolFromContext(ctx), transA, transB, alpha, a, b, beta, c)
+}
+
+func gemmChecked(pool *ComputePool, transA, transB bool, alpha float64, a, b *Tensor, beta float64, c *Tensor) error {
+	if len(a.Shape) != 2 || len(b.Shape) != 2 || len(c.Shape) != 2 {
+		return fmt.Errorf("Gemm требует 2D тензоры, получены %dD, %dD и %dD", len(a.Shape), len(b.Shape), len(c.Shape))
+	}
+	m, n := gemmOpShape(a, transA)
+	nb, p := gemmOpShape(b, transB)
+	if n != nb {
+		return fmt.Errorf("несовместимые формы для Gemm: op(A) [%d,%d] и op(B) [%d,%d]", m, n, nb, p)
+	}
+	if c.Shape[0] != m || c.Shape[1] != p {
+		return fmt.Errorf("форма C %v не совпадает с результатом [%d,%d]", c.Shape, m, p)
+	}
+	if !c.isCompact() {
+		return fmt.Errorf("Gemm требует плотный тензор C")
+	}
+	gemm(pool, transA, transB, alpha, a, b, beta, c.Data, m, n, p)
+	return nil
+}
+
+// GemmBatchedStrided выполняет Gemm для каждого элемента батча:
+// C[i] = alpha·op(A[i])·op(B[i]) + beta·C[i].
+// A и B — тензоры [batch, ·, ·] или 2D-матрицы, общие для всего батча
+// (шаг по батчу 0, как strideA = 0 в cuBLAS); C — плотный [batch, m, p].
+func GemmBatchedStrided(transA, transB bool, alpha float64, a, b *Tensor, beta float64, c *Tensor) error {
+	return gemmBatchedChecked(DefaultComputePool(), transA, transB, alpha, a, b, beta, c)
+}
+
+// GemmBatchedStridedContext — GemmBatchedStrided, использующий пул потоков из ctx.
+func GemmBatchedStridedContext(ctx context.Context, transA, transB bool, alpha float64, a, b *Tensor, beta float64, c *Tensor) error {
+	return gemmBatchedChecked(ComputePoolFromContext(ctx), transA, transB, alpha, a, b, beta, c)
+}
+
+func gemmBatchedChecked(pool *ComputePool, transA, transB bool, alpha float64, a, b *Tensor, beta float64, c *Tensor) error {
+	if len(c.Shape) != 3 {
+		return fmt.Errorf("GemmBatchedStrided требует 3D тензор C, получен %dD", len(c.Shape))
+	}
+	batch := c.Shape[0]
+	for _, t := range []*Tensor{a, b} {
+		if len(t.Shape) == 3 && t.Shape[0] != batch {
+			return fmt.Errorf("размеры батчей должны совпадать: %d != %d", t.Shape[0], batch)
+		}
+		if len(t.Shape) != 2 && len(t.Shape) != 3 {
+			return fmt.Errorf("GemmBatchedStrided требует 2D или 3D операнды, получен %dD", len(t.Shape))
+		}
+	}
+	aMat, bMat := gemmBatchItem(a, 0), gemmBatchItem(b, 0)
+	m, n := gemmOpShape(aMat, transA)
+	nb, p := gemmOpShape(bMat, transB)
+	if n != nb {
+		return fmt.Errorf("несовместимые формы для Gemm: op(A) [%d,%d] и op(B) [%d,%d]", m, n, nb, p)
+	}
+	if c.Shape[1] != m || c.Shape[2] != p {
+		return fmt.Errorf("форма C %v не совпадает с результатом [%d,%d,%d]", c.Shape, batch, m, p)
+	}
+	if !c.isCompact() {
+		return fmt.Errorf("GemmBatchedStrided требует плотный тензор C")
+	}
+
+	pool.ParallelFor(batch, 1, func(start, end int) {
+		for i := start; i < end; i++ {
+			gemm(pool, transA, transB, alpha, gemmBatchItem(a, i), gemmBatchItem(b, i), beta, c.Data[i*m*p:(i+1)*m*p], m, n, p)
+		}
+	})
+	return nil
+}
+
+// gemmOpShape возвращает форму op(t).
+func gemmOpShape(t *Tensor, trans bool) (rows, cols int) {
+	if trans {
+		return t.Shape[1], t.Shape[0]
+	}
+	return t.Shape[0], t.Shape[1]
+}
+
+// gemmBatchItem возвращает i-ю матрицу батча без копирования.
+// Плотные элементы получают собственный срез Data, чтобы попасть на быстрые ядра.
+func gemmBatchItem(t *Tensor, i int) *Tensor {
+	if len(t.Shape) == 2 {
+		return t
+	}
+	st := stridesOf(t)
+	rows, cols := t.Shape[1], t.Shape[2]
+	off := t.Offset + i*st[0]
+	if st[1] == cols && st[2] == 1 {
+		return &Tensor{Data: t.Data[off : off+rows*cols], Shape: []int{rows, cols}, Strides: []int{cols, 1}}
+	}
+	return &Tensor{Data: t.Data, Shape: []int{rows, cols}, Strides: []int{st[1], st[2]}, Offset: off}
+}
+
+// gemm — общее ядро Gemm для плотного C [m, p] и операндов с общей длиной n.
+func gemm(pool *ComputePool, transA, transB bool, alpha float64, a, b *Tensor, beta float64, c []float64, m, n, p int) {
+	switch beta {
+	case 1:
+	case 0:
+		for i := range c {
+			c[i] = 0
+		}
+	default:
+		for i := range c {
+			c[i] *= beta
+		}
+	}
+	if alpha == 0 || m == 0 || n == 0 || p == 0 {
+		return
+	}
+
+	compact := a.isCompact() && b.isCompact()
+	if BLASAvailable && compact && (m >= BLASThreshold || p >= BLASThreshold || n >= BLASThreshold) {
+		// beta уже применён к C выше.
+		gemmBLAS(transA, transB, m, p, n, alpha, a.Data, a.Shape[1], b.Data, b.Shape[1], 1, c, p)
+		return
+	}
+
+	aRows, lda, aOK := gemmRowMajor(a, transA)
+	bRows, ldb, bOK := gemmRowMajor(b, transB)
+	switch {
+	case aOK && bOK:
+		// Плотные матрицы и views со смежными строками (шаг строк lda/ldb).
+		if alpha != 1 {
+			// Блочные ядра только накапливают A·B, масштаб применяется к отдельному буферу.
+			tmp := make([]float64, m*p)
+			matmulAcc(pool, aRows, lda, bRows, ldb, tmp, m, n, p)
+			axpy(alpha, tmp, c)
+			return
+		}
+		matmulAcc(pool, aRows, lda, bRows, ldb, c, m, n, p)
+	case aOK && transB && b.isCompact():
+		// B хранится как [p, n]: каждый элемент C — скалярное произведение строк.
+		pool.ParallelFor(m, BlockSizeSmall, func(start, end int) {
+			for i := start; i < end; i++ {
+				aRow := aRows[i*lda : i*lda+n]
+				cRow := c[i*p : (i+1)*p]
+				for j := range cRow {
+					cRow[j] += alpha * DotProductSIMD(aRow, b.Data[j*n:(j+1)*n])
+				}
+			}
+		})
+	case transA && a.isCompact() && bOK:
+		// A хранится как [n, m]: строки C накапливаются axpy по строкам B.
+		pool.ParallelFor(m, BlockSizeSmall, func(start, end int) {
+			for k := 0; k < n; k++ {
+				bRow := bRows[k*ldb : k*ldb+p]
+				for i := start; i < end; i++ {
+					if aki := a.Data[k*m+i]; aki != 0 {
+						axpy(alpha*aki, bRow, c[i*p:(i+1)*p])
+					}
+				}
+			}
+		})
+	default:
+		as, bs := stridesOf(a), stridesOf(b)
+		as0, as1 := as[0], as[1]
+		if transA {
+			as0, as1 = as1, as0
+		}
+		bs0, bs1 := bs[0], bs[1]
+		if transB {
+			bs0, bs1 = bs1, bs0
+		}
+		if alpha != 1 {
+			tmp := make([]float64, m*p)
+			matmulStrided(a.Data, a.Offset, as0, as1, b.Data, b.Offset, bs0, bs1, tmp, m, n, p)
+			axpy(alpha, tmp, c)
+			return
+		}
+		matmulStrided(a.Data, a.Offset, as0, as1, b.Data, b.Offset, bs0, bs1, c, m, n, p)
+	}
+}
+
+// matmulAcc добавляет A·B к C, выбирая ядро по размеру так же, как MatMul.
+// lda и ldb — шаги строк A и B; C плотная [m, p].
+func matmulAcc(pool *ComputePool, a []float64, lda int, b []float64, ldb int, c []float64, m, n, p int) {
+	blockSize := chooseBlockSize(m, n, p)
+	if m >= ParallelThreshold || p >= ParallelThreshold {
+		matmulParallelBlockedV2Acc(pool, a, lda, b, ldb, c, m, n, p, blockSize)
+		return
+	}
+	matmulBlockedV2Acc(a, lda, b, ldb, c, m, n, p, blockSize)
+}
+
+// gemmRowMajor возвращает данные и шаг строк op(t), если строки op(t) лежат
+// в памяти подряд (шаг столбцов 1, как у срезов [batch, features] по первой оси).
+// Такие views умножаются блочными ядрами без материализации.
+func gemmRowMajor(t *Tensor, trans bool) (data []float64, ld int, ok bool) {
+	st := stridesOf(t)
+	rowStride, colStride, rows, cols := st[0], st[1], t.Shape[0], t.Shape[1]
+	if trans {
+		rowStride, colStride, rows, cols = st[1], st[0], t.Shape[1], t.Shape[0]
+	}
+	if rows == 1 {
+		rowStride = cols
+	}
+	if (colStride != 1 && cols > 1) || rowStride < cols {
+		return nil, 0, false
+	}
+	return t.Data[t.Offset:], rowStride, true
+}
+
+// axpy вычисляет y += alpha·x.
+func axpy(alpha float64, x, y []float64) {
+	if useAVX2FMA && len(y) >= len(x) {
+		axpyAVX2(alpha, x, y)
+		return
+	}
+	for i, v := range x {
+		y[i] += alpha * v
+	}
+}
@@ -0,0 +1,181 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+// gemmReference вычисляет alpha·op(A)·op(B) + beta·C наивно через At.
+func gemmReference(transA, transB bool, alpha float64, a, b *Tensor, beta float64, c *Tensor) []float64 {
+	m, n := gemmOpShape(a, transA)
+	_, p := gemmOpShape(b, transB)
+	at := func(t *Tensor, trans bool, i, j int) float64 {
+		if trans {
+			return t.At(j, i)
+		}
+		return t.At(i, j)
+	}
+	out := make([]float64, m*p)
+	for i := 0; i < m; i++ {
+		for j := 0; j < p; j++ {
+			sum := 0.0
+			for k := 0; k < n; k++ {
+				sum += at(a, transA, i, k) * at(b, transB, k, j)
+			}
+			out[i*p+j] = alpha*sum + beta*c.Data[i*p+j]
+		}
+	}
+	return out
+}
+
+func assertGemm(t *testing.T, name string, got, want []float64) {
+	t.Helper()
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9*(1+math.Abs(want[i])) {
+			t.Fatalf("%s[%d] = %v, ожидалось %v", name, i, got[i], want[i])
+		}
+	}
+}
+
+func TestGemmTransposesAlphaBeta(t *testing.T) {
+	g := NewGenerator(11)
+	// 150 задевает параллельные ядра.
+	for _, dims := range [][3]int{{3, 4, 5}, {17, 9, 33}, {150, 20, 140}} {
+		m, n, p := dims[0], dims[1], dims[2]
+		for _, tr := range [][2]bool{{false, false}, {false, true}, {true, false}, {true, true}} {
+			aShape, bShape := []int{m, n}, []int{n, p}
+			if tr[0] {
+				aShape = []int{n, m}
+			}
+			if tr[1] {
+				bShape = []int{p, n}
+			}
+			a, b := g.Normal(aShape, 0, 1), g.Normal(bShape, 0, 1)
+			for _, ab := range [][2]float64{{1, 0}, {1, 1}, {-0.5, 2}} {
+				c := g.Normal([]int{m, p}, 0, 1)
+				want := gemmReference(tr[0], tr[1], ab[0], a, b, ab[1], c)
+				if err := Gemm(tr[0], tr[1], ab[0], a, b, ab[1], c); err != nil {
+					t.Fatal(err)
+				}
+				assertGemm(t, "Gemm", c.Data, want)
+			}
+		}
+	}
+}
+
+func TestGemmViewsAndBetaZeroIgnoresNaN(t *testing.T) {
+	g := NewGenerator(12)
+	base := g.Normal([]int{6, 8}, 0, 1)
+	a, _ := Narrow(base, 1, 2, 5) // [6, 5], не плотный
+	bt, _ := Transpose(g.Normal([]int{4, 5}, 0, 1))
+	c := Zeros(6, 4)
+	for i := range c.Data {
+		c.Data[i] = math.NaN()
+	}
+	want := gemmReference(false, false, 1, a, bt, 0, Zeros(6, 4))
+	if err := Gemm(false, false, 1, a, bt, 0, c); err != nil {
+		t.Fatal(err)
+	}
+	assertGemm(t, "Gemm(view)", c.Data, want)
+}
+
+func TestGemmRowStridedViews(t *testing.T) {
+	g := NewGenerator(14)
+	// Шаг [batch, features] из [batch, seq, features], как extractSlice в RNN:
+	// строки смежные, шаг строк seq·features. 150 задевает параллельные ядра.
+	for _, dims := range [][3]int{{3, 4, 5}, {17, 9, 33}, {150, 20, 140}} {
+		m, n, p := dims[0], dims[1], dims[2]
+		seq := g.Normal([]int{m, 3, n}, 0, 1)
+		step, _ := Narrow(seq, 1, 1, 1)
+		step, _ = Squeeze(step, 1) // [m, n]
+		if _, ld, ok := gemmRowMajor(step, false); !ok || ld != 3*n {
+			t.Fatalf("gemmRowMajor(step) = %d, %v; ожидалось %d, true", ld, ok, 3*n)
+		}
+		w := g.Normal([]int{n, p}, 0, 1)
+		wt := g.Normal([]int{p, n}, 0, 1)
+		grad := g.Normal([]int{m, p}, 0, 1)
+
+		got, err := MatMul(step, w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertGemm(t, "MatMul(step)", got.Data, gemmReference(false, false, 1, step, w, 0, Zeros(m, p)))
+
+		got, _ = MatMulTransposeB(step, wt)
+		assertGemm(t, "MatMulTransposeB(step)", got.Data, gemmReference(false, true, 1, step, wt, 0, Zeros(m, p)))
+
+		c := g.Normal([]int{n, p}, 0, 1)
+		want := gemmReference(true, false, 0.5, step, grad, 1, c)
+		if err := Gemm(true, false, 0.5, step, grad, 1, c); err != nil {
+			t.Fatal(err)
+		}
+		assertGemm(t, "Gemm(stepᵀ·grad)", c.Data, want)
+
+		d := g.Normal([]int{m, n}, 0, 1)
+		want = gemmReference(false, true, -1, grad, w, 1, d)
+		wide, _ := Concatenate([]*Tensor{grad, grad}, 1)
+		gradView, _ := Narrow(wide, 1, p, p) // [m, p] с шагом строк 2p
+		if err := Gemm(false, true, -1, gradView, w, 1, d); err != nil {
+			t.Fatal(err)
+		}
+		assertGemm(t, "Gemm(view·Wᵀ)", d.Data, want)
+	}
+}
+
+func TestGemmShapeErrors(t *testing.T) {
+	a, b := Zeros(2, 3), Zeros(3, 4)
+	if err := Gemm(false, false, 1, a, b, 0, Zeros(2, 5)); err == nil {
+		t.Error("ожидалась ошибка формы C")
+	}
+	if err := Gemm(true, false, 1, a, b, 0, Zeros(3, 4)); err == nil {
+		t.Error("ожидалась ошибка несовместимых форм")
+	}
+}
+
+func TestGemmBatchedStrided(t *testing.T) {
+	g := NewGenerator(13)
+	a := g.Normal([]int{3, 4, 5}, 0, 1)
+	w := g.Normal([]int{6, 5}, 0, 1) // общая для батча, используется как Wᵀ
+	c := g.Normal([]int{3, 4, 6}, 0, 1)
+	want := make([]float64, 0, len(c.Data))
+	for i := 0; i < 3; i++ {
+		ci := &Tensor{Data: c.Data[i*24 : (i+1)*24], Shape: []int{4, 6}, Strides: []int{6, 1}}
+		want = append(want, gemmReference(false, true, 2, gemmBatchItem(a, i), w, 0.5, ci)...)
+	}
+	if err := GemmBatchedStrided(false, true, 2, a, w, 0.5, c); err != nil {
+		t.Fatal(err)
+	}
+	assertGemm(t, "GemmBatchedStrided", c.Data, want)
+
+	// Батч как view: переставленные оси дают не плотные элементы.
+	pa, _ := Permute(g.Normal([]int{5, 3, 4}, 0, 1), 1, 2, 0) // [3, 4, 5]
+	b := g.Normal([]int{3, 5, 2}, 0, 1)
+	c2 := Zeros(3, 4, 2)
+	if err := GemmBatchedStrided(false, false, 1, pa, b, 0, c2); err != nil {
+		t.Fatal(err)
+	}
+	ref, _ := BatchMatMul(pa.Contiguous(), b)
+	assertGemm(t, "GemmBatchedStrided(view)", c2.Data, ref.Data)
+}
+
+func BenchmarkMatMulRowStridedView(b *testing.B) {
+	g := NewGenerator(1)
+	seq := g.Normal([]int{128, 16, 256}, 0, 1)
+	step, _ := Narrow(seq, 1, 3, 1)
+	step, _ = Squeeze(step, 1)
+	w := g.Normal([]int{256, 256}, 0, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = MatMul(step, w)
+	}
+}
+
+func BenchmarkGemmAccumulate(b *testing.B) {
+	g := NewGenerator(1)
+	x, w := g.Normal([]int{128, 256}, 0, 1), g.Normal([]int{256, 256}, 0, 1)
+	grad := Zeros(128, 256)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Gemm(false, true, 1, x, w, 1, grad)
+	}
+}
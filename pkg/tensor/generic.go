@@ -0,0 +1,470 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+)
+
+// TensorOf — N-мерный тензор с произвольным вещественным типом элементов.
+// Раскладка совпадает с Tensor: плоский Data в row-major порядке, Shape и Strides.
+// Позволяет хранить и обрабатывать данные во float32, экономя вдвое память и пропускную
+// способность. Для обучения во float32 служат graph.NodeOf, autograd.TypedEngine,
+// layers.DenseOf и optimizers.SGDOf; остальные слои и Engine работают с Tensor
+// (float64), куда данные переводятся через ToFloat64 или RowsToTensor.
+type TensorOf[T Float] struct {
+	Data    []T
+	Shape   []int
+	Strides []int
+}
+
+// Tensor32 — тензор одинарной точности.
+type Tensor32 = TensorOf[float32]
+
+// DType возвращает тип элементов тензора.
+func (t *TensorOf[T]) DType() DType {
+	return DTypeOf[T]()
+}
+
+// Size возвращает общее количество элементов в тензоре
+func (t *TensorOf[T]) Size() int64 {
+	return int64(len(t.Data))
+}
+
+// ZerosOf создаёт тензор типа T, заполненный нулями.
+func ZerosOf[T Float](shape ...int) *TensorOf[T] {
+	return &TensorOf[T]{
+		Data:    make([]T, calculateSize(shape)),
+		Shape:   append([]int{}, shape...),
+		Strides: calculateStrides(shape),
+	}
+}
+
+// OnesOf создаёт тензор типа T, заполненный единицами.
+func OnesOf[T Float](shape ...int) *TensorOf[T] {
+	t := ZerosOf[T](shape...)
+	for i := range t.Data {
+		t.Data[i] = 1
+	}
+	return t
+}
+
+// RandnOf создаёт тензор типа T со значениями из N(0, 1).
+// При одинаковом seed значения совпадают с Randn с точностью до округления к T.
+func RandnOf[T Float](shape []int, seed int64) *TensorOf[T] {
+	t := ZerosOf[T](shape...)
+	rng := NewGenerator(seed)
+	for i := range t.Data {
+		t.Data[i] = T(rng.NormFloat64())
+	}
+	return t
+}
+
+// FromRows собирает двумерный тензор из слайса строк одинаковой длины.
+func FromRows[T Float](rows [][]T) (*TensorOf[T], error) {
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("пустой список строк")
+	}
+	cols := len(rows[0])
+	t := ZerosOf[T](len(rows), cols)
+	for i, row := range rows {
+		if len(row) != cols {
+			return nil, fmt.Errorf("строка %d имеет длину %d, ожидалось %d", i, len(row), cols)
+		}
+		copy(t.Data[i*cols:(i+1)*cols], row)
+	}
+	return t, nil
+}
+
+// RowsToTensor собирает Tensor [len(rows), cols] из строк типа T за одно
+// копирование, например признаки [][]float32 из api/text.
+func RowsToTensor[T Float](rows [][]T) (*Tensor, error) {
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("пустой список строк")
+	}
+	cols := len(rows[0])
+	t := Zeros(len(rows), cols)
+	for i, row := range rows {
+		if len(row) != cols {
+			return nil, fmt.Errorf("строка %d имеет длину %d, ожидалось %d", i, len(row), cols)
+		}
+		dst := t.Data[i*cols : (i+1)*cols]
+		for j, v := range row {
+			dst[j] = float64(v)
+		}
+	}
+	return t, nil
+}
+
+// Convert приводит тензор к типу элементов U, копируя данные.
+func Convert[U, T Float](t *TensorOf[T]) *TensorOf[U] {
+	out := &TensorOf[U]{
+		Data:    make([]U, len(t.Data)),
+		Shape:   append([]int{}, t.Shape...),
+		Strides: append([]int{}, t.Strides...),
+	}
+	for i, v := range t.Data {
+		out.Data[i] = U(v)
+	}
+	return out
+}
+
+// ToFloat32 конвертирует обычный Tensor в плотный тензор одинарной точности.
+// Представления (Narrow, Permute и т. д.) копируются в логическом порядке.
+func ToFloat32(t *Tensor) *Tensor32 {
+	out := ZerosOf[float32](t.Shape...)
+	i := 0
+	forEachStrided(t, func(off int) {
+		out.Data[i] = float32(t.Data[off])
+		i++
+	})
+	return out
+}
+
+// ToFloat64 конвертирует обобщённый тензор в обычный Tensor (float64).
+func ToFloat64[T Float](t *TensorOf[T]) *Tensor {
+	out := &Tensor{
+		Data:    make([]float64, len(t.Data)),
+		Shape:   append([]int{}, t.Shape...),
+		Strides: append([]int{}, t.Strides...),
+	}
+	for i, v := range t.Data {
+		out.Data[i] = float64(v)
+	}
+	return out
+}
+
+// AsGeneric оборачивает Tensor в TensorOf[float64] без копирования данных.
+// У TensorOf нет Offset, поэтому представление сначала копируется (Contiguous).
+func AsGeneric(t *Tensor) *TensorOf[float64] {
+	t = t.Contiguous()
+	return &TensorOf[float64]{Data: t.Data, Shape: t.Shape, Strides: t.Strides}
+}
+
+// AsTensor оборачивает TensorOf[float64] в обычный Tensor без копирования данных.
+func AsTensor(t *TensorOf[float64]) *Tensor {
+	return &Tensor{Data: t.Data, Shape: t.Shape, Strides: t.Strides}
+}
+
+// binaryOf — общая реализация поэлементных операций с NumPy-broadcasting.
+func binaryOf[T Float](a, b *TensorOf[T], f func(x, y T) T) (*TensorOf[T], error) {
+	if shapesEqual(a.Shape, b.Shape) {
+		out := ZerosOf[T](a.Shape...)
+		for i := range out.Data {
+			out.Data[i] = f(a.Data[i], b.Data[i])
+		}
+		return out, nil
+	}
+
+	outShape, err := broadcastShapes(a.Shape, b.Shape)
+	if err != nil {
+		return nil, err
+	}
+	aStrides := broadcastStrides(a.Shape, a.Strides, outShape)
+	bStrides := broadcastStrides(b.Shape, b.Strides, outShape)
+
+	out := ZerosOf[T](outShape...)
+	idx := make([]int, len(outShape))
+	for i := range out.Data {
+		out.Data[i] = f(a.Data[indexOffset(idx, aStrides)], b.Data[indexOffset(idx, bStrides)])
+		nextIndex(idx, outShape)
+	}
+	return out, nil
+}
+
+// broadcastStrides возвращает strides тензора формы shape, расширенного до outShape.
+// Оси размера 1 и отсутствующие оси получают stride 0.
+func broadcastStrides(shape, strides, outShape []int) []int {
+	n, m := len(outShape), len(shape)
+	res := make([]int, n)
+	for i := n - m; i < n; i++ {
+		if shape[i-(n-m)] != 1 {
+			res[i] = strides[i-(n-m)]
+		}
+	}
+	return res
+}
+
+// AddOf выполняет поэлементное сложение с broadcasting.
+func AddOf[T Float](a, b *TensorOf[T]) (*TensorOf[T], error) {
+	return binaryOf(a, b, func(x, y T) T { return x + y })
+}
+
+// SubOf выполняет поэлементное вычитание a - b с broadcasting.
+func SubOf[T Float](a, b *TensorOf[T]) (*TensorOf[T], error) {
+	return binaryOf(a, b, func(x, y T) T { return x - y })
+}
+
+// MulOf выполняет поэлементное умножение (Адамара) с broadcasting.
+func MulOf[T Float](a, b *TensorOf[T]) (*TensorOf[T], error) {
+	return binaryOf(a, b, func(x, y T) T { return x * y })
+}
+
+// DivOf выполняет поэлементное деление a / b с broadcasting.
+func DivOf[T Float](a, b *TensorOf[T]) (*TensorOf[T], error) {
+	return binaryOf(a, b, func(x, y T) T { return x / y })
+}
+
+// ApplyOf применяет функцию f к каждому элементу тензора.
+func ApplyOf[T Float](a *TensorOf[T], f func(T) T) *TensorOf[T] {
+	out := &TensorOf[T]{
+		Data:    make([]T, len(a.Data)),
+		Shape:   append([]int{}, a.Shape...),
+		Strides: append([]int{}, a.Strides...),
+	}
+	for i, v := range a.Data {
+		out.Data[i] = f(v)
+	}
+	return out
+}
+
+// ExpOf применяет e^x к каждому элементу.
+func ExpOf[T Float](a *TensorOf[T]) *TensorOf[T] {
+	return ApplyOf(a, func(x T) T { return T(math.Exp(float64(x))) })
+}
+
+// LogOf применяет ln(x) к каждому элементу.
+func LogOf[T Float](a *TensorOf[T]) *TensorOf[T] {
+	return ApplyOf(a, func(x T) T { return T(math.Log(float64(x))) })
+}
+
+// SumOf вычисляет сумму всех элементов. Накопление ведётся во float64,
+// чтобы не терять точность на больших float32-тензорах.
+func SumOf[T Float](a *TensorOf[T]) *TensorOf[T] {
+	sum := 0.0
+	for _, v := range a.Data {
+		sum += float64(v)
+	}
+	return &TensorOf[T]{Data: []T{T(sum)}, Shape: []int{1}, Strides: []int{1}}
+}
+
+// BroadcastToOf возвращает плотную копию a, расширенную broadcasting-ом до формы shape.
+func BroadcastToOf[T Float](a *TensorOf[T], shape []int) (*TensorOf[T], error) {
+	if out, err := broadcastShapes(a.Shape, shape); err != nil || !shapesEqual(out, shape) {
+		return nil, fmt.Errorf("форма %v не расширяется до %v", a.Shape, shape)
+	}
+	strides := broadcastStrides(a.Shape, a.Strides, shape)
+	out := ZerosOf[T](shape...)
+	idx := make([]int, len(shape))
+	for i := range out.Data {
+		out.Data[i] = a.Data[indexOffset(idx, strides)]
+		nextIndex(idx, shape)
+	}
+	return out, nil
+}
+
+// SumToShapeOf суммирует a до формы shape, в которую a был broadcast-расширен
+// (см. SumToShape): обратная операция к broadcasting в AddOf, MulOf и т. д.
+func SumToShapeOf[T Float](a *TensorOf[T], shape []int) (*TensorOf[T], error) {
+	if shapesEqual(a.Shape, shape) {
+		return a, nil
+	}
+	lead := len(a.Shape) - len(shape)
+	if lead < 0 {
+		return nil, fmt.Errorf("нельзя свести форму %v к большей форме %v", a.Shape, shape)
+	}
+	for d, dim := range shape {
+		if dim != 1 && dim != a.Shape[d+lead] {
+			return nil, fmt.Errorf("форма %v не получается broadcasting-ом из %v", a.Shape, shape)
+		}
+	}
+	out := ZerosOf[T](shape...)
+	outStrides := broadcastStrides(shape, out.Strides, a.Shape)
+	idx := make([]int, len(a.Shape))
+	for _, v := range a.Data {
+		out.Data[indexOffset(idx, outStrides)] += v
+		nextIndex(idx, a.Shape)
+	}
+	return out, nil
+}
+
+// ReshapeOf изменяет форму тензора без копирования данных.
+func ReshapeOf[T Float](a *TensorOf[T], newShape []int) (*TensorOf[T], error) {
+	newSize := 1
+	for _, dim := range newShape {
+		if dim <= 0 {
+			return nil, fmt.Errorf("некорректная размерность: %d", dim)
+		}
+		newSize *= dim
+	}
+	if newSize != len(a.Data) {
+		return nil, fmt.Errorf("невозможно изменить форму тензора размера %d на форму %v (размер %d)", len(a.Data), newShape, newSize)
+	}
+	return &TensorOf[T]{
+		Data:    a.Data,
+		Shape:   append([]int{}, newShape...),
+		Strides: calculateStrides(newShape),
+	}, nil
+}
+
+// TransposeOf транспонирует двумерный тензор.
+func TransposeOf[T Float](a *TensorOf[T]) (*TensorOf[T], error) {
+	if len(a.Shape) != 2 {
+		return nil, fmt.Errorf("транспонирование требует 2D тензор, получен %dD", len(a.Shape))
+	}
+	rows, cols := a.Shape[0], a.Shape[1]
+	out := ZerosOf[T](cols, rows)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			out.Data[j*rows+i] = a.Data[i*cols+j]
+		}
+	}
+	return out, nil
+}
+
+// MatMulOf выполняет умножение матриц A[m,n] * B[n,p] для любого T из Float.
+// Использует тот же cache-blocked обход ikj и построчный планировщик, что и MatMul.
+func MatMulOf[T Float](a, b *TensorOf[T]) (*TensorOf[T], error) {
+	if len(a.Shape) != 2 || len(b.Shape) != 2 {
+		return nil, fmt.Errorf("умножение матриц требует 2D тензоры, получены %dD и %dD", len(a.Shape), len(b.Shape))
+	}
+	m, n, p := a.Shape[0], a.Shape[1], b.Shape[1]
+	if n != b.Shape[0] {
+		return nil, fmt.Errorf("несовместимые формы для умножения матриц: [%d,%d] и [%d,%d]", m, n, b.Shape[0], p)
+	}
+
+	out := ZerosOf[T](m, p)
+	if m >= ParallelThreshold || p >= ParallelThreshold {
+		matmulParallelOf(a.Data, b.Data, out.Data, m, n, p)
+	} else {
+		matmulBlockedRangeOf(a.Data, b.Data, out.Data, 0, m, n, p)
+	}
+	return out, nil
+}
+
+// matmulBlockedRangeOf — блочное умножение для диапазона строк C (C должна быть обнулена).
+func matmulBlockedRangeOf[T Float](a, b, c []T, rowStart, rowEnd, n, p int) {
+	for kk := 0; kk < n; kk += BlockSize {
+		kEnd := min(kk+BlockSize, n)
+		for jj := 0; jj < p; jj += BlockSize {
+			jEnd := min(jj+BlockSize, p)
+			for i := rowStart; i < rowEnd; i++ {
+				aRow := a[i*n : i*n+n]
+				cRow := c[i*p : i*p+p]
+				for k := kk; k < kEnd; k++ {
+					aik := aRow[k]
+					bRow := b[k*p : k*p+p]
+					j := jj
+					for ; j <= jEnd-MicroKernelSize; j += MicroKernelSize {
+						cRow[j] += aik * bRow[j]
+						cRow[j+1] += aik * bRow[j+1]
+						cRow[j+2] += aik * bRow[j+2]
+						cRow[j+3] += aik * bRow[j+3]
+					}
+					for ; j < jEnd; j++ {
+						cRow[j] += aik * bRow[j]
+					}
+				}
+			}
+		}
+	}
+}
+
+// matmulParallelOf распределяет строки C между потоками глобального ComputePool.
+func matmulParallelOf[T Float](a, b, c []T, m, n, p int) {
+	DefaultComputePool().ParallelFor(m, BlockSize, func(start, end int) {
+		matmulBlockedRangeOf(a, b, c, start, end, n, p)
+	})
+}
+
+// In-place операции для обобщённого тензора
+
+// AddInPlaceOf выполняет a = a + b (изменяет a)
+func AddInPlaceOf[T Float](a, b *TensorOf[T]) error {
+	if !shapesEqual(a.Shape, b.Shape) {
+		return fmt.Errorf("формы тензоров должны совпадать: %v != %v", a.Shape, b.Shape)
+	}
+	for i := range a.Data {
+		a.Data[i] += b.Data[i]
+	}
+	return nil
+}
+
+// SubInPlaceOf выполняет a = a - b (изменяет a)
+func SubInPlaceOf[T Float](a, b *TensorOf[T]) error {
+	if !shapesEqual(a.Shape, b.Shape) {
+		return fmt.Errorf("формы тензоров должны совпадать: %v != %v", a.Shape, b.Shape)
+	}
+	for i := range a.Data {
+		a.Data[i] -= b.Data[i]
+	}
+	return nil
+}
+
+// MulInPlaceOf выполняет a = a * b (изменяет a)
+func MulInPlaceOf[T Float](a, b *TensorOf[T]) error {
+	if !shapesEqual(a.Shape, b.Shape) {
+		return fmt.Errorf("формы тензоров должны совпадать: %v != %v", a.Shape, b.Shape)
+	}
+	for i := range a.Data {
+		a.Data[i] *= b.Data[i]
+	}
+	return nil
+}
+
+// DivInPlaceOf выполняет a = a / b (изменяет a)
+func DivInPlaceOf[T Float](a, b *TensorOf[T]) error {
+	if !shapesEqual(a.Shape, b.Shape) {
+		return fmt.Errorf("формы тензоров должны совпадать: %v != %v", a.Shape, b.Shape)
+	}
+	for i := range a.Data {
+		a.Data[i] /= b.Data[i]
+	}
+	return nil
+}
+
+// ApplyInPlaceOf применяет функцию к каждому элементу тензора in-place
+func ApplyInPlaceOf[T Float](a *TensorOf[T], f func(T) T) {
+	for i := range a.Data {
+		a.Data[i] = f(a.Data[i])
+	}
+}
+
+// ClipInPlaceOf ограничивает значения тензора в диапазоне [min, max]
+func ClipInPlaceOf[T Float](a *TensorOf[T], minVal, maxVal T) {
+	for i, v := range a.Data {
+		if v < minVal {
+			a.Data[i] = minVal
+		} else if v > maxVal {
+			a.Data[i] = maxVal
+		}
+	}
+}
+
+// FillInPlaceOf заполняет тензор константой
+func FillInPlaceOf[T Float](a *TensorOf[T], value T) {
+	for i := range a.Data {
+		a.Data[i] = value
+	}
+}
+
+// ZeroInPlaceOf обнуляет тензор
+func ZeroInPlaceOf[T Float](a *TensorOf[T]) {
+	FillInPlaceOf(a, 0)
+}
+
+// CopyIntoOf копирует данные из src в dst (dst изменяется)
+func CopyIntoOf[T Float](dst, src *TensorOf[T]) error {
+	if !shapesEqual(dst.Shape, src.Shape) {
+		return fmt.Errorf("формы тензоров должны совпадать: %v != %v", dst.Shape, src.Shape)
+	}
+	copy(dst.Data, src.Data)
+	return nil
+}
+
+// ScaleInPlaceOf умножает все элементы тензора на скаляр (a = scale * a)
+func ScaleInPlaceOf[T Float](scale T, a *TensorOf[T]) {
+	for i := range a.Data {
+		a.Data[i] *= scale
+	}
+}
+
+// AccumulateIntoOf выполняет dst = dst + alpha * src
+func AccumulateIntoOf[T Float](dst, src *TensorOf[T], alpha T) error {
+	if !shapesEqual(dst.Shape, src.Shape) {
+		return fmt.Errorf("формы тензоров должны совпадать: %v != %v", dst.Shape, src.Shape)
+	}
+	for i := range src.Data {
+		dst.Data[i] += alpha * src.Data[i]
+	}
+	return nil
+}
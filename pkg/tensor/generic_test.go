@@ -0,0 +1,215 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDTypeOf(t *testing.T) {
+	if got := DTypeOf[float32](); got != Float32 {
+		t.Errorf("DTypeOf[float32]() = %v, want float32", got)
+	}
+	if got := DTypeOf[float64](); got != Float64 {
+		t.Errorf("DTypeOf[float64]() = %v, want float64", got)
+	}
+	if Float32.Size() != 4 || Float64.Size() != 8 {
+		t.Errorf("unexpected DType sizes: %d, %d", Float32.Size(), Float64.Size())
+	}
+	if ZerosOf[float32](2).DType() != Float32 {
+		t.Errorf("Tensor32.DType() should be float32")
+	}
+}
+
+func TestGenericConstructors(t *testing.T) {
+	z := ZerosOf[float32](2, 3)
+	if len(z.Data) != 6 || z.Strides[0] != 3 || z.Strides[1] != 1 {
+		t.Fatalf("ZerosOf: data=%d strides=%v", len(z.Data), z.Strides)
+	}
+	o := OnesOf[float32](4)
+	for i, v := range o.Data {
+		if v != 1 {
+			t.Fatalf("OnesOf Data[%d] = %v", i, v)
+		}
+	}
+
+	r32 := RandnOf[float32]([]int{10}, 7)
+	r64 := Randn([]int{10}, 7)
+	for i := range r32.Data {
+		if float32(r64.Data[i]) != r32.Data[i] {
+			t.Fatalf("RandnOf[float32] must match Randn rounded to float32 at %d", i)
+		}
+	}
+}
+
+func TestGenericBinaryOpsBroadcast(t *testing.T) {
+	a := &Tensor32{Data: []float32{1, 2, 3, 4, 5, 6}, Shape: []int{2, 3}, Strides: []int{3, 1}}
+	b := &Tensor32{Data: []float32{10, 20, 30}, Shape: []int{3}, Strides: []int{1}}
+
+	sum, err := AddOf(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []float32{11, 22, 33, 14, 25, 36}
+	for i := range want {
+		if sum.Data[i] != want[i] {
+			t.Fatalf("AddOf Data[%d] = %v, want %v", i, sum.Data[i], want[i])
+		}
+	}
+
+	prod, err := MulOf(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prod.Data[5] != 180 {
+		t.Fatalf("MulOf Data[5] = %v, want 180", prod.Data[5])
+	}
+
+	if _, err := SubOf(a, &Tensor32{Data: []float32{1, 2}, Shape: []int{2}, Strides: []int{1}}); err == nil {
+		t.Fatal("expected broadcast error for [2,3] and [2]")
+	}
+}
+
+func TestMatMulOfMatchesFloat64(t *testing.T) {
+	for _, size := range []int{3, 40, 150} {
+		a64 := Randn([]int{size, size + 1}, 1)
+		b64 := Randn([]int{size + 1, size - 1}, 2)
+		want, err := MatMul(a64, b64)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := MatMulOf(ToFloat32(a64), ToFloat32(b64))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Shape[0] != size || got.Shape[1] != size-1 {
+			t.Fatalf("MatMulOf shape = %v", got.Shape)
+		}
+		for i := range want.Data {
+			if math.Abs(float64(got.Data[i])-want.Data[i]) > 1e-3*float64(size) {
+				t.Fatalf("size %d: MatMulOf Data[%d] = %v, want %v", size, i, got.Data[i], want.Data[i])
+			}
+		}
+	}
+}
+
+func TestGenericInPlaceOps(t *testing.T) {
+	a := &Tensor32{Data: []float32{1, 2, 3}, Shape: []int{3}, Strides: []int{1}}
+	b := &Tensor32{Data: []float32{1, 1, 1}, Shape: []int{3}, Strides: []int{1}}
+
+	if err := AddInPlaceOf(a, b); err != nil {
+		t.Fatal(err)
+	}
+	if err := AccumulateIntoOf(a, b, 0.5); err != nil {
+		t.Fatal(err)
+	}
+	ScaleInPlaceOf(2, a)
+	ClipInPlaceOf(a, 0, 8)
+	want := []float32{5, 7, 8}
+	for i := range want {
+		if a.Data[i] != want[i] {
+			t.Fatalf("Data[%d] = %v, want %v", i, a.Data[i], want[i])
+		}
+	}
+	if err := AddInPlaceOf(a, ZerosOf[float32](2)); err == nil {
+		t.Fatal("expected shape mismatch error")
+	}
+}
+
+func TestGenericConversions(t *testing.T) {
+	src := &Tensor{Data: []float64{0.5, -1.25}, Shape: []int{2}, Strides: []int{1}}
+	f32 := ToFloat32(src)
+	back := ToFloat64(f32)
+	for i := range src.Data {
+		if back.Data[i] != src.Data[i] {
+			t.Fatalf("round trip Data[%d] = %v, want %v", i, back.Data[i], src.Data[i])
+		}
+	}
+
+	conv := Convert[float64](f32)
+	if conv.DType() != Float64 || conv.Data[1] != -1.25 {
+		t.Fatalf("Convert: dtype=%v data=%v", conv.DType(), conv.Data)
+	}
+
+	view := AsGeneric(src)
+	view.Data[0] = 3
+	if src.Data[0] != 3 {
+		t.Fatal("AsGeneric must share data with the source tensor")
+	}
+
+	rows, err := FromRows([][]float32{{1, 2}, {3, 4}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows.Shape[0] != 2 || rows.Shape[1] != 2 || rows.Data[3] != 4 {
+		t.Fatalf("FromRows: shape=%v data=%v", rows.Shape, rows.Data)
+	}
+	if _, err := FromRows([][]float32{{1}, {1, 2}}); err == nil {
+		t.Fatal("expected error for ragged rows")
+	}
+
+	wide, err := RowsToTensor([][]float32{{1, 2.5}, {3, 4}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wide.Shape[0] != 2 || wide.Shape[1] != 2 || wide.Data[1] != 2.5 || wide.Data[3] != 4 {
+		t.Fatalf("RowsToTensor: shape=%v data=%v", wide.Shape, wide.Data)
+	}
+	if _, err := RowsToTensor([][]float32{{1}, {1, 2}}); err == nil {
+		t.Fatal("expected error for ragged rows")
+	}
+}
+
+func TestGenericConversionsOfViews(t *testing.T) {
+	src := arange(3, 4)
+	narrow, err := Narrow(src, 0, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f32 := ToFloat32(narrow)
+	if len(f32.Data) != 8 || f32.Data[0] != 4 || f32.Data[7] != 11 {
+		t.Fatalf("ToFloat32(Narrow): shape=%v data=%v", f32.Shape, f32.Data)
+	}
+	if f32.Strides[0] != 4 || f32.Strides[1] != 1 {
+		t.Fatalf("ToFloat32(Narrow): strides=%v, ожидались плотные", f32.Strides)
+	}
+
+	tr, err := Transpose(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	back := ToFloat64(ToFloat32(tr))
+	g := AsGeneric(tr)
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 3; j++ {
+			want := tr.At(i, j)
+			if back.At(i, j) != want || g.Data[i*3+j] != want {
+				t.Fatalf("[%d,%d]: ToFloat64=%v AsGeneric=%v, ожидалось %v", i, j, back.At(i, j), g.Data[i*3+j], want)
+			}
+		}
+	}
+}
+
+func TestBroadcastAndSumToShapeOf(t *testing.T) {
+	b := &TensorOf[float32]{Data: []float32{1, 2, 3}, Shape: []int{3}, Strides: []int{1}}
+	wide, err := BroadcastToOf(b, []int{2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(wide.Data) != 6 || wide.Data[3] != 1 || wide.Data[5] != 3 {
+		t.Fatalf("BroadcastToOf: %v", wide.Data)
+	}
+	back, err := SumToShapeOf(wide, []int{1, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.Data[0] != 2 || back.Data[2] != 6 {
+		t.Fatalf("SumToShapeOf: %v", back.Data)
+	}
+	if _, err := BroadcastToOf(b, []int{2, 4}); err == nil {
+		t.Fatal("expected broadcast error")
+	}
+	if _, err := SumToShapeOf(wide, []int{2}); err == nil {
+		t.Fatal("expected shape error")
+	}
+}
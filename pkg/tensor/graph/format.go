@@ -0,0 +1,58 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// String возвращает краткое описание узла без значений:
+//
+//	Node(shape=[32 10], op=autograd.MulOperation, grad=set, parents=2)
+func (n *Node) String() string {
+	if n == nil {
+		return "<nil>"
+	}
+	var b strings.Builder
+	b.WriteString("Node(")
+	if n.ID != "" {
+		fmt.Fprintf(&b, "id=%s, ", n.ID)
+	}
+	switch {
+	case n.Sparse != nil:
+		fmt.Fprintf(&b, "shape=%v sparse nnz=%d", n.Sparse.Shape(), n.Sparse.NNZ())
+	case n.Value != nil:
+		fmt.Fprintf(&b, "shape=%v", n.Value.Shape)
+	default:
+		b.WriteString("shape=?")
+	}
+	op := "leaf"
+	if n.Operation != nil {
+		op = strings.TrimPrefix(fmt.Sprintf("%T", n.Operation), "*")
+	}
+	grad := "none"
+	if n.Grad != nil {
+		grad = "set"
+	}
+	fmt.Fprintf(&b, ", op=%s, grad=%s, parents=%d)", op, grad, len(n.Parents))
+	return b.String()
+}
+
+// Format реализует fmt.Formatter: %v и %s выводят String, а %+v
+// дополнительно значение узла и статистику градиента (см. tensor.Tensor.Stats).
+func (n *Node) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('+') && n != nil:
+		io.WriteString(f, n.String())
+		if n.Value != nil {
+			fmt.Fprintf(f, "\nvalue: %v", n.Value)
+		}
+		if n.Grad != nil {
+			fmt.Fprintf(f, "\ngrad: %v", n.Grad.Stats())
+		}
+	case verb == 'v' || verb == 's':
+		io.WriteString(f, n.String())
+	default:
+		fmt.Fprintf(f, "%%!%c(*graph.Node=%s)", verb, n.String())
+	}
+}
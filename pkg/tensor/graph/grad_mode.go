@@ -0,0 +1,172 @@
+package graph
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+)
+
+// GradMode — режим autograd области (Scope).
+type GradMode int32
+
+const (
+	// ModeGrad — обычный режим: NewNode строит граф и аллоцирует Grad.
+	ModeGrad GradMode = iota
+	// ModeNoGrad — граф не строится (аналог torch.no_grad).
+	ModeNoGrad
+	// ModeInference — как ModeNoGrad, но вложенная область с ModeGrad
+	// (Scope.With) не включает граф обратно (аналог torch.inference_mode).
+	ModeInference
+)
+
+func (m GradMode) String() string {
+	switch m {
+	case ModeGrad:
+		return "grad"
+	case ModeNoGrad:
+		return "no_grad"
+	case ModeInference:
+		return "inference"
+	}
+	return "GradMode(" + strconv.Itoa(int(m)) + ")"
+}
+
+// Scope — область режима autograd. Режим не глобальный и не привязан к
+// горутине: узел хранит свою область (Node.Scope), а NewNode берёт её у
+// родителей. Поэтому всё, что вычислено из входа, созданного в области
+// NoGrad, не строит граф, а вычисления в других горутинах от неё не зависят.
+//
+// nil-область означает ModeGrad. Методы безопасны для конкурентного вызова.
+type Scope struct {
+	mode atomic.Int32
+}
+
+// NewScope создаёт область с режимом m.
+func NewScope(m GradMode) *Scope {
+	s := &Scope{}
+	s.mode.Store(int32(m))
+	return s
+}
+
+// Mode возвращает текущий режим области.
+func (s *Scope) Mode() GradMode {
+	if s == nil {
+		return ModeGrad
+	}
+	return GradMode(s.mode.Load())
+}
+
+// SetMode меняет режим области; действует на узлы, создаваемые после вызова.
+func (s *Scope) SetMode(m GradMode) {
+	s.mode.Store(int32(m))
+}
+
+// GradEnabled возвращает true в режиме ModeGrad.
+func (s *Scope) GradEnabled() bool {
+	return s.Mode() == ModeGrad
+}
+
+// With возвращает вложенную область с режимом m. Внутри ModeInference
+// вложенная область остаётся в ModeInference.
+func (s *Scope) With(m GradMode) *Scope {
+	if s.Mode() == ModeInference {
+		m = ModeInference
+	}
+	return NewScope(m)
+}
+
+// Close завершает область NoGrad: узлы, которые позже вычисляются из её
+// выходов, снова строят граф. Область ModeInference остаётся в этом режиме —
+// её тензоры не попадают в граф и после выхода из InferenceMode.
+func (s *Scope) Close() {
+	s.mode.CompareAndSwap(int32(ModeNoGrad), int32(ModeGrad))
+}
+
+// NewNode создаёт узел в области s. Вне ModeGrad Grad не аллоцируется, а
+// Parents и Operation не сохраняются. Узел без области (s == nil) следует
+// IsNoGrad.
+func (s *Scope) NewNode(value *tensor.Tensor, parents []*Node, op Operation) *Node {
+	if s == nil && IsNoGrad() {
+		return &Node{Value: value}
+	}
+	if !s.GradEnabled() {
+		return &Node{Value: value, Scope: s}
+	}
+	return &Node{
+		Value:     value,
+		Grad:      value.ZeroGrad(),
+		Parents:   parents,
+		Operation: op,
+		Scope:     s,
+	}
+}
+
+// Input возвращает лист области s с тем же (плотным или разреженным)
+// значением, что у x: вход для прямого прохода в этой области.
+func (s *Scope) Input(x *Node) *Node {
+	if x.Value == nil {
+		return &Node{Sparse: x.Sparse, Scope: s}
+	}
+	return s.NewNode(x.Value, nil, nil)
+}
+
+// scopeOf возвращает область первого родителя, у которого она задана.
+func scopeOf(parents []*Node) *Scope {
+	for _, p := range parents {
+		if p != nil && p.Scope != nil {
+			return p.Scope
+		}
+	}
+	return nil
+}
+
+// noGradDepth — глубина вложенных блоков gnn.NoGrad(func()). Она действует
+// только на узлы без области: ни у одного родителя и у Engine операции её
+// нет. Узлы из входов графа (autograd.GraphContext.Input) и областей
+// (gnn.NoGradScope) от неё не зависят, поэтому обучение через GraphContext
+// и Trainer не выключается предсказателем в соседней горутине.
+var noGradDepth atomic.Int32
+
+// EnterNoGrad увеличивает глубину no_grad для узлов без области.
+func EnterNoGrad() { noGradDepth.Add(1) }
+
+// ExitNoGrad уменьшает глубину no_grad.
+func ExitNoGrad() { noGradDepth.Add(-1) }
+
+// IsNoGrad возвращает true, когда открыт хотя бы один блок gnn.NoGrad(func()).
+func IsNoGrad() bool { return noGradDepth.Load() > 0 }
+
+type scopeKey struct{}
+
+// WithScope возвращает контекст, несущий область s (см. NewNodeCtx).
+func WithScope(ctx context.Context, s *Scope) context.Context {
+	return context.WithValue(ctx, scopeKey{}, s)
+}
+
+// ScopeFromContext возвращает область из ctx или nil.
+func ScopeFromContext(ctx context.Context) *Scope {
+	s, _ := ctx.Value(scopeKey{}).(*Scope)
+	return s
+}
+
+// WithGradMode возвращает контекст с новой областью режима m, вложенной
+// в область ctx (см. Scope.With).
+func WithGradMode(ctx context.Context, m GradMode) context.Context {
+	return WithScope(ctx, ScopeFromContext(ctx).With(m))
+}
+
+// GradModeFromContext возвращает режим области из ctx; false, если её нет.
+func GradModeFromContext(ctx context.Context) (GradMode, bool) {
+	s := ScopeFromContext(ctx)
+	return s.Mode(), s != nil
+}
+
+// NewNodeCtx создаёт узел в области из ctx; без неё — как NewNode.
+func NewNodeCtx(ctx context.Context, value *tensor.Tensor, parents []*Node, op Operation) *Node {
+	if s := ScopeFromContext(ctx); s != nil {
+		return s.NewNode(value, parents, op)
+	}
+	return NewNode(value, parents, op)
+}
@@ -0,0 +1,122 @@
+package graph
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+)
+
+func TestScope_InheritedFromParents(t *testing.T) {
+	val := &tensor.Tensor{Data: []float64{1, 2}, Shape: []int{2}}
+	param := &Node{Value: val}
+
+	s := NewScope(ModeNoGrad)
+	x := s.Input(&Node{Value: val})
+	// Параметр без области не мешает: узел берёт область входа.
+	h := NewNode(val, []*Node{param, x}, &noOp{})
+	if h.Scope != s || h.Parents != nil || h.Operation != nil || h.Grad != nil {
+		t.Fatal("узел из входа области NoGrad должен наследовать её и не строить граф")
+	}
+
+	// Другие горутины со своими узлами строят граф, пока область открыта.
+	var wg sync.WaitGroup
+	errs := make(chan string, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if n := NewNode(val, []*Node{param}, &noOp{}); n.Operation == nil || n.Grad == nil {
+				errs <- "вне области NoGrad граф должен строиться"
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for msg := range errs {
+		t.Fatal(msg)
+	}
+
+	s.Close()
+	if n := NewNode(val, []*Node{h}, &noOp{}); n.Operation == nil || n.Grad == nil {
+		t.Fatal("после Close вычисления из выходов области должны строить граф")
+	}
+}
+
+func TestScope_InferenceOverridesGrad(t *testing.T) {
+	if got := NewScope(ModeNoGrad).With(ModeGrad).Mode(); got != ModeGrad {
+		t.Fatalf("ModeGrad внутри no_grad: режим %v, ожидался %v", got, ModeGrad)
+	}
+	inf := NewScope(ModeInference)
+	if got := inf.With(ModeGrad).Mode(); got != ModeInference {
+		t.Fatalf("ModeGrad внутри inference: режим %v, ожидался %v", got, ModeInference)
+	}
+	if inf.Mode() != ModeInference {
+		t.Fatal("вложенная область не должна менять внешнюю")
+	}
+	var nilScope *Scope
+	if !nilScope.GradEnabled() || nilScope.With(ModeNoGrad).Mode() != ModeNoGrad {
+		t.Fatal("nil-область должна вести себя как ModeGrad")
+	}
+}
+
+func TestScope_CloseKeepsInference(t *testing.T) {
+	val := &tensor.Tensor{Data: []float64{1, 2}, Shape: []int{2}}
+	s := NewScope(ModeInference)
+	x := s.Input(&Node{Value: val})
+	s.Close()
+	if s.Mode() != ModeInference {
+		t.Fatalf("Close сменил режим inference на %v", s.Mode())
+	}
+	if n := NewNode(val, []*Node{x}, &noOp{}); n.Operation != nil || n.Grad != nil {
+		t.Fatal("тензоры области inference не должны попадать в граф после Close")
+	}
+}
+
+func TestNoGrad_OnlyUnscopedNodes(t *testing.T) {
+	val := &tensor.Tensor{Data: []float64{1, 2}, Shape: []int{2}}
+	param := &Node{Value: val}
+	train := NewScope(ModeGrad).Input(&Node{Value: val})
+
+	EnterNoGrad()
+	n := NewNode(val, []*Node{param}, &noOp{})
+	// Узел из входа в явной области ModeGrad (граф обучения) строит граф.
+	m := NewNode(val, []*Node{param, train}, &noOp{})
+	ExitNoGrad()
+
+	if n.Operation != nil || n.Grad != nil || n.Scope != nil {
+		t.Fatal("узел из параметров под EnterNoGrad не должен строить граф")
+	}
+	if m.Operation == nil || m.Grad == nil {
+		t.Fatal("EnterNoGrad не должен выключать граф узлов с областью ModeGrad")
+	}
+	if n := NewNode(val, []*Node{param}, &noOp{}); n.Operation == nil {
+		t.Fatal("после ExitNoGrad граф должен строиться")
+	}
+	s := NewScope(ModeNoGrad)
+	if n := NewNodeIn(s, val, []*Node{param}, &noOp{}); n.Scope != s || n.Operation != nil {
+		t.Fatal("NewNodeIn должен создавать узел без области родителей в s")
+	}
+}
+
+func TestScope_Context(t *testing.T) {
+	ctx := WithGradMode(context.Background(), ModeInference)
+	if m, ok := GradModeFromContext(ctx); !ok || m != ModeInference {
+		t.Fatalf("GradModeFromContext = %v, %v", m, ok)
+	}
+	if _, ok := GradModeFromContext(context.Background()); ok {
+		t.Fatal("в пустом контексте режима быть не должно")
+	}
+
+	val := &tensor.Tensor{Data: []float64{1, 2}, Shape: []int{2}}
+	parent := &Node{Value: val}
+	done := make(chan *Node)
+	go func() { done <- NewNodeCtx(ctx, val, []*Node{parent}, &noOp{}) }()
+	if n := <-done; n.Operation != nil || n.Grad != nil || n.Scope != ScopeFromContext(ctx) {
+		t.Fatal("NewNodeCtx должен создавать узел в области контекста")
+	}
+	if n := NewNodeCtx(context.Background(), val, []*Node{parent}, &noOp{}); n.Operation == nil || n.Grad == nil {
+		t.Fatal("контекст без области не должен отключать граф")
+	}
+}
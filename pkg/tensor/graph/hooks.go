@@ -0,0 +1,56 @@
+package graph
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+)
+
+// GradHook — хук градиента узла. Получает градиент узла, когда Backward
+// закончил его накапливать (до передачи родителям), и может вернуть новый
+// градиент; nil оставляет градиент без изменений.
+type GradHook func(grad *tensor.Tensor) *tensor.Tensor
+
+// HookHandle снимает зарегистрированный хук.
+type HookHandle struct {
+	once   sync.Once
+	remove func()
+}
+
+// NewHookHandle возвращает хэндл, вызывающий remove при первом Remove.
+func NewHookHandle(remove func()) *HookHandle {
+	return &HookHandle{remove: remove}
+}
+
+// Remove снимает хук; повторные вызовы ничего не делают.
+func (h *HookHandle) Remove() {
+	h.once.Do(h.remove)
+}
+
+// RegisterHook добавляет хук градиента узла. Хуки вызываются в порядке
+// регистрации, каждый получает результат предыдущего.
+func (n *Node) RegisterHook(hook GradHook) *HookHandle {
+	h := &hook
+	n.hooks = append(n.hooks, h)
+	return NewHookHandle(func() {
+		if i := slices.Index(n.hooks, h); i >= 0 {
+			n.hooks = slices.Delete(n.hooks, i, i+1)
+		}
+	})
+}
+
+// HasHooks сообщает, есть ли у узла хуки градиента.
+func (n *Node) HasHooks() bool {
+	return len(n.hooks) > 0
+}
+
+// ApplyHooks пропускает grad через хуки узла и возвращает итоговый градиент.
+func (n *Node) ApplyHooks(grad *tensor.Tensor) *tensor.Tensor {
+	for _, h := range slices.Clone(n.hooks) {
+		if g := (*h)(grad); g != nil {
+			grad = g
+		}
+	}
+	return grad
+}
@@ -1,21 +1,33 @@
 package graph
 
 import (
-	"sync/atomic"
-
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
 )
 
 type Node struct {
 	Value *tensor.Tensor
 
+	// Sparse — значение разреженного листового узла (см. NewSparseNode); у таких узлов Value == nil.
+	Sparse *tensor.CSR
+
 	Grad *tensor.Tensor
 
+	// GradNode — градиент в виде узла графа после Backward с CreateGraph(true);
+	// его можно дифференцировать повторно. В обычном режиме nil.
+	GradNode *Node
+
 	Parents []*Node
 
 	Operation Operation
 
 	ID string
+
+	// Scope — область режима autograd, в которой создан узел (см. Scope);
+	// nil — обычный режим.
+	Scope *Scope
+
+	// hooks — хуки градиента (см. RegisterHook).
+	hooks []*GradHook
 }
 
 type BackwardFunc func(grad *tensor.Tensor)
@@ -24,34 +36,27 @@ type Operation interface {
 	Backward(grad *tensor.Tensor)
 }
 
-// noGradDepth — счётчик вложенности no_grad для текущей горутины.
-// При > 0 NewNode не аллоцирует Grad и не строит граф (нет Parents и Operation).
-var noGradDepth atomic.Uint32
-
-// EnterNoGrad увеличивает счётчик no_grad. Вызывается из gnn.NoGrad.
-func EnterNoGrad() { noGradDepth.Add(1) }
-
-// ExitNoGrad уменьшает счётчик no_grad.
-func ExitNoGrad() { noGradDepth.Add(^uint32(0)) }
-
-// IsNoGrad возвращает true, когда мы внутри блока NoGrad.
-func IsNoGrad() bool { return noGradDepth.Load() > 0 }
-
+// NewNode создаёт узел графа в области первого родителя, у которого она
+// задана (см. Scope.NewNode). В области NoGrad или InferenceMode Grad не
+// аллоцируется, а Parents и Operation не сохраняются.
 func NewNode(value *tensor.Tensor, parents []*Node, op Operation) *Node {
-	if IsNoGrad() {
-		return &Node{
-			Value:     value,
-			Grad:      nil,
-			Parents:   nil,
-			Operation: nil,
-		}
-	}
-	return &Node{
-		Value:     value,
-		Grad:      tensor.Zeros(value.Shape...),
-		Parents:   parents,
-		Operation: op,
+	return scopeOf(parents).NewNode(value, parents, op)
+}
+
+// NewNodeIn — NewNode, в котором s задаёт область узла, если её нет ни у
+// одного родителя (например, у узла, вычисленного только из параметров).
+func NewNodeIn(s *Scope, value *tensor.Tensor, parents []*Node, op Operation) *Node {
+	if ps := scopeOf(parents); ps != nil {
+		s = ps
 	}
+	return s.NewNode(value, parents, op)
+}
+
+// NewSparseNode создаёт листовой узел с разреженным значением (например, батч
+// BoW-признаков). Градиент по такому узлу не вычисляется; его принимают операции,
+// поддерживающие разреженный вход (layers.Dense, Engine.SparseMatMul).
+func NewSparseNode(value *tensor.CSR) *Node {
+	return &Node{Sparse: value}
 }
 
 func (n *Node) IsLeaf() bool {
@@ -59,6 +64,10 @@ func (n *Node) IsLeaf() bool {
 }
 
 func (n *Node) ZeroGrad() {
+	n.GradNode = nil
+	if n.Value == nil {
+		return
+	}
 	n.Grad = tensor.Zeros(n.Value.Shape...)
 }
 
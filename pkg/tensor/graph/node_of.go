@@ -0,0 +1,60 @@
+package graph
+
+import (
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
+)
+
+// NodeOf — узел графа над tensor.TensorOf[T]: аналог Node для обучения во
+// float32 (см. autograd.TypedEngine, layers.DenseOf). Градиент имеет тот же
+// тип, что и значение, и аллоцируется при первом накоплении.
+type NodeOf[T tensor.Float] struct {
+	Value *tensor.TensorOf[T]
+	Grad  *tensor.TensorOf[T]
+
+	Parents   []*NodeOf[T]
+	Operation OperationOf[T]
+
+	// Scope — область режима autograd (см. Scope); nil — обычный режим.
+	Scope *Scope
+}
+
+// OperationOf — операция узла NodeOf: распространяет grad на родителей.
+type OperationOf[T tensor.Float] interface {
+	Backward(grad *tensor.TensorOf[T])
+}
+
+// NewNodeOf создаёт узел в области первого родителя, у которого она задана.
+// Вне ModeGrad Parents и Operation не сохраняются.
+func NewNodeOf[T tensor.Float](value *tensor.TensorOf[T], parents []*NodeOf[T], op OperationOf[T]) *NodeOf[T] {
+	var s *Scope
+	for _, p := range parents {
+		if p != nil && p.Scope != nil {
+			s = p.Scope
+			break
+		}
+	}
+	if !s.GradEnabled() {
+		return &NodeOf[T]{Value: value, Scope: s}
+	}
+	return &NodeOf[T]{Value: value, Parents: parents, Operation: op, Scope: s}
+}
+
+func (n *NodeOf[T]) IsLeaf() bool {
+	return len(n.Parents) == 0
+}
+
+// AccumulateGrad прибавляет g к градиенту узла, аллоцируя его при необходимости.
+func (n *NodeOf[T]) AccumulateGrad(g *tensor.TensorOf[T]) {
+	if n.Grad == nil {
+		n.Grad = tensor.ZerosOf[T](n.Value.Shape...)
+	}
+	if err := tensor.AccumulateIntoOf(n.Grad, g, 1); err != nil {
+		panic("graph: " + err.Error())
+	}
+}
+
+func (n *NodeOf[T]) ZeroGrad() {
+	if n.Grad != nil {
+		tensor.ZeroInPlaceOf(n.Grad)
+	}
+}
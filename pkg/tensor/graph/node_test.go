@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
@@ -15,8 +16,7 @@ func TestNewNode_NoGrad_DoesNotBuildGraphOrAllocateGrad(t *testing.T) {
 	parent := &Node{Value: val}
 	op := (*noOp)(nil) // ненулевой интерфейс для теста
 
-	EnterNoGrad()
-	defer ExitNoGrad()
+	parent.Scope = NewScope(ModeNoGrad)
 
 	n := NewNode(val, []*Node{parent}, op)
 	if n == nil {
@@ -49,24 +49,46 @@ func TestNewNode_Normal_BuildsGraphAndAllocatesGrad(t *testing.T) {
 	}
 }
 
-func TestNoGrad_Nesting(t *testing.T) {
-	if IsNoGrad() {
-		t.Fatal("IsNoGrad должен быть false до EnterNoGrad")
+func TestNoGrad_ScopeMode(t *testing.T) {
+	s := NewScope(ModeGrad)
+	if !s.GradEnabled() {
+		t.Fatal("GradEnabled должен быть true в ModeGrad")
 	}
-	EnterNoGrad()
-	if !IsNoGrad() {
-		t.Fatal("IsNoGrad должен быть true после EnterNoGrad")
+	s.SetMode(ModeNoGrad)
+	if s.GradEnabled() {
+		t.Fatal("GradEnabled должен быть false после SetMode(ModeNoGrad)")
 	}
-	EnterNoGrad()
-	if !IsNoGrad() {
-		t.Fatal("IsNoGrad должен оставаться true после второго EnterNoGrad")
+	s.Close()
+	if !s.GradEnabled() {
+		t.Fatal("GradEnabled должен быть true после Close")
 	}
-	ExitNoGrad()
-	if !IsNoGrad() {
-		t.Fatal("IsNoGrad должен оставаться true после первого ExitNoGrad")
+}
+
+func TestNodeFormat(t *testing.T) {
+	val := &tensor.Tensor{Data: []float64{1, 2, 3, 4}, Shape: []int{2, 2}, Strides: []int{2, 1}}
+	leaf := &Node{Value: val}
+	if got := leaf.String(); got != "Node(shape=[2 2], op=leaf, grad=none, parents=0)" {
+		t.Fatalf("лист: %s", got)
+	}
+
+	n := &Node{ID: "h1", Value: val, Grad: tensor.Ones(2, 2), Parents: []*Node{leaf}, Operation: &noOp{}}
+	if got := fmt.Sprint(n); got != "Node(id=h1, shape=[2 2], op=graph.noOp, grad=set, parents=1)" {
+		t.Fatalf("узел операции: %s", got)
+	}
+	got := fmt.Sprintf("%+v", n)
+	want := "Node(id=h1, shape=[2 2], op=graph.noOp, grad=set, parents=1)\n" +
+		"value: [[1.0000 2.0000]\n [3.0000 4.0000]]\n" +
+		"grad: count=4 min=1 max=1 mean=1 std=0 nan=0 inf=0"
+	if got != want {
+		t.Fatalf("%%+v:\n%s\nожидалось:\n%s", got, want)
+	}
+
+	sparse := NewSparseNode(&tensor.CSR{Rows: 2, Cols: 3, RowPtr: []int{0, 1, 1}, ColIdx: []int{2}, Values: []float64{5}})
+	if got := sparse.String(); got != "Node(shape=[2 3] sparse nnz=1, op=leaf, grad=none, parents=0)" {
+		t.Fatalf("разреженный узел: %s", got)
 	}
-	ExitNoGrad()
-	if IsNoGrad() {
-		t.Fatal("IsNoGrad должен быть false после двух ExitNoGrad")
+	var nilNode *Node
+	if got := fmt.Sprint(nilNode); got != "<nil>" {
+		t.Fatalf("nil: %s", got)
 	}
 }
@@ -0,0 +1,257 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+)
+
+// Индексация тензора другим тензором.
+// Индексы хранятся в обычных float64-тензорах и должны быть целыми числами;
+// маски трактуются как логические: ненулевой элемент — true.
+
+// toIndex проверяет, что v — целый индекс в диапазоне [0, size).
+func toIndex(v float64, size int) (int, error) {
+	i := int(v)
+	if float64(i) != v || math.IsNaN(v) {
+		return 0, fmt.Errorf("индекс %v не является целым числом", v)
+	}
+	if i < 0 || i >= size {
+		return 0, fmt.Errorf("индекс %d вне диапазона [0, %d)", i, size)
+	}
+	return i, nil
+}
+
+// checkGatherShapes проверяет совместимость форм для Gather/ScatterAdd:
+// index имеет ту же размерность, что и a, и не превышает a по осям, кроме axis.
+func checkGatherShapes(a, index *Tensor, axis int) (int, error) {
+	axis, err := normalizeAxis(axis, len(a.Shape))
+	if err != nil {
+		return 0, err
+	}
+	if len(index.Shape) != len(a.Shape) {
+		return 0, fmt.Errorf("размерность индекса %d не совпадает с размерностью тензора %d", len(index.Shape), len(a.Shape))
+	}
+	for d := range a.Shape {
+		if d != axis && index.Shape[d] > a.Shape[d] {
+			return 0, fmt.Errorf("форма индекса %v превышает форму тензора %v по оси %d", index.Shape, a.Shape, d)
+		}
+	}
+	return axis, nil
+}
+
+// forEachIndexed обходит элементы index в row-major порядке и для каждого вычисляет
+// смещение в a, где координата по оси axis заменена значением индекса.
+// f получает порядковый номер элемента index и смещение в a.Data.
+func forEachIndexed(a, index *Tensor, axis int, f func(i, aOff int)) error {
+	aStrides, iStrides := stridesOf(a), stridesOf(index)
+	n := numel(index.Shape)
+	idx := make([]int, len(index.Shape))
+	for i := 0; i < n; i++ {
+		k, err := toIndex(index.Data[index.Offset+indexOffset(idx, iStrides)], a.Shape[axis])
+		if err != nil {
+			return err
+		}
+		off := a.Offset + indexOffset(idx, aStrides) + (k-idx[axis])*aStrides[axis]
+		f(i, off)
+		nextIndex(idx, index.Shape)
+	}
+	return nil
+}
+
+// Gather собирает элементы a вдоль оси axis по индексам index (как torch.gather).
+// Для axis=1 и 3D тензора: out[i][j][k] = a[i][index[i][j][k]][k].
+// Результат имеет форму index.
+func Gather(a *Tensor, axis int, index *Tensor) (*Tensor, error) {
+	axis, err := checkGatherShapes(a, index, axis)
+	if err != nil {
+		return nil, err
+	}
+	out := Zeros(index.Shape...)
+	err = forEachIndexed(a, index, axis, func(i, aOff int) {
+		out.Data[i] = a.Data[aOff]
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScatterAdd возвращает копию dst, в которую вдоль оси axis добавлены элементы src
+// по индексам index (операция, обратная Gather):
+// out[i][index[i][j][k]][k] += src[i][j][k] для axis=1.
+// src и index должны иметь одинаковую форму.
+func ScatterAdd(dst *Tensor, axis int, index, src *Tensor) (*Tensor, error) {
+	axis, err := checkGatherShapes(dst, index, axis)
+	if err != nil {
+		return nil, err
+	}
+	if !shapesEqual(index.Shape, src.Shape) {
+		return nil, fmt.Errorf("формы индекса и источника должны совпадать: %v != %v", index.Shape, src.Shape)
+	}
+	out := dst.Clone()
+	srcData := src.Contiguous().Data
+	err = forEachIndexed(out, index, axis, func(i, off int) {
+		out.Data[off] += srcData[i]
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IndexSelect выбирает срезы a вдоль оси axis по одномерному индексу index.
+// Форма результата совпадает с a, кроме оси axis, размер которой равен len(index).
+// Например, для таблицы эмбеддингов [V, D] и индексов токенов [T] результат — [T, D].
+func IndexSelect(a *Tensor, axis int, index *Tensor) (*Tensor, error) {
+	axis, err := normalizeAxis(axis, len(a.Shape))
+	if err != nil {
+		return nil, err
+	}
+	rows, err := indexList(index, a.Shape[axis])
+	if err != nil {
+		return nil, err
+	}
+	shape := append([]int{}, a.Shape...)
+	shape[axis] = len(rows)
+	out := Zeros(shape...)
+	for i, k := range rows {
+		src, _ := Narrow(a, axis, k, 1)
+		dst, _ := Narrow(out, axis, i, 1)
+		_ = CopyInto(dst, src)
+	}
+	return out, nil
+}
+
+// IndexAdd возвращает копию dst, в которую вдоль оси axis добавлены срезы src:
+// out[..., index[i], ...] += src[..., i, ...]. Повторяющиеся индексы накапливаются.
+// Используется как обратная операция к IndexSelect.
+func IndexAdd(dst *Tensor, axis int, index, src *Tensor) (*Tensor, error) {
+	axis, err := normalizeAxis(axis, len(dst.Shape))
+	if err != nil {
+		return nil, err
+	}
+	rows, err := indexList(index, dst.Shape[axis])
+	if err != nil {
+		return nil, err
+	}
+	want := append([]int{}, dst.Shape...)
+	want[axis] = len(rows)
+	if !shapesEqual(src.Shape, want) {
+		return nil, fmt.Errorf("форма источника %v не совпадает с ожидаемой %v", src.Shape, want)
+	}
+	out := dst.Clone()
+	for i, k := range rows {
+		s, _ := Narrow(src, axis, i, 1)
+		d, _ := Narrow(out, axis, k, 1)
+		_ = AddInPlace(d, s)
+	}
+	return out, nil
+}
+
+// indexList преобразует одномерный тензор индексов в срез int с проверкой диапазона.
+func indexList(index *Tensor, size int) ([]int, error) {
+	if len(index.Shape) != 1 {
+		return nil, fmt.Errorf("индекс должен быть одномерным, получена форма %v", index.Shape)
+	}
+	rows := make([]int, index.Shape[0])
+	for i := range rows {
+		k, err := toIndex(index.At(i), size)
+		if err != nil {
+			return nil, err
+		}
+		rows[i] = k
+	}
+	return rows, nil
+}
+
+// MaskedFill возвращает копию a, в которой элементы под ненулевой маской заменены на value.
+// mask должна broadcast-иться к форме a.
+func MaskedFill(a, mask *Tensor, value float64) (*Tensor, error) {
+	m, err := broadcastTo(mask, a.Shape)
+	if err != nil {
+		return nil, err
+	}
+	out := Zeros(a.Shape...)
+	i := 0
+	forEachStrided2(a, m, func(aOff, mOff int) {
+		if m.Data[mOff] != 0 {
+			out.Data[i] = value
+		} else {
+			out.Data[i] = a.Data[aOff]
+		}
+		i++
+	})
+	return out, nil
+}
+
+// Where выбирает элементы a там, где cond ненулевой, и элементы b в остальных позициях.
+// cond, a и b broadcast-ятся к общей форме.
+func Where(cond, a, b *Tensor) (*Tensor, error) {
+	shape, err := broadcastShapes(a.Shape, b.Shape)
+	if err != nil {
+		return nil, err
+	}
+	if shape, err = broadcastShapes(cond.Shape, shape); err != nil {
+		return nil, err
+	}
+	cB, _ := broadcastTo(cond, shape)
+	aB, _ := broadcastTo(a, shape)
+	bB, _ := broadcastTo(b, shape)
+	cStrides, aStrides, bStrides := stridesOf(cB), stridesOf(aB), stridesOf(bB)
+
+	out := Zeros(shape...)
+	idx := make([]int, len(shape))
+	for i := range out.Data {
+		if cB.Data[cB.Offset+indexOffset(idx, cStrides)] != 0 {
+			out.Data[i] = aB.Data[aB.Offset+indexOffset(idx, aStrides)]
+		} else {
+			out.Data[i] = bB.Data[bB.Offset+indexOffset(idx, bStrides)]
+		}
+		nextIndex(idx, shape)
+	}
+	return out, nil
+}
+
+// MaskedSelect возвращает одномерный тензор из элементов a под ненулевой маской
+// (в row-major порядке). mask должна broadcast-иться к форме a.
+func MaskedSelect(a, mask *Tensor) (*Tensor, error) {
+	m, err := broadcastTo(mask, a.Shape)
+	if err != nil {
+		return nil, err
+	}
+	var data []float64
+	forEachStrided2(a, m, func(aOff, mOff int) {
+		if m.Data[mOff] != 0 {
+			data = append(data, a.Data[aOff])
+		}
+	})
+	return &Tensor{Data: data, Shape: []int{len(data)}, Strides: []int{1}}, nil
+}
+
+// MaskedScatter записывает элементы одномерного src в позиции ненулевой маски
+// в копии dst (в row-major порядке). Обратная операция к MaskedSelect.
+func MaskedScatter(dst, mask, src *Tensor) (*Tensor, error) {
+	m, err := broadcastTo(mask, dst.Shape)
+	if err != nil {
+		return nil, err
+	}
+	out := dst.Clone()
+	srcData := src.Contiguous().Data
+	j := 0
+	var overflow bool
+	forEachStrided2(out, m, func(off, mOff int) {
+		if m.Data[mOff] == 0 {
+			return
+		}
+		if j >= len(srcData) {
+			overflow = true
+			return
+		}
+		out.Data[off] = srcData[j]
+		j++
+	})
+	if overflow || j != len(srcData) {
+		return nil, fmt.Errorf("количество элементов источника %d не совпадает с числом элементов маски", len(srcData))
+	}
+	return out, nil
+}
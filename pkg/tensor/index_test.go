@@ -0,0 +1,83 @@
+package tensor
+
+import "testing"
+
+func TestGatherScatterAdd(t *testing.T) {
+	a := arange(2, 3)
+	index := &Tensor{Data: []float64{2, 0, 1, 1}, Shape: []int{2, 2}, Strides: []int{2, 1}}
+
+	g, err := Gather(a, 1, index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertData(t, "Gather(axis=1)", g, []int{2, 2}, []float64{2, 0, 4, 4})
+
+	s, err := ScatterAdd(Zeros(2, 3), 1, index, Ones(2, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertData(t, "ScatterAdd(axis=1)", s, []int{2, 3}, []float64{1, 0, 1, 0, 2, 0})
+
+	rows := &Tensor{Data: []float64{1, 0, 1}, Shape: []int{1, 3}, Strides: []int{3, 1}}
+	g, _ = Gather(a, 0, rows)
+	assertData(t, "Gather(axis=0)", g, []int{1, 3}, []float64{3, 1, 5})
+
+	bad := &Tensor{Data: []float64{3}, Shape: []int{1, 1}, Strides: []int{1, 1}}
+	if _, err := Gather(a, 1, bad); err == nil {
+		t.Fatal("expected out-of-range index error")
+	}
+	frac := &Tensor{Data: []float64{0.5}, Shape: []int{1, 1}, Strides: []int{1, 1}}
+	if _, err := Gather(a, 1, frac); err == nil {
+		t.Fatal("expected non-integer index error")
+	}
+}
+
+func TestIndexSelectAndIndexAdd(t *testing.T) {
+	emb := arange(4, 2)
+	tokens := &Tensor{Data: []float64{3, 0, 3}, Shape: []int{3}, Strides: []int{1}}
+
+	sel, err := IndexSelect(emb, 0, tokens)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertData(t, "IndexSelect(axis=0)", sel, []int{3, 2}, []float64{6, 7, 0, 1, 6, 7})
+
+	cols, _ := IndexSelect(emb, 1, &Tensor{Data: []float64{1}, Shape: []int{1}, Strides: []int{1}})
+	assertData(t, "IndexSelect(axis=1)", cols, []int{4, 1}, []float64{1, 3, 5, 7})
+
+	acc, err := IndexAdd(Zeros(4, 2), 0, tokens, Ones(3, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertData(t, "IndexAdd", acc, []int{4, 2}, []float64{1, 1, 0, 0, 0, 0, 2, 2})
+}
+
+func TestMaskedOpsAndWhere(t *testing.T) {
+	a := arange(2, 3)
+	mask := &Tensor{Data: []float64{1, 0, 1}, Shape: []int{3}, Strides: []int{1}}
+
+	f, err := MaskedFill(a, mask, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertData(t, "MaskedFill", f, []int{2, 3}, []float64{-1, 1, -1, -1, 4, -1})
+
+	sel, _ := MaskedSelect(a, mask)
+	assertData(t, "MaskedSelect", sel, []int{4}, []float64{0, 2, 3, 5})
+
+	back, err := MaskedScatter(Zeros(2, 3), mask, sel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertData(t, "MaskedScatter", back, []int{2, 3}, []float64{0, 0, 2, 3, 0, 5})
+	if _, err := MaskedScatter(Zeros(2, 3), mask, Ones(3)); err == nil {
+		t.Fatal("expected size mismatch error")
+	}
+
+	cond := &Tensor{Data: []float64{1, 0}, Shape: []int{2, 1}, Strides: []int{1, 1}}
+	w, err := Where(cond, a, Zeros(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertData(t, "Where", w, []int{2, 3}, []float64{0, 1, 2, 0, 0, 0})
+}
@@ -1,9 +1,5 @@
 package tensor
 
-import (
-	"math/rand"
-)
-
 // Zeros создаёт тензор заполненный нулями с указанной формой.
 // Используется для инициализации градиентов и промежуточных результатов.
 func Zeros(shape ...int) *Tensor {
@@ -40,23 +36,9 @@ func Ones(shape ...int) *Tensor {
 // Randn создаёт тензор с случайными значениями из нормального распределения N(0, 1).
 // seed определяет начальное значение генератора случайных чисел для воспроизводимости.
 // Используется для инициализации весов нейронных сетей.
+// Эквивалентно NewGenerator(seed).Normal(shape, 0, 1).
 func Randn(shape []int, seed int64) *Tensor {
-	size := calculateSize(shape)
-	data := make([]float64, size)
-	
-	rng := rand.New(rand.NewSource(seed))
-	
-	for i := range data {
-		data[i] = rng.NormFloat64()
-	}
-	
-	strides := calculateStrides(shape)
-	
-	return &Tensor{
-		Data:    data,
-		Shape:   shape,
-		Strides: strides,
-	}
+	return NewGenerator(seed).Normal(shape, 0, 1)
 }
 
 // calculateSize вычисляет общее количество элементов в тензоре по его форме.
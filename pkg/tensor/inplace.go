@@ -11,6 +11,14 @@ func AddInPlace(a, b *Tensor) error {
 		return fmt.Errorf("формы тензоров должны совпадать: %v != %v", a.Shape, b.Shape)
 	}
 
+	if !a.isCompact() || !b.isCompact() {
+		// Views (например, срезы) обходим по strides
+		forEachStrided2(a, b, func(aOff, bOff int) {
+			a.Data[aOff] += b.Data[bOff]
+		})
+		return nil
+	}
+
 	n := len(a.Data)
 	i := 0
 
@@ -42,6 +50,14 @@ func SubInPlace(a, b *Tensor) error {
 		return fmt.Errorf("формы тензоров должны совпадать: %v != %v", a.Shape, b.Shape)
 	}
 
+	if !a.isCompact() || !b.isCompact() {
+		// Views (например, срезы) обходим по strides
+		forEachStrided2(a, b, func(aOff, bOff int) {
+			a.Data[aOff] -= b.Data[bOff]
+		})
+		return nil
+	}
+
 	n := len(a.Data)
 	i := 0
 
@@ -73,6 +89,14 @@ func MulInPlace(a, b *Tensor) error {
 		return fmt.Errorf("формы тензоров должны совпадать: %v != %v", a.Shape, b.Shape)
 	}
 
+	if !a.isCompact() || !b.isCompact() {
+		// Views (например, срезы) обходим по strides
+		forEachStrided2(a, b, func(aOff, bOff int) {
+			a.Data[aOff] *= b.Data[bOff]
+		})
+		return nil
+	}
+
 	n := len(a.Data)
 	i := 0
 
@@ -104,6 +128,14 @@ func DivInPlace(a, b *Tensor) error {
 		return fmt.Errorf("формы тензоров должны совпадать: %v != %v", a.Shape, b.Shape)
 	}
 
+	if !a.isCompact() || !b.isCompact() {
+		// Views (например, срезы) обходим по strides
+		forEachStrided2(a, b, func(aOff, bOff int) {
+			a.Data[aOff] /= b.Data[bOff]
+		})
+		return nil
+	}
+
 	n := len(a.Data)
 	i := 0
 
@@ -131,6 +163,12 @@ func DivInPlace(a, b *Tensor) error {
 
 // ApplyInPlace применяет функцию к каждому элементу тензора in-place
 func ApplyInPlace(a *Tensor, f func(float64) float64) {
+	if !a.isCompact() {
+		forEachStrided(a, func(off int) {
+			a.Data[off] = f(a.Data[off])
+		})
+		return
+	}
 	for i := range a.Data {
 		a.Data[i] = f(a.Data[i])
 	}
@@ -138,6 +176,18 @@ func ApplyInPlace(a *Tensor, f func(float64) float64) {
 
 // ClipInPlace ограничивает значения тензора в диапазоне [min, max]
 func ClipInPlace(a *Tensor, minVal, maxVal float64) {
+	if !a.isCompact() {
+		ApplyInPlace(a, func(v float64) float64 {
+			if v < minVal {
+				return minVal
+			} else if v > maxVal {
+				return maxVal
+			}
+			return v
+		})
+		return
+	}
+
 	n := len(a.Data)
 	i := 0
 
@@ -164,6 +214,13 @@ func ClipInPlace(a *Tensor, minVal, maxVal float64) {
 
 // FillInPlace заполняет тензор константой
 func FillInPlace(a *Tensor, value float64) {
+	if !a.isCompact() {
+		forEachStrided(a, func(off int) {
+			a.Data[off] = value
+		})
+		return
+	}
+
 	n := len(a.Data)
 	i := 0
 
@@ -198,6 +255,14 @@ func CopyInto(dst, src *Tensor) error {
 		return fmt.Errorf("формы тензоров должны совпадать: %v != %v", dst.Shape, src.Shape)
 	}
 
+	if !dst.isCompact() || !src.isCompact() {
+		// Запись в view меняет исходный тензор
+		forEachStrided2(dst, src, func(aOff, bOff int) {
+			dst.Data[aOff] = src.Data[bOff]
+		})
+		return nil
+	}
+
 	n := len(src.Data)
 	i := 0
 
@@ -226,6 +291,13 @@ func CopyInto(dst, src *Tensor) error {
 // ScaleInPlace умножает все элементы тензора на скаляр (изменяет тензор)
 // a = scale * a
 func ScaleInPlace(scale float64, a *Tensor) {
+	if !a.isCompact() {
+		forEachStrided(a, func(off int) {
+			a.Data[off] *= scale
+		})
+		return
+	}
+
 	n := len(a.Data)
 	i := 0
 
@@ -256,6 +328,14 @@ func AccumulateInto(dst, src *Tensor, alpha float64) error {
 		return fmt.Errorf("формы тензоров должны совпадать: %v != %v", dst.Shape, src.Shape)
 	}
 
+	if !dst.isCompact() || !src.isCompact() {
+		// Views (например, срезы) обходим по strides
+		forEachStrided2(dst, src, func(aOff, bOff int) {
+			dst.Data[aOff] += alpha * src.Data[bOff]
+		})
+		return nil
+	}
+
 	n := len(src.Data)
 	i := 0
 
@@ -0,0 +1,6 @@
+// Package simd содержит ассемблерные AVX2/FMA-ядра пакета tensor.
+//
+// Ядра вынесены из tensor, потому что Go не позволяет держать ассемблер Go
+// в пакете с cgo (tensor использует cgo для BLAS). На других архитектурах
+// и с тегом purego пакет пуст, а tensor использует переносимые версии.
+package simd
@@ -0,0 +1,60 @@
+//go:build amd64 && !purego
+
+package simd
+
+// AVX2FMA — процессор и ОС поддерживают AVX2 и FMA. Определяется один раз при старте.
+var AVX2FMA = detectAVX2FMA()
+
+// detectAVX2FMA проверяет флаги CPUID и то, что ОС сохраняет регистры YMM.
+func detectAVX2FMA() bool {
+	maxLeaf, _, _, _ := cpuid(0, 0)
+	if maxLeaf < 7 {
+		return false
+	}
+	const (
+		fma     = 1 << 12
+		osxsave = 1 << 27
+		avx     = 1 << 28
+		avx2    = 1 << 5
+	)
+	_, _, ecx, _ := cpuid(1, 0)
+	if ecx&(fma|osxsave|avx) != fma|osxsave|avx {
+		return false
+	}
+	// XCR0: биты 1 и 2 — состояние XMM и YMM сохраняется при переключении контекста.
+	if xcr0, _ := xgetbv(); xcr0&6 != 6 {
+		return false
+	}
+	_, ebx, _, _ := cpuid(7, 0)
+	return ebx&avx2 != 0
+}
+
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+func xgetbv() (eax, edx uint32)
+
+// Ядра из simd_amd64.s. Длина берётся из первого аргумента, остальные срезы
+// должны быть не короче — это проверяют вызывающие функции в tensor/simd.go.
+
+//go:noescape
+func Add(a, b, result []float64)
+
+//go:noescape
+func Mul(a, b, result []float64)
+
+//go:noescape
+func FMA(a, b, c, result []float64)
+
+//go:noescape
+func Dot(a, b []float64) float64
+
+// Axpy вычисляет y[i] += alpha * x[i] для i < len(x).
+//
+//go:noescape
+func Axpy(alpha float64, x, y []float64)
+
+// Gemm4x8 — микроядро C[4×8] += A[4×k] · B[k×8]; lda, ldb, ldc — длины строк
+// матриц в элементах. Накопление идёт в регистрах, C читается и пишется один раз.
+//
+//go:noescape
+func Gemm4x8(k int, a *float64, lda int, b *float64, ldb int, c *float64, ldc int)
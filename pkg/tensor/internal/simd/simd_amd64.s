@@ -0,0 +1,368 @@
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// AVX2/FMA-ядра для tensor/simd.go. Основные циклы обрабатывают 16 элементов
+// (четыре регистра YMM по 4 float64), затем по 4, хвост — скалярно.
+// Невыровненные загрузки (VMOVUPD) допускают произвольные срезы.
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+// func xgetbv() (eax, edx uint32)
+TEXT ·xgetbv(SB), NOSPLIT, $0-8
+	MOVL $0, CX
+	XGETBV
+	MOVL AX, eax+0(FP)
+	MOVL DX, edx+4(FP)
+	RET
+
+// func Add(a, b, result []float64)
+TEXT ·Add(SB), NOSPLIT, $0-72
+	MOVQ a_base+0(FP), SI
+	MOVQ a_len+8(FP), CX
+	MOVQ b_base+24(FP), DX
+	MOVQ result_base+48(FP), DI
+	XORQ AX, AX
+
+add_loop16:
+	LEAQ 16(AX), BX
+	CMPQ BX, CX
+	JGT  add_loop4
+	VMOVUPD (SI)(AX*8), Y0
+	VMOVUPD 32(SI)(AX*8), Y1
+	VMOVUPD 64(SI)(AX*8), Y2
+	VMOVUPD 96(SI)(AX*8), Y3
+	VADDPD  (DX)(AX*8), Y0, Y0
+	VADDPD  32(DX)(AX*8), Y1, Y1
+	VADDPD  64(DX)(AX*8), Y2, Y2
+	VADDPD  96(DX)(AX*8), Y3, Y3
+	VMOVUPD Y0, (DI)(AX*8)
+	VMOVUPD Y1, 32(DI)(AX*8)
+	VMOVUPD Y2, 64(DI)(AX*8)
+	VMOVUPD Y3, 96(DI)(AX*8)
+	MOVQ    BX, AX
+	JMP     add_loop16
+
+add_loop4:
+	LEAQ 4(AX), BX
+	CMPQ BX, CX
+	JGT  add_tail
+	VMOVUPD (SI)(AX*8), Y0
+	VADDPD  (DX)(AX*8), Y0, Y0
+	VMOVUPD Y0, (DI)(AX*8)
+	MOVQ    BX, AX
+	JMP     add_loop4
+
+add_tail:
+	CMPQ AX, CX
+	JGE  add_done
+	VMOVSD (SI)(AX*8), X0
+	VADDSD (DX)(AX*8), X0, X0
+	VMOVSD X0, (DI)(AX*8)
+	INCQ   AX
+	JMP    add_tail
+
+add_done:
+	VZEROUPPER
+	RET
+
+// func Mul(a, b, result []float64)
+TEXT ·Mul(SB), NOSPLIT, $0-72
+	MOVQ a_base+0(FP), SI
+	MOVQ a_len+8(FP), CX
+	MOVQ b_base+24(FP), DX
+	MOVQ result_base+48(FP), DI
+	XORQ AX, AX
+
+mul_loop16:
+	LEAQ 16(AX), BX
+	CMPQ BX, CX
+	JGT  mul_loop4
+	VMOVUPD (SI)(AX*8), Y0
+	VMOVUPD 32(SI)(AX*8), Y1
+	VMOVUPD 64(SI)(AX*8), Y2
+	VMOVUPD 96(SI)(AX*8), Y3
+	VMULPD  (DX)(AX*8), Y0, Y0
+	VMULPD  32(DX)(AX*8), Y1, Y1
+	VMULPD  64(DX)(AX*8), Y2, Y2
+	VMULPD  96(DX)(AX*8), Y3, Y3
+	VMOVUPD Y0, (DI)(AX*8)
+	VMOVUPD Y1, 32(DI)(AX*8)
+	VMOVUPD Y2, 64(DI)(AX*8)
+	VMOVUPD Y3, 96(DI)(AX*8)
+	MOVQ    BX, AX
+	JMP     mul_loop16
+
+mul_loop4:
+	LEAQ 4(AX), BX
+	CMPQ BX, CX
+	JGT  mul_tail
+	VMOVUPD (SI)(AX*8), Y0
+	VMULPD  (DX)(AX*8), Y0, Y0
+	VMOVUPD Y0, (DI)(AX*8)
+	MOVQ    BX, AX
+	JMP     mul_loop4
+
+mul_tail:
+	CMPQ AX, CX
+	JGE  mul_done
+	VMOVSD (SI)(AX*8), X0
+	VMULSD (DX)(AX*8), X0, X0
+	VMOVSD X0, (DI)(AX*8)
+	INCQ   AX
+	JMP    mul_tail
+
+mul_done:
+	VZEROUPPER
+	RET
+
+// func FMA(a, b, c, result []float64)
+// result = a*b + c; VFMADD231PD x, y, z вычисляет z = y*x + z.
+TEXT ·FMA(SB), NOSPLIT, $0-96
+	MOVQ a_base+0(FP), SI
+	MOVQ a_len+8(FP), CX
+	MOVQ b_base+24(FP), DX
+	MOVQ c_base+48(FP), R8
+	MOVQ result_base+72(FP), DI
+	XORQ AX, AX
+
+fma_loop16:
+	LEAQ 16(AX), BX
+	CMPQ BX, CX
+	JGT  fma_loop4
+	VMOVUPD     (R8)(AX*8), Y0
+	VMOVUPD     32(R8)(AX*8), Y1
+	VMOVUPD     64(R8)(AX*8), Y2
+	VMOVUPD     96(R8)(AX*8), Y3
+	VMOVUPD     (SI)(AX*8), Y4
+	VMOVUPD     32(SI)(AX*8), Y5
+	VMOVUPD     64(SI)(AX*8), Y6
+	VMOVUPD     96(SI)(AX*8), Y7
+	VFMADD231PD (DX)(AX*8), Y4, Y0
+	VFMADD231PD 32(DX)(AX*8), Y5, Y1
+	VFMADD231PD 64(DX)(AX*8), Y6, Y2
+	VFMADD231PD 96(DX)(AX*8), Y7, Y3
+	VMOVUPD     Y0, (DI)(AX*8)
+	VMOVUPD     Y1, 32(DI)(AX*8)
+	VMOVUPD     Y2, 64(DI)(AX*8)
+	VMOVUPD     Y3, 96(DI)(AX*8)
+	MOVQ        BX, AX
+	JMP         fma_loop16
+
+fma_loop4:
+	LEAQ 4(AX), BX
+	CMPQ BX, CX
+	JGT  fma_tail
+	VMOVUPD     (R8)(AX*8), Y0
+	VMOVUPD     (SI)(AX*8), Y4
+	VFMADD231PD (DX)(AX*8), Y4, Y0
+	VMOVUPD     Y0, (DI)(AX*8)
+	MOVQ        BX, AX
+	JMP         fma_loop4
+
+fma_tail:
+	CMPQ AX, CX
+	JGE  fma_done
+	VMOVSD      (R8)(AX*8), X0
+	VMOVSD      (SI)(AX*8), X4
+	VFMADD231SD (DX)(AX*8), X4, X0
+	VMOVSD      X0, (DI)(AX*8)
+	INCQ        AX
+	JMP         fma_tail
+
+fma_done:
+	VZEROUPPER
+	RET
+
+// func Dot(a, b []float64) float64
+// Четыре независимых аккумулятора скрывают задержку FMA.
+TEXT ·Dot(SB), NOSPLIT, $0-56
+	MOVQ   a_base+0(FP), SI
+	MOVQ   a_len+8(FP), CX
+	MOVQ   b_base+24(FP), DX
+	XORQ   AX, AX
+	VXORPD Y0, Y0, Y0
+	VXORPD Y1, Y1, Y1
+	VXORPD Y2, Y2, Y2
+	VXORPD Y3, Y3, Y3
+
+dot_loop16:
+	LEAQ 16(AX), BX
+	CMPQ BX, CX
+	JGT  dot_loop4
+	VMOVUPD     (SI)(AX*8), Y4
+	VMOVUPD     32(SI)(AX*8), Y5
+	VMOVUPD     64(SI)(AX*8), Y6
+	VMOVUPD     96(SI)(AX*8), Y7
+	VFMADD231PD (DX)(AX*8), Y4, Y0
+	VFMADD231PD 32(DX)(AX*8), Y5, Y1
+	VFMADD231PD 64(DX)(AX*8), Y6, Y2
+	VFMADD231PD 96(DX)(AX*8), Y7, Y3
+	MOVQ        BX, AX
+	JMP         dot_loop16
+
+dot_loop4:
+	LEAQ 4(AX), BX
+	CMPQ BX, CX
+	JGT  dot_reduce
+	VMOVUPD     (SI)(AX*8), Y4
+	VFMADD231PD (DX)(AX*8), Y4, Y0
+	MOVQ        BX, AX
+	JMP         dot_loop4
+
+dot_reduce:
+	VADDPD       Y1, Y0, Y0
+	VADDPD       Y3, Y2, Y2
+	VADDPD       Y2, Y0, Y0
+	VEXTRACTF128 $1, Y0, X1
+	VADDPD       X1, X0, X0
+	VHADDPD      X0, X0, X0
+
+dot_tail:
+	CMPQ AX, CX
+	JGE  dot_done
+	VMOVSD      (SI)(AX*8), X4
+	VFMADD231SD (DX)(AX*8), X4, X0
+	INCQ        AX
+	JMP         dot_tail
+
+dot_done:
+	VMOVSD X0, ret+48(FP)
+	VZEROUPPER
+	RET
+
+// func Axpy(alpha float64, x, y []float64)
+TEXT ·Axpy(SB), NOSPLIT, $0-56
+	VBROADCASTSD alpha+0(FP), Y8
+	MOVQ         x_base+8(FP), SI
+	MOVQ         x_len+16(FP), CX
+	MOVQ         y_base+32(FP), DI
+	XORQ         AX, AX
+
+axpy_loop16:
+	LEAQ 16(AX), BX
+	CMPQ BX, CX
+	JGT  axpy_loop4
+	VMOVUPD     (DI)(AX*8), Y0
+	VMOVUPD     32(DI)(AX*8), Y1
+	VMOVUPD     64(DI)(AX*8), Y2
+	VMOVUPD     96(DI)(AX*8), Y3
+	VFMADD231PD (SI)(AX*8), Y8, Y0
+	VFMADD231PD 32(SI)(AX*8), Y8, Y1
+	VFMADD231PD 64(SI)(AX*8), Y8, Y2
+	VFMADD231PD 96(SI)(AX*8), Y8, Y3
+	VMOVUPD     Y0, (DI)(AX*8)
+	VMOVUPD     Y1, 32(DI)(AX*8)
+	VMOVUPD     Y2, 64(DI)(AX*8)
+	VMOVUPD     Y3, 96(DI)(AX*8)
+	MOVQ        BX, AX
+	JMP         axpy_loop16
+
+axpy_loop4:
+	LEAQ 4(AX), BX
+	CMPQ BX, CX
+	JGT  axpy_tail
+	VMOVUPD     (DI)(AX*8), Y0
+	VFMADD231PD (SI)(AX*8), Y8, Y0
+	VMOVUPD     Y0, (DI)(AX*8)
+	MOVQ        BX, AX
+	JMP         axpy_loop4
+
+axpy_tail:
+	CMPQ AX, CX
+	JGE  axpy_done
+	VMOVSD      (DI)(AX*8), X0
+	VFMADD231SD (SI)(AX*8), X8, X0
+	VMOVSD      X0, (DI)(AX*8)
+	INCQ        AX
+	JMP         axpy_tail
+
+axpy_done:
+	VZEROUPPER
+	RET
+
+// func Gemm4x8(k int, a *float64, lda int, b *float64, ldb int, c *float64, ldc int)
+// Y0..Y7 — плитка C 4×8 (по две половины на строку), Y8/Y9 — строка B,
+// Y10/Y11 — элементы A, размноженные на весь регистр.
+TEXT ·Gemm4x8(SB), NOSPLIT, $0-56
+	MOVQ k+0(FP), CX
+	MOVQ a+8(FP), SI
+	MOVQ lda+16(FP), R8
+	SHLQ $3, R8
+	MOVQ b+24(FP), DX
+	MOVQ ldb+32(FP), R9
+	SHLQ $3, R9
+	MOVQ c+40(FP), DI
+	MOVQ ldc+48(FP), R10
+	SHLQ $3, R10
+
+	LEAQ (SI)(R8*1), R11
+	LEAQ (R11)(R8*1), R12
+	LEAQ (R12)(R8*1), R13
+
+	VXORPD Y0, Y0, Y0
+	VXORPD Y1, Y1, Y1
+	VXORPD Y2, Y2, Y2
+	VXORPD Y3, Y3, Y3
+	VXORPD Y4, Y4, Y4
+	VXORPD Y5, Y5, Y5
+	VXORPD Y6, Y6, Y6
+	VXORPD Y7, Y7, Y7
+
+	TESTQ CX, CX
+	JLE   gemm_store
+
+gemm_loop:
+	VMOVUPD      (DX), Y8
+	VMOVUPD      32(DX), Y9
+	VBROADCASTSD (SI), Y10
+	VFMADD231PD  Y8, Y10, Y0
+	VFMADD231PD  Y9, Y10, Y1
+	VBROADCASTSD (R11), Y11
+	VFMADD231PD  Y8, Y11, Y2
+	VFMADD231PD  Y9, Y11, Y3
+	VBROADCASTSD (R12), Y10
+	VFMADD231PD  Y8, Y10, Y4
+	VFMADD231PD  Y9, Y10, Y5
+	VBROADCASTSD (R13), Y11
+	VFMADD231PD  Y8, Y11, Y6
+	VFMADD231PD  Y9, Y11, Y7
+	ADDQ         $8, SI
+	ADDQ         $8, R11
+	ADDQ         $8, R12
+	ADDQ         $8, R13
+	ADDQ         R9, DX
+	DECQ         CX
+	JNZ          gemm_loop
+
+gemm_store:
+	VADDPD  (DI), Y0, Y0
+	VADDPD  32(DI), Y1, Y1
+	VMOVUPD Y0, (DI)
+	VMOVUPD Y1, 32(DI)
+	ADDQ    R10, DI
+	VADDPD  (DI), Y2, Y2
+	VADDPD  32(DI), Y3, Y3
+	VMOVUPD Y2, (DI)
+	VMOVUPD Y3, 32(DI)
+	ADDQ    R10, DI
+	VADDPD  (DI), Y4, Y4
+	VADDPD  32(DI), Y5, Y5
+	VMOVUPD Y4, (DI)
+	VMOVUPD Y5, 32(DI)
+	ADDQ    R10, DI
+	VADDPD  (DI), Y6, Y6
+	VADDPD  32(DI), Y7, Y7
+	VMOVUPD Y6, (DI)
+	VMOVUPD Y7, 32(DI)
+	VZEROUPPER
+	RET
@@ -1,8 +1,8 @@
 package tensor
 
 import (
+	"context"
 	"fmt"
-	"sync"
 )
 
 // Размер блока для блочного умножения матриц (оптимален для кеша L1)
@@ -24,6 +24,15 @@ const (
 // Использует оптимизированный алгоритм с блочным умножением для лучшей локальности кеша.
 // Автоматически выбирает наилучшую реализацию (BLAS, SIMD, блочное умножение).
 func MatMul(a, b *Tensor) (*Tensor, error) {
+	return matmulWithPool(DefaultComputePool(), a, b)
+}
+
+// MatMulContext — MatMul, использующий пул потоков из ctx (см. WithComputePool).
+func MatMulContext(ctx context.Context, a, b *Tensor) (*Tensor, error) {
+	return matmulWithPool(ComputePoolFromContext(ctx), a, b)
+}
+
+func matmulWithPool(pool *ComputePool, a, b *Tensor) (*Tensor, error) {
 	// Проверка размерностей
 	if len(a.Shape) != 2 || len(b.Shape) != 2 {
 		return nil, fmt.Errorf("умножение матриц требует 2D тензоры, получены %dD и %dD", len(a.Shape), len(b.Shape))
@@ -37,17 +46,18 @@ func MatMul(a, b *Tensor) (*Tensor, error) {
 		return nil, fmt.Errorf("несовместимые формы для умножения матриц: [%d,%d] и [%d,%d]", m, n, b.Shape[0], p)
 	}
 
+	// Views (транспонированные, срезы) обрабатываются без копирования
+	if !a.isCompact() || !b.isCompact() {
+		return matmulViews(pool, a, b, m, n, p)
+	}
+
 	// Для очень больших матриц используем BLAS (если доступен)
 	if BLASAvailable && (m >= BLASThreshold || p >= BLASThreshold || n >= BLASThreshold) {
 		return MatMulBLAS(a, b)
 	}
 
 	// Создаем результирующий тензор
-	result := &Tensor{
-		Data:    make([]float64, m*p),
-		Shape:   []int{m, p},
-		Strides: []int{p, 1},
-	}
+	result := regionOf(a, b).zeros(m, p)
 
 	// Адаптивный выбор алгоритма на основе размера матриц
 	matrixSize := m * n * p
@@ -55,7 +65,7 @@ func MatMul(a, b *Tensor) (*Tensor, error) {
 	if m >= ParallelThreshold || p >= ParallelThreshold {
 		// Tiled MatMul v2 для больших матриц: worker по строкам + упаковка плиток B
 		blockSize := chooseBlockSize(m, n, p)
-		matmulParallelBlockedV2(a.Data, b.Data, result.Data, m, n, p, blockSize)
+		matmulParallelBlockedV2Acc(pool, a.Data, n, b.Data, p, result.Data, m, n, p, blockSize)
 	} else if m >= BlockSizeSmall || p >= BlockSizeSmall {
 		// Cache-blocked MatMul v2 для средних матриц
 		blockSize := chooseBlockSize(m, n, p)
@@ -155,6 +165,23 @@ func matmulBlockedV2(a, b, c []float64, m, n, p int, blockSize int) {
 	for i := range c {
 		c[i] = 0.0
 	}
+	matmulBlockedV2Acc(a, n, b, p, c, m, n, p, blockSize)
+}
+
+// matmulBlockedV2Acc добавляет A*B к C (без обнуления); используется Gemm.
+// lda и ldb — шаги строк A и B (n и p для плотных матриц), C плотная [m, p].
+func matmulBlockedV2Acc(a []float64, lda int, b []float64, ldb int, c []float64, m, n, p int, blockSize int) {
+	// С AVX2/FMA микроядро читает B напрямую, упаковка не нужна.
+	if useAVX2FMA {
+		for jj := 0; jj < p; jj += blockSize {
+			jEnd := min(jj+blockSize, p)
+			for kk := 0; kk < n; kk += blockSize {
+				kEnd := min(kk+blockSize, n)
+				matmulKernelAVX2(a, b, c, lda, ldb, p, 0, m, kk, kEnd, jj, jEnd)
+			}
+		}
+		return
+	}
 
 	var packedBBuf [4096]float64
 	packedB := packedBBuf[:blockSize*blockSize]
@@ -163,11 +190,11 @@ func matmulBlockedV2(a, b, c []float64, m, n, p int, blockSize int) {
 		jSize := min(blockSize, p-jj)
 		for kk := 0; kk < n; kk += blockSize {
 			kSize := min(blockSize, n-kk)
-			packBTileTransposed(b, packedB[:jSize*kSize], kk, jj, kSize, jSize, p)
+			packBTileTransposed(b, packedB[:jSize*kSize], kk, jj, kSize, jSize, ldb)
 
 			for ii := 0; ii < m; ii += blockSize {
 				iEnd := min(ii+blockSize, m)
-				matmulKernelPackedB(a, c, packedB[:jSize*kSize], ii, iEnd, kk, kSize, jj, jSize, n, p)
+				matmulKernelPackedB(a, c, packedB[:jSize*kSize], ii, iEnd, kk, kSize, jj, jSize, lda, p)
 			}
 		}
 	}
@@ -198,36 +225,17 @@ func matmulMicroKernel(a, b, c []float64, iStart, iEnd, kStart, kEnd, jStart, jE
 }
 
 // matmulParallelBlocked - параллельное блочное умножение матриц
-// Использует worker pool для параллелизма на уровне строк
+// Строки делятся между потоками глобального ComputePool
 func matmulParallelBlocked(a, b, c []float64, m, n, p int) {
 	// Инициализируем результат нулями
 	for i := range c {
 		c[i] = 0.0
 	}
 
-	numWorkers := matmulWorkerCount(m, BlockSize)
-	blockRows := matmulChunkRows(m, numWorkers, BlockSize)
-	scheduler := newMatmulRowScheduler(m, blockRows)
-
-	var wg sync.WaitGroup
-
-	// Запускаем воркеры
-	for w := 0; w < numWorkers; w++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for {
-				start, end, ok := scheduler.next()
-				if !ok {
-					return
-				}
-				// Блочное умножение для диапазона строк
-				matmulBlockedRange(a, b, c, start, end, n, p)
-			}
-		}()
-	}
-
-	wg.Wait()
+	DefaultComputePool().ParallelFor(m, BlockSize, func(start, end int) {
+		// Блочное умножение для диапазона строк
+		matmulBlockedRange(a, b, c, start, end, n, p)
+	})
 }
 
 // matmulBlockedRange - блочное умножение для диапазона строк
@@ -262,10 +270,11 @@ func MatMulTransposeB(a, b *Tensor) (*Tensor, error) {
 		return nil, fmt.Errorf("несовместимые формы для умножения матриц с транспонированием")
 	}
 
-	result := &Tensor{
-		Data:    make([]float64, m*p),
-		Shape:   []int{m, p},
-		Strides: []int{p, 1},
+	result := regionOf(a, b).zeros(m, p)
+
+	if !a.isCompact() || !b.isCompact() {
+		gemm(DefaultComputePool(), false, true, 1, a, b, 0, result.Data, m, n, p)
+		return result, nil
 	}
 
 	// Оптимизированное умножение A * B^T через dot product
@@ -307,10 +316,11 @@ func MatMulTransposeA(a, b *Tensor) (*Tensor, error) {
 		return nil, fmt.Errorf("несовместимые формы для умножения матриц с транспонированием")
 	}
 
-	result := &Tensor{
-		Data:    make([]float64, m*p),
-		Shape:   []int{m, p},
-		Strides: []int{p, 1},
+	result := regionOf(a, b).zeros(m, p)
+
+	if !a.isCompact() || !b.isCompact() {
+		gemm(DefaultComputePool(), true, false, 1, a, b, 0, result.Data, m, n, p)
+		return result, nil
 	}
 
 	// Обнуляем результат
@@ -355,7 +365,8 @@ func packBTileTransposed(b, packed []float64, kk, jj, kSize, jSize, p int) {
 
 // matmulBlockedSIMD - историческая SIMD-реализация tiled matmul.
 // Сохраняется как отдельный путь для прямого вызова и обратной совместимости.
-// Основной MatMul при этом использует v2 и не зависит от этой функции.
+// Основной MatMul при этом использует v2 и не зависит от этой функции;
+// на AVX2/FMA оба пути сводятся к микроядру matmulKernelAVX2.
 func matmulBlockedSIMD(a, b, c []float64, m, n, p int, blockSize int) {
 	for i := range c {
 		c[i] = 0.0
@@ -427,44 +438,98 @@ func matmulKernelPackedB(a, c, packedB []float64, iStart, iEnd, kk, kSize, jj, j
 	}
 }
 
-// matmulParallelBlockedV2 - параллельный tiled MatMul v2.
-// Каждый worker владеет диапазоном строк C и собственной упакованной плиткой B.
+// matmulParallelBlockedV2 - параллельный tiled MatMul v2 на глобальном ComputePool.
+// Каждый чанк строк C обрабатывается одним потоком с собственной упакованной плиткой B.
 func matmulParallelBlockedV2(a, b, c []float64, m, n, p int, blockSize int) {
 	for i := range c {
 		c[i] = 0.0
 	}
+	matmulParallelBlockedV2Acc(DefaultComputePool(), a, n, b, p, c, m, n, p, blockSize)
+}
 
-	numWorkers := matmulWorkerCount(m, blockSize)
-	blockRows := matmulChunkRows(m, numWorkers, blockSize)
-	scheduler := newMatmulRowScheduler(m, blockRows)
-
-	var wg sync.WaitGroup
-	for w := 0; w < numWorkers; w++ {
-		wg.Add(1)
-		// Передаем переменные как аргументы, чтобы избежать аллокации замыкания (closure escape)
-		go func(sched *matmulRowScheduler, a, b, c []float64, m, n, p, blockSize int, wg *sync.WaitGroup) {
-			defer wg.Done()
-			var packedBBuf [4096]float64
-			packedB := packedBBuf[:blockSize*blockSize]
-			for {
-				start, end, ok := sched.next()
-				if !ok {
-					return
-				}
-				for jj := 0; jj < p; jj += blockSize {
-					jSize := min(blockSize, p-jj)
-					for kk := 0; kk < n; kk += blockSize {
-						kSize := min(blockSize, n-kk)
-						packBTileTransposed(b, packedB[:jSize*kSize], kk, jj, kSize, jSize, p)
-						for ii := start; ii < end; ii += blockSize {
-							iEnd := min(ii+blockSize, end)
-							matmulKernelPackedB(a, c, packedB[:jSize*kSize], ii, iEnd, kk, kSize, jj, jSize, n, p)
-						}
-					}
+// matmulParallelBlockedV2Acc добавляет A*B к C (без обнуления); используется Gemm.
+// lda и ldb — шаги строк A и B, как в matmulBlockedV2Acc.
+func matmulParallelBlockedV2Acc(pool *ComputePool, a []float64, lda int, b []float64, ldb int, c []float64, m, n, p int, blockSize int) {
+	// Чанк — blockSize строк C; каждый чанк упаковывает свои плитки B.
+	pool.ParallelFor(m, blockSize, func(start, end int) {
+		var packedBBuf [4096]float64
+		packedB := packedBBuf[:blockSize*blockSize]
+		for jj := 0; jj < p; jj += blockSize {
+			jSize := min(blockSize, p-jj)
+			for kk := 0; kk < n; kk += blockSize {
+				kSize := min(blockSize, n-kk)
+				if useAVX2FMA {
+					matmulKernelAVX2(a, b, c, lda, ldb, p, start, end, kk, kk+kSize, jj, jj+jSize)
+					continue
 				}
+				packBTileTransposed(b, packedB[:jSize*kSize], kk, jj, kSize, jSize, ldb)
+				matmulKernelPackedB(a, c, packedB[:jSize*kSize], start, end, kk, kSize, jj, jSize, lda, p)
 			}
-		}(scheduler, a, b, c, m, n, p, blockSize, &wg)
+		}
+	})
+}
+
+// matmulViews умножает матрицы, хотя бы одна из которых — view.
+// Транспонированные плотные матрицы сводятся к MatMulTransposeA/B, остальные views
+// передаются в gemm без материализации операндов: views со смежными строками
+// (срезы по первой оси) идут в блочные ядра, прочие — в strided-ядро.
+func matmulViews(pool *ComputePool, a, b *Tensor, m, n, p int) (*Tensor, error) {
+	if a.isCompact() {
+		if base, ok := transposedBase(b); ok {
+			return MatMulTransposeB(a, base)
+		}
+	}
+	if b.isCompact() {
+		if base, ok := transposedBase(a); ok {
+			return MatMulTransposeA(base, b)
+		}
 	}
 
-	wg.Wait()
+	result := regionOf(a, b).zeros(m, p)
+	gemm(pool, false, false, 1, a, b, 0, result.Data, m, n, p)
+	return result, nil
+}
+
+// transposedBase проверяет, что t — результат Transpose плотной матрицы,
+// и возвращает исходную матрицу без копирования.
+func transposedBase(t *Tensor) (*Tensor, bool) {
+	if len(t.Shape) != 2 || len(t.Strides) != 2 || t.Offset != 0 {
+		return nil, false
+	}
+	rows, cols := t.Shape[0], t.Shape[1]
+	if t.Strides[0] != 1 || t.Strides[1] != rows || len(t.Data) != rows*cols {
+		return nil, false
+	}
+	return &Tensor{Data: t.Data, Shape: []int{cols, rows}, Strides: []int{rows, 1}}, true
+}
+
+// matmulStrided - умножение матриц с произвольными strides операндов.
+// A[i,k] = a[aOff + i*as0 + k*as1], B[k,j] = b[bOff + k*bs0 + j*bs1]; C плотная [m,p].
+// Результат C должен быть обнулён.
+func matmulStrided(a []float64, aOff, as0, as1 int, b []float64, bOff, bs0, bs1 int, c []float64, m, n, p int) {
+	for i := 0; i < m; i++ {
+		cRow := c[i*p : (i+1)*p]
+		aBase := aOff + i*as0
+		for k := 0; k < n; k++ {
+			aik := a[aBase+k*as1]
+			bBase := bOff + k*bs0
+			if bs1 == 1 {
+				bRow := b[bBase : bBase+p]
+				j := 0
+				for ; j <= p-MicroKernelSize; j += MicroKernelSize {
+					cRow[j] += aik * bRow[j]
+					cRow[j+1] += aik * bRow[j+1]
+					cRow[j+2] += aik * bRow[j+2]
+					cRow[j+3] += aik * bRow[j+3]
+				}
+				for ; j < p; j++ {
+					cRow[j] += aik * bRow[j]
+				}
+				continue
+			}
+			for j := 0; j < p; j++ {
+				cRow[j] += aik * b[bBase+j*bs1]
+			}
+		}
+	}
 }
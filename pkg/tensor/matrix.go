@@ -0,0 +1,152 @@
+package tensor
+
+import (
+	"fmt"
+	"iter"
+)
+
+// Matrix и Tensor разделяют представление row-major, поэтому преобразования между
+// ними не копируют данные, а операции над Matrix выполняются ядрами Tensor
+// (Gemm, поэлементные SIMD-ядра, ComputePool).
+
+// AsTensor возвращает двумерный тензор [Rows, Cols], разделяющий Data с матрицей.
+func (m *Matrix) AsTensor() *Tensor {
+	return &Tensor{
+		Data:    m.Data[:m.Rows*m.Cols],
+		Shape:   []int{m.Rows, m.Cols},
+		Strides: []int{m.Cols, 1},
+	}
+}
+
+// TensorAsMatrix оборачивает двумерный тензор в Matrix без копирования.
+// Тензор должен лежать в памяти подряд в row-major порядке (допускается Offset,
+// например у среза строк); для транспонированных и прочих views используйте
+// t.Contiguous().
+func TensorAsMatrix(t *Tensor) (*Matrix, error) {
+	if len(t.Shape) != 2 {
+		return nil, fmt.Errorf("ожидается двумерный тензор, получена форма %v", t.Shape)
+	}
+	if !t.IsContiguous() {
+		return nil, fmt.Errorf("тензор формы %v не лежит в памяти подряд: используйте Contiguous", t.Shape)
+	}
+	rows, cols := t.Shape[0], t.Shape[1]
+	return &Matrix{Data: t.Data[t.Offset : t.Offset+rows*cols], Rows: rows, Cols: cols}, nil
+}
+
+// matrixOf оборачивает плотный результат операции над тензорами.
+func matrixOf(t *Tensor) *Matrix {
+	return &Matrix{Data: t.Data, Rows: t.Shape[0], Cols: t.Shape[1]}
+}
+
+// At возвращает элемент (i, j).
+func (m *Matrix) At(i, j int) float64 {
+	return m.Data[i*m.Cols+j]
+}
+
+// Set записывает элемент (i, j).
+func (m *Matrix) Set(i, j int, v float64) {
+	m.Data[i*m.Cols+j] = v
+}
+
+// MatMul возвращает произведение m·o, вычисленное через Gemm.
+func (m *Matrix) MatMul(o *Matrix) (*Matrix, error) {
+	if m.Cols != o.Rows {
+		return nil, fmt.Errorf("несовместимые размеры матриц: [%d,%d] и [%d,%d]", m.Rows, m.Cols, o.Rows, o.Cols)
+	}
+	c := Zeros(m.Rows, o.Cols)
+	if err := Gemm(false, false, 1, m.AsTensor(), o.AsTensor(), 0, c); err != nil {
+		return nil, err
+	}
+	return matrixOf(c), nil
+}
+
+// Add возвращает поэлементную сумму m + o (с broadcasting, как Add для тензоров).
+func (m *Matrix) Add(o *Matrix) (*Matrix, error) {
+	return m.zip(o, Add)
+}
+
+// Sub возвращает поэлементную разность m - o.
+func (m *Matrix) Sub(o *Matrix) (*Matrix, error) {
+	return m.zip(o, Sub)
+}
+
+// Mul возвращает поэлементное произведение (Адамара) m ⊙ o.
+func (m *Matrix) Mul(o *Matrix) (*Matrix, error) {
+	return m.zip(o, Mul)
+}
+
+// Div возвращает поэлементное частное m / o.
+func (m *Matrix) Div(o *Matrix) (*Matrix, error) {
+	return m.zip(o, Div)
+}
+
+func (m *Matrix) zip(o *Matrix, op func(a, b *Tensor) (*Tensor, error)) (*Matrix, error) {
+	t, err := op(m.AsTensor(), o.AsTensor())
+	if err != nil {
+		return nil, err
+	}
+	return matrixOf(t), nil
+}
+
+// Scale возвращает матрицу, умноженную на скаляр s.
+func (m *Matrix) Scale(s float64) *Matrix {
+	t := m.AsTensor().Clone()
+	ScaleInPlace(s, t)
+	return matrixOf(t)
+}
+
+// Apply возвращает матрицу из f(x) для каждого элемента x.
+func (m *Matrix) Apply(f func(float64) float64) *Matrix {
+	return matrixOf(Apply(m.AsTensor(), f))
+}
+
+// Transpose возвращает транспонированную копию матрицы.
+// Для транспонирования без копирования используйте Transpose(m.AsTensor()).
+func (m *Matrix) Transpose() *Matrix {
+	t, _ := Transpose(m.AsTensor())
+	return matrixOf(t.Contiguous())
+}
+
+// Row возвращает view [Cols] на строку i, разделяющий данные с матрицей.
+func (m *Matrix) Row(i int) *Tensor {
+	return &Tensor{Data: m.Data, Shape: []int{m.Cols}, Strides: []int{1}, Offset: i * m.Cols}
+}
+
+// Col возвращает view [Rows] на столбец j (шаг Cols), разделяющий данные с матрицей.
+func (m *Matrix) Col(j int) *Tensor {
+	return &Tensor{Data: m.Data, Shape: []int{m.Rows}, Strides: []int{m.Cols}, Offset: j}
+}
+
+// RowSlices перебирает строки матрицы как срезы Data (без копирования):
+//
+//	for i, row := range m.RowSlices() { ... }
+func (m *Matrix) RowSlices() iter.Seq2[int, []float64] {
+	return func(yield func(int, []float64) bool) {
+		for i := 0; i < m.Rows; i++ {
+			if !yield(i, m.Data[i*m.Cols:(i+1)*m.Cols]) {
+				return
+			}
+		}
+	}
+}
+
+// Columns перебирает столбцы матрицы как views (см. Col).
+func (m *Matrix) Columns() iter.Seq2[int, *Tensor] {
+	return func(yield func(int, *Tensor) bool) {
+		for j := 0; j < m.Cols; j++ {
+			if !yield(j, m.Col(j)) {
+				return
+			}
+		}
+	}
+}
+
+// Each вызывает f для каждого элемента в row-major порядке.
+func (m *Matrix) Each(f func(i, j int, v float64)) {
+	for i := 0; i < m.Rows; i++ {
+		row := m.Data[i*m.Cols : (i+1)*m.Cols]
+		for j, v := range row {
+			f(i, j, v)
+		}
+	}
+}
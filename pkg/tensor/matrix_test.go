@@ -0,0 +1,134 @@
+package tensor
+
+import "testing"
+
+func TestMatrixTensorRoundTrip(t *testing.T) {
+	m := &Matrix{Data: []float64{1, 2, 3, 4, 5, 6}, Rows: 2, Cols: 3}
+	x := m.AsTensor()
+	assertShape(t, x, 2, 3)
+	x.Data[4] = 50
+	if m.At(1, 1) != 50 {
+		t.Fatalf("AsTensor должен разделять данные с матрицей")
+	}
+
+	rows, err := Narrow(x, 0, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	back, err := TensorAsMatrix(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.Rows != 1 || back.Cols != 3 || back.At(0, 1) != 50 {
+		t.Fatalf("TensorAsMatrix(срез строки) = %+v", back)
+	}
+	back.Set(0, 0, -4)
+	if m.At(1, 0) != -4 {
+		t.Fatalf("TensorAsMatrix должен разделять данные с тензором")
+	}
+
+	tr, _ := Transpose(x)
+	if _, err := TensorAsMatrix(tr); err == nil {
+		t.Fatalf("ожидалась ошибка для транспонированного view")
+	}
+	if _, err := TensorAsMatrix(arange(2, 3, 4)); err == nil {
+		t.Fatalf("ожидалась ошибка для трёхмерного тензора")
+	}
+}
+
+func TestMatrixOps(t *testing.T) {
+	a := &Matrix{Data: []float64{1, 2, 3, 4, 5, 6}, Rows: 2, Cols: 3}
+	b := &Matrix{Data: []float64{6, 5, 4, 3, 2, 1}, Rows: 2, Cols: 3}
+
+	check := func(name string, got *Matrix, want []float64) {
+		t.Helper()
+		if !floatsEqual(got.Data, want) {
+			t.Fatalf("%s = %v, ожидалось %v", name, got.Data, want)
+		}
+	}
+
+	sum, err := a.Add(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("Add", sum, []float64{7, 7, 7, 7, 7, 7})
+	diff, _ := a.Sub(b)
+	check("Sub", diff, []float64{-5, -3, -1, 1, 3, 5})
+	prod, _ := a.Mul(b)
+	check("Mul", prod, []float64{6, 10, 12, 12, 10, 6})
+	check("Scale", a.Scale(2), []float64{2, 4, 6, 8, 10, 12})
+	check("Apply", a.Apply(func(v float64) float64 { return v * v }), []float64{1, 4, 9, 16, 25, 36})
+
+	at := a.Transpose()
+	if at.Rows != 3 || at.Cols != 2 {
+		t.Fatalf("Transpose: размер %dx%d", at.Rows, at.Cols)
+	}
+	check("Transpose", at, []float64{1, 4, 2, 5, 3, 6})
+
+	mm, err := a.MatMul(at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("MatMul", mm, []float64{14, 32, 32, 77})
+	if _, err := a.MatMul(b); err == nil {
+		t.Fatalf("ожидалась ошибка для несовместимых размеров")
+	}
+	if _, err := a.Add(&Matrix{Data: []float64{1, 2}, Rows: 1, Cols: 2}); err == nil {
+		t.Fatalf("ожидалась ошибка для несовместимых форм")
+	}
+}
+
+func TestMatrixViewsAndIterators(t *testing.T) {
+	m := &Matrix{Data: []float64{1, 2, 3, 4, 5, 6}, Rows: 2, Cols: 3}
+
+	col := m.Col(2)
+	assertShape(t, col, 2)
+	if got := col.Contiguous().Data; got[0] != 3 || got[1] != 6 {
+		t.Fatalf("Col(2) = %v", got)
+	}
+	row := m.Row(1)
+	if got := row.Contiguous().Data; got[0] != 4 || got[2] != 6 {
+		t.Fatalf("Row(1) = %v", got)
+	}
+	ScaleInPlace(10, col)
+	if m.At(0, 2) != 30 || m.At(1, 2) != 60 {
+		t.Fatalf("Col должен быть view: %v", m.Data)
+	}
+
+	var sums []float64
+	for _, r := range m.RowSlices() {
+		s := 0.0
+		for _, v := range r {
+			s += v
+		}
+		sums = append(sums, s)
+	}
+	if len(sums) != 2 || sums[0] != 33 || sums[1] != 69 {
+		t.Fatalf("RowSlices: суммы строк %v", sums)
+	}
+
+	visited := 0
+	for j, c := range m.Columns() {
+		if c.Shape[0] != 2 {
+			t.Fatalf("столбец %d формы %v", j, c.Shape)
+		}
+		visited++
+		if j == 1 {
+			break
+		}
+	}
+	if visited != 2 {
+		t.Fatalf("Columns: break не остановил перебор, посещено %d", visited)
+	}
+
+	total := 0.0
+	m.Each(func(i, j int, v float64) {
+		if v != m.At(i, j) {
+			t.Fatalf("Each(%d, %d) = %v", i, j, v)
+		}
+		total += v
+	})
+	if total != 102 {
+		t.Fatalf("Each: сумма %v", total)
+	}
+}
@@ -0,0 +1,186 @@
+package tensor
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"unsafe"
+)
+
+// MmapTensor — массив только для чтения, данные которого лежат в файле,
+// отображённом в память. На Linux файл отображается через mmap: открытие не
+// читает данные и не расходует кучу, страницы подгружаются ОС по мере обращения.
+// На других ОС файл читается в память целиком (см. mmap_other.go).
+//
+// Данные в формате float64 little-endian доступны без копирования через Tensor;
+// строки любого типа можно получить копией в float64 через Rows.
+// После Close все полученные через Tensor тензоры недействительны.
+type MmapTensor struct {
+	mapping []byte
+	data    []byte
+	dtype   DType
+	order   binary.ByteOrder
+	shape   []int
+}
+
+// OpenMmap отображает в память файл path с «сырыми» данными: элементы типа
+// dtype в порядке little-endian, row-major, без заголовка (как ndarray.tofile).
+// Размер файла должен точно соответствовать форме shape.
+func OpenMmap(path string, dtype DType, shape []int) (*MmapTensor, error) {
+	if dtype.Size() == 0 {
+		return nil, fmt.Errorf("mmap: неподдерживаемый тип %v", dtype)
+	}
+	m, err := mapFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.init(m.mapping, dtype, binary.LittleEndian, shape, true); err != nil {
+		m.Close()
+		return nil, err
+	}
+	return m, nil
+}
+
+// OpenMmapNPY отображает в память файл .npy (см. ReadNPY); форма и тип берутся
+// из заголовка. Поддерживаются только массивы в порядке C.
+func OpenMmapNPY(path string) (*MmapTensor, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("mmap: %w", err)
+	}
+	cr := &countingReader{r: f}
+	h, err := readNPYHeader(cr)
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+	if h.fortran && len(h.shape) > 1 {
+		return nil, fmt.Errorf("mmap: массивы в порядке Fortran не поддерживаются")
+	}
+
+	m, err := mapFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.init(m.mapping[cr.n:], h.dtype, h.order, h.shape, false); err != nil {
+		m.Close()
+		return nil, err
+	}
+	return m, nil
+}
+
+// countingReader считает прочитанные байты (длину заголовка .npy).
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func mapFile(path string) (*MmapTensor, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("mmap: %w", err)
+	}
+	defer f.Close()
+	st, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("mmap: %w", err)
+	}
+	if st.Size() > math.MaxInt {
+		return nil, fmt.Errorf("mmap: файл %s слишком велик", path)
+	}
+	mapping, err := mmapFile(f, int(st.Size()))
+	if err != nil {
+		return nil, fmt.Errorf("mmap: %s: %w", path, err)
+	}
+	return &MmapTensor{mapping: mapping}, nil
+}
+
+// init проверяет, что data вмещает массив формы shape, и заполняет поля.
+func (m *MmapTensor) init(data []byte, dtype DType, order binary.ByteOrder, shape []int, exact bool) error {
+	if len(shape) == 0 {
+		shape = []int{1}
+	}
+	n := 1
+	for _, d := range shape {
+		if d < 0 || (d > 0 && n > math.MaxInt/dtype.Size()/d) {
+			return fmt.Errorf("mmap: некорректная форма %v", shape)
+		}
+		n *= d
+	}
+	need := n * dtype.Size()
+	if len(data) < need || (exact && len(data) != need) {
+		return fmt.Errorf("mmap: размер данных %d байт не соответствует форме %v типа %v (%d байт)", len(data), shape, dtype, need)
+	}
+	m.data = data[:need]
+	m.dtype = dtype
+	m.order = order
+	m.shape = append([]int{}, shape...)
+	return nil
+}
+
+// Shape возвращает форму массива.
+func (m *MmapTensor) Shape() []int {
+	return append([]int{}, m.shape...)
+}
+
+// DType возвращает тип элементов в файле.
+func (m *MmapTensor) DType() DType {
+	return m.dtype
+}
+
+// Tensor возвращает тензор, Data которого указывает прямо на отображённую
+// память (без копирования). Доступно только для float64 little-endian.
+// Тензор только для чтения: запись в Data на Linux завершит программу с SIGSEGV.
+func (m *MmapTensor) Tensor() (*Tensor, error) {
+	if m.dtype != Float64 || m.order != binary.LittleEndian || !hostLittleEndian {
+		return nil, fmt.Errorf("mmap: тензор без копирования доступен только для float64 little-endian, тип файла %v", m.dtype)
+	}
+	var data []float64
+	if len(m.data) > 0 {
+		if uintptr(unsafe.Pointer(&m.data[0]))%8 != 0 {
+			return nil, fmt.Errorf("mmap: данные не выровнены по 8 байтам")
+		}
+		data = unsafe.Slice((*float64)(unsafe.Pointer(&m.data[0])), len(m.data)/8)
+	}
+	return &Tensor{Data: data, Shape: m.Shape(), Strides: calculateStrides(m.shape)}, nil
+}
+
+// Rows копирует строки [start, end) по первой оси в новый тензор float64
+// формы [end-start, shape[1:]...].
+func (m *MmapTensor) Rows(start, end int) (*Tensor, error) {
+	if start < 0 || end > m.shape[0] || start > end {
+		return nil, fmt.Errorf("mmap: строки [%d, %d) вне диапазона [0, %d)", start, end, m.shape[0])
+	}
+	rowLen := 1
+	for _, d := range m.shape[1:] {
+		rowLen *= d
+	}
+	size := m.dtype.Size()
+	raw := m.data[start*rowLen*size : end*rowLen*size]
+	shape := append([]int{end - start}, m.shape[1:]...)
+	return &Tensor{Data: decodeNPY(raw, (end-start)*rowLen, m.dtype, m.order), Shape: shape, Strides: calculateStrides(shape)}, nil
+}
+
+// Close освобождает отображение файла.
+func (m *MmapTensor) Close() error {
+	if m.mapping == nil {
+		return nil
+	}
+	err := munmapFile(m.mapping)
+	m.mapping, m.data = nil, nil
+	return err
+}
+
+// hostLittleEndian — порядок байтов текущей платформы.
+var hostLittleEndian = func() bool {
+	x := uint16(1)
+	return *(*byte)(unsafe.Pointer(&x)) == 1
+}()
@@ -0,0 +1,23 @@
+//go:build linux
+
+package tensor
+
+import (
+	"os"
+	"syscall"
+)
+
+// mmapFile отображает первые size байт файла в память только для чтения.
+func mmapFile(f *os.File, size int) ([]byte, error) {
+	if size == 0 {
+		return []byte{}, nil
+	}
+	return syscall.Mmap(int(f.Fd()), 0, size, syscall.PROT_READ, syscall.MAP_SHARED)
+}
+
+func munmapFile(b []byte) error {
+	if len(b) == 0 {
+		return nil
+	}
+	return syscall.Munmap(b)
+}
@@ -0,0 +1,21 @@
+//go:build !linux
+
+package tensor
+
+import (
+	"io"
+	"os"
+)
+
+// mmapFile без поддержки mmap читает файл в память целиком.
+func mmapFile(f *os.File, size int) ([]byte, error) {
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(f, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+func munmapFile(b []byte) error {
+	return nil
+}
@@ -0,0 +1,124 @@
+package tensor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestOpenMmapFloat64ZeroCopy(t *testing.T) {
+	path := writeFile(t, "x.f64", encodeValues(binary.LittleEndian, []float64{0, 1, 2, 3, 4, 5}))
+	m, err := OpenMmap(path, Float64, []int{3, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	x, err := m.Tensor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertShape(t, x, 3, 2)
+	if x.At(2, 1) != 5 || x.At(1, 0) != 2 {
+		t.Fatalf("неверные данные: %v", x.Data)
+	}
+	// Операции над отображённым тензором создают обычные тензоры в куче.
+	y := Apply(x, func(v float64) float64 { return 2 * v })
+	y.Data[0] = 7
+	if x.Data[0] != 0 || y.At(2, 1) != 10 {
+		t.Fatalf("неверный результат операции: %v", y.Data)
+	}
+
+	rows, err := m.Rows(1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertShape(t, rows, 2, 2)
+	if !floatsEqual(rows.Data, []float64{2, 3, 4, 5}) {
+		t.Fatalf("Rows: %v", rows.Data)
+	}
+}
+
+func TestOpenMmapConvertsOtherTypes(t *testing.T) {
+	path := writeFile(t, "x.u8", []byte{1, 2, 3, 250, 251, 252})
+	m, err := OpenMmap(path, Uint8, []int{2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	if _, err := m.Tensor(); err == nil {
+		t.Error("ожидалась ошибка: uint8 нельзя отдать без копирования")
+	}
+	rows, err := m.Rows(1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !floatsEqual(rows.Data, []float64{250, 251, 252}) || m.DType() != Uint8 {
+		t.Fatalf("Rows: %v", rows.Data)
+	}
+	if _, err := m.Rows(1, 3); err == nil {
+		t.Error("ожидалась ошибка выхода за диапазон строк")
+	}
+}
+
+func TestOpenMmapNPY(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteNPY(&buf, arange(4, 3), WithNPYDType(Float32), WithNPYBigEndian()); err != nil {
+		t.Fatal(err)
+	}
+	m, err := OpenMmapNPY(writeFile(t, "x.npy", buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	if s := m.Shape(); !shapesEqual(s, []int{4, 3}) || m.DType() != Float32 {
+		t.Fatalf("неверный заголовок: %v %v", s, m.DType())
+	}
+	rows, err := m.Rows(3, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !floatsEqual(rows.Data, []float64{9, 10, 11}) {
+		t.Fatalf("Rows: %v", rows.Data)
+	}
+
+	buf.Reset()
+	if err := WriteNPY(&buf, arange(2, 2)); err != nil {
+		t.Fatal(err)
+	}
+	m2, err := OpenMmapNPY(writeFile(t, "y.npy", buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m2.Close()
+	x, err := m2.Tensor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !floatsEqual(x.Data, []float64{0, 1, 2, 3}) {
+		t.Fatalf("Tensor: %v", x.Data)
+	}
+}
+
+func TestOpenMmapSizeMismatch(t *testing.T) {
+	path := writeFile(t, "x.f64", make([]byte, 40))
+	if _, err := OpenMmap(path, Float64, []int{2, 3}); err == nil {
+		t.Error("ожидалась ошибка несоответствия размера файла форме")
+	}
+	if _, err := OpenMmap(filepath.Join(t.TempDir(), "missing"), Float64, []int{1}); err == nil {
+		t.Error("ожидалась ошибка открытия файла")
+	}
+}
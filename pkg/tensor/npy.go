@@ -0,0 +1,436 @@
+package tensor
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Формат .npy (NumPy): магическая строка "\x93NUMPY", версия, длина заголовка
+// и сам заголовок — литерал словаря Python с ключами descr, fortran_order и
+// shape, дополненный пробелами до кратности 64 байтам. Затем идут сырые данные.
+// Архив .npz — zip из файлов <имя>.npy (сжатый или нет).
+
+var npyMagic = []byte("\x93NUMPY")
+
+// npyMaxHeaderLen ограничивает заголовок, чтобы повреждённый файл не приводил
+// к огромной аллокации.
+const npyMaxHeaderLen = 1 << 20
+
+// npyHeader — разобранный заголовок .npy.
+type npyHeader struct {
+	dtype   DType
+	order   binary.ByteOrder
+	fortran bool
+	shape   []int
+}
+
+type npyOptions struct {
+	dtype    DType
+	order    binary.ByteOrder
+	fortran  bool
+	compress bool
+}
+
+// NPYOption настраивает запись в WriteNPY и WriteNPZ.
+type NPYOption func(*npyOptions)
+
+// WithNPYDType задаёт тип элементов в файле (по умолчанию Float64).
+// Для целочисленных типов дробная часть отбрасывается, значения вне
+// диапазона типа и NaN приводят к ошибке.
+func WithNPYDType(d DType) NPYOption {
+	return func(o *npyOptions) { o.dtype = d }
+}
+
+// WithNPYBigEndian записывает данные в порядке big-endian (по умолчанию little-endian).
+func WithNPYBigEndian() NPYOption {
+	return func(o *npyOptions) { o.order = binary.BigEndian }
+}
+
+// WithNPYFortranOrder записывает элементы по столбцам (fortran_order: True).
+func WithNPYFortranOrder() NPYOption {
+	return func(o *npyOptions) { o.fortran = true }
+}
+
+// WithNPZCompression сжимает элементы архива .npz (как np.savez_compressed).
+func WithNPZCompression() NPYOption {
+	return func(o *npyOptions) { o.compress = true }
+}
+
+// ReadNPY читает массив из потока в формате .npy.
+// Поддерживаются float32, float64, int32, int64 и uint8 в любом порядке байтов;
+// значения преобразуются в float64 (int64 по модулю больше 2^53 теряют точность).
+// Массив в порядке Fortran не копируется: тензор получает column-major strides.
+// Нульмерный массив читается как тензор формы [1].
+func ReadNPY(r io.Reader) (*Tensor, error) {
+	h, err := readNPYHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	size := h.dtype.Size()
+	n := 1
+	for _, d := range h.shape {
+		if d > 0 && n > math.MaxInt/size/d {
+			return nil, fmt.Errorf("npy: слишком большая форма %v", h.shape)
+		}
+		n *= d
+	}
+	raw := make([]byte, n*size)
+	if _, err := io.ReadFull(r, raw); err != nil {
+		return nil, fmt.Errorf("npy: чтение данных: %w", err)
+	}
+
+	shape := h.shape
+	if len(shape) == 0 {
+		shape = []int{1}
+	}
+	strides := calculateStrides(shape)
+	if h.fortran {
+		stride := 1
+		for i, d := range shape {
+			strides[i] = stride
+			stride *= d
+		}
+	}
+	return &Tensor{Data: decodeNPY(raw, n, h.dtype, h.order), Shape: shape, Strides: strides}, nil
+}
+
+func readNPYHeader(r io.Reader) (*npyHeader, error) {
+	pre := make([]byte, len(npyMagic)+2)
+	if _, err := io.ReadFull(r, pre); err != nil {
+		return nil, fmt.Errorf("npy: чтение заголовка: %w", err)
+	}
+	if !bytes.Equal(pre[:len(npyMagic)], npyMagic) {
+		return nil, fmt.Errorf("npy: неверная сигнатура файла")
+	}
+	var headerLen int
+	switch major := pre[len(npyMagic)]; major {
+	case 1:
+		var l uint16
+		if err := binary.Read(r, binary.LittleEndian, &l); err != nil {
+			return nil, fmt.Errorf("npy: чтение заголовка: %w", err)
+		}
+		headerLen = int(l)
+	case 2, 3:
+		var l uint32
+		if err := binary.Read(r, binary.LittleEndian, &l); err != nil {
+			return nil, fmt.Errorf("npy: чтение заголовка: %w", err)
+		}
+		if l > npyMaxHeaderLen {
+			return nil, fmt.Errorf("npy: слишком длинный заголовок (%d байт)", l)
+		}
+		headerLen = int(l)
+	default:
+		return nil, fmt.Errorf("npy: неподдерживаемая версия формата %d.%d", major, pre[len(npyMagic)+1])
+	}
+	header := make([]byte, headerLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("npy: чтение заголовка: %w", err)
+	}
+	return parseNPYHeader(string(header))
+}
+
+var (
+	npyDescrRe   = regexp.MustCompile(`['"]descr['"]\s*:\s*['"]([^'"]*)['"]`)
+	npyFortranRe = regexp.MustCompile(`['"]fortran_order['"]\s*:\s*(True|False)`)
+	npyShapeRe   = regexp.MustCompile(`['"]shape['"]\s*:\s*\(([^)]*)\)`)
+)
+
+// parseNPYHeader разбирает словарь заголовка .npy.
+func parseNPYHeader(s string) (*npyHeader, error) {
+	descr := npyDescrRe.FindStringSubmatch(s)
+	fortran := npyFortranRe.FindStringSubmatch(s)
+	shape := npyShapeRe.FindStringSubmatch(s)
+	if descr == nil || fortran == nil || shape == nil {
+		return nil, fmt.Errorf("npy: некорректный заголовок %q", strings.TrimSpace(s))
+	}
+	h := &npyHeader{fortran: fortran[1] == "True", shape: []int{}}
+	var err error
+	if h.dtype, h.order, err = parseNPYDescr(descr[1]); err != nil {
+		return nil, err
+	}
+	for _, part := range strings.Split(shape[1], ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		d, err := strconv.Atoi(strings.TrimSuffix(part, "L"))
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("npy: некорректная форма (%s)", shape[1])
+		}
+		h.shape = append(h.shape, d)
+	}
+	return h, nil
+}
+
+// parseNPYDescr разбирает строку типа NumPy вида '<f8'.
+func parseNPYDescr(descr string) (DType, binary.ByteOrder, error) {
+	if len(descr) < 3 {
+		return 0, nil, fmt.Errorf("npy: неподдерживаемый тип %q", descr)
+	}
+	var order binary.ByteOrder
+	switch descr[0] {
+	case '<', '=', '|':
+		order = binary.LittleEndian
+	case '>':
+		order = binary.BigEndian
+	default:
+		return 0, nil, fmt.Errorf("npy: неподдерживаемый тип %q", descr)
+	}
+	switch descr[1:] {
+	case "f8":
+		return Float64, order, nil
+	case "f4":
+		return Float32, order, nil
+	case "i4":
+		return Int32, order, nil
+	case "i8":
+		return Int64, order, nil
+	case "u1":
+		return Uint8, order, nil
+	}
+	return 0, nil, fmt.Errorf("npy: неподдерживаемый тип %q", descr)
+}
+
+// npyDescr возвращает строку типа NumPy для dtype и порядка байтов.
+func npyDescr(d DType, order binary.ByteOrder) (string, error) {
+	var kind string
+	switch d {
+	case Float64:
+		kind = "f8"
+	case Float32:
+		kind = "f4"
+	case Int32:
+		kind = "i4"
+	case Int64:
+		kind = "i8"
+	case Uint8:
+		return "|u1", nil
+	default:
+		return "", fmt.Errorf("npy: неподдерживаемый тип %v", d)
+	}
+	if order == binary.BigEndian {
+		return ">" + kind, nil
+	}
+	return "<" + kind, nil
+}
+
+func decodeNPY(raw []byte, n int, d DType, order binary.ByteOrder) []float64 {
+	out := make([]float64, n)
+	switch d {
+	case Float64:
+		for i := range out {
+			out[i] = math.Float64frombits(order.Uint64(raw[i*8:]))
+		}
+	case Float32:
+		for i := range out {
+			out[i] = float64(math.Float32frombits(order.Uint32(raw[i*4:])))
+		}
+	case Int32:
+		for i := range out {
+			out[i] = float64(int32(order.Uint32(raw[i*4:])))
+		}
+	case Int64:
+		for i := range out {
+			out[i] = float64(int64(order.Uint64(raw[i*8:])))
+		}
+	case Uint8:
+		for i := range out {
+			out[i] = float64(raw[i])
+		}
+	}
+	return out
+}
+
+// encodeNPY записывает значение v как элемент типа d в buf.
+func encodeNPY(buf []byte, v float64, d DType, order binary.ByteOrder) error {
+	if d != Float64 && d != Float32 {
+		lo, hi := npyIntRange(d)
+		if !(v > lo && v < hi) {
+			return fmt.Errorf("npy: значение %v вне диапазона %v", v, d)
+		}
+	}
+	switch d {
+	case Float64:
+		order.PutUint64(buf, math.Float64bits(v))
+	case Float32:
+		order.PutUint32(buf, math.Float32bits(float32(v)))
+	case Int32:
+		order.PutUint32(buf, uint32(int32(v)))
+	case Int64:
+		order.PutUint64(buf, uint64(int64(v)))
+	case Uint8:
+		buf[0] = uint8(v)
+	}
+	return nil
+}
+
+// npyIntRange возвращает интервал (lo, hi) значений, которые после отбрасывания
+// дробной части помещаются в целый тип d.
+func npyIntRange(d DType) (lo, hi float64) {
+	switch d {
+	case Int32:
+		return math.MinInt32 - 1, math.MaxInt32 + 1
+	case Int64:
+		return math.Nextafter(math.MinInt64, math.Inf(-1)), math.MaxInt64
+	default: // Uint8
+		return -1, math.MaxUint8 + 1
+	}
+}
+
+// WriteNPY записывает тензор в поток в формате .npy (версия 1.0, а для очень
+// длинных заголовков — 2.0). По умолчанию — little-endian float64 в порядке C;
+// см. WithNPYDType, WithNPYBigEndian и WithNPYFortranOrder. Views записываются
+// в логическом порядке элементов.
+func WriteNPY(w io.Writer, t *Tensor, opts ...NPYOption) error {
+	o := npyOptions{dtype: Float64, order: binary.LittleEndian}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	descr, err := npyDescr(o.dtype, o.order)
+	if err != nil {
+		return err
+	}
+
+	src := t
+	if o.fortran && len(t.Shape) > 1 {
+		// Обход транспонированного view в порядке C — это обход t по столбцам.
+		dims := make([]int, len(t.Shape))
+		for i := range dims {
+			dims[i] = len(dims) - 1 - i
+		}
+		if src, err = Permute(t, dims...); err != nil {
+			return err
+		}
+	}
+	size := o.dtype.Size()
+	raw := make([]byte, numel(t.Shape)*size)
+	i := 0
+	forEachStrided(src, func(off int) {
+		if err == nil {
+			err = encodeNPY(raw[i*size:], src.Data[off], o.dtype, o.order)
+		}
+		i++
+	})
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.Write(npyHeaderBytes(descr, o.fortran, t.Shape))
+	bw.Write(raw)
+	return bw.Flush()
+}
+
+// npyHeaderBytes формирует сигнатуру, версию, длину и заголовок, выровненные до 64 байт.
+func npyHeaderBytes(descr string, fortran bool, shape []int) []byte {
+	dims := make([]string, len(shape))
+	for i, d := range shape {
+		dims[i] = strconv.Itoa(d)
+	}
+	tuple := "(" + strings.Join(dims, ", ") + ")"
+	if len(shape) == 1 {
+		tuple = "(" + dims[0] + ",)"
+	}
+	fortranStr := "False"
+	if fortran {
+		fortranStr = "True"
+	}
+	dict := fmt.Sprintf("{'descr': '%s', 'fortran_order': %s, 'shape': %s, }", descr, fortranStr, tuple)
+
+	major, lenBytes := byte(1), 2
+	if len(dict)+1+len(npyMagic)+2+lenBytes > math.MaxUint16 {
+		major, lenBytes = 2, 4
+	}
+	pre := len(npyMagic) + 2 + lenBytes
+	headerLen := len(dict) + 1
+	if rem := (pre + headerLen) % 64; rem != 0 {
+		headerLen += 64 - rem
+	}
+
+	out := make([]byte, 0, pre+headerLen)
+	out = append(out, npyMagic...)
+	out = append(out, major, 0)
+	if major == 1 {
+		out = binary.LittleEndian.AppendUint16(out, uint16(headerLen))
+	} else {
+		out = binary.LittleEndian.AppendUint32(out, uint32(headerLen))
+	}
+	out = append(out, dict...)
+	for len(out) < pre+headerLen-1 {
+		out = append(out, ' ')
+	}
+	return append(out, '\n')
+}
+
+// ReadNPZ читает все массивы архива .npz. Ключи — имена файлов без расширения .npy.
+func ReadNPZ(path string) (map[string]*Tensor, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("npz: %w", err)
+	}
+	defer zr.Close()
+
+	out := make(map[string]*Tensor, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("npz: %s: %w", f.Name, err)
+		}
+		t, err := ReadNPY(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("npz: %s: %w", f.Name, err)
+		}
+		out[strings.TrimSuffix(f.Name, ".npy")] = t
+	}
+	return out, nil
+}
+
+// WriteNPZ записывает массивы в архив .npz (в порядке ключей); каждый массив
+// сохраняется как <ключ>.npy с параметрами opts (см. WriteNPY и WithNPZCompression).
+func WriteNPZ(path string, arrays map[string]*Tensor, opts ...NPYOption) error {
+	var o npyOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	method := zip.Store
+	if o.compress {
+		method = zip.Deflate
+	}
+	keys := make([]string, 0, len(arrays))
+	for k := range arrays {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("npz: %w", err)
+	}
+	zw := zip.NewWriter(f)
+	for _, k := range keys {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: k + ".npy", Method: method})
+		if err == nil {
+			err = WriteNPY(w, arrays[k], opts...)
+		}
+		if err != nil {
+			f.Close()
+			return fmt.Errorf("npz: %s: %w", k, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		f.Close()
+		return fmt.Errorf("npz: %w", err)
+	}
+	return f.Close()
+}
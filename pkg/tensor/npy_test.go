@@ -0,0 +1,220 @@
+package tensor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// npyFile собирает .npy версии 1.0 так же, как np.save: заголовок дополняется
+// пробелами и '\n' до кратности 64 байтам.
+func npyFile(header string, data []byte) []byte {
+	n := len(header) + 1
+	if rem := (10 + n) % 64; rem != 0 {
+		n += 64 - rem
+	}
+	var buf bytes.Buffer
+	buf.WriteString("\x93NUMPY\x01\x00")
+	binary.Write(&buf, binary.LittleEndian, uint16(n))
+	buf.WriteString(header)
+	buf.WriteString(strings.Repeat(" ", n-len(header)-1))
+	buf.WriteByte('\n')
+	buf.Write(data)
+	return buf.Bytes()
+}
+
+func encodeValues(order binary.ByteOrder, values ...any) []byte {
+	var buf bytes.Buffer
+	for _, v := range values {
+		binary.Write(&buf, order, v)
+	}
+	return buf.Bytes()
+}
+
+func TestReadNPYFloat64COrder(t *testing.T) {
+	raw := npyFile("{'descr': '<f8', 'fortran_order': False, 'shape': (2, 3), }",
+		encodeValues(binary.LittleEndian, []float64{0, 1, 2, 3, 4, 5}))
+	got, err := ReadNPY(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertShape(t, got, 2, 3)
+	if got.At(1, 2) != 5 || got.At(0, 1) != 1 {
+		t.Fatalf("неверные данные: %v", got.Data)
+	}
+}
+
+func TestReadNPYFortranBigEndianFloat32(t *testing.T) {
+	// Матрица [[1, 2, 3], [4, 5, 6]], записанная по столбцам.
+	raw := npyFile("{'descr': '>f4', 'fortran_order': True, 'shape': (2, 3), }",
+		encodeValues(binary.BigEndian, []float32{1, 4, 2, 5, 3, 6}))
+	got, err := ReadNPY(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertShape(t, got, 2, 3)
+	if got.Strides[0] != 1 || got.Strides[1] != 2 {
+		t.Fatalf("ожидались column-major strides [1 2], получены %v", got.Strides)
+	}
+	want := []float64{1, 2, 3, 4, 5, 6}
+	if c := got.Contiguous(); !floatsEqual(c.Data, want) {
+		t.Fatalf("неверные данные: %v, ожидалось %v", c.Data, want)
+	}
+}
+
+func TestReadNPYIntegerTypes(t *testing.T) {
+	cases := []struct {
+		descr string
+		data  []byte
+		want  []float64
+	}{
+		{"<i4", encodeValues(binary.LittleEndian, []int32{-7, 0, 1 << 30}), []float64{-7, 0, 1 << 30}},
+		{">i4", encodeValues(binary.BigEndian, []int32{-7, 0, 1 << 30}), []float64{-7, 0, 1 << 30}},
+		{"<i8", encodeValues(binary.LittleEndian, []int64{-1 << 40, 3, 9}), []float64{-1 << 40, 3, 9}},
+		{">i8", encodeValues(binary.BigEndian, []int64{-1 << 40, 3, 9}), []float64{-1 << 40, 3, 9}},
+		{"|u1", []byte{0, 128, 255}, []float64{0, 128, 255}},
+	}
+	for _, c := range cases {
+		raw := npyFile("{'descr': '"+c.descr+"', 'fortran_order': False, 'shape': (3,), }", c.data)
+		got, err := ReadNPY(bytes.NewReader(raw))
+		if err != nil {
+			t.Fatalf("%s: %v", c.descr, err)
+		}
+		assertShape(t, got, 3)
+		if !floatsEqual(got.Data, c.want) {
+			t.Fatalf("%s: получено %v, ожидалось %v", c.descr, got.Data, c.want)
+		}
+	}
+}
+
+func TestReadNPYScalar(t *testing.T) {
+	raw := npyFile("{'descr': '<f8', 'fortran_order': False, 'shape': (), }",
+		encodeValues(binary.LittleEndian, 2.5))
+	got, err := ReadNPY(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertShape(t, got, 1)
+	if got.Data[0] != 2.5 {
+		t.Fatalf("неверное значение скаляра: %v", got.Data)
+	}
+}
+
+func TestWriteNPYMatchesNumPyLayout(t *testing.T) {
+	x := arange(2, 3)
+	var buf bytes.Buffer
+	if err := WriteNPY(&buf, x); err != nil {
+		t.Fatal(err)
+	}
+	want := npyFile("{'descr': '<f8', 'fortran_order': False, 'shape': (2, 3), }",
+		encodeValues(binary.LittleEndian, []float64{0, 1, 2, 3, 4, 5}))
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("вывод отличается от np.save:\n%q\n%q", buf.Bytes(), want)
+	}
+	if buf.Len()%64 != 48 { // 64 байта заголовка + 48 байт данных
+		t.Fatalf("заголовок не выровнен до 64 байт: длина файла %d", buf.Len())
+	}
+}
+
+func TestNPYRoundTrip(t *testing.T) {
+	src := arange(3, 4)
+	view, err := Transpose(src) // view [4, 3]
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := view.Contiguous().Data
+	optsCases := map[string][]NPYOption{
+		"f8":         nil,
+		"f4 big":     {WithNPYDType(Float32), WithNPYBigEndian()},
+		"i4 fortran": {WithNPYDType(Int32), WithNPYFortranOrder()},
+		"i8 big F":   {WithNPYDType(Int64), WithNPYBigEndian(), WithNPYFortranOrder()},
+		"u1":         {WithNPYDType(Uint8)},
+	}
+	for name, opts := range optsCases {
+		var buf bytes.Buffer
+		if err := WriteNPY(&buf, view, opts...); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		got, err := ReadNPY(&buf)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		assertShape(t, got, 4, 3)
+		if c := got.Contiguous(); !floatsEqual(c.Data, want) {
+			t.Fatalf("%s: получено %v, ожидалось %v", name, c.Data, want)
+		}
+	}
+}
+
+func TestNPYErrors(t *testing.T) {
+	if _, err := ReadNPY(strings.NewReader("not a numpy file")); err == nil {
+		t.Error("ожидалась ошибка сигнатуры")
+	}
+	raw := npyFile("{'descr': '<c16', 'fortran_order': False, 'shape': (1,), }", make([]byte, 16))
+	if _, err := ReadNPY(bytes.NewReader(raw)); err == nil {
+		t.Error("ожидалась ошибка неподдерживаемого типа")
+	}
+	raw = npyFile("{'descr': '<f8', 'fortran_order': False, 'shape': (4,), }", make([]byte, 16))
+	if _, err := ReadNPY(bytes.NewReader(raw)); err == nil {
+		t.Error("ожидалась ошибка усечённых данных")
+	}
+
+	var buf bytes.Buffer
+	for _, v := range []float64{256, -1, math.NaN()} {
+		x := &Tensor{Data: []float64{v}, Shape: []int{1}, Strides: []int{1}}
+		if err := WriteNPY(&buf, x, WithNPYDType(Uint8)); err == nil {
+			t.Errorf("ожидалась ошибка записи %v как uint8", v)
+		}
+	}
+}
+
+func TestNPZRoundTrip(t *testing.T) {
+	arrays := map[string]*Tensor{
+		"x": arange(5, 2),
+		"y": {Data: []float64{0, 1, 1, 0, 1}, Shape: []int{5}, Strides: []int{1}},
+	}
+	for _, compressed := range []bool{false, true} {
+		path := filepath.Join(t.TempDir(), "data.npz")
+		var opts []NPYOption
+		if compressed {
+			opts = append(opts, WithNPZCompression())
+		}
+		if err := WriteNPZ(path, arrays, opts...); err != nil {
+			t.Fatal(err)
+		}
+		got, err := ReadNPZ(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 2 {
+			t.Fatalf("ожидалось 2 массива, получено %d", len(got))
+		}
+		for k, want := range arrays {
+			if !floatsEqual(got[k].Data, want.Data) || !shapesEqual(got[k].Shape, want.Shape) {
+				t.Fatalf("массив %s: %v %v", k, got[k].Shape, got[k].Data)
+			}
+		}
+	}
+}
+
+func assertShape(t *testing.T, x *Tensor, shape ...int) {
+	t.Helper()
+	if !shapesEqual(x.Shape, shape) {
+		t.Fatalf("форма %v, ожидалась %v", x.Shape, shape)
+	}
+}
+
+func floatsEqual(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
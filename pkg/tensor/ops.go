@@ -66,6 +66,7 @@ func broadcastTo(t *Tensor, shape []int) (*Tensor, error) {
 		}
 	}
 	// Новые strides: если размер == 1, stride = 0 (повторение значения)
+	strides := stridesOf(t)
 	newStrides := make([]int, n)
 	for i := 0; i < n; i++ {
 		if i < n-m {
@@ -73,13 +74,15 @@ func broadcastTo(t *Tensor, shape []int) (*Tensor, error) {
 		} else if t.Shape[i-(n-m)] == 1 {
 			newStrides[i] = 0
 		} else {
-			newStrides[i] = t.Strides[i-(n-m)]
+			newStrides[i] = strides[i-(n-m)]
 		}
 	}
 	return &Tensor{
 		Data:    t.Data,
 		Shape:   append([]int{}, shape...),
 		Strides: newStrides,
+		Offset:  t.Offset,
+		region:  t.region,
 	}, nil
 }
 
@@ -104,6 +107,57 @@ func nextIndex(idx, shape []int) bool {
 	return false
 }
 
+// elementwiseGrain — размер чанка поэлементных операций в ComputePool.
+// Тензоры меньше двух чанков обрабатываются в вызывающей горутине.
+const elementwiseGrain = 1 << 14
+
+// fillBinary заполняет плотный result значениями op над broadcast-views aB и bB.
+// Плотные операнды обрабатываются векторным ядром vec; большие тензоры делятся на
+// чанки глобального ComputePool. Каждый элемент считается независимо, поэтому
+// результат не зависит от числа потоков.
+func fillBinary(aB, bB, result *Tensor, vec func(a, b, out []float64), op func(x, y float64) float64) {
+	size := len(result.Data)
+	if aB.isCompact() && bB.isCompact() {
+		DefaultComputePool().ParallelFor(size, elementwiseGrain, func(s, e int) {
+			vec(aB.Data[s:e], bB.Data[s:e], result.Data[s:e])
+		})
+		return
+	}
+	aStrides, bStrides := stridesOf(aB), stridesOf(bB)
+	shape := result.Shape
+	DefaultComputePool().ParallelFor(size, elementwiseGrain, func(s, e int) {
+		idx := unravelIndex(s, shape)
+		for i := s; i < e; i++ {
+			ai := aB.Offset + indexOffset(idx, aStrides)
+			bi := bB.Offset + indexOffset(idx, bStrides)
+			result.Data[i] = op(aB.Data[ai], bB.Data[bi])
+			nextIndex(idx, shape)
+		}
+	})
+}
+
+// unravelIndex переводит плоский row-major номер элемента в многомерный индекс.
+func unravelIndex(flat int, shape []int) []int {
+	idx := make([]int, len(shape))
+	for d := len(shape) - 1; d >= 0 && flat > 0; d-- {
+		idx[d] = flat % shape[d]
+		flat /= shape[d]
+	}
+	return idx
+}
+
+func subSlices(a, b, out []float64) {
+	for i := range out {
+		out[i] = a[i] - b[i]
+	}
+}
+
+func divSlices(a, b, out []float64) {
+	for i := range out {
+		out[i] = a[i] / b[i]
+	}
+}
+
 // Add выполняет поэлементное сложение двух тензоров с векторизацией.
 // Возвращает новый тензор c, где c[i] = a[i] + b[i]
 // Тензоры должны иметь одинаковую форму (shape).
@@ -124,10 +178,12 @@ func Add(a, b *Tensor) (*Tensor, error) {
 	for _, d := range outShape {
 		size *= d
 	}
+	r := regionOf(a, b)
 	result := &Tensor{
-		Data:    make([]float64, size),
+		Data:    r.alloc(size),
 		Shape:   append([]int{}, outShape...),
 		Strides: make([]int, len(outShape)),
+		region:  r,
 	}
 	// Вычисляем strides для результата
 	stride := 1
@@ -135,14 +191,7 @@ func Add(a, b *Tensor) (*Tensor, error) {
 		result.Strides[i] = stride
 		stride *= outShape[i]
 	}
-	// Индексируем по outShape
-	idx := make([]int, len(outShape))
-	for i := 0; i < size; i++ {
-		ai := indexOffset(idx, aB.Strides)
-		bi := indexOffset(idx, bB.Strides)
-		result.Data[i] = aB.Data[ai] + bB.Data[bi]
-		nextIndex(idx, outShape)
-	}
+	fillBinary(aB, bB, result, AddSIMD, func(x, y float64) float64 { return x + y })
 	return result, nil
 }
 
@@ -166,23 +215,19 @@ func Mul(a, b *Tensor) (*Tensor, error) {
 	for _, d := range outShape {
 		size *= d
 	}
+	r := regionOf(a, b)
 	result := &Tensor{
-		Data:    make([]float64, size),
+		Data:    r.alloc(size),
 		Shape:   append([]int{}, outShape...),
 		Strides: make([]int, len(outShape)),
+		region:  r,
 	}
 	stride := 1
 	for i := len(outShape) - 1; i >= 0; i-- {
 		result.Strides[i] = stride
 		stride *= outShape[i]
 	}
-	idx := make([]int, len(outShape))
-	for i := 0; i < size; i++ {
-		ai := indexOffset(idx, aB.Strides)
-		bi := indexOffset(idx, bB.Strides)
-		result.Data[i] = aB.Data[ai] * bB.Data[bi]
-		nextIndex(idx, outShape)
-	}
+	fillBinary(aB, bB, result, MulSIMD, func(x, y float64) float64 { return x * y })
 	return result, nil
 }
 
@@ -204,23 +249,19 @@ func Sub(a, b *Tensor) (*Tensor, error) {
 	for _, d := range outShape {
 		size *= d
 	}
+	r := regionOf(a, b)
 	result := &Tensor{
-		Data:    make([]float64, size),
+		Data:    r.alloc(size),
 		Shape:   append([]int{}, outShape...),
 		Strides: make([]int, len(outShape)),
+		region:  r,
 	}
 	stride := 1
 	for i := len(outShape) - 1; i >= 0; i-- {
 		result.Strides[i] = stride
 		stride *= outShape[i]
 	}
-	idx := make([]int, len(outShape))
-	for i := 0; i < size; i++ {
-		ai := indexOffset(idx, aB.Strides)
-		bi := indexOffset(idx, bB.Strides)
-		result.Data[i] = aB.Data[ai] - bB.Data[bi]
-		nextIndex(idx, outShape)
-	}
+	fillBinary(aB, bB, result, subSlices, func(x, y float64) float64 { return x - y })
 	return result, nil
 }
 
@@ -242,34 +283,82 @@ func Div(a, b *Tensor) (*Tensor, error) {
 	for _, d := range outShape {
 		size *= d
 	}
+	r := regionOf(a, b)
 	result := &Tensor{
-		Data:    make([]float64, size),
+		Data:    r.alloc(size),
 		Shape:   append([]int{}, outShape...),
 		Strides: make([]int, len(outShape)),
+		region:  r,
 	}
 	stride := 1
 	for i := len(outShape) - 1; i >= 0; i-- {
 		result.Strides[i] = stride
 		stride *= outShape[i]
 	}
-	idx := make([]int, len(outShape))
-	for i := 0; i < size; i++ {
-		ai := indexOffset(idx, aB.Strides)
-		bi := indexOffset(idx, bB.Strides)
-		result.Data[i] = aB.Data[ai] / bB.Data[bi]
-		nextIndex(idx, outShape)
+	fillBinary(aB, bB, result, divSlices, func(x, y float64) float64 { return x / y })
+	return result, nil
+}
+
+// ZipWith применяет бинарную функцию f к элементам a и b с NumPy-style broadcasting.
+// Возвращает новый плотный тензор формы broadcastShapes(a.Shape, b.Shape).
+func ZipWith(a, b *Tensor, f func(x, y float64) float64) (*Tensor, error) {
+	outShape, err := broadcastShapes(a.Shape, b.Shape)
+	if err != nil {
+		return nil, err
+	}
+	aB, err := broadcastTo(a, outShape)
+	if err != nil {
+		return nil, err
+	}
+	bB, err := broadcastTo(b, outShape)
+	if err != nil {
+		return nil, err
 	}
+	result := regionOf(a, b).zeros(outShape...)
+	i := 0
+	forEachStrided2(aB, bB, func(aOff, bOff int) {
+		result.Data[i] = f(aB.Data[aOff], bB.Data[bOff])
+		i++
+	})
 	return result, nil
 }
 
+// Pow возводит a в степень b поэлементно (с broadcasting).
+func Pow(a, b *Tensor) (*Tensor, error) {
+	return ZipWith(a, b, math.Pow)
+}
+
+// Maximum возвращает поэлементный максимум a и b (с broadcasting).
+func Maximum(a, b *Tensor) (*Tensor, error) {
+	return ZipWith(a, b, math.Max)
+}
+
+// Minimum возвращает поэлементный минимум a и b (с broadcasting).
+func Minimum(a, b *Tensor) (*Tensor, error) {
+	return ZipWith(a, b, math.Min)
+}
+
 // Apply применяет функцию f к каждому элементу тензора.
 // Возвращает новый тензор с результатами применения функции.
 // Используется для функций активации (ReLU, sigmoid, tanh).
 func Apply(a *Tensor, f func(float64) float64) *Tensor {
+	if !a.isCompact() {
+		// View: обходим по strides, результат — плотный тензор.
+		result := regionOf(a).zeros(append([]int{}, a.Shape...)...)
+		i := 0
+		forEachStrided(a, func(off int) {
+			result.Data[i] = f(a.Data[off])
+			i++
+		})
+		return result
+	}
+
+	r := regionOf(a)
 	result := &Tensor{
-		Data:    make([]float64, len(a.Data)),
+		Data:    r.alloc(len(a.Data)),
 		Shape:   append([]int{}, a.Shape...),
 		Strides: append([]int{}, a.Strides...),
+		region:  r,
 	}
 
 	for i := range a.Data {
@@ -295,6 +384,7 @@ func shapesEqual(a, b []int) bool {
 // Reshape изменяет форму тензора без изменения данных.
 // Возвращает новый тензор с новой формой, используя те же данные.
 // Общее количество элементов должно совпадать.
+// Для непрерывных views данные разделяются, остальные views сначала материализуются через Contiguous.
 func Reshape(a *Tensor, newShape []int) (*Tensor, error) {
 	// Вычисляем общее количество элементов в новой форме
 	newSize := 1
@@ -323,53 +413,48 @@ func Reshape(a *Tensor, newShape []int) (*Tensor, error) {
 		stride *= newShape[i]
 	}
 
+	if !a.IsContiguous() {
+		a = a.Contiguous()
+	}
+
 	return &Tensor{
 		Data:    a.Data, // Используем те же данные
 		Shape:   append([]int{}, newShape...),
 		Strides: newStrides,
+		Offset:  a.Offset,
+		region:  a.region,
 	}, nil
 }
 
 // Transpose транспонирует двумерный тензор (матрицу).
 // Меняет местами оси: строки становятся столбцами и наоборот.
-// Для матрицы [m, n] возвращает матрицу [n, m].
+// Для матрицы [m, n] возвращает view [n, m] на те же данные (strides переставлены).
+// Для плотной копии используйте Transpose(a) с последующим Contiguous().
 func Transpose(a *Tensor) (*Tensor, error) {
 	if len(a.Shape) != 2 {
 		return nil, fmt.Errorf("транспонирование требует 2D тензор, получен %dD", len(a.Shape))
 	}
 
-	rows := a.Shape[0]
-	cols := a.Shape[1]
-
-	result := &Tensor{
-		Data:    make([]float64, len(a.Data)),
-		Shape:   []int{cols, rows},
-		Strides: []int{rows, 1},
-	}
-
-	// Транспонируем данные
-	for i := 0; i < rows; i++ {
-		for j := 0; j < cols; j++ {
-			result.Data[j*rows+i] = a.Data[i*cols+j]
-		}
-	}
-
-	return result, nil
+	return Permute(a, 1, 0)
 }
 
 // Sum вычисляет сумму всех элементов тензора.
 // Возвращает скаляр (одноэлементный тензор).
 func Sum(a *Tensor) *Tensor {
 	sum := 0.0
-	for _, val := range a.Data {
-		sum += val
+	if a.isCompact() {
+		for _, val := range a.Data {
+			sum += val
+		}
+	} else {
+		forEachStrided(a, func(off int) {
+			sum += a.Data[off]
+		})
 	}
 
-	return &Tensor{
-		Data:    []float64{sum},
-		Shape:   []int{1},
-		Strides: []int{1},
-	}
+	result := regionOf(a).zeros(1)
+	result.Data[0] = sum
+	return result
 }
 
 // Exp применяет экспоненциальную функцию e^x к каждому элементу тензора.
@@ -412,11 +497,12 @@ func Concatenate(tensors []*Tensor, axis int) (*Tensor, error) {
 	newShape[axis] = concatDimSize
 
 	// 2. Инициализация результирующего тензора (используем твои Zeros)
-	res := Zeros(newShape...)
+	res := regionOf(tensors...).zeros(newShape...)
 
 	// 3. Копирование данных
 	offset := 0
 	for _, t := range tensors {
+		t = t.Contiguous()
 		// Копируем данные из t в res со смещением по оси axis
 		// Используем обход по всем элементам входного тензора
 		for i := 0; i < len(t.Data); i++ {
@@ -445,29 +531,7 @@ func Concatenate(tensors []*Tensor, axis int) (*Tensor, error) {
 }
 
 // Slice извлекает часть тензора. Нужен для обратного прохода Concatenate.
+// Возвращает view без копирования данных (см. Narrow).
 func Slice(t *Tensor, axis int, start int, length int) (*Tensor, error) {
-	newShape := append([]int{}, t.Shape...)
-	newShape[axis] = length
-
-	res := Zeros(newShape...)
-
-	for i := 0; i < len(res.Data); i++ {
-		coords := make([]int, len(newShape))
-		tempIdx := i
-		for d := len(newShape) - 1; d >= 0; d-- {
-			coords[d] = tempIdx % newShape[d]
-			tempIdx /= newShape[d]
-		}
-
-		// Сдвигаемся в исходном тензоре
-		coords[axis] += start
-
-		oldIdx := 0
-		for d := range coords {
-			oldIdx += coords[d] * t.Strides[d]
-		}
-		res.Data[i] = t.Data[oldIdx]
-	}
-
-	return res, nil
+	return Narrow(t, axis, start, length)
 }
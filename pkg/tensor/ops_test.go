@@ -475,9 +475,11 @@ func TestTranspose(t *testing.T) {
 				if !shapesEqual(got.Shape, tt.wantShape) {
 					t.Errorf("Transpose() shape = %v, want %v", got.Shape, tt.wantShape)
 				}
+				// Transpose возвращает view, сравниваем плотную раскладку
+				dense := got.Contiguous()
 				for i := range tt.wantData {
-					if got.Data[i] != tt.wantData[i] {
-						t.Errorf("Transpose() data[%d] = %v, want %v", i, got.Data[i], tt.wantData[i])
+					if dense.Data[i] != tt.wantData[i] {
+						t.Errorf("Transpose() data[%d] = %v, want %v", i, dense.Data[i], tt.wantData[i])
 					}
 				}
 			}
@@ -788,3 +790,33 @@ func TestConcatenate(t *testing.T) {
 		}
 	}
 }
+
+func TestPowMaximumMinimumBroadcast(t *testing.T) {
+	a := &Tensor{Data: []float64{1, 2, 3, 4}, Shape: []int{2, 2}, Strides: []int{2, 1}}
+	b := &Tensor{Data: []float64{2, 3}, Shape: []int{2}, Strides: []int{1}}
+
+	cases := []struct {
+		name string
+		fn   func(a, b *Tensor) (*Tensor, error)
+		want []float64
+	}{
+		{"Pow", Pow, []float64{1, 8, 9, 64}},
+		{"Maximum", Maximum, []float64{2, 3, 3, 4}},
+		{"Minimum", Minimum, []float64{1, 2, 2, 3}},
+	}
+	for _, tc := range cases {
+		got, err := tc.fn(a, b)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		for i, w := range tc.want {
+			if got.Data[i] != w {
+				t.Errorf("%s data[%d] = %v, want %v", tc.name, i, got.Data[i], w)
+			}
+		}
+	}
+
+	if _, err := Pow(a, &Tensor{Data: []float64{1, 2, 3}, Shape: []int{3}, Strides: []int{1}}); err == nil {
+		t.Error("expected broadcast error")
+	}
+}
@@ -0,0 +1,288 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+)
+
+// Generator — детерминированный генератор случайных чисел на основе счётчика.
+// i-е число потока вычисляется как хеш пары (key, i), поэтому поток полностью
+// определяется seed, а независимые потоки получаются через Split и Stream без
+// общего состояния. Для воспроизводимости в многопоточном коде каждой горутине
+// следует выдавать собственный поток: g.Stream(workerID).
+//
+// Методы безопасны для конкурентного вызова (счётчик атомарный), но порядок
+// чисел между горутинами, делящими один Generator, не детерминирован.
+type Generator struct {
+	key     uint64
+	counter atomic.Uint64
+}
+
+// golden — дробная часть золотого сечения, шаг SplitMix64.
+const golden = 0x9E3779B97F4A7C15
+
+// mix64 — финализатор SplitMix64 (биективное перемешивание 64 бит).
+func mix64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return z ^ (z >> 31)
+}
+
+// NewGenerator создаёт генератор с заданным seed.
+func NewGenerator(seed int64) *Generator {
+	return &Generator{key: mix64(uint64(seed) + golden)}
+}
+
+// Uint64 возвращает следующее псевдослучайное 64-битное число потока.
+func (g *Generator) Uint64() uint64 {
+	i := g.counter.Add(1)
+	return mix64(g.key + i*golden)
+}
+
+// Split возвращает новый независимый поток и продвигает текущий.
+// Последовательность вызовов Split детерминирована так же, как и Uint64.
+func (g *Generator) Split() *Generator {
+	return &Generator{key: mix64(g.Uint64() ^ g.key)}
+}
+
+// Stream возвращает независимый поток с номером id, не изменяя g.
+// Одинаковые (seed, id) всегда дают один и тот же поток.
+func (g *Generator) Stream(id uint64) *Generator {
+	return &Generator{key: mix64(g.key ^ mix64(id+golden))}
+}
+
+// Position возвращает позицию потока — число уже выданных значений.
+// Вместе с Seek позволяет повторить ту же последовательность.
+func (g *Generator) Position() uint64 {
+	return g.counter.Load()
+}
+
+// Seek переставляет поток на позицию pos.
+func (g *Generator) Seek(pos uint64) {
+	g.counter.Store(pos)
+}
+
+// Float64 возвращает равномерно распределённое число из [0, 1).
+func (g *Generator) Float64() float64 {
+	return float64(g.Uint64()>>11) / (1 << 53)
+}
+
+// Intn возвращает равномерно распределённое целое из [0, n). Паникует при n <= 0.
+func (g *Generator) Intn(n int) int {
+	if n <= 0 {
+		panic("Intn: n должно быть положительным")
+	}
+	bound := uint64(n)
+	// Отбрасываем «хвост», чтобы остаток от деления был несмещённым.
+	limit := math.MaxUint64 - math.MaxUint64%bound
+	for {
+		if v := g.Uint64(); v < limit {
+			return int(v % bound)
+		}
+	}
+}
+
+// NormFloat64 возвращает число из стандартного нормального распределения N(0, 1)
+// (преобразование Бокса — Мюллера).
+func (g *Generator) NormFloat64() float64 {
+	u1 := 1 - g.Float64() // (0, 1], чтобы логарифм был конечным
+	u2 := g.Float64()
+	return math.Sqrt(-2*math.Log(u1)) * math.Cos(2*math.Pi*u2)
+}
+
+// gamma возвращает число из Gamma(alpha, 1) методом Марсальи — Цанга.
+func (g *Generator) gamma(alpha float64) float64 {
+	if alpha < 1 {
+		// Gamma(α) = Gamma(α+1)·U^(1/α)
+		return g.gamma(alpha+1) * math.Pow(1-g.Float64(), 1/alpha)
+	}
+	d := alpha - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := g.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := 1 - g.Float64()
+		if math.Log(u) < 0.5*x*x+d-d*v+d*math.Log(v) {
+			return d * v
+		}
+	}
+}
+
+// Perm возвращает случайную перестановку чисел [0, n) (Фишер — Йетс).
+func (g *Generator) Perm(n int) []int {
+	p := make([]int, n)
+	for i := range p {
+		p[i] = i
+	}
+	g.Shuffle(n, func(i, j int) { p[i], p[j] = p[j], p[i] })
+	return p
+}
+
+// Shuffle перемешивает n элементов, вызывая swap для обмена (Фишер — Йетс).
+func (g *Generator) Shuffle(n int, swap func(i, j int)) {
+	for i := n - 1; i > 0; i-- {
+		swap(i, g.Intn(i+1))
+	}
+}
+
+// fill создаёт тензор формы shape и заполняет его значениями f.
+func (g *Generator) fill(shape []int, f func() float64) *Tensor {
+	t := Zeros(append([]int{}, shape...)...)
+	for i := range t.Data {
+		t.Data[i] = f()
+	}
+	return t
+}
+
+// Uniform возвращает тензор со значениями из равномерного распределения U[low, high).
+func (g *Generator) Uniform(shape []int, low, high float64) *Tensor {
+	return g.fill(shape, func() float64 { return low + (high-low)*g.Float64() })
+}
+
+// Normal возвращает тензор со значениями из N(mean, std²).
+func (g *Generator) Normal(shape []int, mean, std float64) *Tensor {
+	return g.fill(shape, func() float64 { return mean + std*g.NormFloat64() })
+}
+
+// TruncatedNormal возвращает тензор из N(mean, std²), усечённого на [low, high]
+// (значения вне интервала отбрасываются и генерируются заново).
+func (g *Generator) TruncatedNormal(shape []int, mean, std, low, high float64) (*Tensor, error) {
+	if !(low < high) {
+		return nil, fmt.Errorf("пустой интервал усечения [%v, %v]", low, high)
+	}
+	// Доля принятых значений; при слишком узком интервале далеко в хвосте
+	// отбор длился бы неограниченно долго.
+	cdf := func(x float64) float64 { return 0.5 * math.Erfc(-(x-mean)/(std*math.Sqrt2)) }
+	if cdf(high)-cdf(low) < 1e-6 {
+		return nil, fmt.Errorf("интервал усечения [%v, %v] содержит слишком малую массу распределения", low, high)
+	}
+	return g.fill(shape, func() float64 {
+		for {
+			if v := mean + std*g.NormFloat64(); v >= low && v <= high {
+				return v
+			}
+		}
+	}), nil
+}
+
+// Bernoulli возвращает тензор из 0 и 1, где 1 выпадает с вероятностью p.
+func (g *Generator) Bernoulli(shape []int, p float64) *Tensor {
+	return g.fill(shape, func() float64 {
+		if g.Float64() < p {
+			return 1
+		}
+		return 0
+	})
+}
+
+// Gamma возвращает тензор из распределения Gamma(alpha, scale) (alpha > 0 — форма).
+func (g *Generator) Gamma(shape []int, alpha, scale float64) (*Tensor, error) {
+	if alpha <= 0 || scale <= 0 {
+		return nil, fmt.Errorf("параметры Gamma должны быть положительными: alpha=%v, scale=%v", alpha, scale)
+	}
+	return g.fill(shape, func() float64 { return scale * g.gamma(alpha) }), nil
+}
+
+// Beta возвращает тензор из распределения Beta(a, b).
+func (g *Generator) Beta(shape []int, a, b float64) (*Tensor, error) {
+	if a <= 0 || b <= 0 {
+		return nil, fmt.Errorf("параметры Beta должны быть положительными: a=%v, b=%v", a, b)
+	}
+	return g.fill(shape, func() float64 {
+		x := g.gamma(a)
+		return x / (x + g.gamma(b))
+	}), nil
+}
+
+// Multinomial выбирает numSamples индексов категорий с вероятностями,
+// пропорциональными весам probs (неотрицательные, не обязательно нормированные).
+// Для probs формы [K] результат — [numSamples], для [B, K] — [B, numSamples];
+// индексы хранятся как float64. Без возвращения (replacement=false) каждая
+// категория выбирается не более одного раза.
+func (g *Generator) Multinomial(probs *Tensor, numSamples int, replacement bool) (*Tensor, error) {
+	var rows, k int
+	switch len(probs.Shape) {
+	case 1:
+		rows, k = 1, probs.Shape[0]
+	case 2:
+		rows, k = probs.Shape[0], probs.Shape[1]
+	default:
+		return nil, fmt.Errorf("ожидаются вероятности формы [K] или [B, K], получена форма %v", probs.Shape)
+	}
+	if !replacement && numSamples > k {
+		return nil, fmt.Errorf("нельзя выбрать %d категорий без возвращения из %d", numSamples, k)
+	}
+	p := probs.Contiguous().Data
+	outShape := []int{numSamples}
+	if len(probs.Shape) == 2 {
+		outShape = []int{rows, numSamples}
+	}
+	out := Zeros(outShape...)
+	w := make([]float64, k)
+	for r := 0; r < rows; r++ {
+		copy(w, p[r*k:(r+1)*k])
+		for s := 0; s < numSamples; s++ {
+			total := 0.0
+			for _, v := range w {
+				if v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+					return nil, fmt.Errorf("некорректный вес категории %v", v)
+				}
+				total += v
+			}
+			if total == 0 && s > 0 {
+				return nil, fmt.Errorf("недостаточно категорий с ненулевым весом для %d выборок без возвращения", numSamples)
+			}
+			if total == 0 {
+				return nil, fmt.Errorf("сумма весов категорий равна нулю")
+			}
+			u := g.Float64() * total
+			c := 0
+			for c < k-1 && u >= w[c] {
+				u -= w[c]
+				c++
+			}
+			for w[c] == 0 {
+				c-- // ошибка округления в конце: берём последний ненулевой вес
+			}
+			out.Data[r*numSamples+s] = float64(c)
+			if !replacement {
+				w[c] = 0
+			}
+		}
+	}
+	return out, nil
+}
+
+// Categorical выбирает numSamples индексов категорий с возвращением
+// (см. Multinomial).
+func (g *Generator) Categorical(probs *Tensor, numSamples int) (*Tensor, error) {
+	return g.Multinomial(probs, numSamples, true)
+}
+
+// defaultGenerator используется там, где генератор не передан явно
+// (инициализаторы весов, Dropout).
+var defaultGenerator atomic.Pointer[Generator]
+
+func init() {
+	defaultGenerator.Store(NewGenerator(0))
+}
+
+// DefaultGenerator возвращает глобальный генератор по умолчанию.
+func DefaultGenerator() *Generator {
+	return defaultGenerator.Load()
+}
+
+// SetDefaultGenerator заменяет глобальный генератор по умолчанию.
+func SetDefaultGenerator(g *Generator) {
+	defaultGenerator.Store(g)
+}
+
+// ManualSeed пересоздаёт глобальный генератор по умолчанию с заданным seed.
+func ManualSeed(seed int64) {
+	SetDefaultGenerator(NewGenerator(seed))
+}
@@ -0,0 +1,194 @@
+package tensor
+
+import (
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestGeneratorReproducible(t *testing.T) {
+	a, b := NewGenerator(42), NewGenerator(42)
+	for i := 0; i < 100; i++ {
+		if a.Uint64() != b.Uint64() {
+			t.Fatal("generators with the same seed diverged")
+		}
+	}
+	if NewGenerator(1).Uint64() == NewGenerator(2).Uint64() {
+		t.Fatal("different seeds produced the same first value")
+	}
+
+	// Stream не зависит от состояния родителя
+	g := NewGenerator(7)
+	s1 := g.Stream(3).Float64()
+	g.Uint64()
+	if s2 := g.Stream(3).Float64(); s1 != s2 {
+		t.Fatalf("Stream(3) = %v, then %v", s1, s2)
+	}
+	if g.Stream(3).Uint64() == g.Stream(4).Uint64() {
+		t.Fatal("different streams produced the same value")
+	}
+
+	// Split детерминирован
+	x, y := NewGenerator(5), NewGenerator(5)
+	if x.Split().Uint64() != y.Split().Uint64() {
+		t.Fatal("Split is not deterministic")
+	}
+
+	// Seek повторяет последовательность с сохранённой позиции
+	pos := g.Position()
+	first := []float64{g.Float64(), g.NormFloat64()}
+	g.Seek(pos)
+	if again := []float64{g.Float64(), g.NormFloat64()}; again[0] != first[0] || again[1] != first[1] {
+		t.Fatalf("after Seek got %v, want %v", again, first)
+	}
+}
+
+func TestGeneratorStreamsAcrossGoroutines(t *testing.T) {
+	run := func() []float64 {
+		g := NewGenerator(11)
+		out := make([]float64, 8)
+		var wg sync.WaitGroup
+		for w := range out {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				s := g.Stream(uint64(w))
+				for i := 0; i < 1000; i++ {
+					out[w] += s.NormFloat64()
+				}
+			}(w)
+		}
+		wg.Wait()
+		return out
+	}
+	first, second := run(), run()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("worker %d: %v != %v", i, first[i], second[i])
+		}
+	}
+}
+
+func meanStd(data []float64) (float64, float64) {
+	mean := 0.0
+	for _, v := range data {
+		mean += v
+	}
+	mean /= float64(len(data))
+	v := 0.0
+	for _, x := range data {
+		v += (x - mean) * (x - mean)
+	}
+	return mean, math.Sqrt(v / float64(len(data)))
+}
+
+func TestGeneratorDistributions(t *testing.T) {
+	g := NewGenerator(2024)
+	const n = 20000
+
+	u := g.Uniform([]int{n}, -2, 4)
+	for _, v := range u.Data {
+		if v < -2 || v >= 4 {
+			t.Fatalf("Uniform value %v outside [-2, 4)", v)
+		}
+	}
+	if m, _ := meanStd(u.Data); math.Abs(m-1) > 0.05 {
+		t.Errorf("Uniform mean = %v, want 1", m)
+	}
+
+	norm := g.Normal([]int{n}, 3, 2)
+	if m, s := meanStd(norm.Data); math.Abs(m-3) > 0.05 || math.Abs(s-2) > 0.05 {
+		t.Errorf("Normal mean/std = %v/%v, want 3/2", m, s)
+	}
+
+	tn, err := g.TruncatedNormal([]int{n}, 0, 1, -1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range tn.Data {
+		if v < -1 || v > 2 {
+			t.Fatalf("TruncatedNormal value %v outside [-1, 2]", v)
+		}
+	}
+	if _, err := g.TruncatedNormal([]int{1}, 0, 1, 1, 0); err == nil {
+		t.Fatal("expected empty interval error")
+	}
+
+	bern := g.Bernoulli([]int{n}, 0.3)
+	if m, _ := meanStd(bern.Data); math.Abs(m-0.3) > 0.02 {
+		t.Errorf("Bernoulli mean = %v, want 0.3", m)
+	}
+
+	gam, err := g.Gamma([]int{n}, 2.5, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m, _ := meanStd(gam.Data); math.Abs(m-5) > 0.15 {
+		t.Errorf("Gamma mean = %v, want 5", m)
+	}
+	small, _ := g.Gamma([]int{n}, 0.5, 1)
+	if m, _ := meanStd(small.Data); math.Abs(m-0.5) > 0.03 {
+		t.Errorf("Gamma(0.5) mean = %v, want 0.5", m)
+	}
+
+	beta, err := g.Beta([]int{n}, 2, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m, _ := meanStd(beta.Data); math.Abs(m-0.25) > 0.01 {
+		t.Errorf("Beta mean = %v, want 0.25", m)
+	}
+	if _, err := g.Beta([]int{1}, 0, 1); err == nil {
+		t.Fatal("expected invalid parameter error")
+	}
+}
+
+func TestGeneratorCategoricalAndPerm(t *testing.T) {
+	g := NewGenerator(9)
+	probs := &Tensor{Data: []float64{1, 0, 3}, Shape: []int{3}, Strides: []int{1}}
+	s, err := g.Categorical(probs, 10000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := make([]int, 3)
+	for _, v := range s.Data {
+		counts[int(v)]++
+	}
+	if counts[1] != 0 {
+		t.Fatalf("zero-weight category sampled %d times", counts[1])
+	}
+	if frac := float64(counts[2]) / 10000; math.Abs(frac-0.75) > 0.02 {
+		t.Errorf("category 2 frequency = %v, want 0.75", frac)
+	}
+
+	batch := &Tensor{Data: []float64{1, 1, 1, 1, 5, 1, 2, 1}, Shape: []int{2, 4}, Strides: []int{4, 1}}
+	m, err := g.Multinomial(batch, 4, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !shapesEqual(m.Shape, []int{2, 4}) {
+		t.Fatalf("Multinomial shape = %v", m.Shape)
+	}
+	for r := 0; r < 2; r++ {
+		seen := map[float64]bool{}
+		for _, v := range m.Data[r*4 : (r+1)*4] {
+			if seen[v] {
+				t.Fatalf("row %d: category %v sampled twice without replacement", r, v)
+			}
+			seen[v] = true
+		}
+	}
+	sparse := &Tensor{Data: []float64{5, 0, 0, 1}, Shape: []int{4}, Strides: []int{1}}
+	if _, err := g.Multinomial(sparse, 3, false); err == nil {
+		t.Fatal("expected error: only two categories have non-zero weight")
+	}
+
+	p := g.Perm(10)
+	used := make([]bool, 10)
+	for _, v := range p {
+		if used[v] {
+			t.Fatalf("Perm repeated %d: %v", v, p)
+		}
+		used[v] = true
+	}
+}
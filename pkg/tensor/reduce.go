@@ -0,0 +1,302 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+)
+
+// Редукции по осям.
+// Все функции принимают список осей axes (отрицательные оси считаются с конца,
+// пустой список — редукция по всем осям) и флаг keepDims: при keepDims=true
+// редуцированные оси остаются в форме с размером 1, иначе удаляются.
+// Полная редукция без keepDims возвращает скаляр формы [1], как и Sum.
+
+// reduction описывает разбиение элементов тензора на группы для редукции.
+type reduction struct {
+	reduced   []bool // reduced[d] — редуцируется ли ось d
+	keepShape []int  // форма результата с осями размера 1 на месте редуцированных
+	outShape  []int  // итоговая форма результата с учётом keepDims
+	count     int    // количество элементов в каждой группе
+}
+
+func newReduction(shape []int, axes []int, keepDims bool) (*reduction, error) {
+	r := &reduction{
+		reduced:   make([]bool, len(shape)),
+		keepShape: make([]int, len(shape)),
+		count:     1,
+	}
+	if len(axes) == 0 {
+		for d := range r.reduced {
+			r.reduced[d] = true
+		}
+	}
+	for _, ax := range axes {
+		axis, err := normalizeAxis(ax, len(shape))
+		if err != nil {
+			return nil, err
+		}
+		if r.reduced[axis] {
+			return nil, fmt.Errorf("ось %d указана дважды", axis)
+		}
+		r.reduced[axis] = true
+	}
+	for d, size := range shape {
+		if r.reduced[d] {
+			r.keepShape[d] = 1
+			r.count *= size
+			if keepDims {
+				r.outShape = append(r.outShape, 1)
+			}
+			continue
+		}
+		r.keepShape[d] = size
+		r.outShape = append(r.outShape, size)
+	}
+	if len(r.outShape) == 0 {
+		r.outShape = []int{1}
+	}
+	return r, nil
+}
+
+// each обходит элементы a в row-major порядке и вызывает f со смещением элемента в Data,
+// номером группы (индексом в плотном результате) и многомерным индексом элемента.
+// Внутри группы элементы обходятся по возрастанию индексов редуцируемых осей.
+func (r *reduction) each(a *Tensor, f func(off, group int, idx []int)) {
+	n := numel(a.Shape)
+	if n == 0 {
+		return
+	}
+	strides := stridesOf(a)
+	outStrides := calculateStrides(r.keepShape)
+	for d := range outStrides {
+		if r.reduced[d] {
+			outStrides[d] = 0
+		}
+	}
+	idx := make([]int, len(a.Shape))
+	off, group := a.Offset, 0
+	for i := 0; i < n; i++ {
+		f(off, group, idx)
+		for d := len(idx) - 1; d >= 0; d-- {
+			idx[d]++
+			off += strides[d]
+			group += outStrides[d]
+			if idx[d] < a.Shape[d] {
+				break
+			}
+			off -= strides[d] * idx[d]
+			group -= outStrides[d] * idx[d]
+			idx[d] = 0
+		}
+	}
+}
+
+// newResult создаёт плотный результат редукции, заполненный init.
+func (r *reduction) newResult(init float64) *Tensor {
+	out := Zeros(r.outShape...)
+	if init != 0 {
+		for i := range out.Data {
+			out.Data[i] = init
+		}
+	}
+	return out
+}
+
+// KeepDimsShape возвращает форму результата редукции a по axes с keepDims=true.
+// Тензор такой формы broadcast-совместим с исходным, что используется в backward.
+func KeepDimsShape(shape []int, axes []int) ([]int, error) {
+	r, err := newReduction(shape, axes, true)
+	if err != nil {
+		return nil, err
+	}
+	return r.keepShape, nil
+}
+
+func reduceAxes(a *Tensor, axes []int, keepDims bool, init float64, f func(acc, v float64) float64) (*Tensor, error) {
+	r, err := newReduction(a.Shape, axes, keepDims)
+	if err != nil {
+		return nil, err
+	}
+	out := r.newResult(init)
+	r.each(a, func(off, group int, _ []int) {
+		out.Data[group] = f(out.Data[group], a.Data[off])
+	})
+	return out, nil
+}
+
+// SumAxes суммирует элементы тензора по осям axes.
+func SumAxes(a *Tensor, axes []int, keepDims bool) (*Tensor, error) {
+	return reduceAxes(a, axes, keepDims, 0, func(acc, v float64) float64 { return acc + v })
+}
+
+// MeanAxes вычисляет среднее по осям axes.
+func MeanAxes(a *Tensor, axes []int, keepDims bool) (*Tensor, error) {
+	out, err := SumAxes(a, axes, keepDims)
+	if err != nil {
+		return nil, err
+	}
+	n := float64(numel(a.Shape) / numel(out.Shape))
+	ScaleInPlace(1/n, out)
+	return out, nil
+}
+
+// ProdAxes вычисляет произведение элементов по осям axes.
+func ProdAxes(a *Tensor, axes []int, keepDims bool) (*Tensor, error) {
+	return reduceAxes(a, axes, keepDims, 1, func(acc, v float64) float64 { return acc * v })
+}
+
+// MaxAxes возвращает максимум по осям axes.
+func MaxAxes(a *Tensor, axes []int, keepDims bool) (*Tensor, error) {
+	return reduceAxes(a, axes, keepDims, math.Inf(-1), math.Max)
+}
+
+// MinAxes возвращает минимум по осям axes.
+func MinAxes(a *Tensor, axes []int, keepDims bool) (*Tensor, error) {
+	return reduceAxes(a, axes, keepDims, math.Inf(1), math.Min)
+}
+
+// VarAxes вычисляет дисперсию по осям axes с поправкой ddof:
+// var = Σ(x - mean)² / (N - ddof). ddof=0 — смещённая оценка (как в BatchNorm), ddof=1 — несмещённая.
+func VarAxes(a *Tensor, axes []int, keepDims bool, ddof int) (*Tensor, error) {
+	r, err := newReduction(a.Shape, axes, keepDims)
+	if err != nil {
+		return nil, err
+	}
+	if r.count-ddof <= 0 {
+		return nil, fmt.Errorf("дисперсия: ddof=%d недопустим для групп из %d элементов", ddof, r.count)
+	}
+	mean := r.newResult(0)
+	r.each(a, func(off, group int, _ []int) {
+		mean.Data[group] += a.Data[off]
+	})
+	ScaleInPlace(1/float64(r.count), mean)
+
+	out := r.newResult(0)
+	r.each(a, func(off, group int, _ []int) {
+		d := a.Data[off] - mean.Data[group]
+		out.Data[group] += d * d
+	})
+	ScaleInPlace(1/float64(r.count-ddof), out)
+	return out, nil
+}
+
+// StdAxes вычисляет стандартное отклонение по осям axes (корень из VarAxes).
+func StdAxes(a *Tensor, axes []int, keepDims bool, ddof int) (*Tensor, error) {
+	out, err := VarAxes(a, axes, keepDims, ddof)
+	if err != nil {
+		return nil, err
+	}
+	ApplyInPlace(out, math.Sqrt)
+	return out, nil
+}
+
+// LogSumExpAxes вычисляет log(Σ exp(x)) по осям axes численно устойчиво
+// (через вычитание максимума группы).
+func LogSumExpAxes(a *Tensor, axes []int, keepDims bool) (*Tensor, error) {
+	r, err := newReduction(a.Shape, axes, keepDims)
+	if err != nil {
+		return nil, err
+	}
+	maxes := r.newResult(math.Inf(-1))
+	r.each(a, func(off, group int, _ []int) {
+		maxes.Data[group] = math.Max(maxes.Data[group], a.Data[off])
+	})
+
+	out := r.newResult(0)
+	r.each(a, func(off, group int, _ []int) {
+		if m := maxes.Data[group]; !math.IsInf(m, 0) {
+			out.Data[group] += math.Exp(a.Data[off] - m)
+		}
+	})
+	for i, s := range out.Data {
+		m := maxes.Data[i]
+		if math.IsInf(m, 0) {
+			// Все элементы -Inf (или есть +Inf): результат совпадает с максимумом
+			out.Data[i] = m
+			continue
+		}
+		out.Data[i] = m + math.Log(s)
+	}
+	return out, nil
+}
+
+// NormAxes вычисляет евклидову (L2) норму по осям axes.
+func NormAxes(a *Tensor, axes []int, keepDims bool) (*Tensor, error) {
+	out, err := reduceAxes(a, axes, keepDims, 0, func(acc, v float64) float64 { return acc + v*v })
+	if err != nil {
+		return nil, err
+	}
+	ApplyInPlace(out, math.Sqrt)
+	return out, nil
+}
+
+// SumToShape суммирует a до формы shape, в которую a был broadcast-расширен:
+// лишние ведущие оси и оси, имеющие в shape размер 1, суммируются.
+// Используется в backward бинарных операций с broadcasting.
+func SumToShape(a *Tensor, shape []int) (*Tensor, error) {
+	if shapesEqual(a.Shape, shape) {
+		return a, nil
+	}
+	lead := len(a.Shape) - len(shape)
+	if lead < 0 {
+		return nil, fmt.Errorf("нельзя свести форму %v к большей форме %v", a.Shape, shape)
+	}
+	var axes []int
+	for d := range a.Shape {
+		if d < lead {
+			axes = append(axes, d)
+			continue
+		}
+		switch target := shape[d-lead]; {
+		case target == a.Shape[d]:
+		case target == 1:
+			axes = append(axes, d)
+		default:
+			return nil, fmt.Errorf("форма %v не получается broadcasting-ом из %v", a.Shape, shape)
+		}
+	}
+	if len(axes) == 0 {
+		return Reshape(a, shape)
+	}
+	out, err := SumAxes(a, axes, true)
+	if err != nil {
+		return nil, err
+	}
+	return Reshape(out, shape)
+}
+
+// ArgMax возвращает индексы максимальных элементов вдоль оси axis.
+// Индексы хранятся как float64; при равенстве выбирается первый элемент.
+func ArgMax(a *Tensor, axis int, keepDims bool) (*Tensor, error) {
+	return argReduce(a, axis, keepDims, func(v, best float64) bool { return v > best })
+}
+
+// ArgMin возвращает индексы минимальных элементов вдоль оси axis.
+// Индексы хранятся как float64; при равенстве выбирается первый элемент.
+func ArgMin(a *Tensor, axis int, keepDims bool) (*Tensor, error) {
+	return argReduce(a, axis, keepDims, func(v, best float64) bool { return v < best })
+}
+
+func argReduce(a *Tensor, axis int, keepDims bool, better func(v, best float64) bool) (*Tensor, error) {
+	axis, err := normalizeAxis(axis, len(a.Shape))
+	if err != nil {
+		return nil, err
+	}
+	if a.Shape[axis] == 0 {
+		return nil, fmt.Errorf("arg-редукция по пустой оси %d", axis)
+	}
+	r, err := newReduction(a.Shape, []int{axis}, keepDims)
+	if err != nil {
+		return nil, err
+	}
+	out := r.newResult(0)
+	best := r.newResult(0)
+	r.each(a, func(off, group int, idx []int) {
+		v := a.Data[off]
+		if idx[axis] == 0 || better(v, best.Data[group]) {
+			best.Data[group] = v
+			out.Data[group] = float64(idx[axis])
+		}
+	})
+	return out, nil
+}
@@ -0,0 +1,137 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+func assertData(t *testing.T, name string, got *Tensor, wantShape []int, want []float64) {
+	t.Helper()
+	if !shapesEqual(got.Shape, wantShape) {
+		t.Fatalf("%s: shape = %v, want %v", name, got.Shape, wantShape)
+	}
+	for i := range want {
+		if math.Abs(got.Data[i]-want[i]) > 1e-12 {
+			t.Fatalf("%s: data[%d] = %v, want %v", name, i, got.Data[i], want[i])
+		}
+	}
+}
+
+func TestReduceAxes(t *testing.T) {
+	a := arange(2, 3, 4)
+
+	s, err := SumAxes(a, []int{1}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertData(t, "SumAxes(1)", s, []int{2, 4}, []float64{12, 15, 18, 21, 48, 51, 54, 57})
+
+	s, _ = SumAxes(a, []int{0, -1}, true)
+	assertData(t, "SumAxes(0,-1,keep)", s, []int{1, 3, 1}, []float64{60, 92, 124})
+
+	s, _ = SumAxes(a, nil, false)
+	assertData(t, "SumAxes(all)", s, []int{1}, []float64{276})
+
+	m, _ := MeanAxes(a, []int{2}, false)
+	assertData(t, "MeanAxes(2)", m, []int{2, 3}, []float64{1.5, 5.5, 9.5, 13.5, 17.5, 21.5})
+
+	mx, _ := MaxAxes(a, []int{0, 2}, false)
+	assertData(t, "MaxAxes(0,2)", mx, []int{3}, []float64{15, 19, 23})
+
+	mn, _ := MinAxes(a, []int{1}, true)
+	assertData(t, "MinAxes(1,keep)", mn, []int{2, 1, 4}, []float64{0, 1, 2, 3, 12, 13, 14, 15})
+
+	b := &Tensor{Data: []float64{1, 2, 3, 4}, Shape: []int{2, 2}, Strides: []int{2, 1}}
+	p, _ := ProdAxes(b, []int{0}, false)
+	assertData(t, "ProdAxes(0)", p, []int{2}, []float64{3, 8})
+
+	if _, err := SumAxes(a, []int{3}, false); err == nil {
+		t.Fatal("expected error for out-of-range axis")
+	}
+	if _, err := SumAxes(a, []int{1, -2}, false); err == nil {
+		t.Fatal("expected error for duplicate axis")
+	}
+}
+
+func TestVarStdNorm(t *testing.T) {
+	a := &Tensor{Data: []float64{1, 2, 3, 4, 2, 4, 6, 8}, Shape: []int{2, 4}, Strides: []int{4, 1}}
+
+	v, _ := VarAxes(a, []int{1}, false, 0)
+	assertData(t, "VarAxes(ddof=0)", v, []int{2}, []float64{1.25, 5})
+
+	v, _ = VarAxes(a, []int{1}, false, 1)
+	assertData(t, "VarAxes(ddof=1)", v, []int{2}, []float64{5.0 / 3, 20.0 / 3})
+
+	s, _ := StdAxes(a, []int{1}, true, 0)
+	assertData(t, "StdAxes", s, []int{2, 1}, []float64{math.Sqrt(1.25), math.Sqrt(5)})
+
+	n, _ := NormAxes(a, []int{1}, false)
+	assertData(t, "NormAxes", n, []int{2}, []float64{math.Sqrt(30), math.Sqrt(120)})
+
+	if _, err := VarAxes(a, []int{1}, false, 4); err == nil {
+		t.Fatal("expected error when ddof >= group size")
+	}
+}
+
+func TestLogSumExpAxesStable(t *testing.T) {
+	a := &Tensor{Data: []float64{1000, 1000, -1000, math.Inf(-1)}, Shape: []int{2, 2}, Strides: []int{2, 1}}
+	l, err := LogSumExpAxes(a, []int{1}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertData(t, "LogSumExpAxes", l, []int{2}, []float64{1000 + math.Log(2), -1000})
+
+	inf := &Tensor{Data: []float64{math.Inf(-1), math.Inf(-1)}, Shape: []int{2}, Strides: []int{1}}
+	l, _ = LogSumExpAxes(inf, nil, false)
+	if !math.IsInf(l.Data[0], -1) {
+		t.Fatalf("LogSumExp of -Inf = %v, want -Inf", l.Data[0])
+	}
+}
+
+func TestArgMaxArgMin(t *testing.T) {
+	a := &Tensor{Data: []float64{1, 5, 5, 0, 9, 2}, Shape: []int{2, 3}, Strides: []int{3, 1}}
+
+	am, err := ArgMax(a, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertData(t, "ArgMax(1)", am, []int{2}, []float64{1, 1})
+
+	am, _ = ArgMax(a, 0, true)
+	assertData(t, "ArgMax(0,keep)", am, []int{1, 3}, []float64{0, 1, 0})
+
+	an, _ := ArgMin(a, -1, false)
+	assertData(t, "ArgMin(-1)", an, []int{2}, []float64{0, 0})
+}
+
+func TestReduceAxesOnView(t *testing.T) {
+	a := arange(3, 4)
+	at, _ := Transpose(a)
+	s, err := SumAxes(at, []int{1}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertData(t, "SumAxes(transposed)", s, []int{4}, []float64{12, 15, 18, 21})
+}
+
+func TestSumToShape(t *testing.T) {
+	g := arange(2, 3, 4)
+
+	s, err := SumToShape(g, []int{4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertData(t, "SumToShape([4])", s, []int{4}, []float64{60, 66, 72, 78})
+
+	s, _ = SumToShape(g, []int{3, 1})
+	assertData(t, "SumToShape([3,1])", s, []int{3, 1}, []float64{60, 92, 124})
+
+	s, _ = SumToShape(g, []int{2, 3, 4})
+	if s != g {
+		t.Fatal("SumToShape with equal shape must return the input")
+	}
+
+	if _, err := SumToShape(g, []int{2, 4}); err == nil {
+		t.Fatal("expected error for incompatible shape")
+	}
+}
@@ -0,0 +1,322 @@
+package tensor
+
+import "fmt"
+
+// Операции, копирующие элементы по независимым отображениям осей:
+// Pad, Tile, Repeat, Flip и Roll. Каждая задаёт для каждой выходной оси
+// список координат входа (maps[d][i] — откуда берётся координата i оси d),
+// поэтому все они выполняются одним проходом remap, а их backward — remapAdd.
+// Результат всегда плотный; входом может быть любой view.
+
+// remap строит плотный тензор out[i0, i1, ...] = a[maps[0][i0], maps[1][i1], ...].
+// Координата -1 означает, что элемент берётся равным fill.
+func remap(a *Tensor, maps [][]int, fill float64) *Tensor {
+	shape := make([]int, len(maps))
+	for d, m := range maps {
+		shape[d] = len(m)
+	}
+	out := Zeros(shape...)
+	if len(out.Data) == 0 {
+		return out
+	}
+	strides := stridesOf(a)
+	idx := make([]int, len(shape))
+	for i := range out.Data {
+		off, inside := a.Offset, true
+		for d, k := range idx {
+			src := maps[d][k]
+			if src < 0 {
+				inside = false
+				break
+			}
+			off += src * strides[d]
+		}
+		if inside {
+			out.Data[i] = a.Data[off]
+		} else {
+			out.Data[i] = fill
+		}
+		nextIndex(idx, shape)
+	}
+	return out
+}
+
+// remapAdd — сопряжённая к remap операция: каждый элемент grad добавляется
+// в ту позицию тензора формы inShape, из которой он был скопирован.
+// Элементы с координатой -1 (заполнение) отбрасываются.
+func remapAdd(grad *Tensor, maps [][]int, inShape []int) *Tensor {
+	out := Zeros(append([]int{}, inShape...)...)
+	g := grad.Contiguous()
+	if len(g.Data) == 0 || len(out.Data) == 0 {
+		return out
+	}
+	strides := out.Strides
+	idx := make([]int, len(maps))
+	for i := 0; i < numel(g.Shape); i++ {
+		off, inside := 0, true
+		for d, k := range idx {
+			src := maps[d][k]
+			if src < 0 {
+				inside = false
+				break
+			}
+			off += src * strides[d]
+		}
+		if inside {
+			out.Data[off] += g.Data[i]
+		}
+		nextIndex(idx, g.Shape)
+	}
+	return out
+}
+
+func identityMap(n int) []int {
+	m := make([]int, n)
+	for i := range m {
+		m[i] = i
+	}
+	return m
+}
+
+// mod возвращает неотрицательный остаток i по модулю n.
+func mod(i, n int) int {
+	i %= n
+	if i < 0 {
+		i += n
+	}
+	return i
+}
+
+// PadMode задаёт способ заполнения полей в Pad.
+type PadMode int
+
+const (
+	// PadConstant заполняет поля значением value.
+	PadConstant PadMode = iota
+	// PadReflect отражает тензор относительно крайнего элемента, не повторяя его:
+	// [1 2 3] с полями 2 → [3 2 1 2 3 2 1].
+	PadReflect
+	// PadReplicate повторяет крайний элемент: [1 2 3] → [1 1 1 2 3 3 3].
+	PadReplicate
+	// PadCircular продолжает тензор периодически: [1 2 3] → [2 3 1 2 3 1 2].
+	PadCircular
+)
+
+// String возвращает название режима как в numpy.pad.
+func (m PadMode) String() string {
+	switch m {
+	case PadConstant:
+		return "constant"
+	case PadReflect:
+		return "reflect"
+	case PadReplicate:
+		return "edge"
+	case PadCircular:
+		return "wrap"
+	}
+	return fmt.Sprintf("PadMode(%d)", int(m))
+}
+
+// padIndex возвращает координату входа размера n для координаты i,
+// отсчитанной от начала исходных данных (i < 0 и i >= n — поля).
+func padIndex(i, n int, mode PadMode) int {
+	if i >= 0 && i < n {
+		return i
+	}
+	switch mode {
+	case PadReplicate:
+		if i < 0 {
+			return 0
+		}
+		return n - 1
+	case PadCircular:
+		return mod(i, n)
+	case PadReflect:
+		if n == 1 {
+			return 0
+		}
+		i = mod(i, 2*(n-1))
+		if i >= n {
+			i = 2*(n-1) - i
+		}
+		return i
+	}
+	return -1
+}
+
+// padMaps проверяет поля pads для тензора формы shape и строит отображения осей.
+func padMaps(shape []int, pads [][2]int, mode PadMode) ([][]int, error) {
+	if len(pads) != len(shape) {
+		return nil, fmt.Errorf("pad: ожидалось %d пар полей, получено %d", len(shape), len(pads))
+	}
+	if mode < PadConstant || mode > PadCircular {
+		return nil, fmt.Errorf("pad: неизвестный режим %v", mode)
+	}
+	maps := make([][]int, len(shape))
+	for d, n := range shape {
+		before, after := pads[d][0], pads[d][1]
+		if before < 0 || after < 0 {
+			return nil, fmt.Errorf("pad: отрицательные поля %v по оси %d", pads[d], d)
+		}
+		if n == 0 && mode != PadConstant && before+after > 0 {
+			return nil, fmt.Errorf("pad: режим %v не применим к пустой оси %d", mode, d)
+		}
+		maps[d] = make([]int, before+n+after)
+		for i := range maps[d] {
+			maps[d][i] = padIndex(i-before, n, mode)
+		}
+	}
+	return maps, nil
+}
+
+// Pad дополняет тензор полями: pads[d] = {до, после} для каждой оси d
+// (нулевые пары оставляют ось без изменений). value используется только в PadConstant.
+// Например, поля 1 по H и W для изображений NCHW:
+//
+//	Pad(x, [][2]int{{0, 0}, {0, 0}, {1, 1}, {1, 1}}, PadConstant, 0)
+//
+// В отличие от PyTorch, отражение допускает поля шире самой оси (как numpy.pad).
+func Pad(a *Tensor, pads [][2]int, mode PadMode, value float64) (*Tensor, error) {
+	maps, err := padMaps(a.Shape, pads, mode)
+	if err != nil {
+		return nil, err
+	}
+	return remap(a, maps, value), nil
+}
+
+// Unpad — обратная к Pad операция для градиентов: возвращает тензор формы входа Pad,
+// в котором элементы полей просуммированы в позиции, откуда они были скопированы.
+// Для PadConstant это просто вырезание центральной части.
+func Unpad(grad *Tensor, pads [][2]int, mode PadMode) (*Tensor, error) {
+	if len(pads) != len(grad.Shape) {
+		return nil, fmt.Errorf("unpad: ожидалось %d пар полей, получено %d", len(grad.Shape), len(pads))
+	}
+	inShape := make([]int, len(grad.Shape))
+	for d, n := range grad.Shape {
+		inShape[d] = n - pads[d][0] - pads[d][1]
+		if inShape[d] < 0 {
+			return nil, fmt.Errorf("unpad: поля %v больше оси %d размера %d", pads[d], d, n)
+		}
+	}
+	maps, err := padMaps(inShape, pads, mode)
+	if err != nil {
+		return nil, err
+	}
+	return remapAdd(grad, maps, inShape), nil
+}
+
+// Tile повторяет тензор целиком reps[d] раз вдоль каждой оси d (как numpy.tile):
+// Tile([1 2], 3) → [1 2 1 2 1 2]. Если reps короче числа осей, он дополняется
+// единицами слева; если длиннее — слева к тензору добавляются оси размера 1.
+func Tile(a *Tensor, reps ...int) (*Tensor, error) {
+	for len(a.Shape) < len(reps) {
+		a, _ = Unsqueeze(a, 0)
+	}
+	lead := len(a.Shape) - len(reps)
+	maps := make([][]int, len(a.Shape))
+	for d, n := range a.Shape {
+		r := 1
+		if d >= lead {
+			r = reps[d-lead]
+		}
+		if r < 0 {
+			return nil, fmt.Errorf("tile: отрицательное число повторов %d", r)
+		}
+		maps[d] = make([]int, n*r)
+		for i := range maps[d] {
+			maps[d][i] = i % n
+		}
+	}
+	return remap(a, maps, 0), nil
+}
+
+// Repeat повторяет каждый элемент repeats раз вдоль оси axis
+// (как numpy.repeat и torch.repeat_interleave): [1 2] → [1 1 1 2 2 2].
+func Repeat(a *Tensor, repeats, axis int) (*Tensor, error) {
+	axis, err := normalizeAxis(axis, len(a.Shape))
+	if err != nil {
+		return nil, err
+	}
+	if repeats < 0 {
+		return nil, fmt.Errorf("repeat: отрицательное число повторов %d", repeats)
+	}
+	maps := make([][]int, len(a.Shape))
+	for d, n := range a.Shape {
+		maps[d] = identityMap(n)
+	}
+	maps[axis] = make([]int, a.Shape[axis]*repeats)
+	for i := range maps[axis] {
+		maps[axis][i] = i / repeats
+	}
+	return remap(a, maps, 0), nil
+}
+
+// Flip переставляет элементы в обратном порядке вдоль осей axes
+// (без аргументов — вдоль всех осей).
+func Flip(a *Tensor, axes ...int) (*Tensor, error) {
+	flip := make([]bool, len(a.Shape))
+	if len(axes) == 0 {
+		for d := range flip {
+			flip[d] = true
+		}
+	}
+	for _, ax := range axes {
+		axis, err := normalizeAxis(ax, len(a.Shape))
+		if err != nil {
+			return nil, err
+		}
+		if flip[axis] {
+			return nil, fmt.Errorf("flip: ось %d указана дважды", axis)
+		}
+		flip[axis] = true
+	}
+	maps := make([][]int, len(a.Shape))
+	for d, n := range a.Shape {
+		maps[d] = identityMap(n)
+		if flip[d] {
+			for i := range maps[d] {
+				maps[d][i] = n - 1 - i
+			}
+		}
+	}
+	return remap(a, maps, 0), nil
+}
+
+// Roll циклически сдвигает элементы на shift позиций вдоль оси axis
+// (как numpy.roll): Roll([1 2 3 4], 1, 0) → [4 1 2 3]. Отрицательный shift сдвигает влево.
+func Roll(a *Tensor, shift, axis int) (*Tensor, error) {
+	axis, err := normalizeAxis(axis, len(a.Shape))
+	if err != nil {
+		return nil, err
+	}
+	maps := make([][]int, len(a.Shape))
+	for d, n := range a.Shape {
+		maps[d] = identityMap(n)
+	}
+	n := a.Shape[axis]
+	for i := range maps[axis] {
+		maps[axis][i] = mod(i-shift, n)
+	}
+	return remap(a, maps, 0), nil
+}
+
+// Stack объединяет тензоры одинаковой формы вдоль новой оси axis:
+// для k тензоров формы [A, B] и axis=1 результат имеет форму [A, k, B].
+func Stack(tensors []*Tensor, axis int) (*Tensor, error) {
+	if len(tensors) == 0 {
+		return nil, fmt.Errorf("stack: пустой список тензоров")
+	}
+	shape := tensors[0].Shape
+	axis, err := normalizeAxis(axis, len(shape)+1)
+	if err != nil {
+		return nil, err
+	}
+	expanded := make([]*Tensor, len(tensors))
+	for i, t := range tensors {
+		if !shapesEqual(t.Shape, shape) {
+			return nil, fmt.Errorf("stack: форма тензора %d %v отличается от %v", i, t.Shape, shape)
+		}
+		expanded[i], _ = Unsqueeze(t, axis)
+	}
+	return Concatenate(expanded, axis)
+}
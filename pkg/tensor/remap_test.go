@@ -0,0 +1,136 @@
+package tensor
+
+import "testing"
+
+func vector(values ...float64) *Tensor {
+	return &Tensor{Data: values, Shape: []int{len(values)}, Strides: []int{1}}
+}
+
+func TestPadModes(t *testing.T) {
+	x := vector(1, 2, 3)
+	cases := []struct {
+		mode PadMode
+		pads [2]int
+		want []float64
+	}{
+		{PadConstant, [2]int{2, 1}, []float64{-1, -1, 1, 2, 3, -1}},
+		{PadReflect, [2]int{2, 2}, []float64{3, 2, 1, 2, 3, 2, 1}},
+		{PadReflect, [2]int{5, 0}, []float64{2, 1, 2, 3, 2, 1, 2, 3}},
+		{PadReplicate, [2]int{2, 2}, []float64{1, 1, 1, 2, 3, 3, 3}},
+		{PadCircular, [2]int{2, 2}, []float64{2, 3, 1, 2, 3, 1, 2}},
+	}
+	for _, c := range cases {
+		got, err := Pad(x, [][2]int{c.pads}, c.mode, -1)
+		if err != nil {
+			t.Fatalf("%v: %v", c.mode, err)
+		}
+		if !floatsEqual(got.Data, c.want) {
+			t.Errorf("%v %v: получено %v, ожидалось %v", c.mode, c.pads, got.Data, c.want)
+		}
+	}
+}
+
+func TestPad2DViewAndUnpad(t *testing.T) {
+	view, err := Transpose(arange(3, 2)) // [[0 2 4] [1 3 5]]
+	if err != nil {
+		t.Fatal(err)
+	}
+	pads := [][2]int{{1, 0}, {0, 1}}
+	got, err := Pad(view, pads, PadReplicate, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertShape(t, got, 3, 4)
+	want := []float64{0, 2, 4, 4, 0, 2, 4, 4, 1, 3, 5, 5}
+	if !floatsEqual(got.Data, want) {
+		t.Fatalf("Pad view: %v", got.Data)
+	}
+
+	// Unpad суммирует скопированные в поля элементы обратно в источник.
+	back, err := Unpad(Ones(3, 4), pads, PadReplicate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertShape(t, back, 2, 3)
+	if !floatsEqual(back.Data, []float64{2, 2, 4, 1, 1, 2}) {
+		t.Fatalf("Unpad: %v", back.Data)
+	}
+
+	if _, err := Pad(view, [][2]int{{1, 1}}, PadConstant, 0); err == nil {
+		t.Fatal("ожидалась ошибка числа пар полей")
+	}
+	if _, err := Pad(view, [][2]int{{-1, 0}, {0, 0}}, PadConstant, 0); err == nil {
+		t.Fatal("ожидалась ошибка отрицательных полей")
+	}
+}
+
+func TestTileAndRepeat(t *testing.T) {
+	x := arange(2, 2) // [[0 1] [2 3]]
+	tiled, err := Tile(x, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertShape(t, tiled, 2, 4)
+	if !floatsEqual(tiled.Data, []float64{0, 1, 0, 1, 2, 3, 2, 3}) {
+		t.Fatalf("Tile(2): %v", tiled.Data)
+	}
+	tiled, err = Tile(vector(1, 2), 2, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertShape(t, tiled, 2, 1, 4)
+	if !floatsEqual(tiled.Data, []float64{1, 2, 1, 2, 1, 2, 1, 2}) {
+		t.Fatalf("Tile с новыми осями: %v", tiled.Data)
+	}
+
+	rep, err := Repeat(x, 3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertShape(t, rep, 2, 6)
+	if !floatsEqual(rep.Data, []float64{0, 0, 0, 1, 1, 1, 2, 2, 2, 3, 3, 3}) {
+		t.Fatalf("Repeat: %v", rep.Data)
+	}
+	if _, err := Repeat(x, -1, 0); err == nil {
+		t.Fatal("ожидалась ошибка отрицательного числа повторов")
+	}
+}
+
+func TestFlipRollStack(t *testing.T) {
+	x := arange(2, 3) // [[0 1 2] [3 4 5]]
+	f, err := Flip(x, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !floatsEqual(f.Data, []float64{2, 1, 0, 5, 4, 3}) {
+		t.Fatalf("Flip(1): %v", f.Data)
+	}
+	f, _ = Flip(x)
+	if !floatsEqual(f.Data, []float64{5, 4, 3, 2, 1, 0}) {
+		t.Fatalf("Flip(): %v", f.Data)
+	}
+
+	r, err := Roll(x, 1, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !floatsEqual(r.Data, []float64{2, 0, 1, 5, 3, 4}) {
+		t.Fatalf("Roll(1): %v", r.Data)
+	}
+	r, _ = Roll(x, -4, 1)
+	if !floatsEqual(r.Data, []float64{1, 2, 0, 4, 5, 3}) {
+		t.Fatalf("Roll(-4): %v", r.Data)
+	}
+
+	s, err := Stack([]*Tensor{vector(1, 2), vector(3, 4), vector(5, 6)}, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertShape(t, s, 2, 3)
+	if !floatsEqual(s.Data, []float64{1, 3, 5, 2, 4, 6}) {
+		t.Fatalf("Stack: %v", s.Data)
+	}
+	if _, err := Stack([]*Tensor{vector(1), vector(1, 2)}, 0); err == nil {
+		t.Fatal("ожидалась ошибка разных форм")
+	}
+}
@@ -1,84 +1,68 @@
 package tensor
 
 import (
-	"runtime"
-	"sync/atomic"
+	"sync"
 )
 
-// matmulRowScheduler раздаёт непересекающиеся диапазоны строк C.
-// Каждый worker получает эксклюзивный row-range, поэтому запись в результат не конфликтует.
-type matmulRowScheduler struct {
-	totalRows int
-	chunkRows int
-	nextRow   atomic.Int64
+// stealScheduler раздаёт номера чанков [0, chunks) участникам ComputePool.ParallelFor.
+// Изначально чанки делятся поровну между очередями участников; участник берёт
+// чанки с начала своей очереди, а опустошив её, забирает половину чужой с конца.
+// Каждый чанк выдаётся ровно один раз, поэтому запись в результат не конфликтует.
+type stealScheduler struct {
+	queues []stealQueue
 }
 
-func newMatmulRowScheduler(totalRows, chunkRows int) *matmulRowScheduler {
-	if chunkRows < 1 {
-		chunkRows = 1
-	}
-
-	return &matmulRowScheduler{
-		totalRows: totalRows,
-		chunkRows: chunkRows,
-	}
-}
-
-func (s *matmulRowScheduler) next() (start, end int, ok bool) {
-	start = int(s.nextRow.Add(int64(s.chunkRows))) - s.chunkRows
-	if start >= s.totalRows {
-		return 0, 0, false
-	}
-
-	end = start + s.chunkRows
-	if end > s.totalRows {
-		end = s.totalRows
-	}
-
-	return start, end, true
+// stealQueue — непрерывный диапазон номеров чанков [lo, hi).
+type stealQueue struct {
+	mu     sync.Mutex
+	lo, hi int
+	_      [40]byte // разносим очереди по разным линиям кеша
 }
 
-func matmulWorkerCount(totalRows, blockSize int) int {
-	if totalRows <= 0 {
-		return 1
-	}
-
-	workers := runtime.GOMAXPROCS(0)
+func newStealScheduler(chunks, workers int) *stealScheduler {
 	if workers < 1 {
 		workers = 1
 	}
-
-	rowChunks := ceilDiv(totalRows, max(1, blockSize))
-	if rowChunks > 0 && workers > rowChunks {
-		workers = rowChunks
+	s := &stealScheduler{queues: make([]stealQueue, workers)}
+	for i := range s.queues {
+		s.queues[i].lo = i * chunks / workers
+		s.queues[i].hi = (i + 1) * chunks / workers
 	}
-	if workers > totalRows {
-		workers = totalRows
-	}
-	if workers < 1 {
-		workers = 1
-	}
-
-	return workers
+	return s
 }
 
-func matmulChunkRows(totalRows, workers, blockSize int) int {
-	if totalRows <= 0 {
-		return 1
+// next возвращает очередной чанк для участника id или ok=false, если работа кончилась.
+func (s *stealScheduler) next(id int) (chunk int, ok bool) {
+	own := &s.queues[id]
+	own.mu.Lock()
+	if own.lo < own.hi {
+		chunk = own.lo
+		own.lo++
+		own.mu.Unlock()
+		return chunk, true
 	}
-	if workers < 1 {
-		workers = 1
-	}
-
-	chunkRows := ceilDiv(totalRows, workers)
-	if blockSize > 1 {
-		chunkRows = ceilDiv(chunkRows, blockSize) * blockSize
+	own.mu.Unlock()
+
+	for k := 1; k < len(s.queues); k++ {
+		victim := &s.queues[(id+k)%len(s.queues)]
+		victim.mu.Lock()
+		rest := victim.hi - victim.lo
+		if rest == 0 {
+			victim.mu.Unlock()
+			continue
+		}
+		take := (rest + 1) / 2
+		victim.hi -= take
+		start := victim.hi
+		victim.mu.Unlock()
+
+		// Первый украденный чанк выполняем сразу, остальные кладём в свою очередь.
+		own.mu.Lock()
+		own.lo, own.hi = start+1, start+take
+		own.mu.Unlock()
+		return start, true
 	}
-	if chunkRows < 1 {
-		chunkRows = 1
-	}
-
-	return chunkRows
+	return 0, false
 }
 
 func ceilDiv(x, y int) int {
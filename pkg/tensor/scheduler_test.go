@@ -2,63 +2,69 @@ package tensor
 
 import (
 	"fmt"
-	"runtime"
 	"sync"
 	"testing"
 )
 
-func TestMatmulWorkerCountUsesGOMAXPROCS(t *testing.T) {
-	previous := runtime.GOMAXPROCS(1)
-	defer runtime.GOMAXPROCS(previous)
-
-	if got := matmulWorkerCount(1024, 64); got != 1 {
-		t.Fatalf("matmulWorkerCount() with GOMAXPROCS=1 = %d, want 1", got)
+func TestStealSchedulerSplitsChunksEvenly(t *testing.T) {
+	s := newStealScheduler(10, 4)
+	want := [][2]int{{0, 2}, {2, 5}, {5, 7}, {7, 10}}
+	for i := range s.queues {
+		q := &s.queues[i]
+		if q.lo != want[i][0] || q.hi != want[i][1] {
+			t.Fatalf("очередь %d = [%d, %d), ожидалось %v", i, q.lo, q.hi, want[i])
+		}
 	}
+}
 
-	runtime.GOMAXPROCS(4)
-	if got := matmulWorkerCount(1024, 64); got != 4 {
-		t.Fatalf("matmulWorkerCount() with GOMAXPROCS=4 = %d, want 4", got)
+func TestStealSchedulerStealsFromOthers(t *testing.T) {
+	s := newStealScheduler(8, 2)
+	// Участник 0 выбирает свою очередь [0, 4), затем крадёт половину [4, 8) с конца.
+	var got []int
+	for {
+		c, ok := s.next(0)
+		if !ok {
+			break
+		}
+		got = append(got, c)
 	}
-
-	runtime.GOMAXPROCS(32)
-	if got := matmulWorkerCount(128, 64); got != 2 {
-		t.Fatalf("matmulWorkerCount() should cap workers by row chunks, got %d want 2", got)
+	if fmt.Sprint(got) != "[0 1 2 3 6 7 5 4]" {
+		t.Fatalf("порядок чанков = %v", got)
 	}
 }
 
-func TestMatmulRowSchedulerProducesNonOverlappingRanges(t *testing.T) {
-	scheduler := newMatmulRowScheduler(130, 32)
+func TestStealSchedulerProducesNonOverlappingChunks(t *testing.T) {
+	const chunks, workers = 130, 8
+	scheduler := newStealScheduler(chunks, workers)
 
-	seen := make([]bool, 130)
+	seen := make([]bool, chunks)
 	var mu sync.Mutex
 	var wg sync.WaitGroup
 	errCh := make(chan error, 1)
 
-	for i := 0; i < 8; i++ {
+	for id := 0; id < workers; id++ {
 		wg.Add(1)
-		go func() {
+		go func(id int) {
 			defer wg.Done()
 			for {
-				start, end, ok := scheduler.next()
+				c, ok := scheduler.next(id)
 				if !ok {
 					return
 				}
 
 				mu.Lock()
-				for row := start; row < end; row++ {
-					if seen[row] {
-						mu.Unlock()
-						select {
-						case errCh <- fmt.Errorf("row %d scheduled more than once", row):
-						default:
-						}
-						return
+				if seen[c] {
+					mu.Unlock()
+					select {
+					case errCh <- fmt.Errorf("chunk %d scheduled more than once", c):
+					default:
 					}
-					seen[row] = true
+					return
 				}
+				seen[c] = true
 				mu.Unlock()
 			}
-		}()
+		}(id)
 	}
 
 	wg.Wait()
@@ -68,9 +74,9 @@ func TestMatmulRowSchedulerProducesNonOverlappingRanges(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	for row, ok := range seen {
+	for c, ok := range seen {
 		if !ok {
-			t.Fatalf("row %d was not scheduled", row)
+			t.Fatalf("chunk %d was not scheduled", c)
 		}
 	}
 }
@@ -10,9 +10,105 @@ import (
 // SIMDVectorSize - размер SIMD вектора (обычно 4 для AVX2 с float64)
 const SIMDVectorSize = 4
 
-// AddSIMD выполняет векторизованное сложение с использованием unsafe
-// Оптимизировано для AVX/AVX2 инструкций процессора
+// SIMD-операции выбирают реализацию при старте: на amd64 с AVX2 и FMA вызываются
+// ассемблерные ядра (simd_amd64.s), иначе — переносимые версии на Go ниже.
+// Сборка с тегом purego отключает ассемблер на любой архитектуре.
+
+// SIMDBackend возвращает имя используемой реализации: "avx2+fma" или "go".
+func SIMDBackend() string {
+	if useAVX2FMA {
+		return "avx2+fma"
+	}
+	return "go"
+}
+
+// AddSIMD выполняет векторизованное сложение: result = a + b
 func AddSIMD(a, b, result []float64) {
+	if useAVX2FMA && len(b) >= len(a) && len(result) >= len(a) {
+		addAVX2(a, b, result)
+		return
+	}
+	addGo(a, b, result)
+}
+
+// MulSIMD выполняет векторизованное поэлементное умножение: result = a * b
+func MulSIMD(a, b, result []float64) {
+	if useAVX2FMA && len(b) >= len(a) && len(result) >= len(a) {
+		mulAVX2(a, b, result)
+		return
+	}
+	mulGo(a, b, result)
+}
+
+// FMAOperation выполняет Fused Multiply-Add: result = a*b + c
+// На AVX2/FMA произведение не округляется отдельно, поэтому результат может
+// отличаться от переносимой версии в последнем бите.
+func FMAOperation(a, b, c, result []float64) {
+	if useAVX2FMA && len(b) >= len(a) && len(c) >= len(a) && len(result) >= len(a) {
+		fmaAVX2(a, b, c, result)
+		return
+	}
+	fmaGo(a, b, c, result)
+}
+
+// DotProductSIMD вычисляет скалярное произведение векторов с SIMD оптимизацией
+// Используется в MatMulTransposeB
+func DotProductSIMD(a, b []float64) float64 {
+	if useAVX2FMA && len(b) >= len(a) {
+		return dotAVX2(a, b)
+	}
+	return dotGo(a, b)
+}
+
+// MatMulSIMDKernel - SIMD-оптимизированное ядро умножения матриц:
+// C[i, j] += A[i, k] * B[k, j] для i, k, j из заданных диапазонов.
+func MatMulSIMDKernel(a, b, c []float64, m, n, p int, iStart, iEnd, kStart, kEnd, jStart, jEnd int) {
+	if useAVX2FMA {
+		matmulKernelAVX2(a, b, c, n, p, p, iStart, iEnd, kStart, kEnd, jStart, jEnd)
+		return
+	}
+	matmulKernelGo(a, b, c, n, p, iStart, iEnd, kStart, kEnd, jStart, jEnd)
+}
+
+// matmulKernelAVX2 обходит блок C плитками 4×8 ассемблерного микроядра;
+// остатки по строкам и столбцам досчитываются построчным axpy.
+// Ассемблер не проверяет границы, поэтому крайние индексы проверяются заранее.
+// lda, ldb и ldc — шаги строк A, B и C (для плотных матриц n, p и p).
+func matmulKernelAVX2(a, b, c []float64, lda, ldb, ldc, iStart, iEnd, kStart, kEnd, jStart, jEnd int) {
+	if iStart >= iEnd || kStart >= kEnd || jStart >= jEnd {
+		return
+	}
+	_ = a[(iEnd-1)*lda+kEnd-1]
+	_ = b[(kEnd-1)*ldb+jEnd-1]
+	_ = c[(iEnd-1)*ldc+jEnd-1]
+
+	kSize := kEnd - kStart
+	jMain := jStart + (jEnd-jStart)/8*8
+	i := iStart
+	for ; i+4 <= iEnd; i += 4 {
+		for j := jStart; j < jMain; j += 8 {
+			gemm4x8AVX2(kSize, &a[i*lda+kStart], lda, &b[kStart*ldb+j], ldb, &c[i*ldc+j], ldc)
+		}
+		matmulRowsAxpy(a, b, c, lda, ldb, ldc, i, i+4, kStart, kEnd, jMain, jEnd)
+	}
+	matmulRowsAxpy(a, b, c, lda, ldb, ldc, i, iEnd, kStart, kEnd, jStart, jEnd)
+}
+
+// matmulRowsAxpy добавляет к строкам C[i, jStart:jEnd] вклады A[i, k] * B[k, jStart:jEnd].
+func matmulRowsAxpy(a, b, c []float64, lda, ldb, ldc, iStart, iEnd, kStart, kEnd, jStart, jEnd int) {
+	if jStart >= jEnd {
+		return
+	}
+	for i := iStart; i < iEnd; i++ {
+		cRow := c[i*ldc+jStart : i*ldc+jEnd]
+		for k := kStart; k < kEnd; k++ {
+			axpyAVX2(a[i*lda+k], b[k*ldb+jStart:k*ldb+jEnd], cRow)
+		}
+	}
+}
+
+// addGo — переносимая реализация AddSIMD с ручной разверткой через unsafe
+func addGo(a, b, result []float64) {
 	n := len(a)
 
 	// Проверка выравнивания для SIMD
@@ -67,8 +163,8 @@ func AddSIMD(a, b, result []float64) {
 	}
 }
 
-// MulSIMD выполняет векторизованное умножение с использованием unsafe
-func MulSIMD(a, b, result []float64) {
+// mulGo — переносимая реализация MulSIMD
+func mulGo(a, b, result []float64) {
 	n := len(a)
 
 	if n < SIMDVectorSize*2 {
@@ -114,9 +210,8 @@ func MulSIMD(a, b, result []float64) {
 	}
 }
 
-// FMAOperation выполняет Fused Multiply-Add: result = a*b + c
-// Это важная операция для MatMul, которая может использовать FMA инструкции процессора
-func FMAOperation(a, b, c, result []float64) {
+// fmaGo — переносимая реализация FMAOperation
+func fmaGo(a, b, c, result []float64) {
 	n := len(a)
 
 	if n < SIMDVectorSize*2 {
@@ -169,9 +264,8 @@ func FMAOperation(a, b, c, result []float64) {
 	}
 }
 
-// DotProductSIMD вычисляет скалярное произведение векторов с SIMD оптимизацией
-// Используется в MatMulTransposeB
-func DotProductSIMD(a, b []float64) float64 {
+// dotGo — переносимая реализация DotProductSIMD
+func dotGo(a, b []float64) float64 {
 	n := len(a)
 
 	if n < SIMDVectorSize*2 {
@@ -221,9 +315,8 @@ func DotProductSIMD(a, b []float64) float64 {
 	return sum
 }
 
-// MatMulSIMDKernel - SIMD-оптимизированное ядро умножения матриц
-// Использует unsafe для максимальной производительности
-func MatMulSIMDKernel(a, b, c []float64, m, n, p int, iStart, iEnd, kStart, kEnd, jStart, jEnd int) {
+// matmulKernelGo — переносимая реализация MatMulSIMDKernel
+func matmulKernelGo(a, b, c []float64, n, p int, iStart, iEnd, kStart, kEnd, jStart, jEnd int) {
 	for i := iStart; i < iEnd; i++ {
 		iOffsetA := i * n
 		iOffsetC := i * p
@@ -0,0 +1,29 @@
+//go:build amd64 && !purego
+
+package tensor
+
+import "github.com/Hirogava/Go-NN-Learn/pkg/tensor/internal/simd"
+
+// useAVX2FMA — процессор и ОС поддерживают AVX2 и FMA (см. internal/simd);
+// переменная, а не константа, чтобы тесты могли сравнить оба пути.
+var useAVX2FMA = simd.AVX2FMA
+
+// Ассемблерные ядра живут в internal/simd: пакет tensor использует cgo, а Go
+// не собирает ассемблер Go в таких пакетах.
+
+func addAVX2(a, b, result []float64) { simd.Add(a, b, result) }
+
+func mulAVX2(a, b, result []float64) { simd.Mul(a, b, result) }
+
+func fmaAVX2(a, b, c, result []float64) { simd.FMA(a, b, c, result) }
+
+func dotAVX2(a, b []float64) float64 { return simd.Dot(a, b) }
+
+// axpyAVX2 вычисляет y[i] += alpha * x[i] для i < len(x).
+func axpyAVX2(alpha float64, x, y []float64) { simd.Axpy(alpha, x, y) }
+
+// gemm4x8AVX2 — микроядро C[4×8] += A[4×k] · B[k×8]; lda, ldb, ldc — длины строк
+// матриц в элементах.
+func gemm4x8AVX2(k int, a *float64, lda int, b *float64, ldb int, c *float64, ldc int) {
+	simd.Gemm4x8(k, a, lda, b, ldb, c, ldc)
+}
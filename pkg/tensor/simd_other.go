@@ -0,0 +1,25 @@
+//go:build !amd64 || purego
+
+package tensor
+
+// На архитектурах без ассемблерных ядер всегда используется переносимый путь;
+// заглушки ниже нужны только для компиляции и делегируют Go-версиям.
+var useAVX2FMA = false
+
+func addAVX2(a, b, result []float64) { addGo(a, b, result) }
+
+func mulAVX2(a, b, result []float64) { mulGo(a, b, result) }
+
+func fmaAVX2(a, b, c, result []float64) { fmaGo(a, b, c, result) }
+
+func dotAVX2(a, b []float64) float64 { return dotGo(a, b) }
+
+func axpyAVX2(alpha float64, x, y []float64) {
+	for i, v := range x {
+		y[i] += alpha * v
+	}
+}
+
+func gemm4x8AVX2(k int, a *float64, lda int, b *float64, ldb int, c *float64, ldc int) {
+	panic("gemm4x8AVX2: ассемблерное ядро недоступно на этой архитектуре")
+}
@@ -0,0 +1,189 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+// requireAVX2FMA пропускает тест, если ассемблерные ядра недоступны.
+func requireAVX2FMA(tb testing.TB) {
+	tb.Helper()
+	if !useAVX2FMA {
+		tb.Skip("AVX2/FMA недоступны, используется переносимый путь")
+	}
+}
+
+// withGoSIMD временно переключает SIMD-функции на переносимую реализацию.
+func withGoSIMD(f func()) {
+	saved := useAVX2FMA
+	useAVX2FMA = false
+	defer func() { useAVX2FMA = saved }()
+	f()
+}
+
+func simdInputs(n int, seed int64) (a, b, c []float64) {
+	g := NewGenerator(seed)
+	return g.Normal([]int{n}, 0, 1).Data, g.Normal([]int{n}, 0, 1).Data, g.Normal([]int{n}, 0, 1).Data
+}
+
+func assertClose(t *testing.T, name string, got, want []float64, tol float64) {
+	t.Helper()
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > tol {
+			t.Fatalf("%s[%d] = %v, ожидалось %v", name, i, got[i], want[i])
+		}
+	}
+}
+
+// Длины покрывают пустой вход, скалярный хвост, шаг 4 и основной цикл по 16.
+var simdLengths = []int{0, 1, 3, 4, 5, 8, 15, 16, 17, 31, 33, 64, 100, 1023}
+
+func TestSIMDAssemblyMatchesGo(t *testing.T) {
+	requireAVX2FMA(t)
+	for _, n := range simdLengths {
+		a, b, c := simdInputs(n, int64(n))
+
+		got, want := make([]float64, n), make([]float64, n)
+		AddSIMD(a, b, got)
+		withGoSIMD(func() { AddSIMD(a, b, want) })
+		assertClose(t, "AddSIMD", got, want, 0)
+
+		MulSIMD(a, b, got)
+		withGoSIMD(func() { MulSIMD(a, b, want) })
+		assertClose(t, "MulSIMD", got, want, 0)
+
+		FMAOperation(a, b, c, got)
+		withGoSIMD(func() { FMAOperation(a, b, c, want) })
+		assertClose(t, "FMAOperation", got, want, 1e-12)
+
+		dot := DotProductSIMD(a, b)
+		var wantDot float64
+		withGoSIMD(func() { wantDot = DotProductSIMD(a, b) })
+		if math.Abs(dot-wantDot) > 1e-10 {
+			t.Fatalf("DotProductSIMD(n=%d) = %v, ожидалось %v", n, dot, wantDot)
+		}
+
+		y := append([]float64(nil), c...)
+		axpyAVX2(0.5, a, y)
+		for i := range y {
+			want[i] = c[i] + 0.5*a[i]
+		}
+		assertClose(t, "axpy", y, want, 1e-12)
+	}
+}
+
+func TestSIMDDoesNotWritePastLength(t *testing.T) {
+	requireAVX2FMA(t)
+	a, b, _ := simdInputs(21, 1)
+	result := make([]float64, 24)
+	result[21], result[22], result[23] = -1, -1, -1
+	AddSIMD(a, b, result)
+	if result[21] != -1 || result[22] != -1 || result[23] != -1 {
+		t.Fatalf("AddSIMD изменил элементы за пределами len(a): %v", result[21:])
+	}
+}
+
+func TestMatMulSIMDKernelMatchesGo(t *testing.T) {
+	requireAVX2FMA(t)
+	// Размеры не кратны 4 и 8, чтобы задеть остатки по строкам и столбцам.
+	for _, dims := range [][3]int{{1, 1, 1}, {4, 3, 8}, {5, 7, 9}, {13, 17, 19}, {64, 64, 64}, {70, 33, 45}} {
+		m, n, p := dims[0], dims[1], dims[2]
+		a, _, _ := simdInputs(m*n, int64(m))
+		b, _, _ := simdInputs(n*p, int64(p))
+		got, want := make([]float64, m*p), make([]float64, m*p)
+
+		matmulBlockedSIMD(a, b, got, m, n, p, BlockSizeSmall)
+		withGoSIMD(func() { matmulBlockedSIMD(a, b, want, m, n, p, BlockSizeSmall) })
+		assertClose(t, "matmulBlockedSIMD", got, want, 1e-10)
+
+		// Подблок: ядро не должно трогать C вне [iStart, iEnd) × [jStart, jEnd).
+		got, want = make([]float64, m*p), make([]float64, m*p)
+		MatMulSIMDKernel(a, b, got, m, n, p, m/3, m, n/2, n, p/4, p)
+		withGoSIMD(func() { MatMulSIMDKernel(a, b, want, m, n, p, m/3, m, n/2, n, p/4, p) })
+		assertClose(t, "MatMulSIMDKernel", got, want, 1e-10)
+	}
+}
+
+func TestMatMulAVX2MatchesGo(t *testing.T) {
+	requireAVX2FMA(t)
+	// 40 — последовательный v2, 150 — параллельный v2.
+	for _, size := range []int{40, 150} {
+		g := NewGenerator(int64(size))
+		a := g.Normal([]int{size, size + 3}, 0, 1)
+		b := g.Normal([]int{size + 3, size - 1}, 0, 1)
+		got, err := MatMul(a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var want *Tensor
+		withGoSIMD(func() { want, err = MatMul(a, b) })
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertClose(t, "MatMul", got.Data, want.Data, 1e-10)
+	}
+}
+
+func benchmarkMatMulBlockedSIMD(b *testing.B, size int, goPath bool) {
+	a, c := benchmarkTensorSquare(size)
+	out := make([]float64, size*size)
+	run := func() {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			matmulBlockedSIMD(a.Data, c.Data, out, size, size, size, BlockSizeMedium)
+		}
+	}
+	if goPath {
+		withGoSIMD(run)
+		return
+	}
+	requireAVX2FMA(b)
+	run()
+}
+
+func BenchmarkMatMulBlockedSIMD_Go_256(b *testing.B)   { benchmarkMatMulBlockedSIMD(b, 256, true) }
+func BenchmarkMatMulBlockedSIMD_AVX2_256(b *testing.B) { benchmarkMatMulBlockedSIMD(b, 256, false) }
+func BenchmarkMatMulBlockedSIMD_Go_512(b *testing.B)   { benchmarkMatMulBlockedSIMD(b, 512, true) }
+func BenchmarkMatMulBlockedSIMD_AVX2_512(b *testing.B) { benchmarkMatMulBlockedSIMD(b, 512, false) }
+
+func benchmarkElementwiseSIMD(b *testing.B, goPath bool, op func(a, b, c, out []float64)) {
+	x, y, z := simdInputs(4096, 1)
+	out := make([]float64, len(x))
+	run := func() {
+		b.SetBytes(int64(len(x) * 8))
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			op(x, y, z, out)
+		}
+	}
+	if goPath {
+		withGoSIMD(run)
+		return
+	}
+	requireAVX2FMA(b)
+	run()
+}
+
+func BenchmarkAddSIMD_Go(b *testing.B) {
+	benchmarkElementwiseSIMD(b, true, func(x, y, _, out []float64) { AddSIMD(x, y, out) })
+}
+
+func BenchmarkAddSIMD_AVX2(b *testing.B) {
+	benchmarkElementwiseSIMD(b, false, func(x, y, _, out []float64) { AddSIMD(x, y, out) })
+}
+
+func BenchmarkFMAOperation_Go(b *testing.B) {
+	benchmarkElementwiseSIMD(b, true, FMAOperation)
+}
+
+func BenchmarkFMAOperation_AVX2(b *testing.B) {
+	benchmarkElementwiseSIMD(b, false, FMAOperation)
+}
+
+func BenchmarkDotProductSIMD_Go(b *testing.B) {
+	benchmarkElementwiseSIMD(b, true, func(x, y, _, _ []float64) { DotProductSIMD(x, y) })
+}
+
+func BenchmarkDotProductSIMD_AVX2(b *testing.B) {
+	benchmarkElementwiseSIMD(b, false, func(x, y, _, _ []float64) { DotProductSIMD(x, y) })
+}
@@ -0,0 +1,130 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Сортировка вдоль оси. NaN считается больше любого числа: при сортировке
+// по возрастанию NaN оказываются в конце, по убыванию — в начале.
+// Индексы хранятся как float64, как в ArgMax.
+
+// compareFloat сравнивает x и y, считая NaN наибольшим значением
+// (все NaN равны между собой).
+func compareFloat(x, y float64) int {
+	xNaN, yNaN := math.IsNaN(x), math.IsNaN(y)
+	switch {
+	case xNaN || yNaN:
+		return boolToInt(xNaN) - boolToInt(yNaN)
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// lanes вызывает f для каждой одномерной «дорожки» вдоль оси axis плотного
+// тензора формы shape: base — смещение первого элемента, step — шаг между элементами.
+func lanes(shape []int, axis int, f func(base, step int)) {
+	outer, inner := 1, 1
+	for _, d := range shape[:axis] {
+		outer *= d
+	}
+	for _, d := range shape[axis+1:] {
+		inner *= d
+	}
+	n := shape[axis]
+	for o := 0; o < outer; o++ {
+		for i := 0; i < inner; i++ {
+			f(o*n*inner+i, inner)
+		}
+	}
+}
+
+// sortAxis устойчиво сортирует a вдоль оси axis и возвращает плотные тензоры
+// отсортированных значений и их исходных индексов.
+func sortAxis(a *Tensor, axis int, descending bool) (values, indices *Tensor, err error) {
+	axis, err = normalizeAxis(axis, len(a.Shape))
+	if err != nil {
+		return nil, nil, err
+	}
+	src := a.Contiguous()
+	values = Zeros(append([]int{}, a.Shape...)...)
+	indices = Zeros(append([]int{}, a.Shape...)...)
+	perm := make([]int, a.Shape[axis])
+	lanes(a.Shape, axis, func(base, step int) {
+		for k := range perm {
+			perm[k] = k
+		}
+		at := func(k int) float64 { return src.Data[base+k*step] }
+		slices.SortStableFunc(perm, func(i, j int) int {
+			c := compareFloat(at(i), at(j))
+			if descending {
+				c = -c
+			}
+			return c
+		})
+		for k, p := range perm {
+			values.Data[base+k*step] = at(p)
+			indices.Data[base+k*step] = float64(p)
+		}
+	})
+	return values, indices, nil
+}
+
+// Sort возвращает копию a, отсортированную вдоль оси axis.
+// Сортировка устойчива: равные элементы сохраняют исходный порядок.
+func Sort(a *Tensor, axis int, descending bool) (*Tensor, error) {
+	values, _, err := sortAxis(a, axis, descending)
+	return values, err
+}
+
+// ArgSort возвращает индексы, упорядочивающие a вдоль оси axis
+// (Gather(a, axis, ArgSort(a, axis, ...)) совпадает с Sort).
+func ArgSort(a *Tensor, axis int, descending bool) (*Tensor, error) {
+	_, indices, err := sortAxis(a, axis, descending)
+	return indices, err
+}
+
+// TopK возвращает k наибольших (largest=true) или наименьших элементов
+// вдоль оси axis и их индексы. Элементы упорядочены от лучшего к худшему;
+// форма результатов совпадает с a, кроме оси axis размера k.
+func TopK(a *Tensor, k, axis int, largest bool) (values, indices *Tensor, err error) {
+	ax, err := normalizeAxis(axis, len(a.Shape))
+	if err != nil {
+		return nil, nil, err
+	}
+	if k < 0 || k > a.Shape[ax] {
+		return nil, nil, fmt.Errorf("topk: k=%d вне диапазона [0, %d]", k, a.Shape[ax])
+	}
+	values, indices, err = sortAxis(a, ax, largest)
+	if err != nil {
+		return nil, nil, err
+	}
+	values, _ = Slice(values, ax, 0, k)
+	indices, _ = Slice(indices, ax, 0, k)
+	return values.Contiguous(), indices.Contiguous(), nil
+}
+
+// Unique возвращает отсортированные по возрастанию уникальные значения a
+// в виде одномерного тензора. Все NaN сводятся к одному значению в конце.
+func Unique(a *Tensor) *Tensor {
+	vals := append([]float64{}, a.Contiguous().Data...)
+	if len(a.Shape) > 0 {
+		vals = vals[:numel(a.Shape)]
+	}
+	slices.SortFunc(vals, compareFloat)
+	vals = slices.CompactFunc(vals, func(x, y float64) bool { return compareFloat(x, y) == 0 })
+	out := Zeros(len(vals))
+	copy(out.Data, vals)
+	return out
+}
@@ -0,0 +1,87 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSortAndArgSortAlongAxis(t *testing.T) {
+	x := &Tensor{Data: []float64{3, 1, 2, 0, 5, 4}, Shape: []int{2, 3}, Strides: []int{3, 1}}
+
+	rows, err := Sort(x, -1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !floatsEqual(rows.Data, []float64{1, 2, 3, 0, 4, 5}) {
+		t.Fatalf("Sort по строкам: %v", rows.Data)
+	}
+	cols, err := Sort(x, 0, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !floatsEqual(cols.Data, []float64{3, 5, 4, 0, 1, 2}) {
+		t.Fatalf("Sort по столбцам: %v", cols.Data)
+	}
+
+	idx, err := ArgSort(x, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !floatsEqual(idx.Data, []float64{1, 2, 0, 0, 2, 1}) {
+		t.Fatalf("ArgSort: %v", idx.Data)
+	}
+	gathered, err := Gather(x, 1, idx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !floatsEqual(gathered.Data, rows.Data) {
+		t.Fatalf("Gather по ArgSort не совпадает с Sort: %v", gathered.Data)
+	}
+	if _, err := Sort(x, 2, false); err == nil {
+		t.Fatal("ожидалась ошибка некорректной оси")
+	}
+}
+
+func TestSortStableWithNaN(t *testing.T) {
+	x := &Tensor{Data: []float64{2, math.NaN(), 1, 2, -1}, Shape: []int{5}, Strides: []int{1}}
+	idx, _ := ArgSort(x, 0, false)
+	if !floatsEqual(idx.Data, []float64{4, 2, 0, 3, 1}) {
+		t.Fatalf("по возрастанию: %v", idx.Data)
+	}
+	idx, _ = ArgSort(x, 0, true)
+	if !floatsEqual(idx.Data, []float64{1, 0, 3, 2, 4}) {
+		t.Fatalf("по убыванию: %v", idx.Data)
+	}
+}
+
+func TestTopK(t *testing.T) {
+	view, err := Transpose(&Tensor{Data: []float64{1, 9, 3, 7, 2, 8}, Shape: []int{2, 3}, Strides: []int{3, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// view: [[1 7] [9 2] [3 8]]
+	values, indices, err := TopK(view, 2, 0, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertShape(t, values, 2, 2)
+	if !floatsEqual(values.Data, []float64{9, 8, 3, 7}) || !floatsEqual(indices.Data, []float64{1, 2, 2, 0}) {
+		t.Fatalf("TopK: значения %v, индексы %v", values.Data, indices.Data)
+	}
+	values, _, _ = TopK(view, 1, 1, false)
+	if !floatsEqual(values.Data, []float64{1, 2, 3}) {
+		t.Fatalf("TopK smallest: %v", values.Data)
+	}
+	if _, _, err := TopK(view, 4, 0, true); err == nil {
+		t.Fatal("ожидалась ошибка k больше размера оси")
+	}
+}
+
+func TestUnique(t *testing.T) {
+	x := &Tensor{Data: []float64{3, 1, math.NaN(), 3, 0, 1, math.NaN()}, Shape: []int{7}, Strides: []int{1}}
+	u := Unique(x)
+	assertShape(t, u, 4)
+	if !floatsEqual(u.Data[:3], []float64{0, 1, 3}) || !math.IsNaN(u.Data[3]) {
+		t.Fatalf("Unique: %v", u.Data)
+	}
+}
@@ -0,0 +1,243 @@
+package tensor
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Разреженные матрицы. COO удобен для построения (тройки строка/столбец/значение
+// в произвольном порядке), CSR — для вычислений: строки хранятся подряд,
+// RowPtr[i]..RowPtr[i+1] — диапазон элементов строки i в ColIdx и Values.
+
+// COO — разреженная матрица в координатном формате.
+// Допускаются повторяющиеся координаты: при преобразовании их значения суммируются.
+type COO struct {
+	Rows, Cols int
+	RowIdx     []int
+	ColIdx     []int
+	Values     []float64
+}
+
+// CSR — разреженная матрица в формате сжатых строк.
+// Внутри строки столбцы упорядочены по возрастанию и не повторяются.
+type CSR struct {
+	Rows, Cols int
+	RowPtr     []int
+	ColIdx     []int
+	Values     []float64
+}
+
+// NewCOO создаёт COO-матрицу rows×cols из координат и значений с проверкой диапазонов.
+func NewCOO(rows, cols int, rowIdx, colIdx []int, values []float64) (*COO, error) {
+	if rows < 0 || cols < 0 {
+		return nil, fmt.Errorf("некорректная форма разреженной матрицы [%d, %d]", rows, cols)
+	}
+	if len(rowIdx) != len(values) || len(colIdx) != len(values) {
+		return nil, fmt.Errorf("длины индексов (%d, %d) и значений (%d) не совпадают", len(rowIdx), len(colIdx), len(values))
+	}
+	for k := range values {
+		if rowIdx[k] < 0 || rowIdx[k] >= rows || colIdx[k] < 0 || colIdx[k] >= cols {
+			return nil, fmt.Errorf("индекс (%d, %d) вне матрицы [%d, %d]", rowIdx[k], colIdx[k], rows, cols)
+		}
+	}
+	return &COO{Rows: rows, Cols: cols, RowIdx: rowIdx, ColIdx: colIdx, Values: values}, nil
+}
+
+// NNZ возвращает количество хранимых элементов.
+func (c *COO) NNZ() int { return len(c.Values) }
+
+// Shape возвращает форму [Rows, Cols].
+func (c *COO) Shape() []int { return []int{c.Rows, c.Cols} }
+
+// ToCSR преобразует матрицу в CSR, сортируя элементы и суммируя повторы.
+func (c *COO) ToCSR() *CSR {
+	order := make([]int, len(c.Values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		if c.RowIdx[a] != c.RowIdx[b] {
+			return c.RowIdx[a] < c.RowIdx[b]
+		}
+		return c.ColIdx[a] < c.ColIdx[b]
+	})
+
+	out := &CSR{Rows: c.Rows, Cols: c.Cols, RowPtr: make([]int, c.Rows+1)}
+	last := -1
+	for _, k := range order {
+		r, col := c.RowIdx[k], c.ColIdx[k]
+		if n := len(out.Values); n > 0 && last == r && out.ColIdx[n-1] == col {
+			out.Values[n-1] += c.Values[k]
+			continue
+		}
+		out.ColIdx = append(out.ColIdx, col)
+		out.Values = append(out.Values, c.Values[k])
+		out.RowPtr[r+1]++
+		last = r
+	}
+	for i := 0; i < c.Rows; i++ {
+		out.RowPtr[i+1] += out.RowPtr[i]
+	}
+	return out
+}
+
+// ToDense возвращает плотный тензор [Rows, Cols]; повторы суммируются.
+func (c *COO) ToDense() *Tensor {
+	out := Zeros(c.Rows, c.Cols)
+	for k, v := range c.Values {
+		out.Data[c.RowIdx[k]*c.Cols+c.ColIdx[k]] += v
+	}
+	return out
+}
+
+// NewCSR создаёт CSR-матрицу из готовых массивов с проверкой структуры.
+func NewCSR(rows, cols int, rowPtr, colIdx []int, values []float64) (*CSR, error) {
+	if rows < 0 || cols < 0 {
+		return nil, fmt.Errorf("некорректная форма разреженной матрицы [%d, %d]", rows, cols)
+	}
+	if len(rowPtr) != rows+1 || rowPtr[0] != 0 || rowPtr[rows] != len(values) || len(colIdx) != len(values) {
+		return nil, fmt.Errorf("некорректная структура CSR: len(RowPtr)=%d, nnz=%d", len(rowPtr), len(values))
+	}
+	for i := 0; i < rows; i++ {
+		if rowPtr[i] > rowPtr[i+1] {
+			return nil, fmt.Errorf("RowPtr убывает в строке %d", i)
+		}
+		for k := rowPtr[i]; k < rowPtr[i+1]; k++ {
+			if colIdx[k] < 0 || colIdx[k] >= cols {
+				return nil, fmt.Errorf("столбец %d вне матрицы [%d, %d]", colIdx[k], rows, cols)
+			}
+			if k > rowPtr[i] && colIdx[k] <= colIdx[k-1] {
+				return nil, fmt.Errorf("столбцы строки %d не упорядочены строго по возрастанию", i)
+			}
+		}
+	}
+	return &CSR{Rows: rows, Cols: cols, RowPtr: rowPtr, ColIdx: colIdx, Values: values}, nil
+}
+
+// DenseToCSR преобразует двумерный тензор в CSR, сохраняя только ненулевые элементы.
+func DenseToCSR(t *Tensor) (*CSR, error) {
+	if len(t.Shape) != 2 {
+		return nil, fmt.Errorf("ожидается двумерный тензор, получена форма %v", t.Shape)
+	}
+	rows, cols := t.Shape[0], t.Shape[1]
+	out := &CSR{Rows: rows, Cols: cols, RowPtr: make([]int, rows+1)}
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			if v := t.At(i, j); v != 0 {
+				out.ColIdx = append(out.ColIdx, j)
+				out.Values = append(out.Values, v)
+			}
+		}
+		out.RowPtr[i+1] = len(out.Values)
+	}
+	return out, nil
+}
+
+// NNZ возвращает количество хранимых элементов.
+func (c *CSR) NNZ() int { return len(c.Values) }
+
+// Shape возвращает форму [Rows, Cols].
+func (c *CSR) Shape() []int { return []int{c.Rows, c.Cols} }
+
+// ToDense возвращает плотный тензор [Rows, Cols].
+func (c *CSR) ToDense() *Tensor {
+	out := Zeros(c.Rows, c.Cols)
+	for i := 0; i < c.Rows; i++ {
+		for k := c.RowPtr[i]; k < c.RowPtr[i+1]; k++ {
+			out.Data[i*c.Cols+c.ColIdx[k]] = c.Values[k]
+		}
+	}
+	return out
+}
+
+// ToCOO преобразует матрицу в COO (элементы в порядке строк).
+func (c *CSR) ToCOO() *COO {
+	out := &COO{
+		Rows:   c.Rows,
+		Cols:   c.Cols,
+		RowIdx: make([]int, len(c.Values)),
+		ColIdx: append([]int(nil), c.ColIdx...),
+		Values: append([]float64(nil), c.Values...),
+	}
+	for i := 0; i < c.Rows; i++ {
+		for k := c.RowPtr[i]; k < c.RowPtr[i+1]; k++ {
+			out.RowIdx[k] = i
+		}
+	}
+	return out
+}
+
+// RowSlice возвращает строки [start, end) как новую CSR-матрицу.
+// ColIdx и Values разделяются с исходной матрицей.
+func (c *CSR) RowSlice(start, end int) (*CSR, error) {
+	if start < 0 || end > c.Rows || start > end {
+		return nil, fmt.Errorf("срез строк [%d, %d) вне диапазона [0, %d)", start, end, c.Rows)
+	}
+	lo, hi := c.RowPtr[start], c.RowPtr[end]
+	rowPtr := make([]int, end-start+1)
+	for i := range rowPtr {
+		rowPtr[i] = c.RowPtr[start+i] - lo
+	}
+	return &CSR{Rows: end - start, Cols: c.Cols, RowPtr: rowPtr, ColIdx: c.ColIdx[lo:hi], Values: c.Values[lo:hi]}, nil
+}
+
+// SelectRows собирает строки с номерами rows (в заданном порядке, возможны повторы)
+// в новую CSR-матрицу. Используется для формирования мини-батчей.
+func (c *CSR) SelectRows(rows []int) (*CSR, error) {
+	out := &CSR{Rows: len(rows), Cols: c.Cols, RowPtr: make([]int, len(rows)+1)}
+	for i, r := range rows {
+		if r < 0 || r >= c.Rows {
+			return nil, fmt.Errorf("строка %d вне диапазона [0, %d)", r, c.Rows)
+		}
+		lo, hi := c.RowPtr[r], c.RowPtr[r+1]
+		out.ColIdx = append(out.ColIdx, c.ColIdx[lo:hi]...)
+		out.Values = append(out.Values, c.Values[lo:hi]...)
+		out.RowPtr[i+1] = len(out.Values)
+	}
+	return out, nil
+}
+
+// SparseDenseMatMul вычисляет A·B для разреженной A [m, k] и плотной B [k, n].
+// Стоимость пропорциональна nnz(A)·n, а не m·k·n.
+func SparseDenseMatMul(a *CSR, b *Tensor) (*Tensor, error) {
+	if len(b.Shape) != 2 || b.Shape[0] != a.Cols {
+		return nil, fmt.Errorf("несовместимые формы для умножения: [%d, %d] и %v", a.Rows, a.Cols, b.Shape)
+	}
+	b = b.Contiguous()
+	n := b.Shape[1]
+	out := Zeros(a.Rows, n)
+	for i := 0; i < a.Rows; i++ {
+		row := out.Data[i*n : (i+1)*n]
+		for k := a.RowPtr[i]; k < a.RowPtr[i+1]; k++ {
+			v := a.Values[k]
+			bRow := b.Data[a.ColIdx[k]*n : (a.ColIdx[k]+1)*n]
+			for j, x := range bRow {
+				row[j] += v * x
+			}
+		}
+	}
+	return out, nil
+}
+
+// SparseDenseMatMulTransposeA вычисляет Aᵀ·B для разреженной A [m, k] и плотной B [m, n]
+// без явного транспонирования A. Используется для градиента весов при разреженном входе.
+func SparseDenseMatMulTransposeA(a *CSR, b *Tensor) (*Tensor, error) {
+	if len(b.Shape) != 2 || b.Shape[0] != a.Rows {
+		return nil, fmt.Errorf("несовместимые формы для умножения: [%d, %d]ᵀ и %v", a.Rows, a.Cols, b.Shape)
+	}
+	b = b.Contiguous()
+	n := b.Shape[1]
+	out := Zeros(a.Cols, n)
+	for i := 0; i < a.Rows; i++ {
+		bRow := b.Data[i*n : (i+1)*n]
+		for k := a.RowPtr[i]; k < a.RowPtr[i+1]; k++ {
+			v := a.Values[k]
+			row := out.Data[a.ColIdx[k]*n : (a.ColIdx[k]+1)*n]
+			for j, x := range bRow {
+				row[j] += v * x
+			}
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,94 @@
+package tensor
+
+import "testing"
+
+func TestSparseConversions(t *testing.T) {
+	// Повторяющаяся координата (1, 2) суммируется
+	coo, err := NewCOO(3, 4, []int{2, 1, 0, 1}, []int{0, 2, 3, 2}, []float64{5, 1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []float64{
+		0, 0, 0, 2,
+		0, 0, 4, 0,
+		5, 0, 0, 0,
+	}
+	assertData(t, "COO.ToDense", coo.ToDense(), []int{3, 4}, want)
+
+	csr := coo.ToCSR()
+	if csr.NNZ() != 3 {
+		t.Fatalf("NNZ = %d, want 3", csr.NNZ())
+	}
+	assertData(t, "CSR.ToDense", csr.ToDense(), []int{3, 4}, want)
+	assertData(t, "CSR.ToCOO.ToDense", csr.ToCOO().ToDense(), []int{3, 4}, want)
+
+	fromDense, err := DenseToCSR(csr.ToDense())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewCSR(fromDense.Rows, fromDense.Cols, fromDense.RowPtr, fromDense.ColIdx, fromDense.Values); err != nil {
+		t.Fatalf("DenseToCSR produced invalid structure: %v", err)
+	}
+
+	if _, err := NewCOO(2, 2, []int{2}, []int{0}, []float64{1}); err == nil {
+		t.Fatal("expected out-of-range error")
+	}
+	if _, err := NewCSR(2, 2, []int{0, 2, 2}, []int{1, 0}, []float64{1, 1}); err == nil {
+		t.Fatal("expected unsorted columns error")
+	}
+}
+
+func TestSparseRowSliceAndSelect(t *testing.T) {
+	dense := &Tensor{Data: []float64{
+		1, 0, 0,
+		0, 0, 2,
+		0, 3, 4,
+	}, Shape: []int{3, 3}, Strides: []int{3, 1}}
+	csr, _ := DenseToCSR(dense)
+
+	s, err := csr.RowSlice(1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertData(t, "RowSlice", s.ToDense(), []int{2, 3}, []float64{0, 0, 2, 0, 3, 4})
+
+	sel, err := csr.SelectRows([]int{2, 0, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertData(t, "SelectRows", sel.ToDense(), []int{3, 3}, []float64{0, 3, 4, 1, 0, 0, 0, 3, 4})
+
+	if _, err := csr.RowSlice(2, 4); err == nil {
+		t.Fatal("expected out-of-range slice error")
+	}
+}
+
+func TestSparseDenseMatMul(t *testing.T) {
+	a := Randn([]int{4, 5}, 3)
+	for i := range a.Data {
+		if i%3 != 0 {
+			a.Data[i] = 0
+		}
+	}
+	csr, _ := DenseToCSR(a)
+	b := Randn([]int{5, 2}, 4)
+
+	got, err := SparseDenseMatMul(csr, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := MatMul(a, b)
+	assertData(t, "SparseDenseMatMul", got, []int{4, 2}, want.Data)
+
+	g := Randn([]int{4, 2}, 5)
+	gotT, err := SparseDenseMatMulTransposeA(csr, g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantT, _ := MatMulTransposeA(a, g)
+	assertData(t, "SparseDenseMatMulTransposeA", gotT, []int{5, 2}, wantT.Data)
+
+	if _, err := SparseDenseMatMul(csr, g); err == nil {
+		t.Fatal("expected shape mismatch error")
+	}
+}
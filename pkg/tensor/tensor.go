@@ -18,22 +18,50 @@ type Matrix struct {
 // Shape - размерность по каждой оси.
 // Strides - количество элементов для перехода к следующему элементу по оси.
 // Strides позволяют эффективно работать с транспонированием и подтензорами без копирования.
+// Offset - индекс первого элемента тензора в Data (ненулевой у views, см. view.go).
 type Tensor struct {
 	Data    []float64
 	Shape   []int
 	Strides []int
+	Offset  int
+
+	// region — шаг арены, из которой выделены данные или к которой тензор
+	// привязан (см. Arena.Attach); результаты операций наследуют его.
+	region *arenaRegion
 }
 
 // ZeroGrad создает тензор с нулевыми градиентами той же формы
+// Результат всегда плотный, даже если t — view.
 func (t *Tensor) ZeroGrad() *Tensor {
-	return &Tensor{
-		Data:    make([]float64, len(t.Data)),
-		Shape:   append([]int{}, t.Shape...),
-		Strides: append([]int{}, t.Strides...),
+	r := regionOf(t)
+	if t.isCompact() {
+		return &Tensor{
+			Data:    r.alloc(len(t.Data)),
+			Shape:   append([]int{}, t.Shape...),
+			Strides: append([]int{}, t.Strides...),
+			region:  r,
+		}
 	}
+	return r.zeros(append([]int{}, t.Shape...)...)
 }
 
 // Size возвращает общее количество элементов в тензоре
 func (t *Tensor) Size() int64 {
-	return int64(len(t.Data))
+	if len(t.Shape) == 0 {
+		return int64(len(t.Data))
+	}
+	return int64(numel(t.Shape))
+}
+
+// Clone возвращает плотную копию тензора, не разделяющую данные с исходным.
+// Копия всегда выделяется вне арены.
+func (t *Tensor) Clone() *Tensor {
+	if !t.isCompact() {
+		return t.contiguousIn(nil)
+	}
+	return &Tensor{
+		Data:    append([]float64(nil), t.Data...),
+		Shape:   append([]int{}, t.Shape...),
+		Strides: calculateStrides(t.Shape),
+	}
 }
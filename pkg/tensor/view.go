@@ -0,0 +1,262 @@
+package tensor
+
+import "fmt"
+
+// Views: тензоры, разделяющие Data с исходным тензором.
+// View описывается Shape, Strides и Offset — смещением первого элемента в Data.
+// Transpose, Permute, Narrow/Slice, Expand, Squeeze и Unsqueeze не копируют данные;
+// Contiguous() материализует view в плотный row-major буфер, когда это нужно.
+
+// stridesOf возвращает strides тензора. Для тензоров, собранных вручную без Strides,
+// подразумевается плотная row-major раскладка.
+func stridesOf(t *Tensor) []int {
+	if len(t.Strides) != len(t.Shape) {
+		return calculateStrides(t.Shape)
+	}
+	return t.Strides
+}
+
+// numel возвращает количество элементов по форме (скаляр без осей — 1 элемент).
+func numel(shape []int) int {
+	n := 1
+	for _, d := range shape {
+		n *= d
+	}
+	return n
+}
+
+// normalizeAxis приводит отрицательную ось к положительной и проверяет диапазон.
+func normalizeAxis(axis, ndim int) (int, error) {
+	if axis < 0 {
+		axis += ndim
+	}
+	if axis < 0 || axis >= ndim {
+		return 0, fmt.Errorf("некорректная ось %d для тензора с %d измерениями", axis, ndim)
+	}
+	return axis, nil
+}
+
+// IsContiguous сообщает, лежат ли элементы тензора подряд в row-major порядке
+// (начиная с Offset). Оси размера 1 на результат не влияют.
+func (t *Tensor) IsContiguous() bool {
+	if len(t.Strides) != len(t.Shape) {
+		return true
+	}
+	expected := 1
+	for i := len(t.Shape) - 1; i >= 0; i-- {
+		if t.Shape[i] == 1 {
+			continue
+		}
+		if t.Strides[i] != expected {
+			return false
+		}
+		expected *= t.Shape[i]
+	}
+	return true
+}
+
+// isCompact сообщает, что Data целиком и без смещения описывает тензор.
+// Такие тензоры можно обрабатывать плоским циклом по Data.
+func (t *Tensor) isCompact() bool {
+	if len(t.Shape) == 0 {
+		// Тензоры без формы (например, из TensorPool) трактуются как плоский буфер.
+		return t.Offset == 0
+	}
+	return t.Offset == 0 && len(t.Data) == numel(t.Shape) && t.IsContiguous()
+}
+
+// Contiguous возвращает плотную row-major копию тензора.
+// Если тензор уже плотный и без смещения, возвращается он сам без копирования.
+func (t *Tensor) Contiguous() *Tensor {
+	if t.isCompact() {
+		return t
+	}
+	return t.contiguousIn(regionOf(t))
+}
+
+// contiguousIn копирует t в плотный тензор, выделенный в шаге арены r.
+func (t *Tensor) contiguousIn(r *arenaRegion) *Tensor {
+	out := r.zeros(append([]int{}, t.Shape...)...)
+	i := 0
+	forEachStrided(t, func(off int) {
+		out.Data[i] = t.Data[off]
+		i++
+	})
+	return out
+}
+
+// At возвращает элемент по многомерному индексу с учётом strides и Offset.
+func (t *Tensor) At(idx ...int) float64 {
+	return t.Data[t.Offset+indexOffset(idx, stridesOf(t))]
+}
+
+// Set записывает элемент по многомерному индексу с учётом strides и Offset.
+func (t *Tensor) Set(value float64, idx ...int) {
+	t.Data[t.Offset+indexOffset(idx, stridesOf(t))] = value
+}
+
+// forEachStrided вызывает f со смещением в Data для каждого элемента тензора в row-major порядке.
+func forEachStrided(t *Tensor, f func(off int)) {
+	if t.isCompact() {
+		for i := range t.Data {
+			f(i)
+		}
+		return
+	}
+	strides := stridesOf(t)
+	n := numel(t.Shape)
+	if n == 0 {
+		return
+	}
+	idx := make([]int, len(t.Shape))
+	off := t.Offset
+	for i := 0; i < n; i++ {
+		f(off)
+		// Инкремент N-мерного счётчика с поддержкой текущего смещения.
+		for d := len(idx) - 1; d >= 0; d-- {
+			idx[d]++
+			off += strides[d]
+			if idx[d] < t.Shape[d] {
+				break
+			}
+			off -= strides[d] * idx[d]
+			idx[d] = 0
+		}
+	}
+}
+
+// forEachStrided2 обходит два тензора одинаковой формы синхронно.
+func forEachStrided2(a, b *Tensor, f func(aOff, bOff int)) {
+	if a.isCompact() && b.isCompact() {
+		for i := range a.Data {
+			f(i, i)
+		}
+		return
+	}
+	aStrides, bStrides := stridesOf(a), stridesOf(b)
+	n := numel(a.Shape)
+	if n == 0 {
+		return
+	}
+	idx := make([]int, len(a.Shape))
+	for i := 0; i < n; i++ {
+		f(a.Offset+indexOffset(idx, aStrides), b.Offset+indexOffset(idx, bStrides))
+		nextIndex(idx, a.Shape)
+	}
+}
+
+// Permute переставляет оси тензора без копирования данных.
+// dims[i] — номер исходной оси, которая станет i-й осью результата.
+func Permute(a *Tensor, dims ...int) (*Tensor, error) {
+	ndim := len(a.Shape)
+	if len(dims) != ndim {
+		return nil, fmt.Errorf("permute: ожидалось %d осей, получено %d", ndim, len(dims))
+	}
+	strides := stridesOf(a)
+	seen := make([]bool, ndim)
+	shape := make([]int, ndim)
+	newStrides := make([]int, ndim)
+	for i, d := range dims {
+		axis, err := normalizeAxis(d, ndim)
+		if err != nil {
+			return nil, err
+		}
+		if seen[axis] {
+			return nil, fmt.Errorf("permute: ось %d указана дважды", axis)
+		}
+		seen[axis] = true
+		shape[i] = a.Shape[axis]
+		newStrides[i] = strides[axis]
+	}
+	return &Tensor{Data: a.Data, Shape: shape, Strides: newStrides, Offset: a.Offset, region: a.region}, nil
+}
+
+// Narrow возвращает view на отрезок [start, start+length) вдоль оси axis без копирования.
+func Narrow(a *Tensor, axis, start, length int) (*Tensor, error) {
+	axis, err := normalizeAxis(axis, len(a.Shape))
+	if err != nil {
+		return nil, err
+	}
+	if start < 0 || length < 0 || start+length > a.Shape[axis] {
+		return nil, fmt.Errorf("narrow: диапазон [%d, %d) вне оси %d размера %d", start, start+length, axis, a.Shape[axis])
+	}
+	strides := stridesOf(a)
+	shape := append([]int{}, a.Shape...)
+	shape[axis] = length
+	return &Tensor{
+		Data:    a.Data,
+		Shape:   shape,
+		Strides: append([]int{}, strides...),
+		Offset:  a.Offset + start*strides[axis],
+		region:  a.region,
+	}, nil
+}
+
+// Expand расширяет оси размера 1 до shape без копирования (stride таких осей становится 0).
+// Значение -1 в shape означает «оставить размер оси как есть».
+func Expand(a *Tensor, shape ...int) (*Tensor, error) {
+	target := append([]int{}, shape...)
+	lead := len(target) - len(a.Shape)
+	for i, d := range target {
+		if d == -1 {
+			if i < lead {
+				return nil, fmt.Errorf("expand: -1 недопустим для новой оси %d", i)
+			}
+			target[i] = a.Shape[i-lead]
+		}
+	}
+	return broadcastTo(a, target)
+}
+
+// Squeeze удаляет оси размера 1 без копирования.
+// Без аргументов удаляются все такие оси, иначе — только перечисленные.
+func Squeeze(a *Tensor, axes ...int) (*Tensor, error) {
+	drop := make([]bool, len(a.Shape))
+	if len(axes) == 0 {
+		for i, d := range a.Shape {
+			drop[i] = d == 1
+		}
+	}
+	for _, ax := range axes {
+		axis, err := normalizeAxis(ax, len(a.Shape))
+		if err != nil {
+			return nil, err
+		}
+		if a.Shape[axis] != 1 {
+			return nil, fmt.Errorf("squeeze: ось %d имеет размер %d, ожидался 1", axis, a.Shape[axis])
+		}
+		drop[axis] = true
+	}
+	strides := stridesOf(a)
+	shape := make([]int, 0, len(a.Shape))
+	newStrides := make([]int, 0, len(a.Shape))
+	for i, d := range a.Shape {
+		if !drop[i] {
+			shape = append(shape, d)
+			newStrides = append(newStrides, strides[i])
+		}
+	}
+	return &Tensor{Data: a.Data, Shape: shape, Strides: newStrides, Offset: a.Offset, region: a.region}, nil
+}
+
+// Unsqueeze вставляет ось размера 1 в позицию axis без копирования.
+func Unsqueeze(a *Tensor, axis int) (*Tensor, error) {
+	axis, err := normalizeAxis(axis, len(a.Shape)+1)
+	if err != nil {
+		return nil, err
+	}
+	strides := stridesOf(a)
+	inner := 1
+	if axis < len(a.Shape) {
+		inner = strides[axis] * a.Shape[axis]
+	}
+	shape := make([]int, 0, len(a.Shape)+1)
+	newStrides := make([]int, 0, len(a.Shape)+1)
+	shape = append(shape, a.Shape[:axis]...)
+	shape = append(shape, 1)
+	shape = append(shape, a.Shape[axis:]...)
+	newStrides = append(newStrides, strides[:axis]...)
+	newStrides = append(newStrides, inner)
+	newStrides = append(newStrides, strides[axis:]...)
+	return &Tensor{Data: a.Data, Shape: shape, Strides: newStrides, Offset: a.Offset, region: a.region}, nil
+}
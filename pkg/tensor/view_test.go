@@ -0,0 +1,251 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+func arange(shape ...int) *Tensor {
+	t := Zeros(shape...)
+	for i := range t.Data {
+		t.Data[i] = float64(i)
+	}
+	return t
+}
+
+func TestTransposeIsView(t *testing.T) {
+	a := arange(2, 3)
+	at, err := Transpose(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if &at.Data[0] != &a.Data[0] {
+		t.Fatal("Transpose must share data with the source tensor")
+	}
+	if at.IsContiguous() {
+		t.Fatal("transposed view must not be contiguous")
+	}
+	if got := at.At(2, 1); got != 5 {
+		t.Fatalf("At(2, 1) = %v, want 5", got)
+	}
+	at.Set(42, 0, 1)
+	if a.Data[3] != 42 {
+		t.Fatalf("Set through view: a.Data[3] = %v, want 42", a.Data[3])
+	}
+}
+
+func TestNarrowAndSliceShareData(t *testing.T) {
+	a := arange(2, 3, 4)
+	n, err := Narrow(a, 1, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.Offset != 4 || !shapesEqual(n.Shape, []int{2, 2, 4}) {
+		t.Fatalf("Narrow: offset=%d shape=%v", n.Offset, n.Shape)
+	}
+	want := []float64{4, 5, 6, 7, 8, 9, 10, 11, 16, 17, 18, 19, 20, 21, 22, 23}
+	dense := n.Contiguous()
+	for i := range want {
+		if dense.Data[i] != want[i] {
+			t.Fatalf("Narrow data[%d] = %v, want %v", i, dense.Data[i], want[i])
+		}
+	}
+
+	s, err := Slice(a, 2, 3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := CopyInto(s, Ones(2, 3, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if a.Data[3] != 1 || a.Data[23] != 1 || a.Data[22] != 22 {
+		t.Fatalf("CopyInto view did not write through: %v", a.Data)
+	}
+	if _, err := Narrow(a, 1, 2, 2); err == nil {
+		t.Fatal("expected out-of-range error")
+	}
+}
+
+func TestPermuteSqueezeUnsqueeze(t *testing.T) {
+	a := arange(2, 3, 4)
+	p, err := Permute(a, 2, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !shapesEqual(p.Shape, []int{4, 2, 3}) || p.At(3, 1, 2) != a.At(1, 2, 3) {
+		t.Fatalf("Permute: shape=%v", p.Shape)
+	}
+	if _, err := Permute(a, 0, 0, 1); err == nil {
+		t.Fatal("expected duplicate axis error")
+	}
+
+	u, err := Unsqueeze(a, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !shapesEqual(u.Shape, []int{2, 3, 4, 1}) || !u.IsContiguous() {
+		t.Fatalf("Unsqueeze: shape=%v strides=%v", u.Shape, u.Strides)
+	}
+	sq, err := Squeeze(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !shapesEqual(sq.Shape, a.Shape) {
+		t.Fatalf("Squeeze: shape=%v", sq.Shape)
+	}
+	if _, err := Squeeze(a, 0); err == nil {
+		t.Fatal("expected error when squeezing a non-unit axis")
+	}
+}
+
+func TestExpandBroadcastsWithoutCopy(t *testing.T) {
+	b := &Tensor{Data: []float64{1, 2, 3}, Shape: []int{1, 3}, Strides: []int{3, 1}}
+	e, err := Expand(b, 4, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(e.Data) != 3 || e.Strides[0] != 0 {
+		t.Fatalf("Expand must not copy: len=%d strides=%v", len(e.Data), e.Strides)
+	}
+	if Sum(e).Data[0] != 24 {
+		t.Fatalf("Sum(expand) = %v, want 24", Sum(e).Data[0])
+	}
+}
+
+func TestOpsOnViews(t *testing.T) {
+	a := arange(3, 4)
+	at, _ := Transpose(a)
+	dense := at.Contiguous()
+
+	sum, err := Add(at, dense)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range sum.Data {
+		if sum.Data[i] != 2*dense.Data[i] {
+			t.Fatalf("Add(view) data[%d] = %v, want %v", i, sum.Data[i], 2*dense.Data[i])
+		}
+	}
+
+	sq := Apply(at, func(v float64) float64 { return v * v })
+	if sq.At(3, 2) != 121 {
+		t.Fatalf("Apply(view) = %v, want 121", sq.At(3, 2))
+	}
+
+	r, err := Reshape(at, []int{12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range r.Data {
+		if r.Data[i] != dense.Data[i] {
+			t.Fatalf("Reshape(view) data[%d] = %v, want %v", i, r.Data[i], dense.Data[i])
+		}
+	}
+}
+
+func TestMatMulOnViews(t *testing.T) {
+	a := Randn([]int{5, 7}, 1)
+	b := Randn([]int{6, 7}, 2)
+	bt, _ := Transpose(b)
+
+	want, err := MatMul(a, bt.Contiguous())
+	if err != nil {
+		t.Fatal(err)
+	}
+	check := func(name string, got *Tensor) {
+		t.Helper()
+		for i := range want.Data {
+			if math.Abs(got.Data[i]-want.Data[i]) > 1e-9 {
+				t.Fatalf("%s data[%d] = %v, want %v", name, i, got.Data[i], want.Data[i])
+			}
+		}
+	}
+
+	got, err := MatMul(a, bt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("MatMul(a, b^T view)", got)
+
+	// Срез по столбцам: strided строки A
+	wide := Randn([]int{5, 10}, 3)
+	if err := CopyInto(mustNarrow(t, wide, 1, 2, 7), a); err != nil {
+		t.Fatal(err)
+	}
+	got, err = MatMul(mustNarrow(t, wide, 1, 2, 7), bt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("MatMul(narrow, b^T view)", got)
+
+	got, err = MatMulTransposeB(mustNarrow(t, wide, 1, 2, 7), b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("MatMulTransposeB(narrow, b)", got)
+}
+
+func mustNarrow(t *testing.T, a *Tensor, axis, start, length int) *Tensor {
+	t.Helper()
+	v, err := Narrow(a, axis, start, length)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestBatchOpsOnViews(t *testing.T) {
+	a := arange(2, 3, 3)
+	at, err := Permute(a, 0, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dense := at.Contiguous()
+
+	for name, f := range map[string]func(x, y *Tensor) (*Tensor, error){
+		"BatchMatMul":     BatchMatMul,
+		"BatchMatMulSIMD": BatchMatMulSIMD,
+	} {
+		got, err := f(at, a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, _ := f(dense, a)
+		// Первый элемент: столбец 0 первой матрицы, умноженный на столбец 0.
+		if got.Data[0] != 45 {
+			t.Fatalf("%s над представлением: %v, ожидалось %v", name, got.Data, want.Data)
+		}
+		for i, v := range want.Data {
+			if got.Data[i] != v {
+				t.Fatalf("%s над представлением: %v, ожидалось %v", name, got.Data, want.Data)
+			}
+		}
+	}
+
+	sum, err := BatchAdd([]*Tensor{at, dense})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sum.At(0, 0, 1); got != 6 {
+		t.Fatalf("BatchAdd над представлением: [0,0,1] = %v, ожидалось 6", got)
+	}
+
+	n, err := Narrow(a, 0, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := BatchGather(n, []int{0}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Data[0] != 9 || len(g.Data) != 9 {
+		t.Fatalf("BatchGather над Narrow: %v", g.Data)
+	}
+	mean, err := BatchMean(at, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := mean.Data[1]; got != 7.5 {
+		t.Fatalf("BatchMean над представлением: [1] = %v, ожидалось 7.5", got)
+	}
+}
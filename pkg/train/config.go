@@ -1,13 +1,12 @@
 package train
 
 import (
-	"math/rand"
-
 	"github.com/Hirogava/Go-NN-Learn/pkg/autograd"
 	"github.com/Hirogava/Go-NN-Learn/pkg/dataloader"
 	"github.com/Hirogava/Go-NN-Learn/pkg/layers"
 	"github.com/Hirogava/Go-NN-Learn/pkg/metrics"
 	"github.com/Hirogava/Go-NN-Learn/pkg/optimizers"
+	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
 )
 
 // TrainerConfig задаёт параметры обучения для создания Trainer.
@@ -16,17 +15,29 @@ type TrainerConfig struct {
 	BatchSize int
 	Device    string
 	Seed      int64
+	// Generator — генератор случайных чисел обучения; если nil, создаётся из Seed.
+	Generator *tensor.Generator
 }
 
 // SetGlobalSeed фиксирует глобальный seed для воспроизводимости.
-// Вызывается при создании Trainer из конфига, чтобы все операции,
-// использующие math/rand (например, dropout), давали детерминированный результат.
+// Пересоздаёт tensor.DefaultGenerator, которым пользуются операции без явного
+// генератора (инициализаторы весов, dropout).
 func SetGlobalSeed(seed int64) {
-	rand.Seed(seed)
+	tensor.ManualSeed(seed)
+}
+
+// RNG возвращает генератор обучения: cfg.Generator, а если он не задан —
+// создаёт его из Seed и запоминает в cfg. Передайте его инициализаторам при
+// построении модели, чтобы веса и обучение шли из одного потока.
+func (cfg *TrainerConfig) RNG() *tensor.Generator {
+	if cfg.Generator == nil {
+		cfg.Generator = tensor.NewGenerator(cfg.Seed)
+	}
+	return cfg.Generator
 }
 
-// NewTrainerFromConfig создаёт Trainer через конфиг.
-// Фиксирует seed глобально (SetGlobalSeed), затем создаёт Trainer с заданным числом эпох.
+// NewTrainerFromConfig создаёт Trainer через конфиг с заданным числом эпох и
+// генератором cfg.RNG() (см. Trainer.SetGenerator).
 // BatchSize и Device заданы в конфиге для использования при создании DataLoader и устройств снаружи.
 func NewTrainerFromConfig(
 	cfg *TrainerConfig,
@@ -38,8 +49,7 @@ func NewTrainerFromConfig(
 	metric metrics.Metric,
 	callbacks CallbackList,
 ) *Trainer {
-	SetGlobalSeed(cfg.Seed)
-	return NewTrainer(
+	t := NewTrainer(
 		model,
 		dataLoader,
 		opt,
@@ -49,4 +59,6 @@ func NewTrainerFromConfig(
 		callbacks,
 		cfg.Epochs,
 	)
+	t.SetGenerator(cfg.RNG())
+	return t
 }
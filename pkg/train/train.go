@@ -8,6 +8,7 @@ import (
 	"github.com/Hirogava/Go-NN-Learn/pkg/layers"
 	"github.com/Hirogava/Go-NN-Learn/pkg/metrics"
 	"github.com/Hirogava/Go-NN-Learn/pkg/optimizers"
+	"github.com/Hirogava/Go-NN-Learn/pkg/profiling"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
 )
@@ -24,6 +25,11 @@ type Trainer struct {
 
 	callbacks CallbackList
 
+	gen *tensor.Generator
+
+	arena    *tensor.Arena
+	profiler *profiling.Profiler
+
 	context TrainingContext
 }
 
@@ -51,6 +57,46 @@ func NewTrainer(
 	}
 }
 
+// SetGenerator задаёт генератор случайных чисел обучения и передаёт его
+// слоям модели с SetGenerator (Dropout) и DataLoader для перемешивания.
+// tensor.DefaultGenerator не меняется, так что тренеры в разных горутинах
+// не влияют друг на друга. Веса модели к этому моменту уже созданы:
+// чтобы инициализация шла из того же потока, передайте gen инициализаторам.
+func (t *Trainer) SetGenerator(gen *tensor.Generator) {
+	t.gen = gen
+	if gen == nil {
+		return
+	}
+	for _, l := range t.model.Layers() {
+		layers.Walk(l, func(l layers.Layer) {
+			if s, ok := l.(interface{ SetGenerator(*tensor.Generator) }); ok {
+				s.SetGenerator(gen)
+			}
+		})
+	}
+	if t.dataLoader != nil {
+		t.dataLoader.SetGenerator(gen)
+	}
+}
+
+// Generator возвращает генератор обучения (nil, если не задан).
+func (t *Trainer) Generator() *tensor.Generator {
+	return t.gen
+}
+
+// SetArena включает выделение промежуточных тензоров каждого батча из арены a
+// (см. autograd.GraphContext.UseArena): буферы переиспользуются между батчами.
+// Состояние, которое слои хранят между батчами (скрытое состояние RNN, LSTM,
+// GRU), они выносят из арены сами (tensor.DetachFromArena).
+func (t *Trainer) SetArena(a *tensor.Arena) {
+	t.arena = a
+}
+
+// SetProfiler задаёт профилировщик, в который пишется статистика памяти арены.
+func (t *Trainer) SetProfiler(p *profiling.Profiler) {
+	t.profiler = p
+}
+
 // Train содержит основной TrainLoop для обучения модели
 func (t *Trainer) Train() {
 	t.model.Train()
@@ -118,16 +164,21 @@ func (t *Trainer) processBatch(batch *dataloader.Batch) error {
 
 	ctx := autograd.NewGraph()
 	ctx.WithGrad()
+	if t.arena != nil {
+		ctx.UseArena(t.arena)
+		ctx.SetProfiler(t.profiler)
+	}
 	autograd.SetGraph(ctx)
 
-	n := graph.NewNode(input, nil, nil) // Засовываем в граф
+	n := ctx.Input(input)               // Засовываем в граф
 	pred := t.model.Forward(n)          // Делаем Forward проход
 
+	// Рассчет метрик — до Backward: с ареной значения промежуточных
+	// узлов после него недействительны
+	err := t.calculateMetrics(pred, labels)
+
 	// Вычисляем потери (loss)
 	lossVal := t.calculateLoss(ctx, pred, labels)
-
-	// Рассчет метрик
-	err := t.calculateMetrics(pred, labels)
 	if err != nil {
 		return err
 	}
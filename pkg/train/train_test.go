@@ -1,13 +1,16 @@
 package train
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/Hirogava/Go-NN-Learn/pkg/autograd"
 	"github.com/Hirogava/Go-NN-Learn/pkg/dataloader"
+	"github.com/Hirogava/Go-NN-Learn/pkg/gnn"
 	"github.com/Hirogava/Go-NN-Learn/pkg/layers"
 	"github.com/Hirogava/Go-NN-Learn/pkg/metrics"
 	"github.com/Hirogava/Go-NN-Learn/pkg/optimizers"
+	"github.com/Hirogava/Go-NN-Learn/pkg/profiling"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor"
 	"github.com/Hirogava/Go-NN-Learn/pkg/tensor/graph"
 )
@@ -108,6 +111,155 @@ func TestProcessBatch_ComputesLossAndStepsOptimizer(t *testing.T) {
 	}
 }
 
+// TestProcessBatch_ArenaMatchesHeap проверяет, что обучение с ареной даёт те же
+// веса и loss, что и без неё, и что статистика памяти попадает в профилировщик.
+func TestProcessBatch_ArenaMatchesHeap(t *testing.T) {
+	newTrainer := func() *Trainer {
+		gen := tensor.NewGenerator(7)
+		model := optimizers.NewSequential(
+			layers.NewDense(2, 4, layers.XavierUniform(2, 4, gen), layers.ZeroInit()),
+			layers.NewReLU(),
+			layers.NewDense(4, 1, layers.XavierUniform(4, 1, gen), layers.ZeroInit()),
+		)
+		return &Trainer{
+			model:   model,
+			opt:     optimizers.NewSGD(0.1),
+			lossFn:  &autograd.MSELossOp{},
+			metric:  metrics.NewMAE(),
+			context: *NewTrainingContext(model, 1),
+		}
+	}
+	batch := &dataloader.Batch{
+		Features: &tensor.Tensor{Data: []float64{1, 2, -1, 0.5, 0, 1}, Shape: []int{3, 2}, Strides: []int{2, 1}},
+		Targets:  &tensor.Tensor{Data: []float64{1, -1, 0.5}, Shape: []int{3, 1}, Strides: []int{1, 1}},
+	}
+
+	plain, pooled := newTrainer(), newTrainer()
+	arena := tensor.NewArena()
+	prof := profiling.NewProfiler(nil)
+	pooled.SetArena(arena)
+	pooled.SetProfiler(prof)
+
+	const steps = 3
+	for i := 0; i < steps; i++ {
+		if err := plain.processBatch(batch); err != nil {
+			t.Fatal(err)
+		}
+		if err := pooled.processBatch(batch); err != nil {
+			t.Fatal(err)
+		}
+		if a, b := plain.context.Metrics["loss"], pooled.context.Metrics["loss"]; a != b {
+			t.Fatalf("шаг %d: loss без арены %v, с ареной %v", i, a, b)
+		}
+	}
+	pp, ap := plain.model.Params(), pooled.model.Params()
+	for i := range pp {
+		for j, v := range pp[i].Value.Data {
+			if ap[i].Value.Data[j] != v {
+				t.Fatalf("параметр %d[%d]: %v != %v", i, j, ap[i].Value.Data[j], v)
+			}
+		}
+	}
+
+	mem := prof.Stats.Memory()
+	if mem.Steps != steps || mem.Reused == 0 || mem.PeakBytes == 0 {
+		t.Fatalf("неожиданная статистика арены: %+v", mem)
+	}
+	if arena.Stats().Resets != steps {
+		t.Fatalf("шаг арены не завершён после батча: %+v", arena.Stats())
+	}
+}
+
+// TestProcessBatch_ArenaIgnoresConcurrentPredictor проверяет, что предсказатель
+// под gnn.NoGrad в другой горутине не берёт память из арены тренера: его
+// выходы не затираются сбросом арены и не попадают в её статистику.
+func TestProcessBatch_ArenaIgnoresConcurrentPredictor(t *testing.T) {
+	gen := tensor.NewGenerator(3)
+	model := optimizers.NewSequential(
+		layers.NewDense(2, 4, layers.XavierUniform(2, 4, gen), layers.ZeroInit()),
+		layers.NewReLU(),
+		layers.NewDense(4, 1, layers.XavierUniform(4, 1, gen), layers.ZeroInit()),
+	)
+	tr := &Trainer{
+		model:   model,
+		opt:     optimizers.NewSGD(0.1),
+		lossFn:  &autograd.MSELossOp{},
+		metric:  metrics.NewMAE(),
+		context: *NewTrainingContext(model, 1),
+	}
+	arena := tensor.NewArena()
+	tr.SetArena(arena)
+	batch := &dataloader.Batch{
+		Features: &tensor.Tensor{Data: []float64{1, 2, -1, 0.5, 0, 1}, Shape: []int{3, 2}, Strides: []int{2, 1}},
+		Targets:  &tensor.Tensor{Data: []float64{1, -1, 0.5}, Shape: []int{3, 1}, Strides: []int{1, 1}},
+	}
+
+	predictor := optimizers.NewSequential(
+		layers.NewDense(2, 8, layers.XavierUniform(2, 8, gen), layers.ZeroInit()),
+		layers.NewReLU(),
+		layers.NewDense(8, 3, layers.XavierUniform(8, 3, gen), layers.ZeroInit()),
+	)
+	x := &tensor.Tensor{Data: []float64{0.5, -1, 2, 1}, Shape: []int{2, 2}, Strides: []int{2, 1}}
+	predict := func() *tensor.Tensor {
+		var out *tensor.Tensor
+		gnn.NoGrad(func() {
+			out = predictor.Forward(&graph.Node{Value: x}).Value
+		})
+		return out
+	}
+	want := predict().Clone()
+
+	const steps = 50
+	before := arena.Stats()
+	done := make(chan struct{})
+	errs := make(chan string, 1)
+	go func() {
+		defer close(errs)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			out := predict()
+			if tensor.ArenaOf(out) != nil {
+				errs <- "выход предсказателя выделен из арены тренера"
+				return
+			}
+			// Даём тренеру сбросить арену, пока выход ещё используется.
+			for i := 0; i < 100; i++ {
+				for j, v := range want.Data {
+					if out.Data[j] != v {
+						errs <- "выход предсказателя изменён сбросом арены"
+						return
+					}
+				}
+			}
+		}
+	}()
+	for i := 0; i < steps; i++ {
+		if err := tr.processBatch(batch); err != nil {
+			close(done)
+			t.Fatal(err)
+		}
+	}
+	close(done)
+	for msg := range errs {
+		t.Fatal(msg)
+	}
+
+	// Одна и та же форма батча: начиная со второго шага тренер должен
+	// обходиться памятью из списка свободных буферов.
+	st := arena.Stats()
+	if st.Resets-before.Resets != steps {
+		t.Fatalf("шаг арены не завершён после батча: %+v", st)
+	}
+	perStep := (st.Allocs - before.Allocs) / steps
+	if perStep == 0 || st.Allocs-before.Allocs != perStep*steps {
+		t.Fatalf("в статистику арены попали чужие выделения: %+v", st)
+	}
+}
+
 // TestNewTrainerFromConfig_CreatesTrainer проверяет, что Trainer создаётся через конфиг
 // и контекст содержит правильное число эпох из конфига.
 func TestNewTrainerFromConfig_CreatesTrainer(t *testing.T) {
@@ -208,6 +360,54 @@ func TestTrainerFromConfig_Deterministic(t *testing.T) {
 	}
 }
 
+// TestTrainerFromConfig_ConcurrentReproducible проверяет, что тренеры с одним
+// seed, обучающиеся параллельно, дают одинаковые веса (инициализация, маски
+// Dropout и перемешивание идут из генератора конфига) и не трогают
+// tensor.DefaultGenerator.
+func TestTrainerFromConfig_ConcurrentReproducible(t *testing.T) {
+	features := &tensor.Tensor{Data: []float64{1, 2, -1, 0.5, 0, 1, 2, -2}, Shape: []int{4, 2}, Strides: []int{2, 1}}
+	targets := &tensor.Tensor{Data: []float64{1, -1, 0.5, 0}, Shape: []int{4, 1}, Strides: []int{1, 1}}
+	newTrainer := func() *Trainer {
+		cfg := &TrainerConfig{Epochs: 3, BatchSize: 2, Seed: 99}
+		gen := cfg.RNG()
+		model := optimizers.NewSequential(
+			layers.NewDense(2, 8, layers.XavierUniform(2, 8, gen), layers.ZeroInit()),
+			layers.NewReLU(),
+			layers.NewDropout(0.5),
+			layers.NewDense(8, 1, layers.XavierUniform(8, 1, gen), layers.ZeroInit()),
+		)
+		dl := dataloader.NewDataLoader(dataloader.NewSimpleDataset(features, targets),
+			dataloader.DataLoaderConfig{BatchSize: 2, Shuffle: true})
+		return NewTrainerFromConfig(cfg, model, dl, optimizers.NewSGD(0.1), &autograd.MSELossOp{},
+			optimizers.NewStepLR(0.1, 0.5, 1), metrics.NewMAE(), *NewCallbackList())
+	}
+
+	def := tensor.DefaultGenerator()
+	defPos := def.Position()
+	trainers := []*Trainer{newTrainer(), newTrainer()}
+	var wg sync.WaitGroup
+	for _, tr := range trainers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tr.Train()
+		}()
+	}
+	wg.Wait()
+
+	if tensor.DefaultGenerator() != def || def.Position() != defPos {
+		t.Fatal("Trainer изменил tensor.DefaultGenerator")
+	}
+	p1, p2 := trainers[0].model.Params(), trainers[1].model.Params()
+	for i := range p1 {
+		for j, v := range p1[i].Value.Data {
+			if p2[i].Value.Data[j] != v {
+				t.Fatalf("параметр %d[%d]: %v != %v", i, j, p2[i].Value.Data[j], v)
+			}
+		}
+	}
+}
+
 // TestTrainerTrain_CallsModelTrainMode проверяет, что Trainer переводит модель в train
 // перед стартом и в начале каждой эпохи (поддержка Dropout / BatchNorm / RNN.training).
 func TestTrainerTrain_CallsModelTrainMode(t *testing.T) {